@@ -0,0 +1,108 @@
+/*
+Real-time Charging System for Telecom & ISP environments
+Copyright (C) 2012-2015 ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package v1
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+type AttrAccountsSnapshot struct {
+	SnapshotId string
+	Tenant     string
+	Direction  string   // defaults to *out
+	Accounts   []string // all the tenant accounts on the direction if empty
+}
+
+// Stores the current state of the accounts into StorDB
+func (self *ApierV1) SnapshotAccounts(attr AttrAccountsSnapshot, reply *string) error {
+	if missing := utils.MissingStructFields(&attr, []string{"SnapshotId", "Tenant"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	accountIds, err := attr.getAccountIds()
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if len(accountIds) == 0 {
+		return utils.ErrNotFound
+	}
+	if err := engine.SnapshotAccounts(self.CdrDb, attr.SnapshotId, accountIds); err != nil {
+		if err == utils.ErrNotFound {
+			return err
+		}
+		return utils.NewErrServerError(err)
+	}
+	*reply = OK
+	return nil
+}
+
+func (attr *AttrAccountsSnapshot) getAccountIds() ([]string, error) {
+	if attr.Direction == "" {
+		attr.Direction = engine.OUTBOUND
+	}
+	if len(attr.Accounts) == 0 {
+		return engine.GetTenantAccountIds(attr.Tenant, attr.Direction)
+	}
+	accountIds := make([]string, len(attr.Accounts))
+	for i, account := range attr.Accounts {
+		accountIds[i] = utils.AccountKey(attr.Tenant, account, attr.Direction)
+	}
+	return accountIds, nil
+}
+
+type AttrRestoreAccounts struct {
+	SnapshotId string
+	Tenant     string
+	Direction  string   // defaults to *out
+	Accounts   []string // all the tenant accounts on the direction in the snapshot if empty
+	DryRun     bool     // only return the differences
+}
+
+// Restores the accounts from a snapshot, returning the differences it undoes
+func (self *ApierV1) RestoreAccounts(attr AttrRestoreAccounts, reply *[]*engine.AccountDiff) error {
+	if missing := utils.MissingStructFields(&attr, []string{"SnapshotId", "Tenant"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if attr.Direction == "" {
+		attr.Direction = engine.OUTBOUND
+	}
+	var accountIds []string
+	if len(attr.Accounts) != 0 {
+		var err error
+		if accountIds, err = (&AttrAccountsSnapshot{Tenant: attr.Tenant, Direction: attr.Direction, Accounts: attr.Accounts}).getAccountIds(); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	}
+	diffs, err := engine.RestoreAccounts(self.CdrDb, attr.SnapshotId, attr.Tenant, attr.Direction, accountIds, attr.DryRun)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return err
+		}
+		for _, ad := range diffs {
+			engine.Logger.Warning(fmt.Sprintf("<ApierV1.RestoreAccounts> Account %s restored from snapshot %s before the error: %v", ad.AccountId, attr.SnapshotId, err))
+		}
+		return utils.NewErrServerError(err)
+	}
+	if diffs == nil {
+		diffs = []*engine.AccountDiff{}
+	}
+	*reply = diffs
+	return nil
+}
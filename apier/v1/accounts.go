@@ -170,10 +170,26 @@ func (self *ApierV1) SetAccount(attr utils.AttrSetAccount, reply *string) error
 			ub = bal
 		} else { // Not found in db, create it here
 			ub = &engine.Account{
-				Id:            balanceId,
-				AllowNegative: attr.AllowNegative,
+				Id: balanceId,
 			}
 		}
+		if attr.AllowNegative {
+			ub.SetCreditLimit(attr.Direction, &engine.CreditLimit{Unlimited: true})
+		} else if attr.CreditLimit != nil {
+			ub.SetCreditLimit(attr.Direction, &engine.CreditLimit{Amount: utils.NewDecimalFromFloat(*attr.CreditLimit)})
+		}
+		if attr.ParentAccount != nil {
+			var parentId string
+			if *attr.ParentAccount != "" {
+				parentId = utils.AccountKey(attr.Tenant, *attr.ParentAccount, attr.Direction)
+			}
+			if err := ub.SetParentId(parentId); err != nil {
+				return 0, err
+			}
+		}
+		if attr.ResellerSubject != nil {
+			ub.ResellerSubject = *attr.ResellerSubject
+		}
 
 		if len(attr.ActionPlanId) != 0 {
 			var err error
@@ -186,6 +202,7 @@ func (self *ApierV1) SetAccount(attr utils.AttrSetAccount, reply *string) error
 			}
 		}
 		// All prepared, save account
+		ub.SetLedgerCause(engine.LEDGER_API, "ApierV1.SetAccount")
 		if err := self.AccountDb.SetAccount(ub); err != nil {
 			return 0, err
 		}
@@ -262,3 +279,31 @@ func (self *ApierV1) GetAccounts(attr AttrGetAccounts, reply *[]*engine.Account)
 	*reply = retAccounts
 	return nil
 }
+
+// Returns the accounts under the reseller, out of the accounts with the same tenant and direction
+func (self *ApierV1) GetAccountTree(attr utils.AttrGetAccount, reply *engine.AccountTree) error {
+	if missing := utils.MissingStructFields(&attr, []string{"Tenant", "Account"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if len(attr.Direction) == 0 {
+		attr.Direction = utils.OUT
+	}
+	rootId := utils.AccountKey(attr.Tenant, attr.Account, attr.Direction)
+	if _, err := self.AccountDb.GetAccount(rootId); err != nil {
+		return err
+	}
+	accountKeys, err := self.AccountDb.GetKeysForPrefix(utils.ACCOUNT_PREFIX + utils.ConcatenatedKey(attr.Direction, attr.Tenant))
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	var accounts []*engine.Account
+	for _, acntKey := range accountKeys {
+		if acnt, err := self.AccountDb.GetAccount(acntKey[len(engine.ACCOUNT_PREFIX):]); err != nil && err != utils.ErrNotFound {
+			return utils.NewErrServerError(err)
+		} else if acnt != nil {
+			accounts = append(accounts, acnt)
+		}
+	}
+	*reply = *engine.NewAccountTree(rootId, accounts)
+	return nil
+}
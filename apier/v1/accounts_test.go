@@ -40,7 +40,7 @@ func init() {
 func TestSetAccounts(t *testing.T) {
 	cgrTenant := "cgrates.org"
 	iscTenant := "itsyscom.com"
-	b10 := &engine.Balance{Value: 10, Weight: 10}
+	b10 := &engine.Balance{Value: utils.NewDecimalFromFloat(10), Weight: 10}
 	cgrAcnt1 := &engine.Account{Id: utils.ConcatenatedKey(utils.OUT, cgrTenant, "account1"),
 		BalanceMap: map[string]engine.BalanceChain{utils.MONETARY + engine.OUTBOUND: engine.BalanceChain{b10}}}
 	cgrAcnt2 := &engine.Account{Id: utils.ConcatenatedKey(utils.OUT, cgrTenant, "account2"),
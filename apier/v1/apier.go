@@ -108,6 +108,7 @@ type AttrAddBalance struct {
 	DestinationId string
 	Weight        float64
 	SharedGroup   string
+	Currency      string
 	Overwrite     bool // When true it will reset if the balance is already there
 }
 
@@ -141,6 +142,7 @@ func (self *ApierV1) AddBalance(attr *AttrAddBalance, reply *string) error {
 	if attr.Overwrite {
 		aType = engine.DEBIT_RESET
 	}
+	at.SetLedgerCause(engine.LEDGER_API, "ApierV1.AddBalance")
 	at.SetActions(engine.Actions{
 		&engine.Action{
 			ActionType:  aType,
@@ -148,12 +150,13 @@ func (self *ApierV1) AddBalance(attr *AttrAddBalance, reply *string) error {
 			Direction:   attr.Direction,
 			Balance: &engine.Balance{
 				Id:             attr.BalanceId,
-				Value:          attr.Value,
+				Value:          utils.NewDecimalFromFloat(attr.Value),
 				ExpirationDate: expTime,
 				RatingSubject:  attr.RatingSubject,
 				DestinationIds: attr.DestinationId,
 				Weight:         attr.Weight,
 				SharedGroup:    attr.SharedGroup,
+				Currency:       attr.Currency,
 			},
 		},
 	})
@@ -165,6 +168,54 @@ func (self *ApierV1) AddBalance(attr *AttrAddBalance, reply *string) error {
 	return nil
 }
 
+type AttrTransferBalance struct {
+	Tenant        string
+	FromAccount   string
+	ToAccount     string
+	BalanceType   string
+	Direction     string
+	Value         float64
+	BalanceId     string // the filters select the source balances and the receiving one
+	DestinationId string
+	Category      string
+	ExpiryTime    string
+}
+
+// Moves balance units from one account to another, both accounts are locked during the transfer
+func (self *ApierV1) TransferBalance(attr AttrTransferBalance, reply *string) error {
+	if missing := utils.MissingStructFields(&attr, []string{"Tenant", "FromAccount", "ToAccount", "BalanceType"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	expTime, err := utils.ParseDate(attr.ExpiryTime)
+	if err != nil {
+		return err
+	}
+	if attr.Direction == "" {
+		attr.Direction = engine.OUTBOUND
+	}
+	a := &engine.Action{
+		ActionType:  engine.TRANSFER_BALANCE,
+		BalanceType: attr.BalanceType,
+		Direction:   attr.Direction,
+		Balance: &engine.Balance{
+			Id:             attr.BalanceId,
+			Value:          utils.NewDecimalFromFloat(attr.Value),
+			ExpirationDate: expTime,
+			DestinationIds: attr.DestinationId,
+			Category:       attr.Category,
+		},
+	}
+	if err := engine.TransferBalance(utils.AccountKey(attr.Tenant, attr.FromAccount, attr.Direction),
+		utils.AccountKey(attr.Tenant, attr.ToAccount, attr.Direction), a); err != nil {
+		if err == utils.ErrNotFound || err == engine.ErrInsufficientCredit {
+			return err
+		}
+		return utils.NewErrServerError(err)
+	}
+	*reply = OK
+	return nil
+}
+
 func (self *ApierV1) ExecuteAction(attr *utils.AttrExecuteAction, reply *string) error {
 	tag := fmt.Sprintf("%s:%s:%s", attr.Direction, attr.Tenant, attr.Account)
 	at := &engine.ActionPlan{
@@ -201,7 +252,7 @@ func (self *ApierV1) LoadDestination(attrs AttrLoadDestination, reply *string) e
 	if len(attrs.DestinationId) == 0 {
 		destIds = nil // Cache all destinations, temporary here until we add ApierV2.LoadDestinations
 	}
-	if err := self.RatingDb.CacheRating(destIds, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange); err != nil {
+	if err := self.RatingDb.CacheRating(destIds, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange); err != nil {
 		return err
 	}
 	*reply = OK
@@ -224,7 +275,7 @@ func (self *ApierV1) LoadDerivedChargers(attrs utils.TPDerivedChargers, reply *s
 	if len(attrs.Direction) != 0 && len(attrs.Tenant) != 0 && len(attrs.Category) != 0 && len(attrs.Account) != 0 && len(attrs.Subject) != 0 {
 		derivedChargingKeys = []string{engine.DERIVEDCHARGERS_PREFIX + attrs.GetDerivedChargersKey()}
 	}
-	if err := self.RatingDb.CacheRating(didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, derivedChargingKeys); err != nil {
+	if err := self.RatingDb.CacheRating(didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, derivedChargingKeys, didNotChange, didNotChange, didNotChange, didNotChange); err != nil {
 		return err
 	}
 	*reply = OK
@@ -253,7 +304,7 @@ func (self *ApierV1) LoadRatingPlan(attrs AttrLoadRatingPlan, reply *string) err
 	if len(attrs.TPid) != 0 {
 		changedRPlKeys = []string{engine.RATING_PLAN_PREFIX + attrs.RatingPlanId}
 	}
-	if err := self.RatingDb.CacheRating(nil, changedRPlKeys, didNotChange, didNotChange, didNotChange, didNotChange); err != nil {
+	if err := self.RatingDb.CacheRating(nil, changedRPlKeys, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange); err != nil {
 		return err
 	}
 	*reply = OK
@@ -276,7 +327,7 @@ func (self *ApierV1) LoadRatingProfile(attrs utils.TPRatingProfile, reply *strin
 	if attrs.KeyId() != ":::" { // if has some filters
 		ratingProfile = []string{engine.RATING_PROFILE_PREFIX + attrs.KeyId()}
 	}
-	if err := self.RatingDb.CacheRating(didNotChange, didNotChange, ratingProfile, didNotChange, didNotChange, didNotChange); err != nil {
+	if err := self.RatingDb.CacheRating(didNotChange, didNotChange, ratingProfile, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange); err != nil {
 		return err
 	}
 	*reply = OK
@@ -403,8 +454,28 @@ func (self *ApierV1) LoadTariffPlanFromStorDb(attrs AttrLoadTpFromStorDb, reply
 	for idx, dc := range dcs {
 		dcsKeys[idx] = engine.DERIVEDCHARGERS_PREFIX + dc
 	}
+	exrIds, _ := dbReader.GetLoadedIds(engine.EXCHANGE_RATE_PREFIX)
+	exrKeys := make([]string, len(exrIds))
+	for idx, exrId := range exrIds {
+		exrKeys[idx] = engine.EXCHANGE_RATE_PREFIX + exrId
+	}
+	taxIds, _ := dbReader.GetLoadedIds(engine.TAXES_PREFIX)
+	taxKeys := make([]string, len(taxIds))
+	for idx, taxId := range taxIds {
+		taxKeys[idx] = engine.TAXES_PREFIX + taxId
+	}
+	hclIds, _ := dbReader.GetLoadedIds(engine.HOLIDAY_CALENDAR_PREFIX)
+	hclKeys := make([]string, len(hclIds))
+	for idx, hclId := range hclIds {
+		hclKeys[idx] = engine.HOLIDAY_CALENDAR_PREFIX + hclId
+	}
+	mnpIds, _ := dbReader.GetLoadedIds(engine.NUMBER_PORTABILITY_PREFIX)
+	mnpKeys := make([]string, len(mnpIds))
+	for idx, mnpId := range mnpIds {
+		mnpKeys[idx] = engine.NUMBER_PORTABILITY_PREFIX + mnpId
+	}
 	engine.Logger.Info("ApierV1.LoadTariffPlanFromStorDb, reloading cache.")
-	if err := self.RatingDb.CacheRating(dstKeys, rpKeys, rpfKeys, rpAlsKeys, lcrKeys, dcsKeys); err != nil {
+	if err := self.RatingDb.CacheRating(dstKeys, rpKeys, rpfKeys, rpAlsKeys, lcrKeys, dcsKeys, exrKeys, taxKeys, hclKeys, mnpKeys); err != nil {
 		return err
 	}
 	if err := self.AccountDb.CacheAccounting(actKeys, shgKeys, accAlsKeys); err != nil {
@@ -502,15 +573,20 @@ func (self *ApierV1) SetRatingProfile(attrs AttrSetRatingProfile, reply *string)
 		} else if !exists {
 			return fmt.Errorf(fmt.Sprintf("%s:RatingPlanId:%s", utils.ErrNotFound.Error(), ra.RatingPlanId))
 		}
+		if ra.Timezone != "" {
+			if _, err := utils.GetLocation(ra.Timezone); err != nil {
+				return fmt.Errorf("%s:Timezone:%s", utils.ErrParserError.Error(), ra.Timezone)
+			}
+		}
 		rpfl.RatingPlanActivations[idx] = &engine.RatingPlanActivation{ActivationTime: at, RatingPlanId: ra.RatingPlanId,
-			FallbackKeys: utils.FallbackSubjKeys(tpRpf.Direction, tpRpf.Tenant, tpRpf.Category, ra.FallbackSubjects)}
+			FallbackKeys: utils.FallbackSubjKeys(tpRpf.Direction, tpRpf.Tenant, tpRpf.Category, ra.FallbackSubjects), Timezone: ra.Timezone}
 	}
 	if err := self.RatingDb.SetRatingProfile(rpfl); err != nil {
 		return utils.NewErrServerError(err)
 	}
 	//Automatic cache of the newly inserted rating profile
 	didNotChange := []string{}
-	if err := self.RatingDb.CacheRating(didNotChange, didNotChange, []string{engine.RATING_PROFILE_PREFIX + keyId}, didNotChange, didNotChange, didNotChange); err != nil {
+	if err := self.RatingDb.CacheRating(didNotChange, didNotChange, []string{engine.RATING_PROFILE_PREFIX + keyId}, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange); err != nil {
 		return err
 	}
 	*reply = OK
@@ -550,11 +626,12 @@ func (self *ApierV1) SetActions(attrs utils.AttrSetActions, reply *string) error
 			Balance: &engine.Balance{
 				Uuid:           utils.GenUUID(),
 				Id:             apiAct.BalanceId,
-				Value:          apiAct.Units,
+				Value:          utils.NewDecimalFromFloat(apiAct.Units),
 				Weight:         apiAct.BalanceWeight,
 				DestinationIds: apiAct.DestinationIds,
 				RatingSubject:  apiAct.RatingSubject,
 				SharedGroup:    apiAct.SharedGroup,
+				Currency:       apiAct.Currency,
 			},
 		}
 		storeActions[idx] = a
@@ -587,7 +664,7 @@ func (self *ApierV1) GetActions(actsId string, reply *[]*utils.TPAction) error {
 			Weight:          engAct.Weight,
 		}
 		if engAct.Balance != nil {
-			act.Units = engAct.Balance.Value
+			act.Units = engAct.Balance.Value.Float64()
 			act.DestinationIds = engAct.Balance.DestinationIds
 			act.RatingSubject = engAct.Balance.RatingSubject
 			act.SharedGroup = engAct.Balance.SharedGroup
@@ -644,6 +721,7 @@ func (self *ApierV1) SetActionPlan(attrs AttrSetActionPlan, reply *string) error
 		timing.Years.Parse(apiAtm.Years, ";")
 		timing.Months.Parse(apiAtm.Months, ";")
 		timing.MonthDays.Parse(apiAtm.MonthDays, ";")
+		timing.Holidays.Parse(apiAtm.MonthDays, ";")
 		timing.WeekDays.Parse(apiAtm.WeekDays, ";")
 		timing.StartTime = apiAtm.Time
 		at := &engine.ActionPlan{
@@ -842,7 +920,7 @@ func (self *ApierV1) ReloadScheduler(input string, reply *string) error {
 }
 
 func (self *ApierV1) ReloadCache(attrs utils.ApiReloadCache, reply *string) error {
-	var dstKeys, rpKeys, rpfKeys, actKeys, shgKeys, rpAlsKeys, accAlsKeys, lcrKeys, dcsKeys []string
+	var dstKeys, rpKeys, rpfKeys, actKeys, shgKeys, rpAlsKeys, accAlsKeys, lcrKeys, dcsKeys, exrKeys, taxKeys, hclKeys, mnpKeys []string
 	if len(attrs.DestinationIds) > 0 {
 		dstKeys = make([]string, len(attrs.DestinationIds))
 		for idx, dId := range attrs.DestinationIds {
@@ -898,7 +976,31 @@ func (self *ApierV1) ReloadCache(attrs utils.ApiReloadCache, reply *string) erro
 			dcsKeys[idx] = engine.DERIVEDCHARGERS_PREFIX + dc
 		}
 	}
-	if err := self.RatingDb.CacheRating(dstKeys, rpKeys, rpfKeys, rpAlsKeys, lcrKeys, dcsKeys); err != nil {
+	if len(attrs.ExchangeRates) > 0 {
+		exrKeys = make([]string, len(attrs.ExchangeRates))
+		for idx, exr := range attrs.ExchangeRates {
+			exrKeys[idx] = engine.EXCHANGE_RATE_PREFIX + exr
+		}
+	}
+	if len(attrs.Taxes) > 0 {
+		taxKeys = make([]string, len(attrs.Taxes))
+		for idx, tax := range attrs.Taxes {
+			taxKeys[idx] = engine.TAXES_PREFIX + tax
+		}
+	}
+	if len(attrs.HolidayCalendars) > 0 {
+		hclKeys = make([]string, len(attrs.HolidayCalendars))
+		for idx, hcl := range attrs.HolidayCalendars {
+			hclKeys[idx] = engine.HOLIDAY_CALENDAR_PREFIX + hcl
+		}
+	}
+	if len(attrs.PortedNumbers) > 0 {
+		mnpKeys = make([]string, len(attrs.PortedNumbers))
+		for idx, number := range attrs.PortedNumbers {
+			mnpKeys[idx] = engine.NUMBER_PORTABILITY_PREFIX + number
+		}
+	}
+	if err := self.RatingDb.CacheRating(dstKeys, rpKeys, rpfKeys, rpAlsKeys, lcrKeys, dcsKeys, exrKeys, taxKeys, hclKeys, mnpKeys); err != nil {
 		return err
 	}
 	if err := self.AccountDb.CacheAccounting(actKeys, shgKeys, accAlsKeys); err != nil {
@@ -910,7 +1012,7 @@ func (self *ApierV1) ReloadCache(attrs utils.ApiReloadCache, reply *string) erro
 
 func (self *ApierV1) GetCacheStats(attrs utils.AttrCacheStats, reply *utils.CacheStats) error {
 	cs := new(utils.CacheStats)
-	cs.Destinations = cache2go.CountEntries(engine.DESTINATION_PREFIX)
+	cs.Destinations = engine.CachedDestinationsCount()
 	cs.RatingPlans = cache2go.CountEntries(engine.RATING_PLAN_PREFIX)
 	cs.RatingProfiles = cache2go.CountEntries(engine.RATING_PROFILE_PREFIX)
 	cs.Actions = cache2go.CountEntries(engine.ACTION_PREFIX)
@@ -919,6 +1021,10 @@ func (self *ApierV1) GetCacheStats(attrs utils.AttrCacheStats, reply *utils.Cach
 	cs.AccountAliases = cache2go.CountEntries(engine.ACC_ALIAS_PREFIX)
 	cs.DerivedChargers = cache2go.CountEntries(engine.DERIVEDCHARGERS_PREFIX)
 	cs.LcrProfiles = cache2go.CountEntries(engine.LCR_PREFIX)
+	cs.ExchangeRates = cache2go.CountEntries(engine.EXCHANGE_RATE_PREFIX)
+	cs.Taxes = cache2go.CountEntries(engine.TAXES_PREFIX)
+	cs.HolidayCalendars = cache2go.CountEntries(engine.HOLIDAY_CALENDAR_PREFIX)
+	cs.PortedNumbers = cache2go.CountEntries(engine.NUMBER_PORTABILITY_PREFIX)
 	*reply = *cs
 	return nil
 }
@@ -987,7 +1093,11 @@ func (self *ApierV1) LoadTariffPlanFromFolder(attrs utils.AttrLoadTpFromFolder,
 		path.Join(attrs.FolderPath, utils.ACTION_TRIGGERS_CSV),
 		path.Join(attrs.FolderPath, utils.ACCOUNT_ACTIONS_CSV),
 		path.Join(attrs.FolderPath, utils.DERIVED_CHARGERS_CSV),
-		path.Join(attrs.FolderPath, utils.CDR_STATS_CSV)), "")
+		path.Join(attrs.FolderPath, utils.CDR_STATS_CSV),
+		path.Join(attrs.FolderPath, utils.EXCHANGE_RATES_CSV),
+		path.Join(attrs.FolderPath, utils.TAXES_CSV),
+		path.Join(attrs.FolderPath, utils.HOLIDAYS_CSV),
+		path.Join(attrs.FolderPath, utils.NUMBER_PORTABILITY_CSV)), "")
 	if err := loader.LoadAll(); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -1052,9 +1162,29 @@ func (self *ApierV1) LoadTariffPlanFromFolder(attrs utils.AttrLoadTpFromFolder,
 	for idx, dc := range dcs {
 		dcsKeys[idx] = engine.DERIVEDCHARGERS_PREFIX + dc
 	}
+	exrIds, _ := loader.GetLoadedIds(engine.EXCHANGE_RATE_PREFIX)
+	exrKeys := make([]string, len(exrIds))
+	for idx, exrId := range exrIds {
+		exrKeys[idx] = engine.EXCHANGE_RATE_PREFIX + exrId
+	}
+	taxIds, _ := loader.GetLoadedIds(engine.TAXES_PREFIX)
+	taxKeys := make([]string, len(taxIds))
+	for idx, taxId := range taxIds {
+		taxKeys[idx] = engine.TAXES_PREFIX + taxId
+	}
+	hclIds, _ := loader.GetLoadedIds(engine.HOLIDAY_CALENDAR_PREFIX)
+	hclKeys := make([]string, len(hclIds))
+	for idx, hclId := range hclIds {
+		hclKeys[idx] = engine.HOLIDAY_CALENDAR_PREFIX + hclId
+	}
+	mnpIds, _ := loader.GetLoadedIds(engine.NUMBER_PORTABILITY_PREFIX)
+	mnpKeys := make([]string, len(mnpIds))
+	for idx, mnpId := range mnpIds {
+		mnpKeys[idx] = engine.NUMBER_PORTABILITY_PREFIX + mnpId
+	}
 	aps, _ := loader.GetLoadedIds(engine.ACTION_TIMING_PREFIX)
 	engine.Logger.Info("ApierV1.LoadTariffPlanFromFolder, reloading cache.")
-	if err := self.RatingDb.CacheRating(dstKeys, rpKeys, rpfKeys, rpAlsKeys, lcrKeys, dcsKeys); err != nil {
+	if err := self.RatingDb.CacheRating(dstKeys, rpKeys, rpfKeys, rpAlsKeys, lcrKeys, dcsKeys, exrKeys, taxKeys, hclKeys, mnpKeys); err != nil {
 		return err
 	}
 	if err := self.AccountDb.CacheAccounting(actKeys, shgKeys, accAlsKeys); err != nil {
@@ -759,7 +759,7 @@ func TestApierSetRatingProfile(t *testing.T) {
 	// Simple test that command is executed without errors
 	if err := rater.Call("Responder.GetCost", cd, &cc); err != nil {
 		t.Error("Got error on Responder.GetCost: ", err.Error())
-	} else if cc.Cost != 0 {
+	} else if cc.Cost.Float64() != 0 {
 		t.Errorf("Calling Responder.GetCost got callcost: %v", cc.Cost)
 	}
 }
@@ -887,8 +887,8 @@ func TestApierGetRatingPlan(t *testing.T) {
 	if len(reply.Timings) != 1 || len(reply.Ratings) != 1 {
 		t.Error("Unexpected number of items received")
 	}
-	riRate := &engine.RIRate{ConnectFee: 0, RoundingMethod: "*up", RoundingDecimals: 2, Rates: []*engine.Rate{
-		&engine.Rate{GroupIntervalStart: 0, Value: 0, RateIncrement: time.Duration(60) * time.Second, RateUnit: time.Duration(60) * time.Second},
+	riRate := &engine.RIRate{ConnectFee: utils.NewDecimalFromFloat(0), RoundingMethod: "*up", RoundingDecimals: 2, Rates: []*engine.Rate{
+		&engine.Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(0), RateIncrement: time.Duration(60) * time.Second, RateUnit: time.Duration(60) * time.Second},
 	}}
 	for _, rating := range reply.Ratings {
 		riRateJson, _ := json.Marshal(rating)
@@ -1157,33 +1157,33 @@ func TestApierGetAccount(t *testing.T) {
 	attrs := &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1001", Direction: "*out"}
 	if err := rater.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 11.5 { // We expect 11.5 since we have added in the previous test 1.5
-		t.Errorf("Calling ApierV1.GetBalance expected: 11.5, received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 11.5 { // We expect 11.5 since we have added in the previous test 1.5
+		t.Errorf("Calling ApierV1.GetBalance expected: 11.5, received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 	attrs = &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "dan", Direction: "*out"}
 	if err := rater.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 1.5 {
-		t.Errorf("Calling ApierV1.GetAccount expected: 1.5, received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 1.5 {
+		t.Errorf("Calling ApierV1.GetAccount expected: 1.5, received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 	// The one we have topped up though executeAction
 	attrs = &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "dan2", Direction: "*out"}
 	if err := rater.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 11.5 {
-		t.Errorf("Calling ApierV1.GetAccount expected: 10, received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 11.5 {
+		t.Errorf("Calling ApierV1.GetAccount expected: 10, received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 	attrs = &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "dan3", Direction: "*out"}
 	if err := rater.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 3.6 {
-		t.Errorf("Calling ApierV1.GetAccount expected: 3.6, received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 3.6 {
+		t.Errorf("Calling ApierV1.GetAccount expected: 3.6, received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 	attrs = &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "dan6", Direction: "*out"}
 	if err := rater.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 1 {
-		t.Errorf("Calling ApierV1.GetAccount expected: 1, received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 1 {
+		t.Errorf("Calling ApierV1.GetAccount expected: 1, received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 }
 
@@ -1287,8 +1287,8 @@ func TestApierGetAccountAfterLoad(t *testing.T) {
 	attrs := &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1001", Direction: "*out"}
 	if err := rater.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 11 {
-		t.Errorf("Calling ApierV1.GetBalance expected: 11, received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 11 {
+		t.Errorf("Calling ApierV1.GetBalance expected: 11, received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 }
 
@@ -1314,7 +1314,7 @@ func TestApierResponderGetCost(t *testing.T) {
 	// Simple test that command is executed without errors
 	if err := rater.Call("Responder.GetCost", cd, &cc); err != nil {
 		t.Error("Got error on Responder.GetCost: ", err.Error())
-	} else if cc.Cost != 90.0 {
+	} else if cc.Cost.Float64() != 90.0 {
 		t.Errorf("Calling Responder.GetCost got callcost: %v", cc)
 	}
 }
@@ -1441,7 +1441,7 @@ func TestApierLocalProcessCdr(t *testing.T) {
 	cdr := engine.StoredCdr{CgrId: utils.Sha1("dsafdsaf", time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC).String()), OrderId: 123, TOR: utils.VOICE, AccId: "dsafdsaf",
 		CdrHost: "192.168.1.1", CdrSource: "test", ReqType: utils.META_RATED, Direction: "*out", Tenant: "cgrates.org", Category: "call", Account: "1001", Subject: "1001", Destination: "1002",
 		SetupTime: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC), AnswerTime: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC), MediationRunId: utils.DEFAULT_RUNID,
-		Usage: time.Duration(10) * time.Second, ExtraFields: map[string]string{"field_extr1": "val_extr1", "fieldextr2": "valextr2"}, Cost: 1.01, RatedAccount: "dan", RatedSubject: "dans",
+		Usage: time.Duration(10) * time.Second, ExtraFields: map[string]string{"field_extr1": "val_extr1", "fieldextr2": "valextr2"}, Cost: utils.NewDecimalFromFloat(1.01), RatedAccount: "dan", RatedSubject: "dans",
 	}
 	if err := rater.Call("CdrsV1.ProcessCdr", cdr, &reply); err != nil {
 		t.Error("Unexpected error: ", err.Error())
@@ -1639,8 +1639,8 @@ func TestApierLocalGetDataCost(t *testing.T) {
 	var rply *engine.DataCost
 	if err := rater.Call("ApierV1.GetDataCost", attrs, &rply); err != nil {
 		t.Error("Unexpected nil error received: ", err.Error())
-	} else if rply.Cost != 128.0240 {
-		t.Errorf("Unexpected cost received: %f", rply.Cost)
+	} else if rply.Cost.Float64() != 128.0240 {
+		t.Errorf("Unexpected cost received: %v", rply.Cost)
 	}
 }
 
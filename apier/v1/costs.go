@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package v1
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/cgrates/cgrates/engine"
@@ -57,3 +58,100 @@ func (apier *ApierV1) GetDataCost(attrs AttrGetDataCost, reply *engine.DataCost)
 	}
 	return nil
 }
+
+type AttrGetCost struct {
+	Direction                             string
+	Category                              string
+	Tenant, Account, Subject, Destination string
+	StartTime                             time.Time
+	Usage                                 int64 // the call duration in seconds
+	Debug                                 bool  // trace the rating decisions along with the cost
+}
+
+// Rates a call, in debug mode the call cost explains how the price was derived
+func (apier *ApierV1) GetCost(attrs AttrGetCost, reply *engine.CallCost) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"Direction", "Category", "Tenant", "Subject", "Destination"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	usageAsDuration := time.Duration(attrs.Usage) * time.Second
+	cd := &engine.CallDescriptor{
+		Direction:     attrs.Direction,
+		Category:      attrs.Category,
+		Tenant:        attrs.Tenant,
+		Account:       attrs.Account,
+		Subject:       attrs.Subject,
+		Destination:   attrs.Destination,
+		TimeStart:     attrs.StartTime,
+		TimeEnd:       attrs.StartTime.Add(usageAsDuration),
+		DurationIndex: usageAsDuration,
+		TOR:           utils.VOICE,
+		Debug:         attrs.Debug,
+	}
+	var cc engine.CallCost
+	if err := apier.Responder.GetCost(cd, &cc); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = cc
+	return nil
+}
+
+// Rates many calls at once, the results keep the order of the call descriptors
+func (apier *ApierV1) GetCosts(cds []*engine.CallDescriptor, reply *[]*engine.CostResult) error {
+	if len(cds) == 0 {
+		return utils.NewErrMandatoryIeMissing("CallDescriptors")
+	}
+	return apier.Responder.GetCosts(cds, reply)
+}
+
+type AttrGetPriceList struct {
+	RatingPlanId string
+	StartTime    time.Time // the rates active at this time, now if not set
+	Usages       []int64   // the call durations in seconds to be priced
+}
+
+// Returns the prices of every prefix in the rating plan as a CSV-style table, header first
+func (apier *ApierV1) GetPriceList(attrs AttrGetPriceList, reply *[][]string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"RatingPlanId"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if attrs.StartTime.IsZero() {
+		attrs.StartTime = time.Now()
+	}
+	var usages []time.Duration
+	for _, usage := range attrs.Usages {
+		if usage <= 0 {
+			return fmt.Errorf("%s:Usages:%d", utils.ErrParserError.Error(), usage)
+		}
+		usages = append(usages, time.Duration(usage)*time.Second)
+	}
+	priceList, err := engine.GetPriceList(attrs.RatingPlanId, attrs.StartTime, usages)
+	if err == utils.ErrNotFound {
+		return err
+	} else if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = priceList
+	return nil
+}
+
+type AttrSimulateRating struct {
+	TPid       string           // the candidate tariff plan loaded in StorDB
+	CdrsFilter utils.CdrsFilter // the rated CDRs to compare the costs on
+}
+
+// Rates the CDRs on the tariff plan without activating it and returns the old vs new costs
+func (apier *ApierV1) SimulateRating(attrs AttrSimulateRating, reply *engine.RatingSimulation) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	sb, err := engine.NewRatingSandbox(apier.StorDb, attrs.TPid)
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	cdrs, _, err := apier.CdrDb.GetStoredCdrs(&attrs.CdrsFilter)
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = *sb.SimulateRating(cdrs)
+	return nil
+}
@@ -68,7 +68,7 @@ func (self *ApierV1) SetDerivedChargers(attrs AttrSetDerivedChargers, reply *str
 	if err := self.RatingDb.SetDerivedChargers(dcKey, attrs.DerivedChargers); err != nil {
 		return utils.NewErrServerError(err)
 	}
-	if err := self.RatingDb.CacheRating([]string{}, []string{}, []string{}, []string{}, []string{}, []string{engine.DERIVEDCHARGERS_PREFIX + dcKey}); err != nil {
+	if err := self.RatingDb.CacheRating([]string{}, []string{}, []string{}, []string{}, []string{}, []string{engine.DERIVEDCHARGERS_PREFIX + dcKey}, []string{}, []string{}, []string{}, []string{}); err != nil {
 		return utils.NewErrServerError(err)
 	}
 	*reply = utils.OK
@@ -100,7 +100,7 @@ func (self *ApierV1) RemDerivedChargers(attrs AttrRemDerivedChargers, reply *str
 	} else {
 		*reply = "OK"
 	}
-	if err := self.RatingDb.CacheRating([]string{}, []string{}, []string{}, []string{}, []string{}, nil); err != nil {
+	if err := self.RatingDb.CacheRating([]string{}, []string{}, []string{}, []string{}, []string{}, nil, []string{}, []string{}, []string{}, []string{}); err != nil {
 		return utils.NewErrServerError(err)
 	}
 	return nil
@@ -0,0 +1,89 @@
+/*
+Real-time Charging System for Telecom & ISP environments
+Copyright (C) 2012-2015 ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package v1
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+type AttrReservation struct {
+	Direction     string
+	Tenant        string
+	Account       string
+	ReservationId string
+	Amount        float64 // the amount to hold or to capture
+	TTL           string  // the hold expires after it, eg: 30m, empty for no expiry
+}
+
+func (attrs *AttrReservation) asReservationRequest() (*engine.ReservationRequest, error) {
+	rr := &engine.ReservationRequest{
+		Direction:     attrs.Direction,
+		Tenant:        attrs.Tenant,
+		Account:       attrs.Account,
+		ReservationId: attrs.ReservationId,
+		Amount:        utils.NewDecimalFromFloat(attrs.Amount),
+	}
+	if rr.Direction == "" {
+		rr.Direction = utils.OUT
+	}
+	if attrs.TTL != "" {
+		ttl, err := utils.ParseDurationWithSecs(attrs.TTL)
+		if err != nil {
+			return nil, err
+		}
+		rr.TTL = ttl
+	}
+	return rr, nil
+}
+
+// Holds the amount on the account balances, the hold is visible in GetAccount
+func (self *ApierV1) ReserveBalance(attrs AttrReservation, reply *engine.Reservation) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"Tenant", "Account", "ReservationId"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	rr, err := attrs.asReservationRequest()
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	return self.Responder.ReserveBalance(rr, reply)
+}
+
+// Debits the actual amount of the reservation, it can be different than the one held
+func (self *ApierV1) CaptureReservation(attrs AttrReservation, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"Tenant", "Account", "ReservationId"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	rr, err := attrs.asReservationRequest()
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	return self.Responder.CaptureReservation(rr, reply)
+}
+
+// Drops the hold without debiting the account
+func (self *ApierV1) ReleaseReservation(attrs AttrReservation, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"Tenant", "Account", "ReservationId"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	rr, err := attrs.asReservationRequest()
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	return self.Responder.ReleaseReservation(rr, reply)
+}
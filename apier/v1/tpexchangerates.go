@@ -0,0 +1,99 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Creates a new ExchangeRates profile within a tariff plan
+func (self *ApierV1) SetTPExchangeRates(attrs utils.TPExchangeRates, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "ExchangeRatesId", "ExchangeRates"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	for _, exr := range attrs.ExchangeRates {
+		if missing := utils.MissingStructFields(exr, []string{"FromCurrency", "ToCurrency"}); len(missing) != 0 {
+			return utils.NewErrMandatoryIeMissing(missing...)
+		}
+	}
+	exrs := engine.APItoModelExchangeRate(&attrs)
+	if err := self.StorDb.SetTpExchangeRates(exrs); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = "OK"
+	return nil
+}
+
+type AttrGetTPExchangeRates struct {
+	TPid            string // Tariff plan id
+	ExchangeRatesId string // ExchangeRates id
+}
+
+// Queries specific ExchangeRates on tariff plan
+func (self *ApierV1) GetTPExchangeRates(attrs AttrGetTPExchangeRates, reply *utils.TPExchangeRates) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "ExchangeRatesId"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if exrs, err := self.StorDb.GetTpExchangeRates(attrs.TPid, attrs.ExchangeRatesId); err != nil {
+		return utils.NewErrServerError(err)
+	} else if len(exrs) == 0 {
+		return utils.ErrNotFound
+	} else {
+		exrMap, err := engine.TpExchangeRates(exrs).GetExchangeRates()
+		if err != nil {
+			return err
+		}
+		*reply = utils.TPExchangeRates{TPid: attrs.TPid, ExchangeRatesId: attrs.ExchangeRatesId, ExchangeRates: exrMap[attrs.ExchangeRatesId]}
+	}
+	return nil
+}
+
+type AttrGetTPExchangeRateIds struct {
+	TPid string // Tariff plan id
+	utils.Paginator
+}
+
+// Queries ExchangeRates identities on specific tariff plan.
+func (self *ApierV1) GetTPExchangeRateIds(attrs AttrGetTPExchangeRateIds, reply *[]string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if ids, err := self.StorDb.GetTpTableIds(attrs.TPid, utils.TBL_TP_EXCHANGE_RATES, utils.TPDistinctIds{"tag"}, nil, &attrs.Paginator); err != nil {
+		return utils.NewErrServerError(err)
+	} else if ids == nil {
+		return utils.ErrNotFound
+	} else {
+		*reply = ids
+	}
+	return nil
+}
+
+// Removes specific ExchangeRates on Tariff plan
+func (self *ApierV1) RemTPExchangeRates(attrs AttrGetTPExchangeRates, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "ExchangeRatesId"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := self.StorDb.RemTpData(utils.TBL_TP_EXCHANGE_RATES, attrs.TPid, attrs.ExchangeRatesId); err != nil {
+		return utils.NewErrServerError(err)
+	} else {
+		*reply = "OK"
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package v1
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Creates a new holiday calendar within a tariff plan
+func (self *ApierV1) SetTPHolidayCalendar(attrs utils.TPHolidayCalendar, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "CalendarId"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	for _, h := range attrs.Holidays {
+		if _, err := engine.NewHoliday(h.Date, h.Name); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	}
+	hcs := engine.APItoModelHolidayCalendar(&attrs)
+	if err := self.StorDb.SetTpHolidays(hcs); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = "OK"
+	return nil
+}
+
+type AttrGetTPHolidayCalendar struct {
+	TPid       string // Tariff plan id
+	CalendarId string // Holiday calendar id
+}
+
+// Queries a specific holiday calendar
+func (self *ApierV1) GetTPHolidayCalendar(attrs AttrGetTPHolidayCalendar, reply *utils.TPHolidayCalendar) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "CalendarId"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if storData, err := self.StorDb.GetTpHolidays(attrs.TPid, attrs.CalendarId); err != nil {
+		return utils.NewErrServerError(err)
+	} else if len(storData) == 0 {
+		return utils.ErrNotFound
+	} else {
+		hcs, err := engine.TpHolidays(storData).GetHolidayCalendars()
+		if err != nil {
+			return err
+		}
+		*reply = *hcs[attrs.CalendarId]
+	}
+	return nil
+}
+
+type AttrGetTPHolidayCalendarIds struct {
+	TPid string // Tariff plan id
+	utils.Paginator
+}
+
+// Queries holiday calendar identities on specific tariff plan.
+func (self *ApierV1) GetTPHolidayCalendarIds(attrs AttrGetTPHolidayCalendarIds, reply *[]string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if ids, err := self.StorDb.GetTpTableIds(attrs.TPid, utils.TBL_TP_HOLIDAYS, utils.TPDistinctIds{"tag"}, nil, &attrs.Paginator); err != nil {
+		return utils.NewErrServerError(err)
+	} else if ids == nil {
+		return utils.ErrNotFound
+	} else {
+		*reply = ids
+	}
+	return nil
+}
+
+func (self *ApierV1) RemTPHolidayCalendar(attrs AttrGetTPHolidayCalendar, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "CalendarId"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := self.StorDb.RemTpData(utils.TBL_TP_HOLIDAYS, attrs.TPid, attrs.CalendarId); err != nil {
+		return utils.NewErrServerError(err)
+	} else {
+		*reply = "OK"
+	}
+	return nil
+}
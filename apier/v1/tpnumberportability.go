@@ -0,0 +1,91 @@
+/*
+Real-time Charging System for Telecom & ISP environments
+Copyright (C) 2012-2015 ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package v1
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Creates a new ported number within a tariff plan
+func (self *ApierV1) SetTPPortedNumber(attrs utils.TPPortedNumber, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "Number", "RoutingNumber"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := self.StorDb.SetTpNumberPortability([]engine.TpNumberPortability{engine.APItoModelPortedNumber(&attrs)}); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = "OK"
+	return nil
+}
+
+type AttrGetTPPortedNumber struct {
+	TPid   string // Tariff plan id
+	Number string // Ported number
+}
+
+// Queries a specific ported number
+func (self *ApierV1) GetTPPortedNumber(attrs AttrGetTPPortedNumber, reply *utils.TPPortedNumber) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "Number"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if storData, err := self.StorDb.GetTpNumberPortability(attrs.TPid, attrs.Number); err != nil {
+		return utils.NewErrServerError(err)
+	} else if len(storData) == 0 {
+		return utils.ErrNotFound
+	} else {
+		pns, err := engine.TpNumberPortabilitys(storData).GetPortedNumbers()
+		if err != nil {
+			return err
+		}
+		*reply = *pns[attrs.Number]
+	}
+	return nil
+}
+
+type AttrGetTPPortedNumbers struct {
+	TPid string // Tariff plan id
+	utils.Paginator
+}
+
+// Queries the ported numbers on specific tariff plan.
+func (self *ApierV1) GetTPPortedNumbers(attrs AttrGetTPPortedNumbers, reply *[]string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if ids, err := self.StorDb.GetTpTableIds(attrs.TPid, utils.TBL_TP_NUMBER_PORTABILITY, utils.TPDistinctIds{"number"}, nil, &attrs.Paginator); err != nil {
+		return utils.NewErrServerError(err)
+	} else if ids == nil {
+		return utils.ErrNotFound
+	} else {
+		*reply = ids
+	}
+	return nil
+}
+
+func (self *ApierV1) RemTPPortedNumber(attrs AttrGetTPPortedNumber, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "Number"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := self.StorDb.RemTpData(utils.TBL_TP_NUMBER_PORTABILITY, attrs.TPid, attrs.Number); err != nil {
+		return utils.NewErrServerError(err)
+	} else {
+		*reply = "OK"
+	}
+	return nil
+}
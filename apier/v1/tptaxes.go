@@ -0,0 +1,106 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package v1
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Creates a new Taxes profile within a tariff plan
+func (self *ApierV1) SetTPTaxes(attrs utils.TPTaxes, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "Tenant", "Category"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	for _, tax := range attrs.Taxes {
+		if missing := utils.MissingStructFields(tax, []string{"DestinationId", "TaxId"}); len(missing) != 0 {
+			return utils.NewErrMandatoryIeMissing(missing...)
+		}
+	}
+	taxes := engine.APItoModelTaxes(&attrs)
+	if err := self.StorDb.SetTpTaxes(taxes); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = "OK"
+	return nil
+}
+
+type AttrGetTPTaxes struct {
+	TPid    string // Tariff plan id
+	TaxesId string // Taxes id, <tenant:category>
+}
+
+// Queries specific Taxes on tariff plan
+func (self *ApierV1) GetTPTaxes(attrs AttrGetTPTaxes, reply *utils.TPTaxes) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "TaxesId"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	filter := &engine.TpTax{Tpid: attrs.TPid}
+	if err := filter.SetTaxesId(attrs.TaxesId); err != nil {
+		return err
+	}
+	if taxes, err := self.StorDb.GetTpTaxes(filter); err != nil {
+		return utils.NewErrServerError(err)
+	} else if len(taxes) == 0 {
+		return utils.ErrNotFound
+	} else {
+		taxesMap, err := engine.TpTaxes(taxes).GetTaxes()
+		if err != nil {
+			return err
+		}
+		*reply = *taxesMap[attrs.TaxesId]
+	}
+	return nil
+}
+
+type AttrGetTPTaxesIds struct {
+	TPid string // Tariff plan id
+	utils.Paginator
+}
+
+// Queries Taxes identities on specific tariff plan.
+func (self *ApierV1) GetTPTaxesIds(attrs AttrGetTPTaxesIds, reply *[]string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if ids, err := self.StorDb.GetTpTableIds(attrs.TPid, utils.TBL_TP_TAXES, utils.TPDistinctIds{"tenant", "category"}, nil, &attrs.Paginator); err != nil {
+		return utils.NewErrServerError(err)
+	} else if ids == nil {
+		return utils.ErrNotFound
+	} else {
+		*reply = ids
+	}
+	return nil
+}
+
+// Removes specific Taxes on Tariff plan
+func (self *ApierV1) RemTPTaxes(attrs AttrGetTPTaxes, reply *string) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"TPid", "TaxesId"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tmpTax := engine.TpTax{}
+	if err := tmpTax.SetTaxesId(attrs.TaxesId); err != nil {
+		return err
+	}
+	if err := self.StorDb.RemTpData(utils.TBL_TP_TAXES, attrs.TPid, tmpTax.Tenant, tmpTax.Category); err != nil {
+		return utils.NewErrServerError(err)
+	} else {
+		*reply = "OK"
+	}
+	return nil
+}
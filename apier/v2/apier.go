@@ -51,7 +51,7 @@ func (self *ApierV2) LoadRatingProfile(attrs AttrLoadRatingProfile, reply *strin
 	if tpRpf.KeyId() != ":::" { // if has some filters
 		ratingProfile = []string{engine.RATING_PROFILE_PREFIX + tpRpf.KeyId()}
 	}
-	if err := self.RatingDb.CacheRating(didNotChange, didNotChange, ratingProfile, didNotChange, didNotChange, didNotChange); err != nil {
+	if err := self.RatingDb.CacheRating(didNotChange, didNotChange, ratingProfile, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, didNotChange); err != nil {
 		return err
 	}
 	*reply = v1.OK
@@ -116,7 +116,7 @@ func (self *ApierV2) LoadDerivedChargers(attrs AttrLoadDerivedChargers, reply *s
 	if len(attrs.DerivedChargersId) != 0 {
 		dcsChanged = []string{engine.DERIVEDCHARGERS_PREFIX + attrs.DerivedChargersId}
 	}
-	if err := self.RatingDb.CacheRating(didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, dcsChanged); err != nil {
+	if err := self.RatingDb.CacheRating(didNotChange, didNotChange, didNotChange, didNotChange, didNotChange, dcsChanged, didNotChange, didNotChange, didNotChange, didNotChange); err != nil {
 		return err
 	}
 	*reply = v1.OK
@@ -0,0 +1,73 @@
+/*
+Real-time Charging System for Telecom & ISP environments
+Copyright (C) 2012-2015 ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package v2
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+type AttrGetAccountLedger struct {
+	Direction    string
+	Tenant       string
+	Account      string
+	BalanceUuids []string // If provided, only the movements on these balances
+	Causes       []string // If provided, only the movements with these causes (*cdr, *refund, *action, *reservation, *api)
+	TimeStart    string   // Movements recorded starting with this time
+	TimeEnd      string   // Movements recorded before this time
+	utils.Paginator
+}
+
+// Retrieves the balance movements of an account, in the order they happened
+func (apier *ApierV2) GetAccountLedger(attrs AttrGetAccountLedger, reply *[]*engine.LedgerEntry) error {
+	if missing := utils.MissingStructFields(&attrs, []string{"Tenant", "Account"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if attrs.Direction == "" {
+		attrs.Direction = utils.OUT
+	}
+	fltr := &utils.LedgerFilter{
+		AccountIds:   []string{utils.ConcatenatedKey(attrs.Direction, attrs.Tenant, attrs.Account)},
+		BalanceUuids: attrs.BalanceUuids,
+		Causes:       attrs.Causes,
+		Paginator:    attrs.Paginator,
+	}
+	if attrs.TimeStart != "" {
+		tStart, err := utils.ParseTimeDetectLayout(attrs.TimeStart)
+		if err != nil {
+			return utils.NewErrServerError(err)
+		}
+		fltr.TimeStart = &tStart
+	}
+	if attrs.TimeEnd != "" {
+		tEnd, err := utils.ParseTimeDetectLayout(attrs.TimeEnd)
+		if err != nil {
+			return utils.NewErrServerError(err)
+		}
+		fltr.TimeEnd = &tEnd
+	}
+	entries, err := apier.CdrDb.GetLedgerEntries(fltr)
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if len(entries) == 0 {
+		entries = make([]*engine.LedgerEntry, 0)
+	}
+	*reply = entries
+	return nil
+}
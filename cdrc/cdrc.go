@@ -83,6 +83,8 @@ func populateStoredCdrField(cdr *engine.StoredCdr, fieldId, fieldVal string) err
 		cdr.Supplier = fieldVal
 	case utils.DISCONNECT_CAUSE:
 		cdr.DisconnectCause = fieldVal
+	case utils.ORIGIN:
+		cdr.Origin = fieldVal
 	default: // Extra fields will not match predefined so they all show up here
 		cdr.ExtraFields[fieldId] = fieldVal
 	}
@@ -286,7 +288,7 @@ func (self *Cdrc) processFile(filePath string) error {
 
 // Takes the record out of csv and turns it into storedCdr which can be processed by CDRS
 func (self *Cdrc) recordToStoredCdr(record []string, cfgIdx int) (*engine.StoredCdr, error) {
-	storedCdr := &engine.StoredCdr{CdrHost: "0.0.0.0", CdrSource: self.cdrSourceIds[cfgIdx], ExtraFields: make(map[string]string), Cost: -1}
+	storedCdr := &engine.StoredCdr{CdrHost: "0.0.0.0", CdrSource: self.cdrSourceIds[cfgIdx], ExtraFields: make(map[string]string), Cost: utils.NewDecimalFromInt(-1)}
 	var err error
 	var lazyHttpFields []*config.CfgCdrField
 	for _, cdrFldCfg := range self.cdrFields[cfgIdx] {
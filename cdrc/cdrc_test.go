@@ -34,6 +34,8 @@ func TestRecordForkCdr(t *testing.T) {
 	cdrcConfig.CdrFields = append(cdrcConfig.CdrFields, &config.CfgCdrField{Tag: "SupplierTest", Type: utils.CDRFIELD, CdrFieldId: "supplier", Value: []*utils.RSRField{&utils.RSRField{Id: "14"}}})
 	cdrcConfig.CdrFields = append(cdrcConfig.CdrFields, &config.CfgCdrField{Tag: "DisconnectCauseTest", Type: utils.CDRFIELD, CdrFieldId: utils.DISCONNECT_CAUSE,
 		Value: []*utils.RSRField{&utils.RSRField{Id: "16"}}})
+	cdrcConfig.CdrFields = append(cdrcConfig.CdrFields, &config.CfgCdrField{Tag: "OriginTest", Type: utils.CDRFIELD, CdrFieldId: utils.ORIGIN,
+		Value: []*utils.RSRField{&utils.RSRField{Id: "17"}}})
 	cdrc := &Cdrc{CdrFormat: CSV, cdrSourceIds: []string{"TEST_CDRC"}, cdrFields: [][]*config.CfgCdrField{cdrcConfig.CdrFields}}
 	cdrRow := []string{"firstField", "secondField"}
 	_, err := cdrc.recordToStoredCdr(cdrRow, 0)
@@ -41,7 +43,7 @@ func TestRecordForkCdr(t *testing.T) {
 		t.Error("Failed to corectly detect missing fields from record")
 	}
 	cdrRow = []string{"ignored", "ignored", utils.VOICE, "acc1", utils.META_PREPAID, "*out", "cgrates.org", "call", "1001", "1001", "+4986517174963",
-		"2013-02-03 19:50:00", "2013-02-03 19:54:00", "62", "supplier1", "172.16.1.1", "NORMAL_DISCONNECT"}
+		"2013-02-03 19:50:00", "2013-02-03 19:54:00", "62", "supplier1", "172.16.1.1", "NORMAL_DISCONNECT", "+40723045326"}
 	rtCdr, err := cdrc.recordToStoredCdr(cdrRow, 0)
 	if err != nil {
 		t.Error("Failed to parse CDR in rated cdr", err)
@@ -64,8 +66,9 @@ func TestRecordForkCdr(t *testing.T) {
 		Usage:           time.Duration(62) * time.Second,
 		Supplier:        "supplier1",
 		DisconnectCause: "NORMAL_DISCONNECT",
+		Origin:          "+40723045326",
 		ExtraFields:     map[string]string{},
-		Cost:            -1,
+		Cost:            utils.NewDecimalFromFloat(-1),
 	}
 	if !reflect.DeepEqual(expectedCdr, rtCdr) {
 		t.Errorf("Expected: \n%v, \nreceived: \n%v", expectedCdr, rtCdr)
@@ -89,7 +92,7 @@ func TestDataMultiplyFactor(t *testing.T) {
 		CdrSource:   "TEST_CDRC",
 		Usage:       time.Duration(1) * time.Second,
 		ExtraFields: map[string]string{},
-		Cost:        -1,
+		Cost:        utils.NewDecimalFromFloat(-1),
 	}
 	if !reflect.DeepEqual(expectedCdr, rtCdr) {
 		t.Errorf("Expected: \n%v, \nreceived: \n%v", expectedCdr, rtCdr)
@@ -102,7 +105,7 @@ func TestDataMultiplyFactor(t *testing.T) {
 		CdrSource:   "TEST_CDRC",
 		Usage:       time.Duration(1024) * time.Second,
 		ExtraFields: map[string]string{},
-		Cost:        -1,
+		Cost:        utils.NewDecimalFromFloat(-1),
 	}
 	if rtCdr, _ := cdrc.recordToStoredCdr(cdrRow, 0); !reflect.DeepEqual(expectedCdr, rtCdr) {
 		t.Errorf("Expected: \n%v, \nreceived: \n%v", expectedCdr, rtCdr)
@@ -115,7 +118,7 @@ func TestDataMultiplyFactor(t *testing.T) {
 		CdrSource:   "TEST_CDRC",
 		Usage:       time.Duration(1) * time.Second,
 		ExtraFields: map[string]string{},
-		Cost:        -1,
+		Cost:        utils.NewDecimalFromFloat(-1),
 	}
 	if rtCdr, _ := cdrc.recordToStoredCdr(cdrRow, 0); !reflect.DeepEqual(expectedCdr, rtCdr) {
 		t.Errorf("Expected: \n%v, \nreceived: \n%v", expectedCdr, rtCdr)
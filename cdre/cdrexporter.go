@@ -101,7 +101,7 @@ type CdrExporter struct {
 	numberOfRecords                                                 int
 	totalDuration, totalDataUsage, totalSmsUsage, totalGenericUsage time.Duration
 
-	totalCost                       float64
+	totalCost                       utils.Decimal
 	firstExpOrderId, lastExpOrderId int64
 	positiveExports                 []string          // CGRIds of successfully exported CDRs
 	negativeExports                 map[string]string // CgrIds of failed exports
@@ -192,6 +192,8 @@ func (cdre *CdrExporter) cdrFieldValue(cdr *engine.StoredCdr, cfgCdrFld *config.
 			}
 		case utils.COST:
 			cdrVal = cdr.FormatCost(cdre.costShiftDigits, cdre.roundDecimals)
+		case utils.TAX_COST:
+			cdrVal = cdr.FormatTaxCost(cdre.costShiftDigits, cdre.roundDecimals)
 		case utils.USAGE:
 			cdrVal = cdr.FormatUsage(layout)
 		case utils.SETUP_TIME:
@@ -237,7 +239,7 @@ func (cdre *CdrExporter) metaHandler(tag, arg string) (string, error) {
 		emulatedCdr := &engine.StoredCdr{TOR: utils.DATA, Usage: cdre.totalDataUsage}
 		return emulatedCdr.FormatUsage(arg), nil
 	case META_COSTCDRS:
-		return strconv.FormatFloat(utils.Round(cdre.totalCost, cdre.roundDecimals, utils.ROUNDING_MIDDLE), 'f', -1, 64), nil
+		return cdre.totalCost.Round(cdre.roundDecimals, utils.ROUNDING_MIDDLE).String(), nil
 	case META_MASKDESTINATION:
 		if cdre.maskedDestination(arg) {
 			return "1", nil
@@ -391,9 +393,8 @@ func (cdre *CdrExporter) processCdr(cdr *engine.StoredCdr) error {
 	if cdr.TOR == utils.DATA { // Count usage for DATA
 		cdre.totalDataUsage += cdr.Usage
 	}
-	if cdr.Cost != -1 {
-		cdre.totalCost += cdr.Cost
-		cdre.totalCost = utils.Round(cdre.totalCost, cdre.roundDecimals, utils.ROUNDING_MIDDLE)
+	if !cdr.Cost.Equal(utils.NewDecimalFromInt(-1)) {
+		cdre.totalCost = cdre.totalCost.Add(cdr.Cost).Round(cdre.roundDecimals, utils.ROUNDING_MIDDLE)
 	}
 	if cdre.firstExpOrderId > cdr.OrderId || cdre.firstExpOrderId == 0 {
 		cdre.firstExpOrderId = cdr.OrderId
@@ -509,7 +510,7 @@ func (cdre *CdrExporter) LastOrderId() int64 {
 }
 
 // Return total cost in the exported cdrs
-func (cdre *CdrExporter) TotalCost() float64 {
+func (cdre *CdrExporter) TotalCost() utils.Decimal {
 	return cdre.totalCost
 }
 
@@ -37,7 +37,7 @@ func TestCsvCdrWriter(t *testing.T) {
 		ReqType: utils.META_RATED, Direction: "*out", Tenant: "cgrates.org",
 		Category: "call", Account: "1001", Subject: "1001", Destination: "1002", SetupTime: time.Unix(1383813745, 0).UTC(), AnswerTime: time.Unix(1383813746, 0).UTC(),
 		Usage: time.Duration(10) * time.Second, MediationRunId: utils.DEFAULT_RUNID,
-		ExtraFields: map[string]string{"extra1": "val_extra1", "extra2": "val_extra2", "extra3": "val_extra3"}, Cost: 1.01,
+		ExtraFields: map[string]string{"extra1": "val_extra1", "extra2": "val_extra2", "extra3": "val_extra3"}, Cost: utils.NewDecimalFromFloat(1.01),
 	}
 	cdre, err := NewCdrExporter([]*engine.StoredCdr{storedCdr1}, nil, cfg.CdreProfiles["*default"], utils.CSV, ',', "firstexport", 0.0, 0.0, 0.0, 0.0, 0, 4,
 		cfg.RoundingDecimals, "", 0, cfg.HttpSkipTlsVerify)
@@ -53,7 +53,7 @@ func TestCsvCdrWriter(t *testing.T) {
 	if result != expected {
 		t.Errorf("Expected: \n%s received: \n%s.", expected, result)
 	}
-	if cdre.TotalCost() != 1.01 {
+	if cdre.TotalCost().Float64() != 1.01 {
 		t.Error("Unexpected TotalCost: ", cdre.TotalCost())
 	}
 }
@@ -65,7 +65,7 @@ func TestAlternativeFieldSeparator(t *testing.T) {
 		ReqType: utils.META_RATED, Direction: "*out", Tenant: "cgrates.org",
 		Category: "call", Account: "1001", Subject: "1001", Destination: "1002", SetupTime: time.Unix(1383813745, 0).UTC(), AnswerTime: time.Unix(1383813746, 0).UTC(),
 		Usage: time.Duration(10) * time.Second, MediationRunId: utils.DEFAULT_RUNID,
-		ExtraFields: map[string]string{"extra1": "val_extra1", "extra2": "val_extra2", "extra3": "val_extra3"}, Cost: 1.01,
+		ExtraFields: map[string]string{"extra1": "val_extra1", "extra2": "val_extra2", "extra3": "val_extra3"}, Cost: utils.NewDecimalFromFloat(1.01),
 	}
 	cdre, err := NewCdrExporter([]*engine.StoredCdr{storedCdr1}, nil, cfg.CdreProfiles["*default"], utils.CSV, '|', "firstexport", 0.0, 0.0, 0.0, 0.0, 0, 4, cfg.RoundingDecimals, "", 0, cfg.HttpSkipTlsVerify)
 	if err != nil {
@@ -80,7 +80,7 @@ func TestAlternativeFieldSeparator(t *testing.T) {
 	if result != expected {
 		t.Errorf("Expected: \n%s received: \n%s.", expected, result)
 	}
-	if cdre.TotalCost() != 1.01 {
+	if cdre.TotalCost().Float64() != 1.01 {
 		t.Error("Unexpected TotalCost: ", cdre.TotalCost())
 	}
 }
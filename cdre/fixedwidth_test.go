@@ -124,7 +124,7 @@ func TestWriteCdr(t *testing.T) {
 		Category: "call", Account: "1001", Subject: "1001", Destination: "1002",
 		SetupTime:  time.Date(2013, 11, 7, 8, 42, 20, 0, time.UTC),
 		AnswerTime: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC),
-		Usage:      time.Duration(10) * time.Second, MediationRunId: utils.DEFAULT_RUNID, Cost: 2.34567,
+		Usage:      time.Duration(10) * time.Second, MediationRunId: utils.DEFAULT_RUNID, Cost: utils.NewDecimalFromFloat(2.34567),
 		ExtraFields: map[string]string{"field_extr1": "val_extr1", "fieldextr2": "valextr2"},
 	}
 	cdre, err := NewCdrExporter([]*engine.StoredCdr{cdr}, nil, cdreCfg, utils.CDRE_FIXED_WIDTH, ',', "fwv_1", 0.0, 0.0, 0.0, 0.0, 0, 4, cfg.RoundingDecimals, "", -1, cfg.HttpSkipTlsVerify)
@@ -153,7 +153,7 @@ func TestWriteCdr(t *testing.T) {
 		t.Error("Unexpected number of records in the stats: ", cdre.numberOfRecords)
 	} else if cdre.totalDuration != cdr.Usage {
 		t.Error("Unexpected total duration in the stats: ", cdre.totalDuration)
-	} else if cdre.totalCost != utils.Round(cdr.Cost, cdre.roundDecimals, utils.ROUNDING_MIDDLE) {
+	} else if cdre.totalCost.Float64() != utils.Round(cdr.Cost.Float64(), cdre.roundDecimals, utils.ROUNDING_MIDDLE) {
 		t.Error("Unexpected total cost in the stats: ", cdre.totalCost)
 	}
 	if cdre.FirstOrderId() != 1 {
@@ -162,7 +162,7 @@ func TestWriteCdr(t *testing.T) {
 	if cdre.LastOrderId() != 1 {
 		t.Error("Unexpected LastOrderId", cdre.LastOrderId())
 	}
-	if cdre.TotalCost() != utils.Round(cdr.Cost, cdre.roundDecimals, utils.ROUNDING_MIDDLE) {
+	if cdre.TotalCost().Float64() != utils.Round(cdr.Cost.Float64(), cdre.roundDecimals, utils.ROUNDING_MIDDLE) {
 		t.Error("Unexpected TotalCost: ", cdre.TotalCost())
 	}
 }
@@ -180,7 +180,7 @@ func TestWriteCdrs(t *testing.T) {
 		Category: "call", Account: "1001", Subject: "1001", Destination: "1010",
 		SetupTime:  time.Date(2013, 11, 7, 8, 42, 20, 0, time.UTC),
 		AnswerTime: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC),
-		Usage:      time.Duration(10) * time.Second, MediationRunId: utils.DEFAULT_RUNID, Cost: 2.25,
+		Usage:      time.Duration(10) * time.Second, MediationRunId: utils.DEFAULT_RUNID, Cost: utils.NewDecimalFromFloat(2.25),
 		ExtraFields: map[string]string{"productnumber": "12341", "fieldextr2": "valextr2"},
 	}
 	cdr2 := &engine.StoredCdr{CgrId: utils.Sha1("aaa2", time.Date(2013, 11, 7, 7, 42, 20, 0, time.UTC).String()),
@@ -188,7 +188,7 @@ func TestWriteCdrs(t *testing.T) {
 		Category: "call", Account: "1002", Subject: "1002", Destination: "1011",
 		SetupTime:  time.Date(2013, 11, 7, 7, 42, 20, 0, time.UTC),
 		AnswerTime: time.Date(2013, 11, 7, 7, 42, 26, 0, time.UTC),
-		Usage:      time.Duration(5) * time.Minute, MediationRunId: utils.DEFAULT_RUNID, Cost: 1.40001,
+		Usage:      time.Duration(5) * time.Minute, MediationRunId: utils.DEFAULT_RUNID, Cost: utils.NewDecimalFromFloat(1.40001),
 		ExtraFields: map[string]string{"productnumber": "12342", "fieldextr2": "valextr2"},
 	}
 	cdr3 := &engine.StoredCdr{}
@@ -197,7 +197,7 @@ func TestWriteCdrs(t *testing.T) {
 		Category: "call", Account: "1004", Subject: "1004", Destination: "1013",
 		SetupTime:  time.Date(2013, 11, 7, 9, 42, 18, 0, time.UTC),
 		AnswerTime: time.Date(2013, 11, 7, 9, 42, 26, 0, time.UTC),
-		Usage:      time.Duration(20) * time.Second, MediationRunId: utils.DEFAULT_RUNID, Cost: 2.34567,
+		Usage:      time.Duration(20) * time.Second, MediationRunId: utils.DEFAULT_RUNID, Cost: utils.NewDecimalFromFloat(2.34567),
 		ExtraFields: map[string]string{"productnumber": "12344", "fieldextr2": "valextr2"},
 	}
 	cfg, _ := config.NewDefaultCGRConfig()
@@ -225,7 +225,7 @@ func TestWriteCdrs(t *testing.T) {
 	if cdre.totalDuration != time.Duration(330)*time.Second {
 		t.Error("Unexpected total duration in the stats: ", cdre.totalDuration)
 	}
-	if cdre.totalCost != 5.9957 {
+	if cdre.totalCost.Float64() != 5.9957 {
 		t.Error("Unexpected total cost in the stats: ", cdre.totalCost)
 	}
 	if cdre.FirstOrderId() != 2 {
@@ -234,7 +234,7 @@ func TestWriteCdrs(t *testing.T) {
 	if cdre.LastOrderId() != 4 {
 		t.Error("Unexpected LastOrderId", cdre.LastOrderId())
 	}
-	if cdre.TotalCost() != 5.9957 {
+	if cdre.TotalCost().Float64() != 5.9957 {
 		t.Error("Unexpected TotalCost: ", cdre.TotalCost())
 	}
 }
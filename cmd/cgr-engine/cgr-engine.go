@@ -76,7 +76,7 @@ var (
 )
 
 func cacheData(ratingDb engine.RatingStorage, accountDb engine.AccountingStorage, doneChan chan struct{}) {
-	if err := ratingDb.CacheRating(nil, nil, nil, nil, nil, nil); err != nil {
+	if err := ratingDb.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
 		engine.Logger.Crit(fmt.Sprintf("Cache rating error: %s", err.Error()))
 		exitChan <- true
 		return
@@ -572,6 +572,7 @@ func main() {
 			apierRpcV1.Sched = sched
 			apierRpcV2.Sched = sched
 			sched.LoadActionPlans(accountDb)
+			go sched.LoopExpiringBalances(cfg.SchedulerExpInterval)
 			sched.Loop()
 		}()
 	}
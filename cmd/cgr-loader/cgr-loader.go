@@ -67,6 +67,9 @@ var (
 	stats           = flag.Bool("stats", false, "Generates statsistics about given data.")
 	fromStorDb      = flag.Bool("from_stordb", false, "Load the tariff plan from storDb to dataDb")
 	toStorDb        = flag.Bool("to_stordb", false, "Import the tariff plan from files to storDb")
+	migrateDecimals = flag.Bool("migrate_decimals", false, "Convert the float money values of accounts and actions in accountDb to decimals and exit (rating data needs a tariff plan reload)")
+	migrateLimits   = flag.Bool("migrate_credit_limits", false, "Convert the AllowNegative flag of the accounts in accountDb to unlimited credit limits and exit")
+	reindexExpiring = flag.Bool("reindex_expiring_triggers", false, "Rebuild the index of the accounts with *balance_expiring triggers in accountDb and exit")
 	historyServer   = flag.String("history_server", cgrConfig.RPCGOBListen, "The history server address:port, empty to disable automaticautomatic  history archiving")
 	raterAddress    = flag.String("rater_address", cgrConfig.RPCGOBListen, "Rater service to contact for cache reloads, empty to disable automatic cache reloads")
 	cdrstatsAddress = flag.String("cdrstats_address", cgrConfig.RPCGOBListen, "CDRStats service to contact for data reloads, empty to disable automatic data reloads")
@@ -130,6 +133,30 @@ func main() {
 			}
 			return
 		}
+		if *migrateDecimals {
+			migrated, err := accountDb.MigrateDecimalValues()
+			if err != nil {
+				log.Fatalf("Could not migrate decimal values: %s", err.Error())
+			}
+			log.Printf("Migrated %d accounts and actions to decimal values", migrated)
+			return
+		}
+		if *migrateLimits {
+			migrated, err := accountDb.MigrateCreditLimits()
+			if err != nil {
+				log.Fatalf("Could not migrate credit limits: %s", err.Error())
+			}
+			log.Printf("Migrated %d accounts to credit limits", migrated)
+			return
+		}
+		if *reindexExpiring {
+			indexed, err := accountDb.ReindexExpiringTriggers()
+			if err != nil {
+				log.Fatalf("Could not reindex the expiring triggers: %s", err.Error())
+			}
+			log.Printf("Indexed %d accounts with expiring triggers", indexed)
+			return
+		}
 	}
 	if *fromStorDb { // Load Tariff Plan from storDb into dataDb
 		loader = storDb
@@ -154,7 +181,11 @@ func main() {
 			path.Join(*dataPath, utils.ACTION_TRIGGERS_CSV),
 			path.Join(*dataPath, utils.ACCOUNT_ACTIONS_CSV),
 			path.Join(*dataPath, utils.DERIVED_CHARGERS_CSV),
-			path.Join(*dataPath, utils.CDR_STATS_CSV))
+			path.Join(*dataPath, utils.CDR_STATS_CSV),
+			path.Join(*dataPath, utils.EXCHANGE_RATES_CSV),
+			path.Join(*dataPath, utils.TAXES_CSV),
+			path.Join(*dataPath, utils.HOLIDAYS_CSV),
+			path.Join(*dataPath, utils.NUMBER_PORTABILITY_CSV))
 	}
 	tpReader := engine.NewTpReader(ratingDb, accountDb, loader, *tpid)
 	err = tpReader.LoadAll()
@@ -225,6 +256,10 @@ func main() {
 		accAliases, _ := tpReader.GetLoadedIds(engine.ACC_ALIAS_PREFIX)
 		lcrIds, _ := tpReader.GetLoadedIds(engine.LCR_PREFIX)
 		dcs, _ := tpReader.GetLoadedIds(engine.DERIVEDCHARGERS_PREFIX)
+		exrIds, _ := tpReader.GetLoadedIds(engine.EXCHANGE_RATE_PREFIX)
+		taxIds, _ := tpReader.GetLoadedIds(engine.TAXES_PREFIX)
+		hclIds, _ := tpReader.GetLoadedIds(engine.HOLIDAY_CALENDAR_PREFIX)
+		mnpIds, _ := tpReader.GetLoadedIds(engine.NUMBER_PORTABILITY_PREFIX)
 		// Reload cache first since actions could be calling info from within
 		if *verbose {
 			log.Print("Reloading cache")
@@ -242,6 +277,10 @@ func main() {
 			AccAliases:       accAliases,
 			LCRIds:           lcrIds,
 			DerivedChargers:  dcs,
+			ExchangeRates:    exrIds,
+			Taxes:            taxIds,
+			HolidayCalendars: hclIds,
+			PortedNumbers:    mnpIds,
 		}, &reply); err != nil {
 			log.Printf("WARNING: Got error on cache reload: %s\n", err.Error())
 		}
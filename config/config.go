@@ -194,6 +194,7 @@ type CGRConfig struct {
 	RaterCdrStats        string        // address where to reach the cdrstats service. Empty to disable stats gathering  <""|internal|x.y.z.y:1234>
 	BalancerEnabled      bool
 	SchedulerEnabled     bool
+	SchedulerExpInterval time.Duration        // Interval to check the *balance_expiring triggers, 0 to disable
 	CDRSEnabled          bool                 // Enable CDR Server service
 	CDRSExtraFields      []*utils.RSRField    // Extra fields to store in CDRs
 	CDRSStoreCdrs        bool                 // store cdrs in storDb
@@ -540,8 +541,15 @@ func (self *CGRConfig) loadFromJsonCfg(jsnCfg *CgrJsonCfg) error {
 		self.BalancerEnabled = *jsnBalancerCfg.Enabled
 	}
 
-	if jsnSchedCfg != nil && jsnSchedCfg.Enabled != nil {
-		self.SchedulerEnabled = *jsnSchedCfg.Enabled
+	if jsnSchedCfg != nil {
+		if jsnSchedCfg.Enabled != nil {
+			self.SchedulerEnabled = *jsnSchedCfg.Enabled
+		}
+		if jsnSchedCfg.Balance_expiring_interval != nil {
+			if self.SchedulerExpInterval, err = utils.ParseDurationWithSecs(*jsnSchedCfg.Balance_expiring_interval); err != nil {
+				return err
+			}
+		}
 	}
 
 	if jsnCdrsCfg != nil {
@@ -92,6 +92,7 @@ const CGRATES_CFG_JSON = `
 
 "scheduler": {
 	"enabled": false,						// start Scheduler service: <true|false>
+	"balance_expiring_interval": "5m",		// interval to check for balances about to expire (*balance_expiring triggers), <0> to disable
 },
 
 
@@ -128,7 +128,7 @@ func TestDfRaterJsonCfg(t *testing.T) {
 }
 
 func TestDfSchedulerJsonCfg(t *testing.T) {
-	eCfg := &SchedulerJsonCfg{Enabled: utils.BoolPointer(false)}
+	eCfg := &SchedulerJsonCfg{Enabled: utils.BoolPointer(false), Balance_expiring_interval: utils.StringPointer("5m")}
 	if cfg, err := dfCgrJsonCfg.SchedulerJsonCfg(); err != nil {
 		t.Error(err)
 	} else if !reflect.DeepEqual(eCfg, cfg) {
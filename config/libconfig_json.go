@@ -63,7 +63,8 @@ type RaterJsonCfg struct {
 
 // Scheduler config section
 type SchedulerJsonCfg struct {
-	Enabled *bool
+	Enabled                   *bool
+	Balance_expiring_interval *string
 }
 
 // Cdrs config section
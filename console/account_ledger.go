@@ -0,0 +1,74 @@
+/*
+Real-time Charging System for Telecom & ISP environments
+Copyright (C) 2012-2015 ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package console
+
+import (
+	"github.com/cgrates/cgrates/apier/v2"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdGetAccountLedger{
+		name:       "account_ledger",
+		rpcMethod:  "ApierV2.GetAccountLedger",
+		clientArgs: []string{"Direction", "Tenant", "Account", "BalanceUuids", "Causes", "TimeStart", "TimeEnd", "Limit", "Offset"},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// Commander implementation
+type CmdGetAccountLedger struct {
+	name       string
+	rpcMethod  string
+	rpcParams  *v2.AttrGetAccountLedger
+	clientArgs []string
+	*CommandExecuter
+}
+
+func (self *CmdGetAccountLedger) Name() string {
+	return self.name
+}
+
+func (self *CmdGetAccountLedger) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdGetAccountLedger) RpcParams(ptr bool) interface{} {
+	if self.rpcParams == nil {
+		self.rpcParams = &v2.AttrGetAccountLedger{Direction: utils.OUT}
+	}
+	if ptr {
+		return self.rpcParams
+	}
+	return *self.rpcParams
+}
+
+func (self *CmdGetAccountLedger) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdGetAccountLedger) RpcResult() interface{} {
+	var entries []*engine.LedgerEntry
+	return &entries
+}
+
+func (self *CmdGetAccountLedger) ClientArgs() []string {
+	return self.clientArgs
+}
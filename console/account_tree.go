@@ -0,0 +1,67 @@
+/*
+Real-time Charging System for Telecom & ISP environments
+Copyright (C) 2012-2015 ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package console
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdGetAccountTree{
+		name:      "account_tree",
+		rpcMethod: "ApierV1.GetAccountTree",
+		rpcParams: &utils.AttrGetAccount{Direction: "*out"},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// Commander implementation
+type CmdGetAccountTree struct {
+	name      string
+	rpcMethod string
+	rpcParams *utils.AttrGetAccount
+	*CommandExecuter
+}
+
+func (self *CmdGetAccountTree) Name() string {
+	return self.name
+}
+
+func (self *CmdGetAccountTree) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdGetAccountTree) RpcParams(ptr bool) interface{} {
+	if self.rpcParams == nil {
+		self.rpcParams = &utils.AttrGetAccount{Direction: "*out"}
+	}
+	if ptr {
+		return self.rpcParams
+	}
+	return *self.rpcParams
+}
+
+func (self *CmdGetAccountTree) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdGetAccountTree) RpcResult() interface{} {
+	return &engine.AccountTree{}
+}
@@ -0,0 +1,64 @@
+/*
+Real-time Charging System for Telecom & ISP environments
+Copyright (C) 2012-2015 ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package console
+
+import "github.com/cgrates/cgrates/apier/v1"
+
+func init() {
+	c := &CmdSnapshotAccounts{
+		name:      "accounts_snapshot",
+		rpcMethod: "ApierV1.SnapshotAccounts",
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// Commander implementation
+type CmdSnapshotAccounts struct {
+	name      string
+	rpcMethod string
+	rpcParams *v1.AttrAccountsSnapshot
+	*CommandExecuter
+}
+
+func (self *CmdSnapshotAccounts) Name() string {
+	return self.name
+}
+
+func (self *CmdSnapshotAccounts) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdSnapshotAccounts) RpcParams(ptr bool) interface{} {
+	if self.rpcParams == nil {
+		self.rpcParams = &v1.AttrAccountsSnapshot{Direction: "*out"}
+	}
+	if ptr {
+		return self.rpcParams
+	}
+	return *self.rpcParams
+}
+
+func (self *CmdSnapshotAccounts) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdSnapshotAccounts) RpcResult() interface{} {
+	var s string
+	return &s
+}
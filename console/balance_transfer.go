@@ -0,0 +1,67 @@
+/*
+Real-time Charging System for Telecom & ISP environments
+Copyright (C) 2012-2015 ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package console
+
+import (
+	"github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdTransferBalance{
+		name:      "balance_transfer",
+		rpcMethod: "ApierV1.TransferBalance",
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// Commander implementation
+type CmdTransferBalance struct {
+	name      string
+	rpcMethod string
+	rpcParams *v1.AttrTransferBalance
+	*CommandExecuter
+}
+
+func (self *CmdTransferBalance) Name() string {
+	return self.name
+}
+
+func (self *CmdTransferBalance) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdTransferBalance) RpcParams(ptr bool) interface{} {
+	if self.rpcParams == nil {
+		self.rpcParams = &v1.AttrTransferBalance{BalanceType: utils.MONETARY}
+	}
+	if ptr {
+		return self.rpcParams
+	}
+	return *self.rpcParams
+}
+
+func (self *CmdTransferBalance) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdTransferBalance) RpcResult() interface{} {
+	var s string
+	return &s
+}
@@ -24,7 +24,7 @@ func init() {
 	c := &CmdGetCost{
 		name:       "cost",
 		rpcMethod:  "Responder.GetCost",
-		clientArgs: []string{"Direction", "Category", "TOR", "Tenant", "Subject", "Account", "Destination", "TimeStart", "TimeEnd", "CallDuration", "FallbackSubject"},
+		clientArgs: []string{"Direction", "Category", "TOR", "Tenant", "Subject", "Account", "Destination", "TimeStart", "TimeEnd", "CallDuration", "FallbackSubject", "Debug"},
 	}
 	commands[c.Name()] = c
 	c.CommandExecuter = &CommandExecuter{c}
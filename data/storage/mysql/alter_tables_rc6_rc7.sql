@@ -0,0 +1,121 @@
+ALTER TABLE cost_details
+	MODIFY COLUMN cost DECIMAL(30,10) NOT NULL;
+
+ALTER TABLE rated_cdrs
+	MODIFY COLUMN cost DECIMAL(30,10) DEFAULT NULL;
+
+ALTER TABLE tp_destination_rates
+	ADD COLUMN currency varchar(8) NOT NULL DEFAULT '' AFTER max_cost_strategy;
+
+ALTER TABLE tp_actions
+	ADD COLUMN currency varchar(8) NOT NULL DEFAULT '' AFTER weight;
+
+CREATE TABLE `tp_exchange_rates` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `tag` varchar(64) NOT NULL,
+  `from_currency` varchar(8) NOT NULL,
+  `to_currency` varchar(8) NOT NULL,
+  `rate` DECIMAL(20,10) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  UNIQUE KEY `unique_exchange_rate` (`tpid`,`tag`,`from_currency`,`to_currency`)
+);
+
+ALTER TABLE rated_cdrs
+	ADD COLUMN taxes text AFTER cost;
+
+ALTER TABLE rated_cdrs
+	ADD COLUMN routing_number varchar(128) NOT NULL DEFAULT '' AFTER taxes;
+
+CREATE TABLE `tp_taxes` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `tenant` varchar(64) NOT NULL,
+  `category` varchar(32) NOT NULL,
+  `destination_tag` varchar(64) NOT NULL,
+  `tax_id` varchar(64) NOT NULL,
+  `rate` DECIMAL(8,4) NOT NULL,
+  `debit` BOOLEAN NOT NULL,
+  `weight` DECIMAL(8,2) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  UNIQUE KEY `unique_tax` (`tpid`,`tenant`,`category`,`destination_tag`,`tax_id`)
+);
+
+CREATE TABLE `tp_holidays` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `tag` varchar(64) NOT NULL,
+  `date` varchar(32) NOT NULL,
+  `name` varchar(64) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  KEY `tpid_tag` (`tpid`,`tag`),
+  UNIQUE KEY `unique_holiday` (`tpid`,`tag`,`date`)
+);
+
+CREATE TABLE `tp_number_portability` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `number` varchar(128) NOT NULL,
+  `routing_number` varchar(128) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  UNIQUE KEY `unique_number` (`tpid`,`number`)
+);
+
+ALTER TABLE tp_rating_profiles
+	ADD COLUMN timezone varchar(64) NOT NULL DEFAULT '' AFTER cdr_stat_queue_ids;
+
+ALTER TABLE tp_destination_rates
+	ADD COLUMN usage_start varchar(24) NOT NULL DEFAULT '' AFTER currency,
+	DROP INDEX tpid_drid_dstid,
+	ADD UNIQUE KEY `tpid_drid_dstid` (`tpid`,`tag`,`destinations_tag`,`usage_start`);
+
+ALTER TABLE tp_rates
+	ADD COLUMN min_cost decimal(7,4) NOT NULL DEFAULT 0 AFTER group_interval_start,
+	ADD COLUMN max_cost decimal(7,4) NOT NULL DEFAULT 0 AFTER min_cost;
+
+ALTER TABLE tp_destination_rates
+	ADD COLUMN origins_tag varchar(64) NOT NULL DEFAULT '' AFTER usage_start,
+	DROP INDEX tpid_drid_dstid,
+	ADD UNIQUE KEY `tpid_drid_dstid` (`tpid`,`tag`,`destinations_tag`,`usage_start`,`origins_tag`);
+
+ALTER TABLE tp_derived_chargers
+	ADD COLUMN origin_field varchar(24) NOT NULL DEFAULT '' AFTER disconnect_cause_field;
+
+ALTER TABLE cdrs_primary
+	ADD COLUMN origin varchar(128) NOT NULL DEFAULT '' AFTER disconnect_cause;
+
+ALTER TABLE rated_cdrs
+	ADD COLUMN origin varchar(128) NOT NULL DEFAULT '' AFTER disconnect_cause;
+
+CREATE TABLE `account_ledger` (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  account varchar(192) NOT NULL,
+  balance_type varchar(32) NOT NULL,
+  balance_uuid varchar(64) NOT NULL,
+  balance_id varchar(64) NOT NULL,
+  delta DECIMAL(30,10) NOT NULL,
+  value DECIMAL(30,10) NOT NULL,
+  cause varchar(24) NOT NULL,
+  cause_id varchar(128) NOT NULL,
+  created_at TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY account_created_at_idx (account, created_at)
+);
+
+CREATE TABLE `account_snapshots` (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  snapshot_id varchar(64) NOT NULL,
+  account varchar(192) NOT NULL,
+  content MEDIUMTEXT NOT NULL,
+  created_at TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY snapshot_account (snapshot_id, account)
+);
@@ -24,6 +24,7 @@ CREATE TABLE cdrs_primary (
   `usage` DECIMAL(30,9) NOT NULL,
   supplier varchar(128) NOT NULL,
   disconnect_cause varchar(64) NOT NULL,
+  origin varchar(128) NOT NULL DEFAULT '',
   created_at TIMESTAMP,
   deleted_at TIMESTAMP,
   PRIMARY KEY (id),
@@ -65,7 +66,7 @@ CREATE TABLE cost_details (
   account varchar(128) NOT NULL,
   subject varchar(128) NOT NULL,
   destination varchar(128) NOT NULL,
-  cost DECIMAL(20,4) NOT NULL,
+  cost DECIMAL(30,10) NOT NULL,
   timespans text,
   cost_source varchar(64) NOT NULL,
   created_at TIMESTAMP,
@@ -97,7 +98,10 @@ CREATE TABLE `rated_cdrs` (
   `usage` DECIMAL(30,9) NOT NULL,
   supplier varchar(128) NOT NULL,
   disconnect_cause varchar(64) NOT NULL,
-  cost DECIMAL(20,4) DEFAULT NULL,
+  origin varchar(128) NOT NULL DEFAULT '',
+  cost DECIMAL(30,10) DEFAULT NULL,
+  taxes text,
+  routing_number varchar(128) NOT NULL DEFAULT '',
   extra_info text,
   created_at TIMESTAMP,
   updated_at TIMESTAMP,
@@ -105,4 +109,37 @@ CREATE TABLE `rated_cdrs` (
   PRIMARY KEY (`id`),
   UNIQUE KEY `costid` (`cgrid`,`runid`),
   KEY deleted_at_idx (deleted_at)
+);
+
+--
+-- Table structure for table `account_ledger`
+--
+DROP TABLE IF EXISTS account_ledger;
+CREATE TABLE `account_ledger` (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  account varchar(192) NOT NULL,
+  balance_type varchar(32) NOT NULL,
+  balance_uuid varchar(64) NOT NULL,
+  balance_id varchar(64) NOT NULL,
+  delta DECIMAL(30,10) NOT NULL,
+  value DECIMAL(30,10) NOT NULL,
+  cause varchar(24) NOT NULL,
+  cause_id varchar(128) NOT NULL,
+  created_at TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY account_created_at_idx (account, created_at)
+);
+
+--
+-- Table structure for table `account_snapshots`
+--
+DROP TABLE IF EXISTS account_snapshots;
+CREATE TABLE `account_snapshots` (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  snapshot_id varchar(64) NOT NULL,
+  account varchar(192) NOT NULL,
+  content MEDIUMTEXT NOT NULL,
+  created_at TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY snapshot_account (snapshot_id, account)
 );
\ No newline at end of file
@@ -49,6 +49,8 @@ CREATE TABLE `tp_rates` (
   `rate_unit` varchar(16) NOT NULL,
   `rate_increment` varchar(16) NOT NULL,
   `group_interval_start` varchar(16) NOT NULL,
+  `min_cost` decimal(7,4) NOT NULL,
+  `max_cost` decimal(7,4) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   UNIQUE KEY `unique_tprate` (`tpid`,`tag`,`group_interval_start`),
@@ -71,11 +73,14 @@ CREATE TABLE `tp_destination_rates` (
   `rounding_decimals` tinyint(4) NOT NULL,
   `max_cost` decimal(7,4) NOT NULL,
   `max_cost_strategy` varchar(16) NOT NULL,
+  `currency` varchar(8) NOT NULL,
+  `usage_start` varchar(24) NOT NULL,
+  `origins_tag` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`),
   KEY `tpid_drid` (`tpid`,`tag`),
-  UNIQUE KEY `tpid_drid_dstid` (`tpid`,`tag`,`destinations_tag`)
+  UNIQUE KEY `tpid_drid_dstid` (`tpid`,`tag`,`destinations_tag`,`usage_start`,`origins_tag`)
 );
 
 --
@@ -114,6 +119,7 @@ CREATE TABLE `tp_rating_profiles` (
   `rating_plan_tag` varchar(64) NOT NULL,
   `fallback_subjects` varchar(64),
   `cdr_stat_queue_ids` varchar(64),
+  `timezone` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
    KEY `tpid` (`tpid`),
@@ -139,6 +145,79 @@ CREATE TABLE `tp_shared_groups` (
   UNIQUE KEY `unique_shared_group` (`tpid`,`tag`,`account`,`strategy`,`rating_subject`)
 );
 
+--
+-- Table structure for table `tp_exchange_rates`
+--
+
+DROP TABLE IF EXISTS `tp_exchange_rates`;
+CREATE TABLE `tp_exchange_rates` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `tag` varchar(64) NOT NULL,
+  `from_currency` varchar(8) NOT NULL,
+  `to_currency` varchar(8) NOT NULL,
+  `rate` DECIMAL(20,10) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  UNIQUE KEY `unique_exchange_rate` (`tpid`,`tag`,`from_currency`,`to_currency`)
+);
+
+--
+-- Table structure for table `tp_taxes`
+--
+
+DROP TABLE IF EXISTS `tp_taxes`;
+CREATE TABLE `tp_taxes` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `tenant` varchar(64) NOT NULL,
+  `category` varchar(32) NOT NULL,
+  `destination_tag` varchar(64) NOT NULL,
+  `tax_id` varchar(64) NOT NULL,
+  `rate` DECIMAL(8,4) NOT NULL,
+  `debit` BOOLEAN NOT NULL,
+  `weight` DECIMAL(8,2) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  UNIQUE KEY `unique_tax` (`tpid`,`tenant`,`category`,`destination_tag`,`tax_id`)
+);
+
+--
+-- Table structure for table `tp_holidays`
+--
+
+DROP TABLE IF EXISTS `tp_holidays`;
+CREATE TABLE `tp_holidays` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `tag` varchar(64) NOT NULL,
+  `date` varchar(32) NOT NULL,
+  `name` varchar(64) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  KEY `tpid_tag` (`tpid`,`tag`),
+  UNIQUE KEY `unique_holiday` (`tpid`,`tag`,`date`)
+);
+
+--
+-- Table structure for table `tp_number_portability`
+--
+
+DROP TABLE IF EXISTS `tp_number_portability`;
+CREATE TABLE `tp_number_portability` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `number` varchar(128) NOT NULL,
+  `routing_number` varchar(128) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  UNIQUE KEY `unique_number` (`tpid`,`number`)
+);
+
 --
 -- Table structure for table `tp_actions`
 --
@@ -162,6 +241,7 @@ CREATE TABLE `tp_actions` (
   `balance_weight` DECIMAL(8,2) NOT NULL,
   `extra_parameters` varchar(256) NOT NULL,
   `weight` DECIMAL(8,2) NOT NULL,
+  `currency` varchar(8) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`),
@@ -292,6 +372,7 @@ CREATE TABLE tp_derived_chargers (
   `usage_field`  varchar(24) NOT NULL,
   `supplier_field`  varchar(24) NOT NULL,
   `disconnect_cause_field`  varchar(24) NOT NULL,
+  `origin_field`  varchar(24) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`)
@@ -0,0 +1,121 @@
+ALTER TABLE cost_details
+	ALTER COLUMN cost TYPE NUMERIC(30,10);
+
+ALTER TABLE rated_cdrs
+	ALTER COLUMN cost TYPE NUMERIC(30,10);
+
+ALTER TABLE tp_destination_rates
+	ADD COLUMN currency VARCHAR(8) NOT NULL DEFAULT '';
+
+ALTER TABLE tp_actions
+	ADD COLUMN currency VARCHAR(8) NOT NULL DEFAULT '';
+
+CREATE TABLE tp_exchange_rates (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  tag VARCHAR(64) NOT NULL,
+  from_currency VARCHAR(8) NOT NULL,
+  to_currency VARCHAR(8) NOT NULL,
+  rate NUMERIC(20,10) NOT NULL,
+  created_at TIMESTAMP,
+  UNIQUE (tpid, tag, from_currency, to_currency)
+);
+CREATE INDEX tpexchangerates_tpid_idx ON tp_exchange_rates (tpid);
+CREATE INDEX tpexchangerates_idx ON tp_exchange_rates (tpid,tag);
+
+ALTER TABLE rated_cdrs
+	ADD COLUMN taxes text;
+
+ALTER TABLE rated_cdrs
+	ADD COLUMN routing_number VARCHAR(128) NOT NULL DEFAULT '';
+
+CREATE TABLE tp_taxes (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  tenant VARCHAR(64) NOT NULL,
+  category VARCHAR(32) NOT NULL,
+  destination_tag VARCHAR(64) NOT NULL,
+  tax_id VARCHAR(64) NOT NULL,
+  rate NUMERIC(8,4) NOT NULL,
+  debit BOOLEAN NOT NULL,
+  weight NUMERIC(8,2) NOT NULL,
+  created_at TIMESTAMP,
+  UNIQUE (tpid, tenant, category, destination_tag, tax_id)
+);
+CREATE INDEX tptaxes_tpid_idx ON tp_taxes (tpid);
+CREATE INDEX tptaxes_idx ON tp_taxes (tpid,tenant,category);
+
+CREATE TABLE tp_holidays (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  tag VARCHAR(64) NOT NULL,
+  date VARCHAR(32) NOT NULL,
+  name VARCHAR(64) NOT NULL,
+  created_at TIMESTAMP,
+  UNIQUE (tpid, tag, date)
+);
+CREATE INDEX tpholidays_tpid_idx ON tp_holidays (tpid);
+CREATE INDEX tpholidays_idx ON tp_holidays (tpid,tag);
+
+CREATE TABLE tp_number_portability (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  number VARCHAR(128) NOT NULL,
+  routing_number VARCHAR(128) NOT NULL,
+  created_at TIMESTAMP,
+  UNIQUE (tpid, number)
+);
+CREATE INDEX tpnumberportability_tpid_idx ON tp_number_portability (tpid);
+
+ALTER TABLE tp_rating_profiles
+	ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE tp_destination_rates
+	ADD COLUMN usage_start VARCHAR(24) NOT NULL DEFAULT '';
+ALTER TABLE tp_destination_rates
+	DROP CONSTRAINT tp_destination_rates_tpid_tag_destinations_tag_key;
+ALTER TABLE tp_destination_rates
+	ADD UNIQUE (tpid, tag, destinations_tag, usage_start);
+
+ALTER TABLE tp_rates
+	ADD COLUMN min_cost NUMERIC(7,4) NOT NULL DEFAULT 0,
+	ADD COLUMN max_cost NUMERIC(7,4) NOT NULL DEFAULT 0;
+
+ALTER TABLE tp_destination_rates
+	ADD COLUMN origins_tag VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE tp_destination_rates
+	DROP CONSTRAINT tp_destination_rates_tpid_tag_destinations_tag_usage_start_key;
+ALTER TABLE tp_destination_rates
+	ADD UNIQUE (tpid, tag, destinations_tag, usage_start, origins_tag);
+
+ALTER TABLE tp_derived_chargers
+	ADD COLUMN origin_field VARCHAR(24) NOT NULL DEFAULT '';
+
+ALTER TABLE cdrs_primary
+	ADD COLUMN origin VARCHAR(128) NOT NULL DEFAULT '';
+
+ALTER TABLE rated_cdrs
+	ADD COLUMN origin VARCHAR(128) NOT NULL DEFAULT '';
+
+CREATE TABLE account_ledger (
+  id SERIAL PRIMARY KEY,
+  account VARCHAR(192) NOT NULL,
+  balance_type VARCHAR(32) NOT NULL,
+  balance_uuid VARCHAR(64) NOT NULL,
+  balance_id VARCHAR(64) NOT NULL,
+  delta NUMERIC(30,10) NOT NULL,
+  value NUMERIC(30,10) NOT NULL,
+  cause VARCHAR(24) NOT NULL,
+  cause_id VARCHAR(128) NOT NULL,
+  created_at TIMESTAMP
+);
+CREATE INDEX account_created_at_idx ON account_ledger (account, created_at);
+
+CREATE TABLE account_snapshots (
+  id SERIAL PRIMARY KEY,
+  snapshot_id VARCHAR(64) NOT NULL,
+  account VARCHAR(192) NOT NULL,
+  content TEXT NOT NULL,
+  created_at TIMESTAMP,
+  UNIQUE (snapshot_id, account)
+);
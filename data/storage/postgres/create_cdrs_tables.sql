@@ -24,6 +24,7 @@ CREATE TABLE cdrs_primary (
   usage NUMERIC(30,9) NOT NULL,
   supplier VARCHAR(128) NOT NULL,
   disconnect_cause VARCHAR(64) NOT NULL,
+  origin VARCHAR(128) NOT NULL DEFAULT '',
   created_at TIMESTAMP,
   deleted_at TIMESTAMP,
   UNIQUE (cgrid)
@@ -62,7 +63,7 @@ CREATE TABLE cost_details (
   account VARCHAR(128) NOT NULL,
   subject VARCHAR(128) NOT NULL,
   destination VARCHAR(128) NOT NULL,
-  cost NUMERIC(20,4) NOT NULL,
+  cost NUMERIC(30,10) NOT NULL,
   timespans text,
   cost_source VARCHAR(64) NOT NULL,
   created_at TIMESTAMP,
@@ -93,11 +94,45 @@ CREATE TABLE rated_cdrs (
   usage NUMERIC(30,9) NOT NULL,
   supplier VARCHAR(128) NOT NULL,
   disconnect_cause VARCHAR(64) NOT NULL,
-  cost NUMERIC(20,4) DEFAULT NULL,
+  origin VARCHAR(128) NOT NULL DEFAULT '',
+  cost NUMERIC(30,10) DEFAULT NULL,
+  taxes text,
+  routing_number VARCHAR(128) NOT NULL DEFAULT '',
   extra_info text,
   created_at TIMESTAMP,
   updated_at TIMESTAMP,
   deleted_at TIMESTAMP,
   UNIQUE (cgrid, runid)
 );
-CREATE INDEX deleted_at_rc_idx ON rated_cdrs (deleted_at);
\ No newline at end of file
+CREATE INDEX deleted_at_rc_idx ON rated_cdrs (deleted_at);
+
+--
+-- Table structure for table `account_ledger`
+--
+DROP TABLE IF EXISTS account_ledger;
+CREATE TABLE account_ledger (
+  id SERIAL PRIMARY KEY,
+  account VARCHAR(192) NOT NULL,
+  balance_type VARCHAR(32) NOT NULL,
+  balance_uuid VARCHAR(64) NOT NULL,
+  balance_id VARCHAR(64) NOT NULL,
+  delta NUMERIC(30,10) NOT NULL,
+  value NUMERIC(30,10) NOT NULL,
+  cause VARCHAR(24) NOT NULL,
+  cause_id VARCHAR(128) NOT NULL,
+  created_at TIMESTAMP
+);
+CREATE INDEX account_created_at_idx ON account_ledger (account, created_at);
+
+--
+-- Table structure for table `account_snapshots`
+--
+DROP TABLE IF EXISTS account_snapshots;
+CREATE TABLE account_snapshots (
+  id SERIAL PRIMARY KEY,
+  snapshot_id VARCHAR(64) NOT NULL,
+  account VARCHAR(192) NOT NULL,
+  content TEXT NOT NULL,
+  created_at TIMESTAMP,
+  UNIQUE (snapshot_id, account)
+);
\ No newline at end of file
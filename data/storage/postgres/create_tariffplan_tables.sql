@@ -47,6 +47,8 @@ CREATE TABLE tp_rates (
   rate_unit VARCHAR(16) NOT NULL,
   rate_increment VARCHAR(16) NOT NULL,
   group_interval_start VARCHAR(16) NOT NULL,
+  min_cost NUMERIC(7,4) NOT NULL,
+  max_cost NUMERIC(7,4) NOT NULL,
   created_at TIMESTAMP,
   UNIQUE (tpid, tag, group_interval_start)
 );
@@ -68,8 +70,11 @@ CREATE TABLE tp_destination_rates (
   rounding_decimals SMALLINT NOT NULL,
   max_cost NUMERIC(7,4) NOT NULL,
   max_cost_strategy VARCHAR(16) NOT NULL,
+  currency VARCHAR(8) NOT NULL,
+  usage_start VARCHAR(24) NOT NULL,
+  origins_tag VARCHAR(64) NOT NULL,
   created_at TIMESTAMP,
-  UNIQUE (tpid, tag , destinations_tag)
+  UNIQUE (tpid, tag , destinations_tag, usage_start, origins_tag)
 );
 CREATE INDEX tpdestrates_tpid_idx ON tp_destination_rates (tpid);
 CREATE INDEX tpdestrates_idx ON tp_destination_rates (tpid,tag);
@@ -110,6 +115,7 @@ CREATE TABLE tp_rating_profiles (
   rating_plan_tag VARCHAR(64) NOT NULL,
   fallback_subjects VARCHAR(64),
   cdr_stat_queue_ids varchar(64),
+  timezone VARCHAR(64) NOT NULL,
   created_at TIMESTAMP,
   UNIQUE (tpid, loadid, tenant, category, direction, subject, activation_time)
 );
@@ -134,6 +140,77 @@ CREATE TABLE tp_shared_groups (
 CREATE INDEX tpsharedgroups_tpid_idx ON tp_shared_groups (tpid);
 CREATE INDEX tpsharedgroups_idx ON tp_shared_groups (tpid,tag);
 
+--
+-- Table structure for table `tp_exchange_rates`
+--
+
+DROP TABLE IF EXISTS tp_exchange_rates;
+CREATE TABLE tp_exchange_rates (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  tag VARCHAR(64) NOT NULL,
+  from_currency VARCHAR(8) NOT NULL,
+  to_currency VARCHAR(8) NOT NULL,
+  rate NUMERIC(20,10) NOT NULL,
+  created_at TIMESTAMP,
+  UNIQUE (tpid, tag, from_currency, to_currency)
+);
+CREATE INDEX tpexchangerates_tpid_idx ON tp_exchange_rates (tpid);
+CREATE INDEX tpexchangerates_idx ON tp_exchange_rates (tpid,tag);
+
+--
+-- Table structure for table `tp_taxes`
+--
+
+DROP TABLE IF EXISTS tp_taxes;
+CREATE TABLE tp_taxes (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  tenant VARCHAR(64) NOT NULL,
+  category VARCHAR(32) NOT NULL,
+  destination_tag VARCHAR(64) NOT NULL,
+  tax_id VARCHAR(64) NOT NULL,
+  rate NUMERIC(8,4) NOT NULL,
+  debit BOOLEAN NOT NULL,
+  weight NUMERIC(8,2) NOT NULL,
+  created_at TIMESTAMP,
+  UNIQUE (tpid, tenant, category, destination_tag, tax_id)
+);
+CREATE INDEX tptaxes_tpid_idx ON tp_taxes (tpid);
+CREATE INDEX tptaxes_idx ON tp_taxes (tpid,tenant,category);
+
+--
+-- Table structure for table `tp_holidays`
+--
+
+DROP TABLE IF EXISTS tp_holidays;
+CREATE TABLE tp_holidays (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  tag VARCHAR(64) NOT NULL,
+  date VARCHAR(32) NOT NULL,
+  name VARCHAR(64) NOT NULL,
+  created_at TIMESTAMP,
+  UNIQUE (tpid, tag, date)
+);
+CREATE INDEX tpholidays_tpid_idx ON tp_holidays (tpid);
+CREATE INDEX tpholidays_idx ON tp_holidays (tpid,tag);
+
+--
+-- Table structure for table `tp_number_portability`
+--
+
+DROP TABLE IF EXISTS tp_number_portability;
+CREATE TABLE tp_number_portability (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  number VARCHAR(128) NOT NULL,
+  routing_number VARCHAR(128) NOT NULL,
+  created_at TIMESTAMP,
+  UNIQUE (tpid, number)
+);
+CREATE INDEX tpnumberportability_tpid_idx ON tp_number_portability (tpid);
+
 --
 -- Table structure for table `tp_actions`
 --
@@ -157,6 +234,7 @@ CREATE TABLE tp_actions (
   balance_weight NUMERIC(8,2) NOT NULL,
   extra_parameters VARCHAR(256) NOT NULL,
   weight NUMERIC(8,2) NOT NULL,
+  currency VARCHAR(8) NOT NULL,
   created_at TIMESTAMP,
   UNIQUE (tpid, tag, action, balance_tag, balance_type, direction, expiry_time, timing_tags, destination_tags, shared_group, balance_weight, weight)
 );
@@ -287,6 +365,7 @@ CREATE TABLE tp_derived_chargers (
   usage_field  VARCHAR(24) NOT NULL,
   supplier_field  VARCHAR(24) NOT NULL,
   disconnect_cause_field  VARCHAR(24) NOT NULL,
+  origin_field  VARCHAR(24) NOT NULL,
   created_at TIMESTAMP
 );
 CREATE INDEX tpderivedchargers_tpid_idx ON tp_derived_chargers (tpid);
@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/cgrates/cgrates/cache2go"
 	"github.com/cgrates/cgrates/utils"
 
 	"strings"
@@ -38,6 +37,9 @@ const (
 	TRIGGER_MAX_COUNTER = "*max_counter"
 	TRIGGER_MIN_BALANCE = "*min_balance"
 	TRIGGER_MAX_BALANCE = "*max_balance"
+	TRIGGER_MIN_CREDIT  = "*min_credit" // money left to spend, credit limit included
+	// seconds before a balance expires, checked periodically by the scheduler
+	TRIGGER_BALANCE_EXPIRING = "*balance_expiring"
 )
 
 /*
@@ -45,18 +47,29 @@ Structure containing information about user's credit (minutes, cents, sms...).'
 This can represent a user or a shared group.
 */
 type Account struct {
-	Id             string
-	BalanceMap     map[string]BalanceChain
-	UnitCounters   []*UnitsCounter
-	ActionTriggers ActionTriggerPriotityList
-	AllowNegative  bool
-	Disabled       bool
+	Id              string
+	BalanceMap      map[string]BalanceChain
+	UnitCounters    []*UnitsCounter
+	UsageCounters   []*UsageCounter
+	ActionTriggers  ActionTriggerPriotityList
+	CreditLimits    map[string]*CreditLimit // debt allowed on the monetary balances, per direction
+	Disabled        bool
+	Reservations    Reservations              // monetary amounts on hold till captured or released
+	ParentId        string                    // reseller account debited for the usage of this one
+	ResellerSubject string                    // rating subject of the usage debited from the child accounts, defaults to the account name
+	SpendingLimits  []*SpendingLimit          // maximum spent per destinations and period
+	SharedLimits    map[string]*SpendingLimit // caps on the *proportional shared groups paid by the account, by group id
+	ledgerBalances  map[string]*ledgerBalance // balances at load time, the differences go in the ledger
+	ledgerCause     string
+	ledgerCauseId   string
+	ledgerEntries   []*LedgerEntry // movements collected before the save, recorded once the account is stored
+	expiringIndexed bool           // the account was loaded or saved with expiring triggers, so it is in their index
 }
 
 // User's available minutes for the specified destination
-func (ub *Account) getCreditForPrefix(cd *CallDescriptor) (duration time.Duration, credit float64, balances BalanceChain) {
-	creditBalances := ub.getBalancesForPrefix(cd.Destination, cd.Category, ub.BalanceMap[utils.MONETARY+cd.Direction], "")
-	unitBalances := ub.getBalancesForPrefix(cd.Destination, cd.Category, ub.BalanceMap[cd.TOR+cd.Direction], "")
+func (ub *Account) getCreditForPrefix(cd *CallDescriptor) (duration time.Duration, credit utils.Decimal, balances BalanceChain) {
+	creditBalances := ub.getBalancesForPrefix(cd.Destination, cd.Category, cd.Direction, ub.BalanceMap[utils.MONETARY+cd.Direction], "")
+	unitBalances := ub.getBalancesForPrefix(cd.Destination, cd.Category, cd.Direction, ub.BalanceMap[cd.TOR+cd.Direction], "")
 	// gather all balances from shared groups
 	var extendedCreditBalances BalanceChain
 	for _, cb := range creditBalances {
@@ -64,6 +77,7 @@ func (ub *Account) getCreditForPrefix(cd *CallDescriptor) (duration time.Duratio
 			if sharedGroup, _ := accountingStorage.GetSharedGroup(cb.SharedGroup, false); sharedGroup != nil {
 				sgb := sharedGroup.GetBalances(cd.Destination, cd.Category, utils.MONETARY+cd.Direction, ub)
 				sgb = sharedGroup.SortBalancesByStrategy(cb, sgb)
+				sgb = sharedGroup.getProportionalBalances(cb, sgb)
 				extendedCreditBalances = append(extendedCreditBalances, sgb...)
 			}
 		} else {
@@ -82,7 +96,11 @@ func (ub *Account) getCreditForPrefix(cd *CallDescriptor) (duration time.Duratio
 			extendedMinuteBalances = append(extendedMinuteBalances, mb)
 		}
 	}
-	credit = extendedCreditBalances.GetTotalValue()
+	creditLimit, _ := ub.getCreditLimitAmount(cd.Direction)
+	credit = extendedCreditBalances.GetTotalValue().Add(creditLimit).Sub(ub.Reservations.GetTotal(cd.Direction))
+	if credit.Sign() < 0 {
+		credit = utils.Decimal{}
+	}
 	balances = extendedMinuteBalances
 	for _, b := range balances {
 		d, c := b.GetMinutesForCredit(cd, credit)
@@ -109,13 +127,20 @@ func (ub *Account) debitBalanceAction(a *Action, reset bool) error {
 	found := false
 	id := a.BalanceType + a.Direction
 	ub.CleanExpiredBalances()
+	ub.CleanExpiredReservations()
 	for _, b := range ub.BalanceMap[id] {
 		if b.IsExpired() {
 			continue // just to be safe (cleaned expired balances above)
 		}
+		if b.RolledOver {
+			continue // used only by the calls, the actions manage the balance it rolled over from
+		}
 		if b.MatchFilter(a.Balance) {
 			if reset {
-				b.Value = 0
+				if rb := b.rollover(time.Now()); rb != nil {
+					ub.BalanceMap[id] = append(ub.BalanceMap[id], rb)
+				}
+				b.Value = utils.Decimal{}
 			}
 			b.SubstractAmount(bClone.Value)
 			found = true
@@ -123,9 +148,7 @@ func (ub *Account) debitBalanceAction(a *Action, reset bool) error {
 	}
 	// if it is not found then we add it to the list
 	if !found {
-		if bClone.Value != 0 {
-			bClone.Value = -bClone.Value
-		}
+		bClone.Value = bClone.Value.Neg()
 		bClone.dirty = true // Mark the balance as dirty since we have modified and it should be checked by action triggers
 		ub.BalanceMap[id] = append(ub.BalanceMap[id], bClone)
 	}
@@ -147,10 +170,10 @@ func (ub *Account) debitBalanceAction(a *Action, reset bool) error {
 	return nil //ub.BalanceMap[id].GetTotalValue()
 }
 
-func (ub *Account) getBalancesForPrefix(prefix, category string, balances BalanceChain, sharedGroup string) BalanceChain {
+func (ub *Account) getBalancesForPrefix(prefix, category, direction string, balances BalanceChain, sharedGroup string) BalanceChain {
 	var usefulBalances BalanceChain
 	for _, b := range balances {
-		if b.IsExpired() || (ub.AllowNegative == false && b.SharedGroup == "" && b.Value <= 0) {
+		if b.IsExpired() || (!ub.allowsNegative(direction) && b.SharedGroup == "" && b.Value.Sign() <= 0) {
 			continue
 		}
 		if sharedGroup != "" && b.SharedGroup != sharedGroup {
@@ -161,22 +184,19 @@ func (ub *Account) getBalancesForPrefix(prefix, category string, balances Balanc
 		}
 		b.account = ub
 		if b.DestinationIds != "" && b.DestinationIds != utils.ANY {
-			for _, p := range utils.SplitPrefix(prefix, MIN_PREFIX_MATCH) {
-				if x, err := cache2go.GetCached(DESTINATION_PREFIX + p); err == nil {
-					destIds := x.(map[interface{}]struct{})
-					for dId, _ := range destIds {
-						balDestIds := strings.Split(b.DestinationIds, utils.INFIELD_SEP)
-						for _, balDestID := range balDestIds {
-							if dId == balDestID {
-								b.precision = len(p)
-								usefulBalances = append(usefulBalances, b)
-								break
-							}
-						}
-						if b.precision > 0 {
+			balDestIds := strings.Split(b.DestinationIds, utils.INFIELD_SEP)
+			for _, match := range destIndex.Match(prefix) {
+				for _, dId := range match.Ids {
+					for _, balDestID := range balDestIds {
+						if dId == balDestID {
+							b.precision = match.Precision
+							usefulBalances = append(usefulBalances, b)
 							break
 						}
 					}
+					if b.precision > 0 {
+						break
+					}
 				}
 				if b.precision > 0 {
 					break
@@ -196,8 +216,8 @@ func (ub *Account) getBalancesForPrefix(prefix, category string, balances Balanc
 }
 
 // like getBalancesForPrefix but expanding shared balances
-func (account *Account) getAlldBalancesForPrefix(destination, category, balanceType string) (bc BalanceChain) {
-	balances := account.getBalancesForPrefix(destination, category, account.BalanceMap[balanceType], "")
+func (account *Account) getAlldBalancesForPrefix(destination, category, direction, balanceType string) (bc BalanceChain) {
+	balances := account.getBalancesForPrefix(destination, category, direction, account.BalanceMap[balanceType], "")
 	for _, b := range balances {
 		if b.SharedGroup != "" {
 			sharedGroup, err := accountingStorage.GetSharedGroup(b.SharedGroup, false)
@@ -207,6 +227,9 @@ func (account *Account) getAlldBalancesForPrefix(destination, category, balanceT
 			}
 			sharedBalances := sharedGroup.GetBalances(destination, category, balanceType, account)
 			sharedBalances = sharedGroup.SortBalancesByStrategy(b, sharedBalances)
+			if strings.HasPrefix(balanceType, utils.MONETARY) {
+				sharedBalances = sharedGroup.getProportionalBalances(b, sharedBalances)
+			}
 			bc = append(bc, sharedBalances...)
 		} else {
 			bc = append(bc, b)
@@ -216,13 +239,17 @@ func (account *Account) getAlldBalancesForPrefix(destination, category, balanceT
 }
 
 func (ub *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bool, goNegative bool) (cc *CallCost, err error) {
-	usefulUnitBalances := ub.getAlldBalancesForPrefix(cd.Destination, cd.Category, cd.TOR+cd.Direction)
-	usefulMoneyBalances := ub.getAlldBalancesForPrefix(cd.Destination, cd.Category, utils.MONETARY+cd.Direction)
+	usefulUnitBalances := ub.getAlldBalancesForPrefix(cd.Destination, cd.Category, cd.Direction, cd.TOR+cd.Direction)
+	usefulMoneyBalances := ub.getAlldBalancesForPrefix(cd.Destination, cd.Category, cd.Direction, utils.MONETARY+cd.Direction)
 	//log.Print(usefulMoneyBalances, usefulUnitBalances)
 	//log.Print("STARTCD: ", cd)
 	var leftCC *CallCost
 	var initialLength int
 	cc = cd.CreateCallCost()
+	// the rate tiers are selected on the usage of this account
+	callStart := cd.TimeStart
+	cd.periodUsage = ub.GetPeriodUsage(cd.Direction, cd.TOR, callStart)
+	cd.periodUsageStart = callStart
 
 	generalBalanceChecker := true
 	for generalBalanceChecker {
@@ -249,7 +276,9 @@ func (ub *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun boo
 					cc.Timespans = append(cc.Timespans, partCC.Timespans...)
 					if initialLength == 0 {
 						// this is the first add, debit the connect fee
-						ub.DebitConnectionFee(cc, usefulMoneyBalances, count)
+						if err = ub.DebitConnectionFee(cc, usefulMoneyBalances, count); err != nil {
+							return nil, err
+						}
 					}
 					// for i, ts := range cc.Timespans {
 					//  log.Printf("cc.times[an[%d]: %+v\n", i, ts)
@@ -291,7 +320,9 @@ func (ub *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun boo
 					cc.Timespans = append(cc.Timespans, partCC.Timespans...)
 					if initialLength == 0 {
 						// this is the first add, debit the connect fee
-						ub.DebitConnectionFee(cc, usefulMoneyBalances, count)
+						if err = ub.DebitConnectionFee(cc, usefulMoneyBalances, count); err != nil {
+							return nil, err
+						}
 					}
 					//for i, ts := range cc.Timespans {
 					//log.Printf("cc.times[an[%d]: %+v\n", i, ts)
@@ -324,34 +355,44 @@ func (ub *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun boo
 	cc.Timespans = append(cc.Timespans, leftCC.Timespans...)
 	if initialLength == 0 {
 		// this is the first add, debit the connect fee
-		ub.DebitConnectionFee(cc, usefulMoneyBalances, count)
+		if err = ub.DebitConnectionFee(cc, usefulMoneyBalances, count); err != nil {
+			return nil, err
+		}
 	}
-	if leftCC.Cost == 0 || goNegative {
+	if leftCC.Cost.IsZero() || goNegative {
 		//log.Printf("Left CC: %+v", leftCC)
 		// get the default money balanance
 		// and go negative on it with the amount still unpaid
-		if len(leftCC.Timespans) > 0 && leftCC.Cost > 0 && !ub.AllowNegative {
-			err = errors.New("not enough credit")
-		}
 		for _, ts := range leftCC.Timespans {
 			if ts.Increments == nil {
 				ts.createIncrementsSlice()
 			}
 			for _, increment := range ts.Increments {
-				cost := increment.Cost
 				defaultBalance := ub.GetDefaultMoneyBalance(leftCC.Direction)
+				exr, exrErr := getExchangeRate(ts.RateInterval.GetCurrency(), defaultBalance.Currency)
+				if exrErr != nil {
+					return nil, exrErr
+				}
+				cost := exr.Convert(increment.Cost)
 				defaultBalance.SubstractAmount(cost)
 				increment.BalanceInfo.MoneyBalanceUuid = defaultBalance.Uuid
 				increment.BalanceInfo.AccountId = ub.Id
+				increment.BalanceInfo.ExchangeRate = exr
 				increment.paid = true
 				if count {
 					ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: leftCC.Direction, Balance: &Balance{Value: cost, DestinationIds: leftCC.Destination}})
 				}
 			}
 		}
+		if len(leftCC.Timespans) > 0 && leftCC.Cost.Sign() > 0 && !cd.forceDebit && ub.isOverCreditLimit(leftCC.Direction) {
+			err = errors.New("not enough credit")
+		}
 	}
 
 COMMIT:
+	if count {
+		ub.countUsage(cd.Direction, cd.TOR, cc.GetDuration(), callStart)
+	}
 	if !dryRun {
 		// save darty shared balances
 		usefulMoneyBalances.SaveDirtyBalances(ub)
@@ -380,35 +421,63 @@ func (ub *Account) GetDefaultMoneyBalance(direction string) *Balance {
 }
 
 func (ub *Account) refundIncrement(increment *Increment, direction, unitType string, count bool) {
+	if count {
+		if uc := ub.getUsageCounter(direction, unitType); uc != nil {
+			uc.addUsage(-increment.Duration, uc.PeriodStart)
+		}
+	}
 	var balance *Balance
 	if increment.BalanceInfo.UnitBalanceUuid != "" {
 		if balance = ub.BalanceMap[unitType+direction].GetBalance(increment.BalanceInfo.UnitBalanceUuid); balance == nil {
 			return
 		}
-		balance.Value += increment.Duration.Seconds()
+		seconds := utils.NewDecimalFromFloat(increment.Duration.Seconds())
+		balance.Value = balance.Value.Add(seconds)
 		if count {
-			ub.countUnits(&Action{BalanceType: unitType, Direction: direction, Balance: &Balance{Value: -increment.Duration.Seconds()}})
+			ub.countUnits(&Action{BalanceType: unitType, Direction: direction, Balance: &Balance{Value: seconds.Neg()}})
 		}
 	}
-	// check money too
-	if increment.BalanceInfo.MoneyBalanceUuid != "" {
+	// check money too, the *proportional shares are refunded by the call descriptor
+	if increment.BalanceInfo.MoneyBalanceUuid != "" && len(increment.BalanceInfo.Shares) == 0 {
 		if balance = ub.BalanceMap[utils.MONETARY+direction].GetBalance(increment.BalanceInfo.MoneyBalanceUuid); balance == nil {
 			return
 		}
-		balance.Value += increment.Cost
+		// refund in the currency the balance was debited with
+		cost := increment.BalanceInfo.ExchangeRate.Convert(increment.Cost)
+		balance.Value = balance.Value.Add(cost)
 		if count {
-			ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: direction, Balance: &Balance{Value: -increment.Cost}})
+			ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: direction, Balance: &Balance{Value: cost.Neg()}})
 		}
 	}
 }
 
+// Gives back the taxes debited on top of the increment cost to the balance which paid it,
+// the *proportional shares taxes are refunded with the shares
+func (ub *Account) refundIncrementTaxes(increment *Increment, direction string, taxRate utils.Decimal, count bool) {
+	if taxRate.Sign() <= 0 || increment.BalanceInfo.MoneyBalanceUuid == "" || len(increment.BalanceInfo.Shares) != 0 {
+		return
+	}
+	balance := ub.BalanceMap[utils.MONETARY+direction].GetBalance(increment.BalanceInfo.MoneyBalanceUuid)
+	if balance == nil {
+		return
+	}
+	tax := getTax(increment.BalanceInfo.ExchangeRate.Convert(increment.Cost), taxRate)
+	balance.Value = balance.Value.Add(tax)
+	if count {
+		ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: direction, Balance: &Balance{Value: tax.Neg()}})
+	}
+}
+
 // Scans the action trigers and execute the actions for which trigger is met
 func (ub *Account) executeActionTriggers(a *Action) {
+	ub.checkCounterWindows(time.Now()) // do not trigger on the units of a past window
 	ub.ActionTriggers.Sort()
 	for _, at := range ub.ActionTriggers {
 		// sanity check
 		if !strings.Contains(at.ThresholdType, "counter") &&
-			!strings.Contains(at.ThresholdType, "balance") {
+			!strings.Contains(at.ThresholdType, "balance") &&
+			at.ThresholdType != TRIGGER_MIN_CREDIT ||
+			at.ThresholdType == TRIGGER_BALANCE_EXPIRING {
 			continue
 		}
 		if at.Executed {
@@ -424,12 +493,12 @@ func (ub *Account) executeActionTriggers(a *Action) {
 				if uc.BalanceType == at.BalanceType {
 					for _, mb := range uc.Balances {
 						if strings.Contains(at.ThresholdType, "*max") {
-							if mb.MatchActionTrigger(at) && mb.Value >= at.ThresholdValue {
+							if mb.MatchActionTrigger(at) && mb.Value.Cmp(utils.NewDecimalFromFloat(at.ThresholdValue)) >= 0 {
 								// run the actions
 								at.Execute(ub, nil)
 							}
 						} else { //MIN
-							if mb.MatchActionTrigger(at) && mb.Value <= at.ThresholdValue {
+							if mb.MatchActionTrigger(at) && mb.Value.Cmp(utils.NewDecimalFromFloat(at.ThresholdValue)) <= 0 {
 								// run the actions
 								at.Execute(ub, nil)
 							}
@@ -437,18 +506,23 @@ func (ub *Account) executeActionTriggers(a *Action) {
 					}
 				}
 			}
+		} else if at.ThresholdType == TRIGGER_MIN_CREDIT {
+			if credit, unlimited := ub.getAvailableCredit(at.BalanceDirection); !unlimited &&
+				credit.Cmp(utils.NewDecimalFromFloat(at.ThresholdValue)) <= 0 {
+				at.Execute(ub, nil)
+			}
 		} else { // BALANCE
 			for _, b := range ub.BalanceMap[at.BalanceType+at.BalanceDirection] {
 				if !b.dirty { // do not check clean balances
 					continue
 				}
 				if strings.Contains(at.ThresholdType, "*max") {
-					if b.MatchActionTrigger(at) && b.Value >= at.ThresholdValue {
+					if b.MatchActionTrigger(at) && b.Value.Cmp(utils.NewDecimalFromFloat(at.ThresholdValue)) >= 0 {
 						// run the actions
 						at.Execute(ub, nil)
 					}
 				} else { //MIN
-					if b.MatchActionTrigger(at) && b.Value <= at.ThresholdValue {
+					if b.MatchActionTrigger(at) && b.Value.Cmp(utils.NewDecimalFromFloat(at.ThresholdValue)) <= 0 {
 						// run the actions
 						at.Execute(ub, nil)
 					}
@@ -497,6 +571,7 @@ func (ub *Account) getUnitCounter(a *Action) *UnitsCounter {
 // Increments the counter for the type specified in the received Action
 // with the actions values
 func (ub *Account) countUnits(a *Action) {
+	ub.checkCounterWindows(time.Now())
 	unitsCounter := ub.getUnitCounter(a)
 	// if not found add the counter
 	if unitsCounter == nil {
@@ -512,6 +587,51 @@ func (ub *Account) countUnits(a *Action) {
 	ub.executeActionTriggers(nil)
 }
 
+// Returns the usage counter for the direction and type of record, nil if nothing was counted yet
+func (ub *Account) getUsageCounter(direction, tor string) *UsageCounter {
+	for _, uc := range ub.UsageCounters {
+		if uc.Direction == direction && uc.TOR == tor {
+			return uc
+		}
+	}
+	return nil
+}
+
+// Returns the usage accumulated in the billing period of the time t
+func (ub *Account) GetPeriodUsage(direction, tor string, t time.Time) time.Duration {
+	if uc := ub.getUsageCounter(direction, tor); uc != nil {
+		return uc.getUsage(t)
+	}
+	return 0
+}
+
+// Adds the usage done at the time t to the counter of its direction and type of record
+func (ub *Account) countUsage(direction, tor string, usage time.Duration, t time.Time) {
+	if usage <= 0 {
+		return
+	}
+	uc := ub.getUsageCounter(direction, tor)
+	if uc == nil {
+		uc = &UsageCounter{Direction: direction, TOR: tor}
+		ub.UsageCounters = append(ub.UsageCounters, uc)
+	}
+	uc.addUsage(usage, t)
+}
+
+// Sets the reset window on the usage counter of the direction and type of record
+func (ub *Account) setUsageWindow(direction, tor, window string) {
+	uc := ub.getUsageCounter(direction, tor)
+	if uc == nil {
+		uc = &UsageCounter{Direction: direction, TOR: tor}
+		ub.UsageCounters = append(ub.UsageCounters, uc)
+	}
+	if uc.ResetWindow != window {
+		uc.ResetWindow = window
+		uc.PeriodStart = time.Time{}
+		uc.Usage = 0
+	}
+}
+
 // Create minute counters for all triggered actions that have actions opertating on balances
 func (ub *Account) initCounters() {
 	ucTempMap := make(map[string]*UnitsCounter, 2)
@@ -534,7 +654,7 @@ func (ub *Account) initCounters() {
 					ub.UnitCounters = append(ub.UnitCounters, uc)
 				}
 				b := a.Balance.Clone()
-				b.Value = 0
+				b.Value = utils.Decimal{}
 				uc.Balances = append(uc.Balances, b)
 				uc.Balances.Sort()
 			}
@@ -542,15 +662,21 @@ func (ub *Account) initCounters() {
 	}
 }
 
+// Removes the expired balances, their unused units are rolled over if requested
 func (ub *Account) CleanExpiredBalances() {
 	for key, bm := range ub.BalanceMap {
+		var rolled BalanceChain
 		for i := 0; i < len(bm); i++ {
 			if bm[i].IsExpired() {
+				if rb := bm[i].rollover(bm[i].ExpirationDate); rb != nil {
+					rolled = append(rolled, rb)
+				}
 				// delete it
 				bm = append(bm[:i], bm[i+1:]...)
+				i--
 			}
 		}
-		ub.BalanceMap[key] = bm
+		ub.BalanceMap[key] = append(bm, rolled...)
 	}
 }
 
@@ -579,8 +705,8 @@ func (ub *Account) GetSharedGroups() (groups []string) {
 
 func (account *Account) GetUniqueSharedGroupMembers(cd *CallDescriptor) ([]string, error) {
 	var balances []*Balance
-	balances = append(balances, account.getBalancesForPrefix(cd.Destination, cd.Category, account.BalanceMap[utils.MONETARY+cd.Direction], "")...)
-	balances = append(balances, account.getBalancesForPrefix(cd.Destination, cd.Category, account.BalanceMap[cd.TOR+cd.Direction], "")...)
+	balances = append(balances, account.getBalancesForPrefix(cd.Destination, cd.Category, cd.Direction, account.BalanceMap[utils.MONETARY+cd.Direction], "")...)
+	balances = append(balances, account.getBalancesForPrefix(cd.Destination, cd.Category, cd.Direction, account.BalanceMap[cd.TOR+cd.Direction], "")...)
 	// gather all shared group ids
 	var sharedGroupIds []string
 	for _, b := range balances {
@@ -610,12 +736,32 @@ type TenantAccount struct {
 
 func (acc *Account) Clone() *Account {
 	newAcc := &Account{
-		Id:             acc.Id,
-		BalanceMap:     make(map[string]BalanceChain, len(acc.BalanceMap)),
-		UnitCounters:   nil, // not used when cloned (dryRun)
-		ActionTriggers: nil, // not used when cloned (dryRun)
-		AllowNegative:  acc.AllowNegative,
-		Disabled:       acc.Disabled,
+		Id:              acc.Id,
+		BalanceMap:      make(map[string]BalanceChain, len(acc.BalanceMap)),
+		UnitCounters:    nil, // not used when cloned (dryRun)
+		ActionTriggers:  nil, // not used when cloned (dryRun)
+		Disabled:        acc.Disabled,
+		ParentId:        acc.ParentId,
+		ResellerSubject: acc.ResellerSubject,
+	}
+	for direction, cl := range acc.CreditLimits {
+		newCl := *cl
+		newAcc.SetCreditLimit(direction, &newCl)
+	}
+	for _, r := range acc.Reservations {
+		newR := *r
+		newAcc.Reservations = append(newAcc.Reservations, &newR)
+	}
+	for sgId, sl := range acc.SharedLimits { // needed for the *proportional shared groups credit
+		newSl := *sl
+		if newAcc.SharedLimits == nil {
+			newAcc.SharedLimits = make(map[string]*SpendingLimit, len(acc.SharedLimits))
+		}
+		newAcc.SharedLimits[sgId] = &newSl
+	}
+	for _, uc := range acc.UsageCounters { // needed to select the rate tiers
+		newUc := *uc
+		newAcc.UsageCounters = append(newAcc.UsageCounters, &newUc)
 	}
 	for key, balanceChain := range acc.BalanceMap {
 		newAcc.BalanceMap[key] = balanceChain.Clone()
@@ -623,30 +769,106 @@ func (acc *Account) Clone() *Account {
 	return newAcc
 }
 
-func (acc *Account) DebitConnectionFee(cc *CallCost, usefulMoneyBalances BalanceChain, count bool) {
+// Debits the taxes marked for debit, the refunds give back the taxes on the refunded cost
+func (acc *Account) debitTaxes(cc *CallCost, count bool) error {
+	amount := cc.Taxes.GetDebitTotal()
+	if amount.Sign() <= 0 {
+		return nil
+	}
+	currency := cc.GetCurrency()
+	usefulMoneyBalances := acc.getAlldBalancesForPrefix(cc.Destination, cc.Category, cc.Direction, utils.MONETARY+cc.Direction)
+	var paidBalance *Balance
+	var paidAmount utils.Decimal
+	for _, b := range usefulMoneyBalances {
+		exr, err := getExchangeRate(currency, b.Currency)
+		if err != nil {
+			continue
+		}
+		tax := exr.Convert(amount)
+		if shares, ok := b.debitAmount(tax); ok {
+			cc.Shares = append(cc.Shares, shares...)
+			cc.addExchangeRate(exr)
+			paidBalance, paidAmount = b, tax
+			break
+		}
+	}
+	if paidBalance == nil { // not enough money, go negative on the default balance
+		paidBalance = acc.GetDefaultMoneyBalance(cc.Direction)
+		exr, err := getExchangeRate(currency, paidBalance.Currency)
+		if err != nil {
+			return err
+		}
+		paidAmount = exr.Convert(amount)
+		cc.addExchangeRate(exr)
+		paidBalance.SubstractAmount(paidAmount)
+	}
+	if count {
+		acc.countUnits(&Action{BalanceType: utils.MONETARY, Direction: cc.Direction, Balance: &Balance{Value: paidAmount, DestinationIds: cc.Destination}})
+		usefulMoneyBalances.SaveDirtyBalances(acc)
+	}
+	return nil
+}
+
+// Debits the part of the minimum cost not covered by the call from the default balance,
+// the negative amounts are given back once covered by the next debits
+func (acc *Account) debitMinCost(cc *CallCost, amount utils.Decimal, count bool) error {
+	if amount.IsZero() {
+		return nil
+	}
+	b := acc.GetDefaultMoneyBalance(cc.Direction)
+	exr, err := getExchangeRate(cc.GetCurrency(), b.Currency)
+	if err != nil {
+		return err
+	}
+	balAmount := exr.Convert(amount)
+	cc.addExchangeRate(exr)
+	b.SubstractAmount(balAmount)
+	if count {
+		acc.countUnits(&Action{BalanceType: utils.MONETARY, Direction: cc.Direction, Balance: &Balance{Value: balAmount, DestinationIds: cc.Destination}})
+	}
+	return nil
+}
+
+// Debits the connect fee from the first balance able to pay it, going negative on the default balance otherwise
+func (acc *Account) DebitConnectionFee(cc *CallCost, usefulMoneyBalances BalanceChain, count bool) error {
 	if cc.deductConnectFee {
 		connectFee := cc.GetConnectFee()
+		currency := cc.GetCurrency()
 		//log.Print("CONNECT FEE: %f", connectFee)
 		connectFeePaid := false
 		for _, b := range usefulMoneyBalances {
-			if b.Value >= connectFee {
-				b.SubstractAmount(connectFee)
+			exr, err := getExchangeRate(currency, b.Currency)
+			if err != nil {
+				continue // no way to pay from this balance
+			}
+			fee := exr.Convert(connectFee)
+			if shares, ok := b.debitAmount(fee); ok {
+				cc.Shares = append(cc.Shares, shares...)
+				cc.addExchangeRate(exr)
 				// the conect fee is not refundable!
 				if count {
-					acc.countUnits(&Action{BalanceType: utils.MONETARY, Direction: cc.Direction, Balance: &Balance{Value: connectFee, DestinationIds: cc.Destination}})
+					acc.countUnits(&Action{BalanceType: utils.MONETARY, Direction: cc.Direction, Balance: &Balance{Value: fee, DestinationIds: cc.Destination}})
 				}
 				connectFeePaid = true
 				break
 			}
 		}
 		// debit connect fee
-		if connectFee > 0 && !connectFeePaid {
+		if connectFee.Sign() > 0 && !connectFeePaid {
 			// there are no money for the connect fee; go negative
-			acc.GetDefaultMoneyBalance(cc.Direction).Value -= connectFee
+			defaultBalance := acc.GetDefaultMoneyBalance(cc.Direction)
+			exr, err := getExchangeRate(currency, defaultBalance.Currency)
+			if err != nil {
+				return err
+			}
+			fee := exr.Convert(connectFee)
+			cc.addExchangeRate(exr)
+			defaultBalance.Value = defaultBalance.Value.Sub(fee)
 			// the conect fee is not refundable!
 			if count {
-				acc.countUnits(&Action{BalanceType: utils.MONETARY, Direction: cc.Direction, Balance: &Balance{Value: connectFee, DestinationIds: cc.Destination}})
+				acc.countUnits(&Action{BalanceType: utils.MONETARY, Direction: cc.Direction, Balance: &Balance{Value: fee, DestinationIds: cc.Destination}})
 			}
 		}
 	}
+	return nil
 }
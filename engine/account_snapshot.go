@@ -0,0 +1,266 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Differences between an account and its snapshot, what a restore changes
+type AccountDiff struct {
+	AccountId      string
+	Missing        bool // the account no longer exists, the restore creates it
+	Balances       []*BalanceDiff
+	ActionTriggers []string // ids of the triggers added, removed or changed since the snapshot
+	Counters       []string // keys of the unit and usage counters changed since the snapshot
+	Disabled       bool     // the disabled flag changed since the snapshot
+}
+
+type BalanceDiff struct {
+	BalanceType   string // key in the balance map, eg: *monetary*out
+	BalanceUuid   string
+	BalanceId     string
+	Value         *utils.Decimal // current value, nil if the balance was removed since the snapshot
+	SnapshotValue *utils.Decimal // nil if the balance was created after the snapshot
+}
+
+func (ad *AccountDiff) IsEmpty() bool {
+	return !ad.Missing && len(ad.Balances) == 0 && len(ad.ActionTriggers) == 0 && len(ad.Counters) == 0 && !ad.Disabled
+}
+
+// Returns the differences of the current account (nil if missing) to the snapshot
+func (snapshot *Account) diff(current *Account) *AccountDiff {
+	ad := &AccountDiff{AccountId: snapshot.Id}
+	if current == nil {
+		ad.Missing = true
+		current = &Account{Id: snapshot.Id}
+	}
+	ad.Disabled = current.Disabled != snapshot.Disabled
+	// balances
+	currentBalances := make(map[string]*Balance)
+	for _, bc := range current.BalanceMap {
+		for _, b := range bc {
+			currentBalances[b.Uuid] = b
+		}
+	}
+	for key, bc := range snapshot.BalanceMap {
+		for _, b := range bc {
+			snapshotValue := b.Value
+			cb, found := currentBalances[b.Uuid]
+			delete(currentBalances, b.Uuid)
+			if !found {
+				ad.Balances = append(ad.Balances, &BalanceDiff{BalanceType: key, BalanceUuid: b.Uuid, BalanceId: b.Id, SnapshotValue: &snapshotValue})
+			} else if !cb.Value.Equal(b.Value) || !cb.ExpirationDate.Equal(b.ExpirationDate) {
+				value := cb.Value
+				ad.Balances = append(ad.Balances, &BalanceDiff{BalanceType: key, BalanceUuid: b.Uuid, BalanceId: b.Id, Value: &value, SnapshotValue: &snapshotValue})
+			}
+		}
+	}
+	for key, bc := range current.BalanceMap {
+		for _, b := range bc {
+			if _, created := currentBalances[b.Uuid]; created {
+				value := b.Value
+				ad.Balances = append(ad.Balances, &BalanceDiff{BalanceType: key, BalanceUuid: b.Uuid, BalanceId: b.Id, Value: &value})
+			}
+		}
+	}
+	sort.Sort(balanceDiffs(ad.Balances))
+	// triggers
+	currentTriggers := make(map[string]string)
+	for _, at := range current.ActionTriggers {
+		currentTriggers[at.Id] = jsonString(at)
+	}
+	for _, at := range snapshot.ActionTriggers {
+		if content, found := currentTriggers[at.Id]; !found || content != jsonString(at) {
+			ad.ActionTriggers = append(ad.ActionTriggers, at.Id)
+		}
+		delete(currentTriggers, at.Id)
+	}
+	for atId := range currentTriggers {
+		ad.ActionTriggers = append(ad.ActionTriggers, atId)
+	}
+	sort.Strings(ad.ActionTriggers)
+	// counters
+	currentCounters, snapshotCounters := current.getCountersContent(), snapshot.getCountersContent()
+	for key, content := range snapshotCounters {
+		if currentCounters[key] != content {
+			ad.Counters = append(ad.Counters, key)
+		}
+		delete(currentCounters, key)
+	}
+	for key := range currentCounters {
+		ad.Counters = append(ad.Counters, key)
+	}
+	sort.Strings(ad.Counters)
+	return ad
+}
+
+// Encoded counters by their keys, units counters by balance type and usage ones by unit type
+func (acc *Account) getCountersContent() map[string]string {
+	counters := make(map[string]string)
+	for _, uc := range acc.UnitCounters {
+		counters[utils.ConcatenatedKey("units", uc.BalanceType, uc.Direction)] = jsonString(uc)
+	}
+	for _, uc := range acc.UsageCounters {
+		counters[utils.ConcatenatedKey("usage", uc.TOR, uc.Direction)] = jsonString(uc)
+	}
+	return counters
+}
+
+func jsonString(v interface{}) string {
+	content, _ := json.Marshal(v)
+	return string(content)
+}
+
+type balanceDiffs []*BalanceDiff
+
+func (bds balanceDiffs) Len() int {
+	return len(bds)
+}
+
+func (bds balanceDiffs) Swap(i, j int) {
+	bds[i], bds[j] = bds[j], bds[i]
+}
+
+func (bds balanceDiffs) Less(i, j int) bool {
+	return bds[i].BalanceType < bds[j].BalanceType ||
+		(bds[i].BalanceType == bds[j].BalanceType && bds[i].BalanceUuid < bds[j].BalanceUuid)
+}
+
+// Returns the ids of the tenant accounts on the direction
+func GetTenantAccountIds(tenant, direction string) (accountIds []string, err error) {
+	keys, err := accountingStorage.GetKeysForPrefix(ACCOUNT_PREFIX)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		accId := strings.TrimPrefix(key, ACCOUNT_PREFIX)
+		if matchAccountTenant(accId, tenant, direction) {
+			accountIds = append(accountIds, accId)
+		}
+	}
+	sort.Strings(accountIds)
+	return
+}
+
+// the lock guard needs each id once
+func uniqueIds(ids []string) (unique []string) {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return
+}
+
+// the account ids are direction:tenant:account
+func matchAccountTenant(accountId, tenant, direction string) bool {
+	parts := strings.Split(accountId, utils.CONCATENATED_KEY_SEP)
+	return len(parts) > 1 && parts[0] == direction && parts[1] == tenant
+}
+
+// Stores the accounts in the snapshot, locking them so no debit runs in between
+func SnapshotAccounts(storage CdrStorage, snapshotId string, accountIds []string) (err error) {
+	accountIds = uniqueIds(accountIds)
+	_, err = AccLock.Guard(func() (interface{}, error) {
+		accounts := make([]*Account, 0, len(accountIds))
+		for _, accId := range accountIds {
+			acc, err := accountingStorage.GetAccount(accId)
+			if err != nil {
+				return 0, err
+			}
+			accounts = append(accounts, acc)
+		}
+		return 0, storage.SetAccountSnapshot(snapshotId, accounts)
+	}, accountIds...)
+	return
+}
+
+// Restores the accounts from the snapshot, the account ids or else the tenant and direction
+// filter the restored ones. Returns the differences, changing nothing on dry run.
+// On error the differences of the accounts already restored are returned with it.
+func RestoreAccounts(storage CdrStorage, snapshotId, tenant, direction string, accountIds []string, dryRun bool) (diffs []*AccountDiff, err error) {
+	snapshots, err := storage.GetAccountSnapshot(snapshotId, accountIds)
+	if err != nil {
+		return nil, err
+	}
+	var restored []*Account
+	var lockIds []string
+	for _, snapshot := range snapshots {
+		if len(accountIds) != 0 || matchAccountTenant(snapshot.Id, tenant, direction) {
+			restored = append(restored, snapshot)
+			lockIds = append(lockIds, snapshot.Id)
+		}
+	}
+	_, err = AccLock.Guard(func() (interface{}, error) {
+		diffs, err = restoreAccounts(snapshotId, restored, dryRun)
+		return 0, err
+	}, uniqueIds(lockIds)...)
+	return
+}
+
+func restoreAccounts(snapshotId string, snapshots []*Account, dryRun bool) (diffs []*AccountDiff, err error) {
+	for _, snapshot := range snapshots {
+		current, err := accountingStorage.GetAccount(snapshot.Id)
+		if err != nil && err != utils.ErrNotFound {
+			return diffs, err
+		}
+		ad := snapshot.diff(current)
+		if ad.IsEmpty() {
+			continue
+		}
+		if dryRun {
+			diffs = append(diffs, ad)
+			continue
+		}
+		if current != nil {
+			snapshot.keepLiveState(current)
+		}
+		snapshot.SetLedgerCause(LEDGER_RESTORE, snapshotId)
+		if err := accountingStorage.SetAccount(snapshot); err != nil {
+			return diffs, err
+		}
+		diffs = append(diffs, ad)
+	}
+	return
+}
+
+// The restore keeps what the account holds or counts since the snapshot: the
+// reservations, the spending limits counters and the shared groups caps.
+// The ledger records the differences to the current balances.
+func (snapshot *Account) keepLiveState(current *Account) {
+	snapshot.Reservations = current.Reservations
+	for _, sl := range snapshot.SpendingLimits {
+		sl.Spent, sl.WindowStart = utils.Decimal{}, time.Time{}
+		for _, currentSl := range current.SpendingLimits {
+			if currentSl.Id == sl.Id {
+				sl.Spent, sl.WindowStart = currentSl.Spent, currentSl.WindowStart
+				break
+			}
+		}
+	}
+	snapshot.SharedLimits = current.SharedLimits
+	snapshot.ledgerBalances = current.ledgerBalances
+}
@@ -0,0 +1,131 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAccountSnapshotDiff(t *testing.T) {
+	snapshot := &Account{Id: "*out:vdf:snap", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "m1", Value: utils.NewDecimalFromFloat(10)}, &Balance{Uuid: "m2", Value: utils.NewDecimalFromFloat(5)}},
+	}, ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{Id: "t1", ThresholdValue: 2}},
+		UnitCounters: []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND}}}
+	if ad := snapshot.diff(nil); !ad.Missing || len(ad.Balances) != 2 || ad.Balances[0].Value != nil {
+		t.Errorf("Wrong diff to missing account: %+v", ad)
+	}
+	current := &Account{Id: "*out:vdf:snap", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "m1", Value: utils.NewDecimalFromFloat(10)}, &Balance{Uuid: "m3", Value: utils.NewDecimalFromFloat(1)}},
+	}, ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{Id: "t1", ThresholdValue: 2, Executed: true}},
+		UnitCounters: []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND}}}
+	ad := snapshot.diff(current)
+	if ad.Missing || len(ad.Balances) != 2 || ad.Balances[0].BalanceUuid != "m2" || ad.Balances[0].Value != nil ||
+		ad.Balances[1].BalanceUuid != "m3" || ad.Balances[1].SnapshotValue != nil || ad.Balances[1].Value.String() != "1" {
+		t.Errorf("Wrong balances diff: %+v", ad.Balances)
+	}
+	if len(ad.ActionTriggers) != 1 || ad.ActionTriggers[0] != "t1" || len(ad.Counters) != 0 {
+		t.Errorf("Wrong triggers and counters diff: %+v", ad)
+	}
+	current.UnitCounters = nil
+	if ad := snapshot.diff(current); len(ad.Counters) != 1 || ad.Counters[0] != "units:*monetary:*out" {
+		t.Errorf("Wrong counters diff: %+v", ad.Counters)
+	}
+	if ad := snapshot.diff(snapshot); !ad.IsEmpty() {
+		t.Errorf("Expecting empty diff: %+v", ad)
+	}
+}
+
+func TestAccountSnapshotRestore(t *testing.T) {
+	snapshot := &Account{Id: "*out:vdf:restored", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)}},
+	}}
+	accountingStorage.SetAccount(&Account{Id: "*out:vdf:restored", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(3)}},
+	}})
+	diffs, err := restoreAccounts("snap1", []*Account{snapshot}, true)
+	if err != nil || len(diffs) != 1 || len(diffs[0].Balances) != 1 ||
+		diffs[0].Balances[0].Value.String() != "3" || diffs[0].Balances[0].SnapshotValue.String() != "10" {
+		t.Fatalf("Wrong dry run diffs: %+v, %v", diffs, err)
+	}
+	if acc, err := accountingStorage.GetAccount("*out:vdf:restored"); err != nil || acc.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().String() != "3" {
+		t.Errorf("Dry run changed the account: %+v, %v", acc, err)
+	}
+	if _, err := restoreAccounts("snap1", []*Account{snapshot}, false); err != nil {
+		t.Fatal("Error restoring accounts: ", err)
+	}
+	if acc, err := accountingStorage.GetAccount("*out:vdf:restored"); err != nil || acc.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().String() != "10" {
+		t.Errorf("Account not restored: %+v, %v", acc, err)
+	}
+	if diffs, err := restoreAccounts("snap1", []*Account{snapshot}, true); err != nil || len(diffs) != 0 {
+		t.Errorf("Expecting no diffs after restore: %+v, %v", diffs, err)
+	}
+}
+
+func TestAccountSnapshotRestorePartial(t *testing.T) {
+	snapshots := []*Account{
+		&Account{Id: "*out:vdf:restored_first", BalanceMap: map[string]BalanceChain{
+			utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)}},
+		}},
+		&Account{Id: "*out:vdf:restored_broken", BalanceMap: map[string]BalanceChain{
+			utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)}},
+		}},
+	}
+	accountingStorage.(*MapStorage).dict[ACCOUNT_PREFIX+"*out:vdf:restored_broken"] = []byte("broken")
+	defer delete(accountingStorage.(*MapStorage).dict, ACCOUNT_PREFIX+"*out:vdf:restored_broken")
+	diffs, err := restoreAccounts("snap1", snapshots, false)
+	if err == nil || len(diffs) != 1 || diffs[0].AccountId != "*out:vdf:restored_first" {
+		t.Errorf("Expecting the restored diffs with the error: %+v, %v", diffs, err)
+	}
+}
+
+func TestAccountSnapshotRestoreKeepsLiveState(t *testing.T) {
+	now := time.Now()
+	snapshot := &Account{Id: "*out:vdf:restored_live", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)}},
+	}, SpendingLimits: []*SpendingLimit{&SpendingLimit{Id: "daily", Value: utils.NewDecimalFromFloat(5), Period: WINDOW_DAILY}}}
+	accountingStorage.SetAccount(&Account{Id: "*out:vdf:restored_live", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(3)}},
+	}, Reservations: Reservations{&Reservation{Id: "hold", Amount: utils.NewDecimalFromFloat(2)}},
+		SpendingLimits: []*SpendingLimit{&SpendingLimit{Id: "daily", Value: utils.NewDecimalFromFloat(5), Period: WINDOW_DAILY,
+			Spent: utils.NewDecimalFromFloat(4), WindowStart: now}},
+		SharedLimits: map[string]*SpendingLimit{"sg": &SpendingLimit{Value: utils.NewDecimalFromFloat(1), Period: WINDOW_MONTHLY}}})
+	if _, err := restoreAccounts("snap1", []*Account{snapshot}, false); err != nil {
+		t.Fatal("Error restoring accounts: ", err)
+	}
+	acc, err := accountingStorage.GetAccount("*out:vdf:restored_live")
+	if err != nil || acc.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().String() != "10" {
+		t.Fatalf("Account not restored: %+v, %v", acc, err)
+	}
+	if len(acc.Reservations) != 1 || acc.Reservations[0].Id != "hold" || len(acc.SharedLimits) != 1 {
+		t.Errorf("Restore dropped the reservations or shared limits: %+v, %+v", acc.Reservations, acc.SharedLimits)
+	}
+	if len(acc.SpendingLimits) != 1 || acc.SpendingLimits[0].Spent.String() != "4" {
+		t.Errorf("Restore reset the spending counters: %+v", acc.SpendingLimits)
+	}
+}
+
+func TestMatchAccountTenant(t *testing.T) {
+	if !matchAccountTenant("*out:vdf:minu", "vdf", OUTBOUND) ||
+		matchAccountTenant("*in:vdf:minu", "vdf", OUTBOUND) ||
+		matchAccountTenant("*out:cgrates.org:minu", "vdf", OUTBOUND) {
+		t.Error("Wrong tenant and direction match")
+	}
+}
@@ -32,7 +32,7 @@ var (
 )
 
 func TestBalanceStoreRestore(t *testing.T) {
-	b := &Balance{Value: 14, Weight: 1, Uuid: "test", ExpirationDate: time.Date(2013, time.July, 15, 17, 48, 0, 0, time.UTC)}
+	b := &Balance{Value: utils.NewDecimalFromFloat(14), Weight: 1, Uuid: "test", ExpirationDate: time.Date(2013, time.July, 15, 17, 48, 0, 0, time.UTC)}
 	marsh := NewCodecMsgpackMarshaler()
 	output, err := marsh.Marshal(b)
 	if err != nil {
@@ -66,7 +66,7 @@ func TestBalanceStoreRestoreZero(t *testing.T) {
 }
 
 func TestBalanceChainStoreRestore(t *testing.T) {
-	bc := BalanceChain{&Balance{Value: 14, ExpirationDate: time.Date(2013, time.July, 15, 17, 48, 0, 0, time.UTC)}, &Balance{Value: 1024}}
+	bc := BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(14), ExpirationDate: time.Date(2013, time.July, 15, 17, 48, 0, 0, time.UTC)}, &Balance{Value: utils.NewDecimalFromFloat(1024)}}
 	output, err := marsh.Marshal(bc)
 	if err != nil {
 		t.Error("Error storing balance chain: ", err)
@@ -82,9 +82,9 @@ func TestBalanceChainStoreRestore(t *testing.T) {
 }
 
 func TestAccountStorageStoreRestore(t *testing.T) {
-	b1 := &Balance{Value: 10, Weight: 10, DestinationIds: "NAT"}
-	b2 := &Balance{Value: 100, Weight: 20, DestinationIds: "RET"}
-	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}}}}
+	b1 := &Balance{Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "NAT"}
+	b2 := &Balance{Value: utils.NewDecimalFromFloat(100), Weight: 20, DestinationIds: "RET"}
+	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}}}}
 	accountingStorage.SetAccount(rifsBalance)
 	ub1, err := accountingStorage.GetAccount("other")
 	if err != nil || !ub1.BalanceMap[utils.MONETARY+OUTBOUND].Equal(rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND]) {
@@ -94,9 +94,9 @@ func TestAccountStorageStoreRestore(t *testing.T) {
 }
 
 func TestGetSecondsForPrefix(t *testing.T) {
-	b1 := &Balance{Value: 10, Weight: 10, DestinationIds: "NAT"}
-	b2 := &Balance{Value: 100, Weight: 20, DestinationIds: "RET"}
-	ub1 := &Account{Id: "OUT:CUSTOMER_1:rif", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 200}}}}
+	b1 := &Balance{Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "NAT"}
+	b2 := &Balance{Value: utils.NewDecimalFromFloat(100), Weight: 20, DestinationIds: "RET"}
+	ub1 := &Account{Id: "OUT:CUSTOMER_1:rif", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(200)}}}}
 	cd := &CallDescriptor{
 		Category:      "0",
 		Tenant:        "vdf",
@@ -110,21 +110,21 @@ func TestGetSecondsForPrefix(t *testing.T) {
 	}
 	seconds, credit, bucketList := ub1.getCreditForPrefix(cd)
 	expected := 110 * time.Second
-	if credit != 200 || seconds != expected || bucketList[0].Weight < bucketList[1].Weight {
+	if credit.Float64() != 200 || seconds != expected || bucketList[0].Weight < bucketList[1].Weight {
 		t.Log(seconds, credit, bucketList)
 		t.Errorf("Expected %v was %v", expected, seconds)
 	}
 }
 
 func TestGetSpecialPricedSeconds(t *testing.T) {
-	b1 := &Balance{Value: 10, Weight: 10, DestinationIds: "NAT", RatingSubject: "minu"}
-	b2 := &Balance{Value: 100, Weight: 20, DestinationIds: "RET", RatingSubject: "minu"}
+	b1 := &Balance{Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "NAT", RatingSubject: "minu"}
+	b2 := &Balance{Value: utils.NewDecimalFromFloat(100), Weight: 20, DestinationIds: "RET", RatingSubject: "minu"}
 
 	ub1 := &Account{
 		Id: "OUT:CUSTOMER_1:rif",
 		BalanceMap: map[string]BalanceChain{
 			utils.VOICE + OUTBOUND:    BalanceChain{b1, b2},
-			utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}},
+			utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}},
 		},
 	}
 	cd := &CallDescriptor{
@@ -139,16 +139,16 @@ func TestGetSpecialPricedSeconds(t *testing.T) {
 	}
 	seconds, credit, bucketList := ub1.getCreditForPrefix(cd)
 	expected := 20 * time.Second
-	if credit != 0 || seconds != expected || len(bucketList) != 2 || bucketList[0].Weight < bucketList[1].Weight {
+	if credit.Float64() != 0 || seconds != expected || len(bucketList) != 2 || bucketList[0].Weight < bucketList[1].Weight {
 		t.Log(seconds, credit, bucketList)
 		t.Errorf("Expected %v was %v", expected, seconds)
 	}
 }
 
 func TestAccountStorageStore(t *testing.T) {
-	b1 := &Balance{Value: 10, Weight: 10, DestinationIds: "NAT"}
-	b2 := &Balance{Value: 100, Weight: 20, DestinationIds: "RET"}
-	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}}}}
+	b1 := &Balance{Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "NAT"}
+	b2 := &Balance{Value: utils.NewDecimalFromFloat(100), Weight: 20, DestinationIds: "RET"}
+	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}}}}
 	accountingStorage.SetAccount(rifsBalance)
 	result, err := accountingStorage.GetAccount(rifsBalance.Id)
 	if err != nil || rifsBalance.Id != result.Id ||
@@ -161,7 +161,7 @@ func TestAccountStorageStore(t *testing.T) {
 }
 
 func TestDebitCreditZeroSecond(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Value: 10, Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1s"}
+	b1 := &Balance{Uuid: "testb", Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1s"}
 	cc := &CallCost{
 		Direction:   OUTBOUND,
 		Destination: "0723045326",
@@ -170,7 +170,7 @@ func TestDebitCreditZeroSecond(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.VOICE,
@@ -184,7 +184,7 @@ func TestDebitCreditZeroSecond(t *testing.T) {
 		TOR:          utils.VOICE,
 		testCallcost: cc,
 	}
-	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Category: "0", Value: 21}}}}
+	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Category: "0", Value: utils.NewDecimalFromFloat(21)}}}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
 	if err != nil {
@@ -194,14 +194,14 @@ func TestDebitCreditZeroSecond(t *testing.T) {
 		t.Logf("%+v", cc.Timespans[0])
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 0 ||
-		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 21 {
+	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 0 ||
+		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 21 {
 		t.Error("Error extracting minutes from balance: ", rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0])
 	}
 }
 
 func TestDebitCreditZeroMinute(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Value: 70, Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
+	b1 := &Balance{Uuid: "testb", Value: utils.NewDecimalFromFloat(70), Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
 	cc := &CallCost{
 		Direction:   OUTBOUND,
 		Destination: "0723045326",
@@ -210,7 +210,7 @@ func TestDebitCreditZeroMinute(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.VOICE,
@@ -226,7 +226,7 @@ func TestDebitCreditZeroMinute(t *testing.T) {
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
 		utils.VOICE + OUTBOUND:    BalanceChain{b1},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -238,16 +238,16 @@ func TestDebitCreditZeroMinute(t *testing.T) {
 		cc.Timespans[0].Increments[0].Duration != time.Minute {
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 10 ||
-		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 21 {
+	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 10 ||
+		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 21 {
 		t.Error("Error extracting minutes from balance: ",
 			rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0])
 	}
 }
 
 func TestDebitCreditZeroMixedMinute(t *testing.T) {
-	b1 := &Balance{Uuid: "testm", Value: 70, Weight: 5, DestinationIds: "NAT", RatingSubject: "*zero1m"}
-	b2 := &Balance{Uuid: "tests", Value: 10, Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1s"}
+	b1 := &Balance{Uuid: "testm", Value: utils.NewDecimalFromFloat(70), Weight: 5, DestinationIds: "NAT", RatingSubject: "*zero1m"}
+	b2 := &Balance{Uuid: "tests", Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1s"}
 	cc := &CallCost{
 		Direction:   OUTBOUND,
 		Destination: "0723045326",
@@ -257,7 +257,7 @@ func TestDebitCreditZeroMixedMinute(t *testing.T) {
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 20, 0, time.UTC),
 				ratingInfo:    &RatingInfo{},
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.VOICE,
@@ -273,7 +273,7 @@ func TestDebitCreditZeroMixedMinute(t *testing.T) {
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
 		utils.VOICE + OUTBOUND:    BalanceChain{b1, b2},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -284,9 +284,9 @@ func TestDebitCreditZeroMixedMinute(t *testing.T) {
 		cc.Timespans[1].Increments[0].BalanceInfo.UnitBalanceUuid != "testm" {
 		t.Error("Error setting balance id to increment: ", cc.Timespans)
 	}
-	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][1].Value != 0 ||
-		rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 10 ||
-		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 21 {
+	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][1].Value.Float64() != 0 ||
+		rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 10 ||
+		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 21 {
 		t.Logf("TS0: %+v", cc.Timespans[0])
 		t.Logf("TS1: %+v", cc.Timespans[1])
 		t.Errorf("Error extracting minutes from balance: %+v", rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][1])
@@ -294,7 +294,7 @@ func TestDebitCreditZeroMixedMinute(t *testing.T) {
 }
 
 func TestDebitCreditNoCredit(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Value: 70, Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
+	b1 := &Balance{Uuid: "testb", Value: utils.NewDecimalFromFloat(70), Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
 	cc := &CallCost{
 		Direction:   OUTBOUND,
 		Destination: "0723045326",
@@ -303,13 +303,13 @@ func TestDebitCreditNoCredit(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 			&TimeSpan{
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 20, 0, time.UTC),
 				DurationIndex: 10 * time.Second,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.VOICE,
@@ -335,7 +335,7 @@ func TestDebitCreditNoCredit(t *testing.T) {
 		cc.Timespans[0].Increments[0].Duration != time.Minute {
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 10 {
+	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 10 {
 		t.Error("Error extracting minutes from balance: ",
 			rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0])
 	}
@@ -345,7 +345,7 @@ func TestDebitCreditNoCredit(t *testing.T) {
 }
 
 func TestDebitCreditHasCredit(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Value: 70, Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
+	b1 := &Balance{Uuid: "testb", Value: utils.NewDecimalFromFloat(70), Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
 	cc := &CallCost{
 		Direction:   OUTBOUND,
 		Destination: "0723045326",
@@ -354,13 +354,13 @@ func TestDebitCreditHasCredit(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 1, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 			&TimeSpan{
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 20, 0, time.UTC),
 				DurationIndex: 10 * time.Second,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 1, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.VOICE,
@@ -376,7 +376,7 @@ func TestDebitCreditHasCredit(t *testing.T) {
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
 		utils.VOICE + OUTBOUND:    BalanceChain{b1},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: 110}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: utils.NewDecimalFromFloat(110)}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -387,8 +387,8 @@ func TestDebitCreditHasCredit(t *testing.T) {
 		cc.Timespans[0].Increments[0].Duration != time.Minute {
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 10 ||
-		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 30 {
+	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 10 ||
+		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 30 {
 		t.Errorf("Error extracting minutes from balance: %+v, %+v",
 			rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value, rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value)
 	}
@@ -398,7 +398,7 @@ func TestDebitCreditHasCredit(t *testing.T) {
 }
 
 func TestDebitCreditSplitMinutesMoney(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Value: 10, Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1s"}
+	b1 := &Balance{Uuid: "testb", Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1s"}
 	cc := &CallCost{
 		Direction:   OUTBOUND,
 		Destination: "0723045326",
@@ -408,7 +408,7 @@ func TestDebitCreditSplitMinutesMoney(t *testing.T) {
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 20, 0, time.UTC),
 				DurationIndex: 0,
 				ratingInfo:    &RatingInfo{},
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 1, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.VOICE,
@@ -424,7 +424,7 @@ func TestDebitCreditSplitMinutesMoney(t *testing.T) {
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
 		utils.VOICE + OUTBOUND:    BalanceChain{b1},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: 50}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: utils.NewDecimalFromFloat(50)}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -435,8 +435,8 @@ func TestDebitCreditSplitMinutesMoney(t *testing.T) {
 		cc.Timespans[0].Increments[0].Duration != 1*time.Second {
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0].Duration)
 	}
-	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 0 ||
-		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 30 {
+	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 0 ||
+		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 30 {
 		t.Errorf("Error extracting minutes from balance: %+v, %+v",
 			rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value, rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value)
 	}
@@ -446,7 +446,7 @@ func TestDebitCreditSplitMinutesMoney(t *testing.T) {
 }
 
 func TestDebitCreditMoreTimespans(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Value: 150, Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
+	b1 := &Balance{Uuid: "testb", Value: utils.NewDecimalFromFloat(150), Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
 	cc := &CallCost{
 		Direction:   OUTBOUND,
 		Destination: "0723045326",
@@ -455,13 +455,13 @@ func TestDebitCreditMoreTimespans(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 			&TimeSpan{
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 20, 0, time.UTC),
 				DurationIndex: 10 * time.Second,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.VOICE,
@@ -487,15 +487,15 @@ func TestDebitCreditMoreTimespans(t *testing.T) {
 		cc.Timespans[0].Increments[0].Duration != time.Minute {
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 30 {
+	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 30 {
 		t.Error("Error extracting minutes from balance: ",
 			rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0])
 	}
 }
 
 func TestDebitCreditMoreTimespansMixed(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Value: 70, Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
-	b2 := &Balance{Uuid: "testa", Value: 150, Weight: 5, DestinationIds: "NAT", RatingSubject: "*zero1s"}
+	b1 := &Balance{Uuid: "testb", Value: utils.NewDecimalFromFloat(70), Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
+	b2 := &Balance{Uuid: "testa", Value: utils.NewDecimalFromFloat(150), Weight: 5, DestinationIds: "NAT", RatingSubject: "*zero1s"}
 	cc := &CallCost{
 		Direction:   OUTBOUND,
 		Destination: "0723045326",
@@ -504,13 +504,13 @@ func TestDebitCreditMoreTimespansMixed(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 			&TimeSpan{
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 20, 0, time.UTC),
 				DurationIndex: 10 * time.Second,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.VOICE,
@@ -536,15 +536,15 @@ func TestDebitCreditMoreTimespansMixed(t *testing.T) {
 		cc.Timespans[0].Increments[0].Duration != time.Minute {
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 10 ||
-		rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][1].Value != 130 {
+	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 10 ||
+		rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][1].Value.Float64() != 130 {
 		t.Error("Error extracting minutes from balance: ",
 			rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][1], cc.Timespans[1])
 	}
 }
 
 func TestDebitCreditNoConectFeeCredit(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Value: 70, Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
+	b1 := &Balance{Uuid: "testb", Value: utils.NewDecimalFromFloat(70), Weight: 10, DestinationIds: "NAT", RatingSubject: "*zero1m"}
 	cc := &CallCost{
 		Direction:   OUTBOUND,
 		Destination: "0723045326",
@@ -553,13 +553,13 @@ func TestDebitCreditNoConectFeeCredit(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{ConnectFee: 10.0, Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{ConnectFee: utils.NewDecimalFromFloat(10.0), Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 			&TimeSpan{
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 20, 0, time.UTC),
 				DurationIndex: 10 * time.Second,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 1, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR:              utils.VOICE,
@@ -583,7 +583,7 @@ func TestDebitCreditNoConectFeeCredit(t *testing.T) {
 		t.Error("Error showing debiting balance error: ", err)
 	}
 
-	if len(cc.Timespans) != 1 || rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue() != 0 {
+	if len(cc.Timespans) != 1 || rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().Float64() != 0 {
 		t.Error("Error cutting at no connect fee: ", rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND])
 	}
 }
@@ -597,14 +597,14 @@ func TestDebitCreditMoneyOnly(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 1, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 			&TimeSpan{
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 20, 0, time.UTC),
 				DurationIndex: 10 * time.Second,
 				ratingInfo:    &RatingInfo{},
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 1, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.VOICE,
@@ -619,7 +619,7 @@ func TestDebitCreditMoneyOnly(t *testing.T) {
 		testCallcost:  cc,
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: 50}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(50)}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -631,7 +631,7 @@ func TestDebitCreditMoneyOnly(t *testing.T) {
 		t.Logf("%+v", cc.Timespans[0].Increments)
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0].BalanceInfo)
 	}
-	if rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 0 {
+	if rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 0 {
 		t.Error("Error extracting minutes from balance: ",
 			rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0])
 	}
@@ -642,8 +642,80 @@ func TestDebitCreditMoneyOnly(t *testing.T) {
 	}
 }
 
+func TestDebitCreditMoneyExchangeRate(t *testing.T) {
+	ratingStorage.SetExchangeRate(&ExchangeRate{FromCurrency: "RON", ToCurrency: "USD", Rate: utils.NewDecimalFromFloat(0.25)})
+	ratingStorage.GetExchangeRate("RON:USD", true) // cache it
+	cc := &CallCost{
+		Direction:   OUTBOUND,
+		Destination: "0723045326",
+		Timespans: []*TimeSpan{
+			&TimeSpan{
+				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
+				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
+				DurationIndex: 0,
+				RateInterval:  &RateInterval{Rating: &RIRate{Currency: "USD", Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+			},
+		},
+		TOR: utils.VOICE,
+	}
+	cd := &CallDescriptor{
+		TimeStart:     cc.Timespans[0].TimeStart,
+		TimeEnd:       cc.Timespans[0].TimeEnd,
+		Direction:     cc.Direction,
+		Destination:   cc.Destination,
+		TOR:           cc.TOR,
+		DurationIndex: cc.GetDuration(),
+		testCallcost:  cc,
+	}
+	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(100), Currency: "RON"}},
+	}}
+	var err error
+	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
+	if err != nil {
+		t.Error("Error debiting balance: ", err)
+	}
+	if rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 60 {
+		t.Error("Error converting debited money: ", rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0])
+	}
+	inc := cc.Timespans[0].Increments[0]
+	if inc.Cost.Float64() != 10 || inc.BalanceInfo.ExchangeRate == nil ||
+		inc.BalanceInfo.ExchangeRate.GetId() != "USD:RON" || inc.BalanceInfo.ExchangeRate.Rate.Float64() != 4 {
+		t.Errorf("Error recording exchange rate: %+v", inc.BalanceInfo)
+	}
+	cc.updateExchangeRates()
+	if len(cc.ExchangeRates) != 1 {
+		t.Error("Error collecting exchange rates: ", cc.ExchangeRates)
+	}
+	rifsBalance.refundIncrement(inc, OUTBOUND, utils.VOICE, false)
+	if rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 100 {
+		t.Error("Error refunding converted money: ", rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0])
+	}
+}
+
+func TestDebitFeesMissingExchangeRate(t *testing.T) {
+	cc := &CallCost{Direction: OUTBOUND, Destination: "0723045326", deductConnectFee: true, Timespans: []*TimeSpan{
+		&TimeSpan{RateInterval: &RateInterval{Rating: &RIRate{Currency: "EUR", ConnectFee: utils.NewDecimalFromFloat(1)}}},
+	}, Taxes: TaxLines{&TaxLine{TaxId: "VAT", Amount: utils.NewDecimalFromFloat(2), Debit: true}}}
+	acc := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(100), Currency: "RON"}},
+	}}
+	if err := acc.DebitConnectionFee(cc, acc.BalanceMap[utils.MONETARY+OUTBOUND], false); err == nil {
+		t.Error("Expecting error on connect fee without exchange rate")
+	}
+	if err := acc.debitTaxes(cc, false); err == nil {
+		t.Error("Expecting error on taxes without exchange rate")
+	}
+	if err := acc.debitMinCost(cc, utils.NewDecimalFromFloat(3), false); err == nil {
+		t.Error("Expecting error on minimum cost without exchange rate")
+	}
+	if acc.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "100" {
+		t.Errorf("Debited without exchange rate: %+v", acc.BalanceMap[utils.MONETARY+OUTBOUND][0])
+	}
+}
+
 func TestDebitCreditSubjectMinutes(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Category: "0", Value: 250, Weight: 10, DestinationIds: "NAT", RatingSubject: "minu"}
+	b1 := &Balance{Uuid: "testb", Category: "0", Value: utils.NewDecimalFromFloat(250), Weight: 10, DestinationIds: "NAT", RatingSubject: "minu"}
 	cc := &CallCost{
 		Tenant:      "vdf",
 		Category:    "0",
@@ -654,7 +726,7 @@ func TestDebitCreditSubjectMinutes(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 1, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR:              utils.VOICE,
@@ -673,7 +745,7 @@ func TestDebitCreditSubjectMinutes(t *testing.T) {
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
 		utils.VOICE + OUTBOUND:    BalanceChain{b1},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: 350}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: utils.NewDecimalFromFloat(350)}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -685,8 +757,8 @@ func TestDebitCreditSubjectMinutes(t *testing.T) {
 		cc.Timespans[0].Increments[0].Duration != 10*time.Second {
 		t.Errorf("Error setting balance id to increment: %+v", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 180 ||
-		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 280 {
+	if rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 180 ||
+		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 280 {
 		t.Errorf("Error extracting minutes from balance: %+v, %+v",
 			rifsBalance.BalanceMap[utils.VOICE+OUTBOUND][0].Value, rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value)
 	}
@@ -709,7 +781,7 @@ func TestDebitCreditSubjectMoney(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 1, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR:              utils.VOICE,
@@ -727,7 +799,7 @@ func TestDebitCreditSubjectMoney(t *testing.T) {
 		testCallcost:  cc,
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: 75, DestinationIds: "NAT", RatingSubject: "minu"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: utils.NewDecimalFromFloat(75), DestinationIds: "NAT", RatingSubject: "minu"}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -738,7 +810,7 @@ func TestDebitCreditSubjectMoney(t *testing.T) {
 		cc.Timespans[0].Increments[0].Duration != 10*time.Second {
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 5 {
+	if rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 5 {
 		t.Errorf("Error extracting minutes from balance: %+v",
 			rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value)
 	}
@@ -804,7 +876,7 @@ func TestDebitCreditSubjectMoney(t *testing.T) {
 }*/
 
 func TestDebitCreditSubjectMixedMoreTS(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Value: 70, Weight: 10, DestinationIds: "NAT", RatingSubject: "minu"}
+	b1 := &Balance{Uuid: "testb", Value: utils.NewDecimalFromFloat(70), Weight: 10, DestinationIds: "NAT", RatingSubject: "minu"}
 	cc := &CallCost{
 		Tenant:      "vdf",
 		Category:    "0",
@@ -815,13 +887,13 @@ func TestDebitCreditSubjectMixedMoreTS(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 			&TimeSpan{
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 20, 0, time.UTC),
 				DurationIndex: 10 * time.Second,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 1, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR:              utils.VOICE,
@@ -840,7 +912,7 @@ func TestDebitCreditSubjectMixedMoreTS(t *testing.T) {
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
 		utils.VOICE + OUTBOUND:    BalanceChain{b1},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: 50, RatingSubject: "minu"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: utils.NewDecimalFromFloat(50), RatingSubject: "minu"}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -868,7 +940,7 @@ func TestDebitCreditSubjectMixedMoreTS(t *testing.T) {
 }
 
 func TestDebitCreditSubjectMixedPartPay(t *testing.T) {
-	b1 := &Balance{Uuid: "testb", Value: 70, Weight: 10, DestinationIds: "NAT", RatingSubject: "minu"}
+	b1 := &Balance{Uuid: "testb", Value: utils.NewDecimalFromFloat(70), Weight: 10, DestinationIds: "NAT", RatingSubject: "minu"}
 	cc := &CallCost{
 		Tenant:      "vdf",
 		Category:    "0",
@@ -879,13 +951,13 @@ func TestDebitCreditSubjectMixedPartPay(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 			&TimeSpan{
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 20, 0, time.UTC),
 				DurationIndex: 10 * time.Second,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 1, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(1), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR:              utils.VOICE,
@@ -904,7 +976,7 @@ func TestDebitCreditSubjectMixedPartPay(t *testing.T) {
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
 		utils.VOICE + OUTBOUND:    BalanceChain{b1},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: 75, RatingSubject: "minu"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: utils.NewDecimalFromFloat(75), RatingSubject: "minu"}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -933,9 +1005,9 @@ func TestDebitCreditSubjectMixedPartPay(t *testing.T) {
 
 func TestAccountdebitBalance(t *testing.T) {
 	ub := &Account{
-		Id:            "rif",
-		AllowNegative: true,
-		BalanceMap:    map[string]BalanceChain{utils.SMS: BalanceChain{&Balance{Value: 14}}, utils.DATA: BalanceChain{&Balance{Value: 1204}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		Id:           "rif",
+		CreditLimits: map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}},
+		BalanceMap:   map[string]BalanceChain{utils.SMS: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(14)}}, utils.DATA: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1204)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
 	}
 	newMb := &Balance{Weight: 20, DestinationIds: "NEW"}
 	a := &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: newMb}
@@ -948,23 +1020,23 @@ func TestAccountdebitBalance(t *testing.T) {
 func TestAccountdebitBalanceExists(t *testing.T) {
 
 	ub := &Account{
-		Id:            "rif",
-		AllowNegative: true,
-		BalanceMap:    map[string]BalanceChain{utils.SMS + OUTBOUND: BalanceChain{&Balance{Value: 14}}, utils.DATA + OUTBOUND: BalanceChain{&Balance{Value: 1024}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 15, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		Id:           "rif",
+		CreditLimits: map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}},
+		BalanceMap:   map[string]BalanceChain{utils.SMS + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(14)}}, utils.DATA + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1024)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(15), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
 	}
-	newMb := &Balance{Value: -10, Weight: 20, DestinationIds: "NAT"}
+	newMb := &Balance{Value: utils.NewDecimalFromFloat(-10), Weight: 20, DestinationIds: "NAT"}
 	a := &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: newMb}
 	ub.debitBalanceAction(a, false)
-	if len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 2 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 25 {
+	if len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 2 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 25 {
 		t.Error("Error adding minute bucket!")
 	}
 }
 
 func TestAccountAddMinuteNil(t *testing.T) {
 	ub := &Account{
-		Id:            "rif",
-		AllowNegative: true,
-		BalanceMap:    map[string]BalanceChain{utils.SMS + OUTBOUND: BalanceChain{&Balance{Value: 14}}, utils.DATA + OUTBOUND: BalanceChain{&Balance{Value: 1024}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		Id:           "rif",
+		CreditLimits: map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}},
+		BalanceMap:   map[string]BalanceChain{utils.SMS + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(14)}}, utils.DATA + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1024)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
 	}
 	ub.debitBalanceAction(nil, false)
 	if len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 2 {
@@ -973,9 +1045,9 @@ func TestAccountAddMinuteNil(t *testing.T) {
 }
 
 func TestAccountAddMinutBucketEmpty(t *testing.T) {
-	mb1 := &Balance{Value: -10, DestinationIds: "NAT"}
-	mb2 := &Balance{Value: -10, DestinationIds: "NAT"}
-	mb3 := &Balance{Value: -10, DestinationIds: "OTHER"}
+	mb1 := &Balance{Value: utils.NewDecimalFromFloat(-10), DestinationIds: "NAT"}
+	mb2 := &Balance{Value: utils.NewDecimalFromFloat(-10), DestinationIds: "NAT"}
+	mb3 := &Balance{Value: utils.NewDecimalFromFloat(-10), DestinationIds: "OTHER"}
 	ub := &Account{}
 	a := &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: mb1}
 	ub.debitBalanceAction(a, false)
@@ -984,7 +1056,7 @@ func TestAccountAddMinutBucketEmpty(t *testing.T) {
 	}
 	a = &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: mb2}
 	ub.debitBalanceAction(a, false)
-	if len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 1 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 20 {
+	if len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 1 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 20 {
 		t.Error("Error adding minute bucket: ", ub.BalanceMap[utils.VOICE+OUTBOUND])
 	}
 	a = &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: mb3}
@@ -997,23 +1069,23 @@ func TestAccountAddMinutBucketEmpty(t *testing.T) {
 func TestAccountExecuteTriggeredActions(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ThresholdType: TRIGGER_MAX_COUNTER, ActionsId: "TEST_ACTIONS"}},
 	}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Balance: &Balance{Value: 1}})
-	if ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 110 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 20 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Balance: &Balance{Value: utils.NewDecimalFromFloat(1)}})
+	if ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 110 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 20 {
 		t.Error("Error executing triggered actions", ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value, ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value)
 	}
 	// are set to executed
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 1}})
-	if ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 110 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 20 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(1)}})
+	if ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 110 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 20 {
 		t.Error("Error executing triggered actions", ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value, ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value)
 	}
 	// we can reset them
 	ub.ResetActionTriggers(nil)
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}})
-	if ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 120 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 30 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}})
+	if ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 120 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 30 {
 		t.Error("Error executing triggered actions", ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value, ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value)
 	}
 }
@@ -1021,12 +1093,12 @@ func TestAccountExecuteTriggeredActions(t *testing.T) {
 func TestAccountExecuteTriggeredActionsBalance(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 100, ThresholdType: TRIGGER_MIN_COUNTER, ActionsId: "TEST_ACTIONS"}},
 	}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Balance: &Balance{Value: 1}})
-	if ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 110 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 20 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Balance: &Balance{Value: utils.NewDecimalFromFloat(1)}})
+	if ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 110 || ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 20 {
 		t.Error("Error executing triggered actions", ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value, ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value)
 	}
 }
@@ -1034,12 +1106,12 @@ func TestAccountExecuteTriggeredActionsBalance(t *testing.T) {
 func TestAccountExecuteTriggeredActionsOrder(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB_OREDER",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 100}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ThresholdType: TRIGGER_MAX_COUNTER, ActionsId: "TEST_ACTIONS_ORDER"}},
 	}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 1}})
-	if len(ub.BalanceMap[utils.MONETARY+OUTBOUND]) != 1 || ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 10 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(1)}})
+	if len(ub.BalanceMap[utils.MONETARY+OUTBOUND]) != 1 || ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 10 {
 		t.Error("Error executing triggered actions in order", ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value)
 	}
 }
@@ -1066,44 +1138,44 @@ func TestCleanExpired(t *testing.T) {
 
 func TestAccountUnitCounting(t *testing.T) {
 	ub := &Account{}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}})
-	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value != 10 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}})
+	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value.Float64() != 10 {
 		t.Error("Error counting units")
 	}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}})
-	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value != 20 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}})
+	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value.Float64() != 20 {
 		t.Error("Error counting units")
 	}
 }
 
 func TestAccountUnitCountingOutbound(t *testing.T) {
 	ub := &Account{}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}})
-	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value != 10 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}})
+	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value.Float64() != 10 {
 		t.Error("Error counting units")
 	}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}})
-	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value != 20 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}})
+	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value.Float64() != 20 {
 		t.Error("Error counting units")
 	}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}})
-	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value != 30 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}})
+	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value.Float64() != 30 {
 		t.Error("Error counting units")
 	}
 }
 
 func TestAccountUnitCountingOutboundInbound(t *testing.T) {
 	ub := &Account{}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Balance: &Balance{Value: 10}})
-	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value != 10 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}})
+	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value.Float64() != 10 {
 		t.Errorf("Error counting units: %+v", ub.UnitCounters[0])
 	}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}})
-	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value != 20 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}})
+	if len(ub.UnitCounters) != 1 && ub.UnitCounters[0].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value.Float64() != 20 {
 		t.Error("Error counting units")
 	}
-	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: INBOUND, Balance: &Balance{Value: 10}})
-	if len(ub.UnitCounters) != 2 && ub.UnitCounters[1].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value != 20 || ub.UnitCounters[1].Balances[0].Value != 10 {
+	ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: INBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}})
+	if len(ub.UnitCounters) != 2 && ub.UnitCounters[1].BalanceType != utils.MONETARY || ub.UnitCounters[0].Balances[0].Value.Float64() != 20 || ub.UnitCounters[1].Balances[0].Value.Float64() != 10 {
 		t.Error("Error counting units")
 	}
 }
@@ -1112,25 +1184,25 @@ func TestAccountRefund(t *testing.T) {
 	ub := &Account{
 		BalanceMap: map[string]BalanceChain{
 			utils.MONETARY + OUTBOUND: BalanceChain{
-				&Balance{Uuid: "moneya", Value: 100},
+				&Balance{Uuid: "moneya", Value: utils.NewDecimalFromFloat(100)},
 			},
 			utils.VOICE + OUTBOUND: BalanceChain{
-				&Balance{Uuid: "minutea", Value: 10, Weight: 20, DestinationIds: "NAT"},
-				&Balance{Uuid: "minuteb", Value: 10, DestinationIds: "RET"},
+				&Balance{Uuid: "minutea", Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"},
+				&Balance{Uuid: "minuteb", Value: utils.NewDecimalFromFloat(10), DestinationIds: "RET"},
 			},
 		},
 	}
 	increments := Increments{
-		&Increment{Cost: 2, BalanceInfo: &BalanceInfo{UnitBalanceUuid: "", MoneyBalanceUuid: "moneya"}},
-		&Increment{Cost: 2, Duration: 3 * time.Second, BalanceInfo: &BalanceInfo{UnitBalanceUuid: "minutea", MoneyBalanceUuid: "moneya"}},
+		&Increment{Cost: utils.NewDecimalFromFloat(2), BalanceInfo: &BalanceInfo{UnitBalanceUuid: "", MoneyBalanceUuid: "moneya"}},
+		&Increment{Cost: utils.NewDecimalFromFloat(2), Duration: 3 * time.Second, BalanceInfo: &BalanceInfo{UnitBalanceUuid: "minutea", MoneyBalanceUuid: "moneya"}},
 		&Increment{Duration: 4 * time.Second, BalanceInfo: &BalanceInfo{UnitBalanceUuid: "minuteb", MoneyBalanceUuid: ""}},
 	}
 	for _, increment := range increments {
 		ub.refundIncrement(increment, OUTBOUND, utils.VOICE, false)
 	}
-	if ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 104 ||
-		ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 13 ||
-		ub.BalanceMap[utils.VOICE+OUTBOUND][1].Value != 14 {
+	if ub.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 104 ||
+		ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 13 ||
+		ub.BalanceMap[utils.VOICE+OUTBOUND][1].Value.Float64() != 14 {
 		t.Error("Error refounding money: ", ub.BalanceMap[utils.VOICE+OUTBOUND][1].Value)
 	}
 }
@@ -1146,7 +1218,7 @@ func TestDebitShared(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 0, 0, time.UTC),
 				DurationIndex: 55 * time.Second,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 2, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(2), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		deductConnectFee: true,
@@ -1163,10 +1235,10 @@ func TestDebitShared(t *testing.T) {
 		testCallcost:  cc,
 	}
 	rif := &Account{Id: "rif", BalanceMap: map[string]BalanceChain{
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: 0, SharedGroup: "SG_TEST"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: utils.NewDecimalFromFloat(0), SharedGroup: "SG_TEST"}},
 	}}
 	groupie := &Account{Id: "groupie", BalanceMap: map[string]BalanceChain{
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneyc", Value: 130, SharedGroup: "SG_TEST"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneyc", Value: utils.NewDecimalFromFloat(130), SharedGroup: "SG_TEST"}},
 	}}
 
 	sg := &SharedGroup{Id: "SG_TEST", MemberIds: []string{rif.Id, groupie.Id}, AccountParameters: map[string]*SharingParameters{"*any": &SharingParameters{Strategy: STRATEGY_MINE_RANDOM}}}
@@ -1178,11 +1250,11 @@ func TestDebitShared(t *testing.T) {
 	if err != nil {
 		t.Error("Error debiting balance: ", err)
 	}
-	if rif.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 0 {
+	if rif.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 0 {
 		t.Errorf("Error debiting from shared group: %+v", rif.BalanceMap[utils.MONETARY+OUTBOUND][0])
 	}
 	groupie, _ = accountingStorage.GetAccount("groupie")
-	if groupie.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 10 {
+	if groupie.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 10 {
 		t.Errorf("Error debiting from shared group: %+v", groupie.BalanceMap[utils.MONETARY+OUTBOUND][0])
 	}
 
@@ -1216,7 +1288,7 @@ func TestMaxDurationShared(t *testing.T) {
 				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
 				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 0, 0, time.UTC),
 				DurationIndex: 55 * time.Second,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 2, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(2), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		deductConnectFee: true,
@@ -1233,10 +1305,10 @@ func TestMaxDurationShared(t *testing.T) {
 		testCallcost:  cc,
 	}
 	rif := &Account{Id: "rif", BalanceMap: map[string]BalanceChain{
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: 0, SharedGroup: "SG_TEST"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneya", Value: utils.NewDecimalFromFloat(0), SharedGroup: "SG_TEST"}},
 	}}
 	groupie := &Account{Id: "groupie", BalanceMap: map[string]BalanceChain{
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneyc", Value: 130, SharedGroup: "SG_TEST"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "moneyc", Value: utils.NewDecimalFromFloat(130), SharedGroup: "SG_TEST"}},
 	}}
 
 	sg := &SharedGroup{Id: "SG_TEST", MemberIds: []string{rif.Id, groupie.Id}, AccountParameters: map[string]*SharingParameters{"*any": &SharingParameters{Strategy: STRATEGY_MINE_RANDOM}}}
@@ -1264,7 +1336,7 @@ func TestDebitSMS(t *testing.T) {
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 1, 0, time.UTC),
 				ratingInfo:    &RatingInfo{},
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 1 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 1 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.SMS,
@@ -1279,8 +1351,8 @@ func TestDebitSMS(t *testing.T) {
 		testCallcost:  cc,
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
-		utils.SMS + OUTBOUND:      BalanceChain{&Balance{Uuid: "testm", Value: 100, Weight: 5, DestinationIds: "NAT"}},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}},
+		utils.SMS + OUTBOUND:      BalanceChain{&Balance{Uuid: "testm", Value: utils.NewDecimalFromFloat(100), Weight: 5, DestinationIds: "NAT"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -1290,8 +1362,8 @@ func TestDebitSMS(t *testing.T) {
 	if cc.Timespans[0].Increments[0].BalanceInfo.UnitBalanceUuid != "testm" {
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.SMS+OUTBOUND][0].Value != 99 ||
-		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 21 {
+	if rifsBalance.BalanceMap[utils.SMS+OUTBOUND][0].Value.Float64() != 99 ||
+		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 21 {
 		t.Log(cc.Timespans[0].Increments)
 		t.Error("Error extracting minutes from balance: ", rifsBalance.BalanceMap[utils.SMS+OUTBOUND][0].Value, rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value)
 	}
@@ -1307,7 +1379,7 @@ func TestDebitGeneric(t *testing.T) {
 				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 1, 0, time.UTC),
 				ratingInfo:    &RatingInfo{},
 				DurationIndex: 0,
-				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 1 * time.Second, RateUnit: time.Second}}}},
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 1 * time.Second, RateUnit: time.Second}}}},
 			},
 		},
 		TOR: utils.GENERIC,
@@ -1322,8 +1394,8 @@ func TestDebitGeneric(t *testing.T) {
 		testCallcost:  cc,
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
-		utils.GENERIC + OUTBOUND:  BalanceChain{&Balance{Uuid: "testm", Value: 100, Weight: 5, DestinationIds: "NAT"}},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}},
+		utils.GENERIC + OUTBOUND:  BalanceChain{&Balance{Uuid: "testm", Value: utils.NewDecimalFromFloat(100), Weight: 5, DestinationIds: "NAT"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -1333,8 +1405,8 @@ func TestDebitGeneric(t *testing.T) {
 	if cc.Timespans[0].Increments[0].BalanceInfo.UnitBalanceUuid != "testm" {
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.GENERIC+OUTBOUND][0].Value != 99 ||
-		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 21 {
+	if rifsBalance.BalanceMap[utils.GENERIC+OUTBOUND][0].Value.Float64() != 99 ||
+		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 21 {
 		t.Log(cc.Timespans[0].Increments)
 		t.Error("Error extracting minutes from balance: ", rifsBalance.BalanceMap[utils.GENERIC+OUTBOUND][0].Value, rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value)
 	}
@@ -1353,8 +1425,8 @@ func TestDebitDataUnits(t *testing.T) {
 				RateInterval: &RateInterval{
 					Rating: &RIRate{
 						Rates: RateGroups{
-							&Rate{GroupIntervalStart: 0, Value: 2, RateIncrement: 1 * time.Second, RateUnit: time.Minute},
-							&Rate{GroupIntervalStart: 60, Value: 1, RateIncrement: 1 * time.Second, RateUnit: time.Second},
+							&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(2), RateIncrement: 1 * time.Second, RateUnit: time.Minute},
+							&Rate{GroupIntervalStart: 60, Value: utils.NewDecimalFromFloat(1), RateIncrement: 1 * time.Second, RateUnit: time.Second},
 						},
 					},
 				},
@@ -1372,8 +1444,8 @@ func TestDebitDataUnits(t *testing.T) {
 		testCallcost:  cc,
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
-		utils.DATA + OUTBOUND:     BalanceChain{&Balance{Uuid: "testm", Value: 100, Weight: 5, DestinationIds: "NAT"}},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}},
+		utils.DATA + OUTBOUND:     BalanceChain{&Balance{Uuid: "testm", Value: utils.NewDecimalFromFloat(100), Weight: 5, DestinationIds: "NAT"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
@@ -1383,8 +1455,8 @@ func TestDebitDataUnits(t *testing.T) {
 	if cc.Timespans[0].Increments[0].BalanceInfo.UnitBalanceUuid != "testm" {
 		t.Error("Error setting balance id to increment: ", cc.Timespans[0].Increments[0])
 	}
-	if rifsBalance.BalanceMap[utils.DATA+OUTBOUND][0].Value != 20 ||
-		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 21 {
+	if rifsBalance.BalanceMap[utils.DATA+OUTBOUND][0].Value.Float64() != 20 ||
+		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 21 {
 		t.Log(cc.Timespans[0].Increments)
 		t.Error("Error extracting minutes from balance: ", rifsBalance.BalanceMap[utils.DATA+OUTBOUND][0].Value, rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value)
 	}
@@ -1403,7 +1475,7 @@ func TestDebitDataMoney(t *testing.T) {
 				RateInterval: &RateInterval{
 					Rating: &RIRate{
 						Rates: RateGroups{
-							&Rate{GroupIntervalStart: 0, Value: 2, RateIncrement: time.Minute, RateUnit: time.Second},
+							&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(2), RateIncrement: time.Minute, RateUnit: time.Second},
 						},
 					},
 				},
@@ -1421,16 +1493,16 @@ func TestDebitDataMoney(t *testing.T) {
 		testCallcost:  cc,
 	}
 	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
-		utils.DATA + OUTBOUND:     BalanceChain{&Balance{Uuid: "testm", Value: 0, Weight: 5, DestinationIds: "NAT"}},
-		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 160}},
+		utils.DATA + OUTBOUND:     BalanceChain{&Balance{Uuid: "testm", Value: utils.NewDecimalFromFloat(0), Weight: 5, DestinationIds: "NAT"}},
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(160)}},
 	}}
 	var err error
 	cc, err = rifsBalance.debitCreditBalance(cd, false, false, true)
 	if err != nil {
 		t.Error("Error debiting balance: ", err)
 	}
-	if rifsBalance.BalanceMap[utils.DATA+OUTBOUND][0].Value != 0 ||
-		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 0 {
+	if rifsBalance.BalanceMap[utils.DATA+OUTBOUND][0].Value.Float64() != 0 ||
+		rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 0 {
 		t.Error("Error extracting minutes from balance: ", rifsBalance.BalanceMap[utils.DATA+OUTBOUND][0].Value, rifsBalance.BalanceMap[utils.MONETARY+OUTBOUND][0].Value)
 	}
 }
@@ -1456,12 +1528,49 @@ func TestAccountGetDefaultMoneyBalance(t *testing.T) {
 
 /*********************************** Benchmarks *******************************/
 
+func TestDebitCreditTieredUsage(t *testing.T) {
+	cd := &CallDescriptor{
+		Direction:   OUTBOUND,
+		Tenant:      "cgrates.org",
+		Category:    "call",
+		Subject:     "tiered",
+		Account:     "tiered",
+		Destination: "0723045326",
+		TimeStart:   time.Date(2015, 6, 10, 10, 0, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2015, 6, 10, 10, 2, 0, 0, time.UTC),
+		TOR:         utils.VOICE,
+	}
+	acc := &Account{Id: "*out:cgrates.org:tiered",
+		BalanceMap: map[string]BalanceChain{
+			utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(200)}},
+		},
+		UsageCounters: []*UsageCounter{
+			&UsageCounter{Direction: OUTBOUND, TOR: utils.VOICE, PeriodStart: time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC), Usage: 999 * time.Minute},
+		},
+	}
+	cc, err := acc.debitCreditBalance(cd, true, false, true)
+	if err != nil {
+		t.Fatal("Error debiting tiered usage: ", err)
+	}
+	if len(cc.Timespans) != 2 || cc.Timespans[0].GetDuration() != time.Minute ||
+		cc.Timespans[1].RateInterval.Rating.Rates[0].Value.String() != "0.5" {
+		t.Errorf("Error splitting on the rate tier: %+v", cc.Timespans)
+	}
+	// connect fee 1, first minute at 1 and the second one at 0.5 per second
+	if acc.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "109" {
+		t.Error("Wrong balance after tiered debit: ", acc.BalanceMap[utils.MONETARY+OUTBOUND][0].Value)
+	}
+	if usage := acc.GetPeriodUsage(OUTBOUND, utils.VOICE, cd.TimeEnd); usage != 1001*time.Minute {
+		t.Error("Wrong usage counted: ", usage)
+	}
+}
+
 func BenchmarkGetSecondForPrefix(b *testing.B) {
 	b.StopTimer()
-	b1 := &Balance{Value: 10, Weight: 10, DestinationIds: "NAT"}
-	b2 := &Balance{Value: 100, Weight: 20, DestinationIds: "RET"}
+	b1 := &Balance{Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "NAT"}
+	b2 := &Balance{Value: utils.NewDecimalFromFloat(100), Weight: 20, DestinationIds: "RET"}
 
-	ub1 := &Account{Id: "other", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}}}}
+	ub1 := &Account{Id: "other", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}}}}
 	cd := &CallDescriptor{
 		Destination: "0723",
 	}
@@ -1472,9 +1581,9 @@ func BenchmarkGetSecondForPrefix(b *testing.B) {
 }
 
 func BenchmarkAccountStorageStoreRestore(b *testing.B) {
-	b1 := &Balance{Value: 10, Weight: 10, DestinationIds: "NAT"}
-	b2 := &Balance{Value: 100, Weight: 20, DestinationIds: "RET"}
-	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}}}}
+	b1 := &Balance{Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "NAT"}
+	b2 := &Balance{Value: utils.NewDecimalFromFloat(100), Weight: 20, DestinationIds: "RET"}
+	rifsBalance := &Account{Id: "other", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}}}}
 	for i := 0; i < b.N; i++ {
 		accountingStorage.SetAccount(rifsBalance)
 		accountingStorage.GetAccount(rifsBalance.Id)
@@ -1482,9 +1591,9 @@ func BenchmarkAccountStorageStoreRestore(b *testing.B) {
 }
 
 func BenchmarkGetSecondsForPrefix(b *testing.B) {
-	b1 := &Balance{Value: 10, Weight: 10, DestinationIds: "NAT"}
-	b2 := &Balance{Value: 100, Weight: 20, DestinationIds: "RET"}
-	ub1 := &Account{Id: "OUT:CUSTOMER_1:rif", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 21}}}}
+	b1 := &Balance{Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "NAT"}
+	b2 := &Balance{Value: utils.NewDecimalFromFloat(100), Weight: 20, DestinationIds: "RET"}
+	ub1 := &Account{Id: "OUT:CUSTOMER_1:rif", BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b1, b2}, utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(21)}}}}
 	cd := &CallDescriptor{
 		Destination: "0723",
 	}
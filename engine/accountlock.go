@@ -19,32 +19,63 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"errors"
 	"sync"
+	"time"
 )
 
 // global package variable
 var AccLock = &AccountLock{queue: make(map[string]chan bool)}
 
+var ErrLockTimeout = errors.New("timeout waiting for the account lock")
+
 type AccountLock struct {
 	queue map[string]chan bool
-	mu    sync.Mutex
+	mu    sync.Mutex // serializes the guards taking their locks
+	qmu   sync.Mutex // protects the queue map
+}
+
+func (cm *AccountLock) getLock(name string) chan bool {
+	cm.qmu.Lock()
+	defer cm.qmu.Unlock()
+	lock, exists := cm.queue[name]
+	if !exists {
+		lock = make(chan bool, 1)
+		cm.queue[name] = lock
+	}
+	return lock
 }
 
 func (cm *AccountLock) Guard(handler func() (interface{}, error), names ...string) (reply interface{}, err error) {
 	cm.mu.Lock()
 	for _, name := range names {
-		lock, exists := AccLock.queue[name]
-		if !exists {
-			lock = make(chan bool, 1)
-			AccLock.queue[name] = lock
-		}
-		lock <- true
+		cm.getLock(name) <- true
 	}
 	cm.mu.Unlock()
 	reply, err = handler()
 	for _, name := range names {
-		lock := AccLock.queue[name]
-		<-lock
+		<-cm.getLock(name)
+	}
+	return
+}
+
+// Guard for handlers already running under the lock of other accounts. A guard waiting
+// for the held accounts would never get them, so it gives up after the timeout.
+func (cm *AccountLock) GuardWithin(handler func() (interface{}, error), timeout time.Duration, names ...string) (reply interface{}, err error) {
+	expired := time.After(timeout)
+	for i, name := range names {
+		select {
+		case cm.getLock(name) <- true:
+		case <-expired:
+			for _, locked := range names[:i] {
+				<-cm.getLock(locked)
+			}
+			return nil, ErrLockTimeout
+		}
+	}
+	reply, err = handler()
+	for _, name := range names {
+		<-cm.getLock(name)
 	}
 	return
 }
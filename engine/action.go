@@ -48,26 +48,30 @@ type Action struct {
 }
 
 const (
-	LOG             = "*log"
-	RESET_TRIGGERS  = "*reset_triggers"
-	SET_RECURRENT   = "*set_recurrent"
-	UNSET_RECURRENT = "*unset_recurrent"
-	ALLOW_NEGATIVE  = "*allow_negative"
-	DENY_NEGATIVE   = "*deny_negative"
-	RESET_ACCOUNT   = "*reset_account"
-	TOPUP_RESET     = "*topup_reset"
-	TOPUP           = "*topup"
-	DEBIT_RESET     = "*debit_reset"
-	DEBIT           = "*debit"
-	RESET_COUNTER   = "*reset_counter"
-	RESET_COUNTERS  = "*reset_counters"
-	ENABLE_ACCOUNT  = "*enable_account"
-	DISABLE_ACCOUNT = "*disable_account"
-	CALL_URL        = "*call_url"
-	CALL_URL_ASYNC  = "*call_url_async"
-	MAIL_ASYNC      = "*mail_async"
-	UNLIMITED       = "*unlimited"
-	CDRLOG          = "*cdrlog"
+	LOG              = "*log"
+	RESET_TRIGGERS   = "*reset_triggers"
+	SET_RECURRENT    = "*set_recurrent"
+	UNSET_RECURRENT  = "*unset_recurrent"
+	ALLOW_NEGATIVE   = "*allow_negative"
+	DENY_NEGATIVE    = "*deny_negative"
+	RESET_ACCOUNT    = "*reset_account"
+	TOPUP_RESET      = "*topup_reset"
+	TOPUP            = "*topup"
+	DEBIT_RESET      = "*debit_reset"
+	DEBIT            = "*debit"
+	RESET_COUNTER    = "*reset_counter"
+	RESET_COUNTERS   = "*reset_counters"
+	ENABLE_ACCOUNT   = "*enable_account"
+	DISABLE_ACCOUNT  = "*disable_account"
+	CALL_URL         = "*call_url"
+	CALL_URL_ASYNC   = "*call_url_async"
+	MAIL_ASYNC       = "*mail_async"
+	UNLIMITED        = "*unlimited"
+	CDRLOG           = "*cdrlog"
+	TRANSFER_BALANCE = "*transfer_balance"
+	ROLLOVER         = "*rollover"
+	SET_SPENDING     = "*set_spending_limit"
+	REMOVE_SPENDING  = "*remove_spending_limit"
 )
 
 func (a *Action) Clone() *Action {
@@ -125,6 +129,14 @@ func getActionFunc(typ string) (actionTypeFunc, bool) {
 		return callUrlAsync, true
 	case MAIL_ASYNC:
 		return mailAsync, true
+	case TRANSFER_BALANCE:
+		return transferBalanceAction, true
+	case ROLLOVER:
+		return rolloverAction, true
+	case SET_SPENDING:
+		return setSpendingLimitAction, true
+	case REMOVE_SPENDING:
+		return removeSpendingLimitAction, true
 	}
 	return nil, false
 }
@@ -169,7 +181,7 @@ func parseTemplateValue(rsrFlds utils.RSRFields, acnt *Account, action *Action)
 		case "balance_id":
 			parsedValue += rsrFld.ParseValue(action.Balance.Id)
 		case "balance_value":
-			parsedValue += rsrFld.ParseValue(strconv.FormatFloat(action.Balance.Value, 'f', -1, 64))
+			parsedValue += rsrFld.ParseValue(action.Balance.Value.String())
 		case "destination_id":
 			parsedValue += rsrFld.ParseValue(action.Balance.DestinationIds)
 		case "extra_params":
@@ -289,11 +301,16 @@ func unsetRecurrentAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs A
 	return
 }
 
+// Sets the credit limit of the direction to the action units, without units the debt is unlimited
 func allowNegativeAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs Actions) (err error) {
 	if ub == nil {
 		return errors.New("nil user balance")
 	}
-	ub.AllowNegative = true
+	cl := &CreditLimit{Unlimited: true}
+	if a != nil && a.Balance != nil && a.Balance.Value.Sign() > 0 {
+		cl = &CreditLimit{Amount: a.Balance.Value}
+	}
+	ub.SetCreditLimit(actionDirection(a), cl)
 	return
 }
 
@@ -301,10 +318,17 @@ func denyNegativeAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs Act
 	if ub == nil {
 		return errors.New("nil user balance")
 	}
-	ub.AllowNegative = false
+	ub.SetCreditLimit(actionDirection(a), nil)
 	return
 }
 
+func actionDirection(a *Action) string {
+	if a == nil || a.Direction == "" {
+		return OUTBOUND
+	}
+	return a.Direction
+}
+
 func resetAccountAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs Actions) (err error) {
 	if ub == nil {
 		return errors.New("nil user balance")
@@ -361,6 +385,14 @@ func resetCounterAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs Act
 		ub.UnitCounters = append(ub.UnitCounters, uc)
 	}
 	uc.initBalances(ub.ActionTriggers)
+	if a.ExtraParameters != "" { // the reset window is set together with the reset
+		if err = uc.setResetWindow(a.ExtraParameters); err != nil {
+			return
+		}
+		if a.BalanceType != utils.MONETARY { // the usage counted for the rate tiers follows the same window
+			ub.setUsageWindow(actionDirection(a), a.BalanceType, uc.ResetWindow)
+		}
+	}
 	return
 }
 
@@ -368,14 +400,23 @@ func resetCountersAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs Ac
 	if ub == nil {
 		return errors.New("nil user balance")
 	}
+	oldCounters := ub.UnitCounters
 	ub.UnitCounters = make([]*UnitsCounter, 0)
 	ub.initCounters()
+	for _, uc := range ub.UnitCounters { // keep the reset windows, starting them over
+		for _, oldUc := range oldCounters {
+			if oldUc.BalanceType == uc.BalanceType && oldUc.Direction == uc.Direction && oldUc.ResetWindow != "" {
+				uc.ResetWindow = oldUc.ResetWindow
+				uc.checkWindow(time.Now())
+			}
+		}
+	}
 	return
 }
 
 func genericMakeNegative(a *Action) {
-	if a.Balance != nil && a.Balance.Value >= 0 { // only apply if not allready negative
-		a.Balance.Value = -a.Balance.Value
+	if a.Balance != nil && a.Balance.Value.Sign() >= 0 { // only apply if not allready negative
+		a.Balance.Value = a.Balance.Value.Neg()
 	}
 }
 
@@ -408,7 +449,7 @@ func disableUserAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs Acti
 
 func genericReset(ub *Account) error {
 	for k, _ := range ub.BalanceMap {
-		ub.BalanceMap[k] = BalanceChain{&Balance{Value: 0}}
+		ub.BalanceMap[k] = BalanceChain{&Balance{}}
 	}
 	ub.UnitCounters = make([]*UnitsCounter, 0)
 	ub.ResetActionTriggers(nil)
@@ -41,6 +41,9 @@ type ActionPlan struct {
 	ActionsId  string
 	actions    Actions
 	stCache    time.Time // cached time of the next start
+	// what the balance changes are recorded with in the ledger, the actions when empty
+	ledgerCause   string
+	ledgerCauseId string
 }
 
 type ActionPlans []*ActionPlan
@@ -57,6 +60,10 @@ func (at *ActionPlan) GetNextStartTime(now time.Time) (t time.Time) {
 	if i.Timing.StartTime == "" {
 		i.Timing.StartTime = "00:00:00"
 	}
+	if len(i.Timing.Holidays) > 0 { // the holidays are searched day by day
+		at.stCache = i.Timing.getNextStartTime(now)
+		return at.stCache
+	}
 	if len(i.Timing.Years) > 0 && len(i.Timing.Months) == 0 {
 		i.Timing.Months = append(i.Timing.Months, 1)
 	}
@@ -234,6 +241,29 @@ func (at *ActionPlan) getActions() (as []*Action, err error) {
 	return at.actions, err
 }
 
+// The actions profile recorded in the ledger, the action type for the ones built on the fly by the APIs
+// Records the balance changes with another cause than the actions, eg: the API call executing them
+func (at *ActionPlan) SetLedgerCause(cause, causeId string) {
+	at.ledgerCause, at.ledgerCauseId = cause, causeId
+}
+
+func (at *ActionPlan) getLedgerCause() string {
+	if at.ledgerCause != "" {
+		return at.ledgerCause
+	}
+	return LEDGER_ACTION
+}
+
+func (at *ActionPlan) getLedgerCauseId(a *Action) string {
+	if at.ledgerCause != "" {
+		return at.ledgerCauseId
+	}
+	if at.ActionsId != "" {
+		return at.ActionsId
+	}
+	return a.ActionType
+}
+
 func (at *ActionPlan) Execute() (err error) {
 	if len(at.AccountIds) == 0 { // nothing to do if no accounts set
 		return
@@ -265,6 +295,7 @@ func (at *ActionPlan) Execute() (err error) {
 					return 0, fmt.Errorf("Account %s is disabled", ubId)
 				}
 				//Logger.Info(fmt.Sprintf("Executing %v on %+v", a.ActionType, ub))
+				ub.SetLedgerCause(at.getLedgerCause(), at.getLedgerCauseId(a))
 				err = actionFunction(ub, nil, a, aac)
 				//Logger.Info(fmt.Sprintf("After execute, account: %+v", ub))
 				accountingStorage.SetAccount(ub)
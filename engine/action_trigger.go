@@ -31,7 +31,7 @@ import (
 
 type ActionTrigger struct {
 	Id            string // for visual identification
-	ThresholdType string //*min_counter, *max_counter, *min_balance, *max_balance
+	ThresholdType string //*min_counter, *max_counter, *min_balance, *max_balance, *min_credit, *balance_expiring
 	// stats: *min_asr, *max_asr, *min_acd, *max_acd, *min_tcd, *max_tcd, *min_acc, *max_acc, *min_tcc, *max_tcc
 	ThresholdValue        float64
 	Recurrent             bool          // reset eexcuted flag each run
@@ -50,17 +50,24 @@ type ActionTrigger struct {
 	ActionsId             string
 	MinQueuedItems        int // Trigger actions only if this number is hit (stats only)
 	Executed              bool
+	NotifiedBalances      []string // uuids of the balances already notified by *balance_expiring
 	lastExecutionTime     time.Time
 }
 
 func (at *ActionTrigger) Execute(ub *Account, sq *StatsQueueTriggered) (err error) {
+	_, err = at.execute(ub, sq)
+	return
+}
+
+// Returns true if at least one of the actions was executed
+func (at *ActionTrigger) execute(ub *Account, sq *StatsQueueTriggered) (atLeastOneActionExecuted bool, err error) {
 	// check for min sleep time
 	if at.Recurrent && !at.lastExecutionTime.IsZero() && time.Since(at.lastExecutionTime) < at.MinSleep {
 		return
 	}
 	at.lastExecutionTime = time.Now()
 	if ub != nil && ub.Disabled {
-		return fmt.Errorf("User %s is disabled and there are triggers in action!", ub.Id)
+		return false, fmt.Errorf("User %s is disabled and there are triggers in action!", ub.Id)
 	}
 	// does NOT need to Lock() because it is triggered from a method that took the Lock
 	var aac Actions
@@ -71,7 +78,12 @@ func (at *ActionTrigger) Execute(ub *Account, sq *StatsQueueTriggered) (err erro
 		return
 	}
 	at.Executed = true
-	atLeastOneActionExecuted := false
+	if ub != nil {
+		// the changes made so far belong to what fired the trigger
+		ub.collectLedger()
+		prevCause, prevCauseId := ub.SetLedgerCause(LEDGER_ACTION, at.ActionsId)
+		defer ub.SetLedgerCause(prevCause, prevCauseId)
+	}
 	for _, a := range aac {
 		if a.Balance == nil {
 			a.Balance = &Balance{}
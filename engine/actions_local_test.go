@@ -117,7 +117,7 @@ func TestActionsLocalSetCdrlogActions(t *testing.T) {
 		rcvedCdrs[0].Subject != "dan2904" ||
 		rcvedCdrs[0].Usage != "1" ||
 		rcvedCdrs[0].MediationRunId != utils.META_DEFAULT ||
-		rcvedCdrs[0].Cost != attrsAA.Actions[0].Units {
+		rcvedCdrs[0].Cost.Float64() != attrsAA.Actions[0].Units {
 		t.Errorf("Received: %+v", rcvedCdrs[0])
 	}
 
@@ -399,7 +399,7 @@ func TestActionPlanLogFunction(t *testing.T) {
 	a := &Action{
 		ActionType:  "*log",
 		BalanceType: "test",
-		Balance:     &Balance{Value: 1.1},
+		Balance:     &Balance{Value: utils.NewDecimalFromFloat(1.1)},
 	}
 	at := &ActionPlan{
 		actions: []*Action{a},
@@ -414,7 +414,7 @@ func TestActionPlanFunctionNotAvailable(t *testing.T) {
 	a := &Action{
 		ActionType:  "VALID_FUNCTION_TYPE",
 		BalanceType: "test",
-		Balance:     &Balance{Value: 1.1},
+		Balance:     &Balance{Value: utils.NewDecimalFromFloat(1.1)},
 	}
 	at := &ActionPlan{
 		AccountIds: []string{"one", "two", "three"},
@@ -648,8 +648,8 @@ func TestActionTriggerPriotityList(t *testing.T) {
 func TestActionResetTriggres(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: 10}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
 	resetTriggersAction(ub, nil, nil, nil)
@@ -661,12 +661,12 @@ func TestActionResetTriggres(t *testing.T) {
 func TestActionResetTriggresExecutesThem(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: 10}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10)}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
 	resetTriggersAction(ub, nil, nil, nil)
-	if ub.ActionTriggers[0].Executed == true || ub.BalanceMap[utils.MONETARY][0].Value == 12 {
+	if ub.ActionTriggers[0].Executed == true || ub.BalanceMap[utils.MONETARY][0].Value.Float64() == 12 {
 		t.Error("Reset triggers action failed!")
 	}
 }
@@ -674,8 +674,8 @@ func TestActionResetTriggresExecutesThem(t *testing.T) {
 func TestActionResetTriggresActionFilter(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: 10}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
 	resetTriggersAction(ub, nil, &Action{BalanceType: utils.SMS}, nil)
@@ -687,12 +687,12 @@ func TestActionResetTriggresActionFilter(t *testing.T) {
 func TestActionSetPostpaid(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
 	allowNegativeAction(ub, nil, nil, nil)
-	if !ub.AllowNegative {
+	if ub.GetCreditLimit(OUTBOUND) == nil {
 		t.Error("Set postpaid action failed!")
 	}
 }
@@ -700,13 +700,13 @@ func TestActionSetPostpaid(t *testing.T) {
 func TestActionSetPrepaid(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		AllowNegative:  true,
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+		CreditLimits:   map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
 	denyNegativeAction(ub, nil, nil, nil)
-	if ub.AllowNegative {
+	if ub.GetCreditLimit(OUTBOUND) != nil {
 		t.Error("Set prepaid action failed!")
 	}
 }
@@ -714,16 +714,16 @@ func TestActionSetPrepaid(t *testing.T) {
 func TestActionResetPrepaid(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		AllowNegative:  true,
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+		CreditLimits:   map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.SMS, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.SMS, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
 	resetAccountAction(ub, nil, nil, nil)
-	if !ub.AllowNegative ||
-		ub.BalanceMap[utils.MONETARY].GetTotalValue() != 0 ||
+	if ub.GetCreditLimit(OUTBOUND) == nil ||
+		ub.BalanceMap[utils.MONETARY].GetTotalValue().Float64() != 0 ||
 		len(ub.UnitCounters) != 0 ||
-		ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 0 ||
+		ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 0 ||
 		ub.ActionTriggers[0].Executed == true || ub.ActionTriggers[1].Executed == true {
 		t.Log(ub.BalanceMap)
 		t.Error("Reset prepaid action failed!")
@@ -733,14 +733,14 @@ func TestActionResetPrepaid(t *testing.T) {
 func TestActionResetPostpaid(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.SMS, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.SMS, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
 	resetAccountAction(ub, nil, nil, nil)
-	if ub.BalanceMap[utils.MONETARY].GetTotalValue() != 0 ||
+	if ub.BalanceMap[utils.MONETARY].GetTotalValue().Float64() != 0 ||
 		len(ub.UnitCounters) != 0 ||
-		ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 0 ||
+		ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 0 ||
 		ub.ActionTriggers[0].Executed == true || ub.ActionTriggers[1].Executed == true {
 		t.Error("Reset postpaid action failed!")
 	}
@@ -749,14 +749,14 @@ func TestActionResetPostpaid(t *testing.T) {
 func TestActionTopupResetCredit(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
-	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}}
+	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}}
 	topupResetAction(ub, nil, a, nil)
-	if ub.AllowNegative ||
-		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue() != 10 ||
+	if ub.GetCreditLimit(OUTBOUND) != nil ||
+		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().Float64() != 10 ||
 		len(ub.UnitCounters) != 1 ||
 		len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 2 ||
 		ub.ActionTriggers[0].Executed != true || ub.ActionTriggers[1].Executed != true {
@@ -769,15 +769,15 @@ func TestActionTopupResetCreditId(t *testing.T) {
 		Id: "TEST_UB",
 		BalanceMap: map[string]BalanceChain{
 			utils.MONETARY + OUTBOUND: BalanceChain{
-				&Balance{Value: 100},
-				&Balance{Id: "TEST_B", Value: 15},
+				&Balance{Value: utils.NewDecimalFromFloat(100)},
+				&Balance{Id: "TEST_B", Value: utils.NewDecimalFromFloat(15)},
 			},
 		},
 	}
-	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Id: "TEST_B", Value: 10}}
+	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Id: "TEST_B", Value: utils.NewDecimalFromFloat(10)}}
 	topupResetAction(ub, nil, a, nil)
-	if ub.AllowNegative ||
-		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue() != 110 ||
+	if ub.GetCreditLimit(OUTBOUND) != nil ||
+		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().Float64() != 110 ||
 		len(ub.BalanceMap[utils.MONETARY+OUTBOUND]) != 2 {
 		t.Errorf("Topup reset action failed: %+v", ub.BalanceMap[utils.MONETARY+OUTBOUND][0])
 	}
@@ -788,15 +788,15 @@ func TestActionTopupResetCreditNoId(t *testing.T) {
 		Id: "TEST_UB",
 		BalanceMap: map[string]BalanceChain{
 			utils.MONETARY + OUTBOUND: BalanceChain{
-				&Balance{Value: 100},
-				&Balance{Id: "TEST_B", Value: 15},
+				&Balance{Value: utils.NewDecimalFromFloat(100)},
+				&Balance{Id: "TEST_B", Value: utils.NewDecimalFromFloat(15)},
 			},
 		},
 	}
-	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}}
+	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}}
 	topupResetAction(ub, nil, a, nil)
-	if ub.AllowNegative ||
-		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue() != 20 ||
+	if ub.GetCreditLimit(OUTBOUND) != nil ||
+		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().Float64() != 20 ||
 		len(ub.BalanceMap[utils.MONETARY+OUTBOUND]) != 2 {
 		t.Errorf("Topup reset action failed: %+v", ub.BalanceMap[utils.MONETARY+OUTBOUND][1])
 	}
@@ -806,16 +806,16 @@ func TestActionTopupResetMinutes(t *testing.T) {
 	ub := &Account{
 		Id: "TEST_UB",
 		BalanceMap: map[string]BalanceChain{
-			utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 100}},
-			utils.VOICE + OUTBOUND:    BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: 1}}}},
+			utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}},
+			utils.VOICE + OUTBOUND:    BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
-	a := &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: &Balance{Value: 5, Weight: 20, DestinationIds: "NAT"}}
+	a := &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(5), Weight: 20, DestinationIds: "NAT"}}
 	topupResetAction(ub, nil, a, nil)
-	if ub.AllowNegative ||
-		ub.BalanceMap[utils.VOICE+OUTBOUND].GetTotalValue() != 5 ||
-		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue() != 100 ||
+	if ub.GetCreditLimit(OUTBOUND) != nil ||
+		ub.BalanceMap[utils.VOICE+OUTBOUND].GetTotalValue().Float64() != 5 ||
+		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().Float64() != 100 ||
 		len(ub.UnitCounters) != 1 ||
 		len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 2 ||
 		ub.ActionTriggers[0].Executed != true || ub.ActionTriggers[1].Executed != true {
@@ -826,14 +826,14 @@ func TestActionTopupResetMinutes(t *testing.T) {
 func TestActionTopupCredit(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
-	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}}
+	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}}
 	topupAction(ub, nil, a, nil)
-	if ub.AllowNegative ||
-		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue() != 110 ||
+	if ub.GetCreditLimit(OUTBOUND) != nil ||
+		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().Float64() != 110 ||
 		len(ub.UnitCounters) != 1 ||
 		len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 2 ||
 		ub.ActionTriggers[0].Executed != true || ub.ActionTriggers[1].Executed != true {
@@ -844,15 +844,15 @@ func TestActionTopupCredit(t *testing.T) {
 func TestActionTopupMinutes(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
-	a := &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: &Balance{Value: 5, Weight: 20, DestinationIds: "NAT"}}
+	a := &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(5), Weight: 20, DestinationIds: "NAT"}}
 	topupAction(ub, nil, a, nil)
-	if ub.AllowNegative ||
-		ub.BalanceMap[utils.VOICE+OUTBOUND].GetTotalValue() != 15 ||
-		ub.BalanceMap[utils.MONETARY].GetTotalValue() != 100 ||
+	if ub.GetCreditLimit(OUTBOUND) != nil ||
+		ub.BalanceMap[utils.VOICE+OUTBOUND].GetTotalValue().Float64() != 15 ||
+		ub.BalanceMap[utils.MONETARY].GetTotalValue().Float64() != 100 ||
 		len(ub.UnitCounters) != 1 ||
 		len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 2 ||
 		ub.ActionTriggers[0].Executed != true || ub.ActionTriggers[1].Executed != true {
@@ -863,14 +863,14 @@ func TestActionTopupMinutes(t *testing.T) {
 func TestActionDebitCredit(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
-	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}}
+	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}}
 	debitAction(ub, nil, a, nil)
-	if ub.AllowNegative ||
-		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue() != 90 ||
+	if ub.GetCreditLimit(OUTBOUND) != nil ||
+		ub.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().Float64() != 90 ||
 		len(ub.UnitCounters) != 1 ||
 		len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 2 ||
 		ub.ActionTriggers[0].Executed != true || ub.ActionTriggers[1].Executed != true {
@@ -881,15 +881,15 @@ func TestActionDebitCredit(t *testing.T) {
 func TestActionDebitMinutes(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}, &ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
-	a := &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: &Balance{Value: 5, Weight: 20, DestinationIds: "NAT"}}
+	a := &Action{BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(5), Weight: 20, DestinationIds: "NAT"}}
 	debitAction(ub, nil, a, nil)
-	if ub.AllowNegative ||
-		ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 5 ||
-		ub.BalanceMap[utils.MONETARY].GetTotalValue() != 100 ||
+	if ub.GetCreditLimit(OUTBOUND) != nil ||
+		ub.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 5 ||
+		ub.BalanceMap[utils.MONETARY].GetTotalValue().Float64() != 100 ||
 		len(ub.UnitCounters) != 1 ||
 		len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 2 ||
 		ub.ActionTriggers[0].Executed != true || ub.ActionTriggers[1].Executed != true {
@@ -899,19 +899,19 @@ func TestActionDebitMinutes(t *testing.T) {
 
 func TestActionResetAllCounters(t *testing.T) {
 	ub := &Account{
-		Id:            "TEST_UB",
-		AllowNegative: true,
+		Id:           "TEST_UB",
+		CreditLimits: map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}},
 		BalanceMap: map[string]BalanceChain{
-			utils.MONETARY: BalanceChain{&Balance{Value: 100}},
+			utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}},
 			utils.VOICE: BalanceChain{
-				&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"},
+				&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"},
 				&Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
 	resetCountersAction(ub, nil, nil, nil)
-	if !ub.AllowNegative ||
-		ub.BalanceMap[utils.MONETARY].GetTotalValue() != 100 ||
+	if ub.GetCreditLimit(OUTBOUND) == nil ||
+		ub.BalanceMap[utils.MONETARY].GetTotalValue().Float64() != 100 ||
 		len(ub.UnitCounters) != 1 ||
 		len(ub.UnitCounters[0].Balances) != 2 ||
 		len(ub.BalanceMap[utils.VOICE]) != 2 ||
@@ -922,25 +922,25 @@ func TestActionResetAllCounters(t *testing.T) {
 		t.FailNow()
 	}
 	mb := ub.UnitCounters[0].Balances[0]
-	if mb.Weight != 20 || mb.Value != 0 || mb.DestinationIds != "NAT" {
+	if mb.Weight != 20 || mb.Value.Float64() != 0 || mb.DestinationIds != "NAT" {
 		t.Errorf("Balance cloned incorrectly: %v!", mb)
 	}
 }
 
 func TestActionResetCounterMinutes(t *testing.T) {
 	ub := &Account{
-		Id:            "TEST_UB",
-		AllowNegative: true,
+		Id:           "TEST_UB",
+		CreditLimits: map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}},
 		BalanceMap: map[string]BalanceChain{
-			utils.MONETARY: BalanceChain{&Balance{Value: 100}},
-			utils.VOICE:    BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: 1}}}},
+			utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}},
+			utils.VOICE:    BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, ThresholdType: "*max_counter", ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
 	a := &Action{BalanceType: utils.VOICE}
 	resetCounterAction(ub, nil, a, nil)
-	if !ub.AllowNegative ||
-		ub.BalanceMap[utils.MONETARY].GetTotalValue() != 100 ||
+	if ub.GetCreditLimit(OUTBOUND) == nil ||
+		ub.BalanceMap[utils.MONETARY].GetTotalValue().Float64() != 100 ||
 		len(ub.UnitCounters) != 2 ||
 		len(ub.UnitCounters[1].Balances) != 2 ||
 		len(ub.BalanceMap[utils.VOICE]) != 2 ||
@@ -954,7 +954,7 @@ func TestActionResetCounterMinutes(t *testing.T) {
 		t.FailNow()
 	}
 	mb := ub.UnitCounters[1].Balances[0]
-	if mb.Weight != 20 || mb.Value != 0 || mb.DestinationIds != "NAT" {
+	if mb.Weight != 20 || mb.Value.Float64() != 0 || mb.DestinationIds != "NAT" {
 		t.Errorf("Balance cloned incorrectly: %+v!", mb)
 	}
 }
@@ -962,15 +962,15 @@ func TestActionResetCounterMinutes(t *testing.T) {
 func TestActionResetCounterCredit(t *testing.T) {
 	ub := &Account{
 		Id:             "TEST_UB",
-		AllowNegative:  true,
-		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: 100}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
-		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: 1}}}, &UnitsCounter{BalanceType: utils.SMS, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: 1}}}},
+		CreditLimits:   map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}},
+		BalanceMap:     map[string]BalanceChain{utils.MONETARY: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(100)}}, utils.VOICE + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		UnitCounters:   []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}, &UnitsCounter{BalanceType: utils.SMS, Direction: OUTBOUND, Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}}}},
 		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ThresholdValue: 2, ActionsId: "TEST_ACTIONS", Executed: true}},
 	}
 	a := &Action{BalanceType: utils.MONETARY, Direction: OUTBOUND}
 	resetCounterAction(ub, nil, a, nil)
-	if !ub.AllowNegative ||
-		ub.BalanceMap[utils.MONETARY].GetTotalValue() != 100 ||
+	if ub.GetCreditLimit(OUTBOUND) == nil ||
+		ub.BalanceMap[utils.MONETARY].GetTotalValue().Float64() != 100 ||
 		len(ub.UnitCounters) != 2 ||
 		len(ub.BalanceMap[utils.VOICE+OUTBOUND]) != 2 ||
 		ub.ActionTriggers[0].Executed != true {
@@ -1020,8 +1020,8 @@ func TestActionPlanLogging(t *testing.T) {
 		},
 		Weight: 10.0,
 		Rating: &RIRate{
-			ConnectFee: 0.0,
-			Rates:      RateGroups{&Rate{0, 1.0, 1 * time.Second, 60 * time.Second}},
+			ConnectFee: utils.NewDecimalFromFloat(0.0),
+			Rates:      RateGroups{&Rate{0, utils.NewDecimalFromFloat(1.0), 1 * time.Second, 60 * time.Second}},
 		},
 	}
 	at := &ActionPlan{
@@ -1053,13 +1053,13 @@ func TestActionPlanLogging(t *testing.T) {
 }
 
 func TestActionMakeNegative(t *testing.T) {
-	a := &Action{Balance: &Balance{Value: 10}}
+	a := &Action{Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}}
 	genericMakeNegative(a)
-	if a.Balance.Value > 0 {
+	if a.Balance.Value.Float64() > 0 {
 		t.Error("Failed to make negative: ", a)
 	}
 	genericMakeNegative(a)
-	if a.Balance.Value > 0 {
+	if a.Balance.Value.Float64() > 0 {
 		t.Error("Failed to preserve negative: ", a)
 	}
 }
@@ -1070,7 +1070,7 @@ func TestTopupAction(t *testing.T) {
 		ActionType:  "*topup",
 		BalanceType: utils.MONETARY,
 		Direction:   OUTBOUND,
-		Balance:     &Balance{Value: 25, DestinationIds: "RET", Weight: 20},
+		Balance:     &Balance{Value: utils.NewDecimalFromFloat(25), DestinationIds: "RET", Weight: 20},
 	}
 
 	at := &ActionPlan{
@@ -1082,7 +1082,7 @@ func TestTopupAction(t *testing.T) {
 	afterUb, _ := accountingStorage.GetAccount("*out:vdf:minu")
 	initialValue := initialUb.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue()
 	afterValue := afterUb.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue()
-	if initialValue != 50 || afterValue != 75 {
+	if initialValue.Float64() != 50 || afterValue.Float64() != 75 {
 		t.Error("Bad topup before and after: ", initialValue, afterValue)
 	}
 }
@@ -1093,7 +1093,7 @@ func TestTopupActionLoaded(t *testing.T) {
 		ActionType:  "*topup",
 		BalanceType: utils.MONETARY,
 		Direction:   OUTBOUND,
-		Balance:     &Balance{Value: 25, DestinationIds: "RET", Weight: 20},
+		Balance:     &Balance{Value: utils.NewDecimalFromFloat(25), DestinationIds: "RET", Weight: 20},
 	}
 
 	at := &ActionPlan{
@@ -1105,7 +1105,7 @@ func TestTopupActionLoaded(t *testing.T) {
 	afterUb, _ := accountingStorage.GetAccount("*out:vdf:minitsboy")
 	initialValue := initialUb.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue()
 	afterValue := afterUb.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue()
-	if initialValue != 100 || afterValue != 125 {
+	if initialValue.Float64() != 100 || afterValue.Float64() != 125 {
 		t.Logf("Initial: %+v", initialUb)
 		t.Logf("After: %+v", afterUb)
 		t.Error("Bad topup before and after: ", initialValue, afterValue)
@@ -1120,7 +1120,7 @@ func TestActionCdrlogEmpty(t *testing.T) {
 	err := cdrLogAction(acnt, nil, cdrlog, Actions{
 		&Action{
 			ActionType: DEBIT,
-			Balance:    &Balance{Value: 25, DestinationIds: "RET", Weight: 20},
+			Balance:    &Balance{Value: utils.NewDecimalFromFloat(25), DestinationIds: "RET", Weight: 20},
 		},
 	})
 	if err != nil {
@@ -1142,11 +1142,11 @@ func TestActionCdrlogWithParams(t *testing.T) {
 	err := cdrLogAction(acnt, nil, cdrlog, Actions{
 		&Action{
 			ActionType: DEBIT,
-			Balance:    &Balance{Value: 25, DestinationIds: "RET", Weight: 20},
+			Balance:    &Balance{Value: utils.NewDecimalFromFloat(25), DestinationIds: "RET", Weight: 20},
 		},
 		&Action{
 			ActionType: DEBIT_RESET,
-			Balance:    &Balance{Value: 25, DestinationIds: "RET", Weight: 20},
+			Balance:    &Balance{Value: utils.NewDecimalFromFloat(25), DestinationIds: "RET", Weight: 20},
 		},
 	})
 	if err != nil {
@@ -1169,11 +1169,11 @@ func TestActionCdrLogParamsWithOverload(t *testing.T) {
 	err := cdrLogAction(acnt, nil, cdrlog, Actions{
 		&Action{
 			ActionType: DEBIT,
-			Balance:    &Balance{Value: 25, DestinationIds: "RET", Weight: 20},
+			Balance:    &Balance{Value: utils.NewDecimalFromFloat(25), DestinationIds: "RET", Weight: 20},
 		},
 		&Action{
 			ActionType: DEBIT_RESET,
-			Balance:    &Balance{Value: 25, DestinationIds: "RET", Weight: 20},
+			Balance:    &Balance{Value: utils.NewDecimalFromFloat(25), DestinationIds: "RET", Weight: 20},
 		},
 	})
 	if err != nil {
@@ -0,0 +1,115 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Executes the *balance_expiring triggers for the balances that expire in
+// less than ThresholdValue seconds from now. Each balance is notified only
+// once, the uuids already notified are kept on the trigger.
+// Returns true if at least one trigger was executed.
+func (ub *Account) executeExpiringTriggers(now time.Time) (executed bool) {
+	ub.ActionTriggers.Sort()
+	for _, at := range ub.ActionTriggers {
+		if at.ThresholdType != TRIGGER_BALANCE_EXPIRING {
+			continue
+		}
+		present := make([]string, 0, len(at.NotifiedBalances))
+		for _, b := range ub.BalanceMap[at.BalanceType+at.BalanceDirection] {
+			if b.ExpirationDate.IsZero() || !b.MatchActionTrigger(at) {
+				continue
+			}
+			if utils.IsSliceMember(at.NotifiedBalances, b.Uuid) {
+				present = append(present, b.Uuid)
+				continue
+			}
+			if !b.ExpirationDate.After(now) ||
+				b.ExpirationDate.Sub(now) > time.Duration(at.ThresholdValue)*time.Second {
+				continue
+			}
+			actionsExecuted, err := at.execute(ub, nil)
+			if err != nil {
+				Logger.Warning(fmt.Sprintf("<BalanceExpiring> Failed executing trigger %s on account %s: %v", at.Id, ub.Id, err))
+			}
+			if !actionsExecuted { // notified on a next check
+				continue
+			}
+			present = append(present, b.Uuid)
+			executed = true
+		}
+		// forget the balances that are gone
+		at.NotifiedBalances = present
+		at.Executed = false // once per balance, not once per trigger
+	}
+	return
+}
+
+// Checks the accounts having *balance_expiring triggers for balances about
+// to expire, meant to be called periodically by the scheduler. The accounts
+// are indexed when saved with changed triggers, the ones stored before the
+// index need a cgr-loader -reindex_expiring_triggers run.
+func CheckExpiringBalances(now time.Time) error {
+	accKeys, err := accountingStorage.GetKeysForPrefix(EXPIRING_TRIGGERS_PREFIX)
+	if err != nil {
+		return err
+	}
+	for _, key := range accKeys {
+		accId := strings.TrimPrefix(key, EXPIRING_TRIGGERS_PREFIX)
+		AccLock.Guard(func() (interface{}, error) {
+			ub, err := accountingStorage.GetAccount(accId)
+			if err != nil {
+				return 0, err
+			}
+			if !ub.hasExpiringTriggers() {
+				return 0, nil
+			}
+			if ub.executeExpiringTriggers(now) {
+				return 0, accountingStorage.SetAccount(ub)
+			}
+			return 0, nil
+		}, accId)
+	}
+	return nil
+}
+
+func (ub *Account) hasExpiringTriggers() bool {
+	for _, at := range ub.ActionTriggers {
+		if at.ThresholdType == TRIGGER_BALANCE_EXPIRING {
+			return true
+		}
+	}
+	return false
+}
+
+// Remembers the expiring triggers index state of the loaded or saved account
+func (ub *Account) snapshotExpiringIndex() {
+	ub.expiringIndexed = ub.hasExpiringTriggers()
+}
+
+// Tells if the save has to add the account to or remove it from the expiring triggers index.
+// Index entries left for accounts without the triggers are skipped by CheckExpiringBalances.
+func (ub *Account) expiringIndexChanged() (indexed, changed bool) {
+	indexed = ub.hasExpiringTriggers()
+	return indexed, indexed != ub.expiringIndexed
+}
@@ -0,0 +1,144 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/cache2go"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestBalanceExpiringTrigger(t *testing.T) {
+	accountingStorage.SetActions("EXP_NOTIFY", Actions{&Action{ActionType: TOPUP, BalanceType: utils.SMS, Direction: OUTBOUND,
+		Balance: &Balance{Value: utils.NewDecimalFromFloat(1)}}})
+	accountingStorage.GetActions("EXP_NOTIFY", true) // cache it
+	defer cache2go.RemKey(ACTION_PREFIX + "EXP_NOTIFY")
+	now := time.Now()
+	acc := &Account{Id: "*out:cgrates.org:expiring", BalanceMap: map[string]BalanceChain{
+		utils.VOICE + OUTBOUND: BalanceChain{
+			&Balance{Uuid: "soon", Value: utils.NewDecimalFromFloat(10), ExpirationDate: now.Add(time.Hour)},
+			&Balance{Uuid: "later", Value: utils.NewDecimalFromFloat(10), ExpirationDate: now.Add(72 * time.Hour)},
+			&Balance{Uuid: "never", Value: utils.NewDecimalFromFloat(10)},
+		}}, ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{Id: "exp", ThresholdType: TRIGGER_BALANCE_EXPIRING,
+		ThresholdValue: 86400, BalanceType: utils.VOICE, BalanceDirection: OUTBOUND, ActionsId: "EXP_NOTIFY"}}}
+	acc.executeActionTriggers(nil)
+	if len(acc.BalanceMap[utils.SMS+OUTBOUND]) != 0 {
+		t.Error("Expiring trigger executed on balance change")
+	}
+	if !acc.executeExpiringTriggers(now) {
+		t.Error("Expiring trigger not executed")
+	}
+	if sms := acc.BalanceMap[utils.SMS+OUTBOUND]; len(sms) != 1 || sms[0].Value.String() != "1" {
+		t.Errorf("Wrong notifications: %+v", sms)
+	}
+	if nb := acc.ActionTriggers[0].NotifiedBalances; len(nb) != 1 || nb[0] != "soon" {
+		t.Errorf("Wrong notified balances: %v", nb)
+	}
+	// once per balance
+	if acc.executeExpiringTriggers(now.Add(time.Minute)) {
+		t.Error("Expiring trigger executed twice for the same balance")
+	}
+	if !acc.executeExpiringTriggers(now.Add(50 * time.Hour)) {
+		t.Error("Expiring trigger not executed for the second balance")
+	}
+	if sms := acc.BalanceMap[utils.SMS+OUTBOUND]; sms[0].Value.String() != "2" {
+		t.Errorf("Wrong notifications: %+v", sms)
+	}
+	// forget the removed balances
+	acc.BalanceMap[utils.VOICE+OUTBOUND] = acc.BalanceMap[utils.VOICE+OUTBOUND][1:]
+	acc.executeExpiringTriggers(now.Add(50 * time.Hour))
+	if nb := acc.ActionTriggers[0].NotifiedBalances; len(nb) != 1 || nb[0] != "later" {
+		t.Errorf("Wrong notified balances: %v", nb)
+	}
+}
+
+func TestBalanceExpiringCheck(t *testing.T) {
+	accountingStorage.SetActions("EXP_NOTIFY", Actions{&Action{ActionType: LOG}})
+	accountingStorage.GetActions("EXP_NOTIFY", true) // cache it
+	defer cache2go.RemKey(ACTION_PREFIX + "EXP_NOTIFY")
+	now := time.Now()
+	accountingStorage.SetAccount(&Account{Id: "*out:cgrates.org:expiring", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "soon", Value: utils.NewDecimalFromFloat(10), ExpirationDate: now.Add(time.Hour)}},
+	}, ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{Id: "exp", ThresholdType: TRIGGER_BALANCE_EXPIRING,
+		ThresholdValue: 7200, BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ActionsId: "EXP_NOTIFY"}}})
+	if err := CheckExpiringBalances(now); err != nil {
+		t.Fatal("Error checking expiring balances: ", err)
+	}
+	acc, err := accountingStorage.GetAccount("*out:cgrates.org:expiring")
+	if err != nil {
+		t.Fatal("Error getting account: ", err)
+	}
+	if nb := acc.ActionTriggers[0].NotifiedBalances; len(nb) != 1 || nb[0] != "soon" {
+		t.Errorf("Notified balances not saved: %v", nb)
+	}
+}
+
+func TestBalanceExpiringNotExecuted(t *testing.T) {
+	accountingStorage.SetActions("EXP_UNKNOWN", Actions{&Action{ActionType: "*not_existing"}})
+	accountingStorage.GetActions("EXP_UNKNOWN", true) // cache it
+	defer cache2go.RemKey(ACTION_PREFIX + "EXP_UNKNOWN")
+	now := time.Now()
+	acc := &Account{Id: "*out:cgrates.org:expiring_unknown", BalanceMap: map[string]BalanceChain{
+		utils.VOICE + OUTBOUND: BalanceChain{&Balance{Uuid: "soon", Value: utils.NewDecimalFromFloat(10), ExpirationDate: now.Add(time.Hour)}},
+	}, ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{Id: "exp", ThresholdType: TRIGGER_BALANCE_EXPIRING,
+		ThresholdValue: 7200, BalanceType: utils.VOICE, BalanceDirection: OUTBOUND, ActionsId: "EXP_UNKNOWN"}}}
+	if acc.executeExpiringTriggers(now) || len(acc.ActionTriggers[0].NotifiedBalances) != 0 {
+		t.Errorf("Balance notified without executed actions: %v", acc.ActionTriggers[0].NotifiedBalances)
+	}
+	acc.ActionTriggers[0].ActionsId = "EXP_MISSING"
+	if acc.executeExpiringTriggers(now) || len(acc.ActionTriggers[0].NotifiedBalances) != 0 {
+		t.Errorf("Balance notified without actions: %v", acc.ActionTriggers[0].NotifiedBalances)
+	}
+}
+
+func TestBalanceExpiringIndex(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:expiring_index", ActionTriggers: ActionTriggerPriotityList{
+		&ActionTrigger{Id: "exp", ThresholdType: TRIGGER_BALANCE_EXPIRING, ThresholdValue: 7200}}}
+	accountingStorage.SetAccount(acc)
+	if keys, _ := accountingStorage.GetKeysForPrefix(EXPIRING_TRIGGERS_PREFIX + acc.Id); len(keys) != 1 {
+		t.Error("Account not indexed: ", keys)
+	}
+	acc.ActionTriggers = nil
+	accountingStorage.SetAccount(acc)
+	if keys, _ := accountingStorage.GetKeysForPrefix(EXPIRING_TRIGGERS_PREFIX + acc.Id); len(keys) != 0 {
+		t.Error("Account still indexed: ", keys)
+	}
+}
+
+func TestBalanceExpiringReindex(t *testing.T) {
+	accountingStorage.SetAccount(&Account{Id: "*out:cgrates.org:expiring_reindex", ActionTriggers: ActionTriggerPriotityList{
+		&ActionTrigger{Id: "exp", ThresholdType: TRIGGER_BALANCE_EXPIRING, ThresholdValue: 7200}}})
+	// account saved before the index existed
+	delete(accountingStorage.(*MapStorage).dict, EXPIRING_TRIGGERS_PREFIX+"*out:cgrates.org:expiring_reindex")
+	acc, err := accountingStorage.GetAccount("*out:cgrates.org:expiring_reindex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountingStorage.SetAccount(acc)
+	if keys, _ := accountingStorage.GetKeysForPrefix(EXPIRING_TRIGGERS_PREFIX + acc.Id); len(keys) != 0 {
+		t.Error("Index written without triggers change: ", keys)
+	}
+	if indexed, err := accountingStorage.ReindexExpiringTriggers(); err != nil || indexed == 0 {
+		t.Error("Error reindexing: ", indexed, err)
+	}
+	if keys, _ := accountingStorage.GetKeysForPrefix(EXPIRING_TRIGGERS_PREFIX + acc.Id); len(keys) != 1 {
+		t.Error("Account not reindexed: ", keys)
+	}
+}
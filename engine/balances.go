@@ -32,17 +32,21 @@ import (
 type Balance struct {
 	Uuid           string //system wide unique
 	Id             string // account wide unique
-	Value          float64
+	Value          utils.Decimal
 	ExpirationDate time.Time
 	Weight         float64
 	DestinationIds string
 	RatingSubject  string
 	Category       string
 	SharedGroup    string
+	Currency       string // monetary balances only, empty means the rating currency
 	Timings        []*RITiming
 	TimingIDs      string
+	Rollover       *Rollover // unused units carried into a new balance on expiry or reset
+	RolledOver     bool      // holds the units carried from another balance, the balance actions do not match it
 	precision      int
-	account        *Account // used to store ub reference for shared balances
+	account        *Account           // used to store ub reference for shared balances
+	proportional   *proportionalShare // set when debiting a *proportional shared group
 	dirty          bool
 }
 
@@ -61,7 +65,8 @@ func (b *Balance) Equal(o *Balance) bool {
 		bDestIds == oDestIds &&
 		b.RatingSubject == o.RatingSubject &&
 		b.Category == o.Category &&
-		b.SharedGroup == o.SharedGroup
+		b.SharedGroup == o.SharedGroup &&
+		b.Currency == o.Currency
 }
 
 func (b *Balance) MatchFilter(o *Balance) bool {
@@ -81,7 +86,8 @@ func (b *Balance) MatchFilter(o *Balance) bool {
 		(oDestIds == "" || bDestIds == oDestIds) &&
 		(o.RatingSubject == "" || b.RatingSubject == o.RatingSubject) &&
 		(o.Category == "" || b.Category == o.Category) &&
-		(o.SharedGroup == "" || b.SharedGroup == o.SharedGroup)
+		(o.SharedGroup == "" || b.SharedGroup == o.SharedGroup) &&
+		(o.Currency == "" || b.Currency == o.Currency)
 }
 
 // the default balance has no destinationid, Expirationdate or ratesubject
@@ -178,16 +184,19 @@ func (b *Balance) Clone() *Balance {
 		RatingSubject:  b.RatingSubject,
 		Category:       b.Category,
 		SharedGroup:    b.SharedGroup,
+		Currency:       b.Currency,
 		TimingIDs:      b.TimingIDs,
 		Timings:        b.Timings, // should not be a problem with aliasing
+		Rollover:       b.Rollover,
+		RolledOver:     b.RolledOver,
 		dirty:          b.dirty,
 	}
 }
 
 // Returns the available number of seconds for a specified credit
-func (b *Balance) GetMinutesForCredit(origCD *CallDescriptor, initialCredit float64) (duration time.Duration, credit float64) {
+func (b *Balance) GetMinutesForCredit(origCD *CallDescriptor, initialCredit utils.Decimal) (duration time.Duration, credit utils.Decimal) {
 	cd := origCD.Clone()
-	availableDuration := time.Duration(b.Value) * time.Second
+	availableDuration := time.Duration(b.Value.Float64()) * time.Second
 	duration = availableDuration
 	credit = initialCredit
 	cc, err := b.GetCost(cd, false)
@@ -197,27 +206,27 @@ func (b *Balance) GetMinutesForCredit(origCD *CallDescriptor, initialCredit floa
 	}
 	if cc.deductConnectFee {
 		connectFee := cc.GetConnectFee()
-		if connectFee <= credit {
-			credit -= connectFee
+		if connectFee.Cmp(credit) <= 0 {
+			credit = credit.Sub(connectFee)
 			// remove connect fee from the total cost
-			cc.Cost -= connectFee
+			cc.Cost = cc.Cost.Sub(connectFee)
 		} else {
 			return 0, credit
 		}
 	}
-	if cc.Cost > 0 {
+	if cc.Cost.Sign() > 0 {
 		duration = 0
 		for _, ts := range cc.Timespans {
 			ts.createIncrementsSlice()
 			if cd.MaxRate > 0 && cd.MaxRateUnit > 0 {
 				rate, _, rateUnit := ts.RateInterval.GetRateParameters(ts.GetGroupStart())
-				if rate/rateUnit.Seconds() > cd.MaxRate/cd.MaxRateUnit.Seconds() {
+				if rate.Float64()/rateUnit.Seconds() > cd.MaxRate/cd.MaxRateUnit.Seconds() {
 					return
 				}
 			}
 			for _, incr := range ts.Increments {
-				if incr.Cost <= credit && availableDuration-incr.Duration >= 0 {
-					credit -= incr.Cost
+				if incr.Cost.Cmp(credit) <= 0 && availableDuration-incr.Duration >= 0 {
+					credit = credit.Sub(incr.Cost)
 					duration += incr.Duration
 					availableDuration -= incr.Duration
 				} else {
@@ -253,19 +262,18 @@ func (b *Balance) GetCost(cd *CallDescriptor, getStandardIfEmpty bool) (*CallCos
 		return cd.getCost()
 	} else {
 		cc := cd.CreateCallCost()
-		cc.Cost = 0
+		cc.Cost = utils.Decimal{}
 		return cc, nil
 	}
 }
 
-func (b *Balance) SubstractAmount(amount float64) {
-	b.Value -= amount
-	b.Value = utils.Round(b.Value, globalRoundingDecimals, utils.ROUNDING_MIDDLE)
+func (b *Balance) SubstractAmount(amount utils.Decimal) {
+	b.Value = b.Value.Sub(amount).Round(globalRoundingDecimals, utils.ROUNDING_MIDDLE)
 	b.dirty = true
 }
 
 func (b *Balance) DebitUnits(cd *CallDescriptor, ub *Account, moneyBalances BalanceChain, count bool, dryRun bool) (cc *CallCost, err error) {
-	if !b.IsActiveAt(cd.TimeStart) || b.Value <= 0 {
+	if !b.IsActiveAt(cd.TimeStart) || b.Value.Sign() <= 0 {
 		return
 	}
 	if duration, err := utils.ParseZeroRatingSubject(b.RatingSubject); err == nil {
@@ -277,7 +285,7 @@ func (b *Balance) DebitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 		})
 
 		seconds := duration.Seconds()
-		amount := seconds
+		amount := utils.NewDecimalFromFloat(seconds)
 		ts := cc.Timespans[0]
 		ts.RoundToDuration(duration)
 		ts.RateInterval = &RateInterval{
@@ -285,7 +293,7 @@ func (b *Balance) DebitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 				Rates: RateGroups{
 					&Rate{
 						GroupIntervalStart: 0,
-						Value:              0,
+						Value:              utils.Decimal{},
 						RateIncrement:      duration,
 						RateUnit:           duration,
 					},
@@ -297,14 +305,14 @@ func (b *Balance) DebitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 		for incIndex, inc := range ts.Increments {
 			//log.Printf("INCREMENET: %+v", inc)
 			if seconds == 1 {
-				amount = inc.Duration.Seconds()
+				amount = utils.NewDecimalFromFloat(inc.Duration.Seconds())
 			}
-			if b.Value >= amount {
+			if b.Value.Cmp(amount) >= 0 {
 				b.SubstractAmount(amount)
 				inc.BalanceInfo.UnitBalanceUuid = b.Uuid
 				inc.BalanceInfo.AccountId = ub.Id
-				inc.UnitInfo = &UnitInfo{cc.Destination, amount, cc.TOR}
-				inc.Cost = 0
+				inc.UnitInfo = &UnitInfo{cc.Destination, amount.Float64(), cc.TOR}
+				inc.Cost = utils.Decimal{}
 				inc.paid = true
 				if count {
 					ub.countUnits(&Action{BalanceType: cc.TOR, Direction: cc.Direction, Balance: &Balance{Value: amount, DestinationIds: cc.Destination}})
@@ -345,11 +353,11 @@ func (b *Balance) DebitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 			maxCost, strategy := ts.RateInterval.GetMaxCost()
 			for incIndex, inc := range ts.Increments {
 				// debit minutes and money
-				seconds := inc.Duration.Seconds()
+				seconds := utils.NewDecimalFromFloat(inc.Duration.Seconds())
 				cost := inc.Cost
 				//log.Printf("INC: %+v", inc)
 				inc.paid = false
-				if strategy == utils.MAX_COST_DISCONNECT && cd.MaxCostSoFar >= maxCost {
+				if strategy == utils.MAX_COST_DISCONNECT && cd.MaxCostSoFar.Cmp(maxCost) >= 0 {
 					// cat the entire current timespan
 					cc.maxCostDisconect = true
 					if dryRun {
@@ -363,8 +371,8 @@ func (b *Balance) DebitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 						return cc, nil
 					}
 				}
-				if strategy == utils.MAX_COST_FREE && cd.MaxCostSoFar >= maxCost {
-					cost, inc.Cost = 0.0, 0.0
+				if strategy == utils.MAX_COST_FREE && cd.MaxCostSoFar.Cmp(maxCost) >= 0 {
+					cost, inc.Cost = utils.Decimal{}, utils.Decimal{}
 					inc.BalanceInfo.MoneyBalanceUuid = b.Uuid
 					inc.BalanceInfo.AccountId = ub.Id
 					inc.paid = true
@@ -375,27 +383,33 @@ func (b *Balance) DebitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 					continue
 				}
 				var moneyBal *Balance
+				var exr *ExchangeRate
 				for _, mb := range moneyBalances {
-					if mb.Value >= cost {
-						moneyBal = mb
+					mbExr, err := getExchangeRate(ts.RateInterval.GetCurrency(), mb.Currency)
+					if err != nil {
+						return nil, err
+					}
+					if mb.Value.Cmp(mbExr.Convert(cost)) >= 0 {
+						moneyBal, exr = mb, mbExr
 						break
 					}
 				}
-				if (cost == 0 || moneyBal != nil) && b.Value >= seconds {
+				if (cost.IsZero() || moneyBal != nil) && b.Value.Cmp(seconds) >= 0 {
 					b.SubstractAmount(seconds)
 					inc.BalanceInfo.UnitBalanceUuid = b.Uuid
 					inc.BalanceInfo.AccountId = ub.Id
-					inc.UnitInfo = &UnitInfo{cc.Destination, seconds, cc.TOR}
-					if cost != 0 {
+					inc.UnitInfo = &UnitInfo{cc.Destination, seconds.Float64(), cc.TOR}
+					if !cost.IsZero() {
 						inc.BalanceInfo.MoneyBalanceUuid = moneyBal.Uuid
-						moneyBal.SubstractAmount(cost)
-						cd.MaxCostSoFar += cost
+						inc.BalanceInfo.ExchangeRate = exr
+						moneyBal.SubstractAmount(exr.Convert(cost))
+						cd.MaxCostSoFar = cd.MaxCostSoFar.Add(cost)
 					}
 					inc.paid = true
 					if count {
 						ub.countUnits(&Action{BalanceType: cc.TOR, Direction: cc.Direction, Balance: &Balance{Value: seconds, DestinationIds: cc.Destination}})
-						if cost != 0 {
-							ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: cc.Direction, Balance: &Balance{Value: cost, DestinationIds: cc.Destination}})
+						if !cost.IsZero() {
+							ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: cc.Direction, Balance: &Balance{Value: exr.Convert(cost), DestinationIds: cc.Destination}})
 						}
 					}
 				} else {
@@ -420,7 +434,8 @@ func (b *Balance) DebitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 }
 
 func (b *Balance) DebitMoney(cd *CallDescriptor, ub *Account, count bool, dryRun bool) (cc *CallCost, err error) {
-	if !b.IsActiveAt(cd.TimeStart) || b.Value <= 0 {
+	if !b.IsActiveAt(cd.TimeStart) || (b.proportional == nil && b.Value.Sign() <= 0) ||
+		(b.proportional != nil && b.proportional.getCredit().Sign() <= 0) {
 		return
 	}
 	//log.Printf("}}}}}}} %+v", cd.testCallcost)
@@ -443,6 +458,10 @@ func (b *Balance) DebitMoney(cd *CallDescriptor, ub *Account, count bool, dryRun
 			return nil, errors.New("timespan with no rate interval assigned")
 		}
 		maxCost, strategy := ts.RateInterval.GetMaxCost()
+		exr, err := getExchangeRate(ts.RateInterval.GetCurrency(), b.Currency)
+		if err != nil {
+			return nil, err
+		}
 		//log.Printf("Timing: %+v", ts.RateInterval.Timing)
 		//log.Printf("Rate: %+v", ts.RateInterval.Rating)
 		for incIndex, inc := range ts.Increments {
@@ -450,7 +469,7 @@ func (b *Balance) DebitMoney(cd *CallDescriptor, ub *Account, count bool, dryRun
 			//log.Printf("INC: %+v", inc)
 			amount := inc.Cost
 			inc.paid = false
-			if strategy == utils.MAX_COST_DISCONNECT && cd.MaxCostSoFar >= maxCost {
+			if strategy == utils.MAX_COST_DISCONNECT && cd.MaxCostSoFar.Cmp(maxCost) >= 0 {
 				// cat the entire current timespan
 				cc.maxCostDisconect = true
 				if dryRun {
@@ -464,8 +483,8 @@ func (b *Balance) DebitMoney(cd *CallDescriptor, ub *Account, count bool, dryRun
 					return cc, nil
 				}
 			}
-			if strategy == utils.MAX_COST_FREE && cd.MaxCostSoFar >= maxCost {
-				amount, inc.Cost = 0.0, 0.0
+			if strategy == utils.MAX_COST_FREE && cd.MaxCostSoFar.Cmp(maxCost) >= 0 {
+				amount, inc.Cost = utils.Decimal{}, utils.Decimal{}
 				inc.BalanceInfo.MoneyBalanceUuid = b.Uuid
 				inc.BalanceInfo.AccountId = ub.Id
 				inc.paid = true
@@ -477,15 +496,23 @@ func (b *Balance) DebitMoney(cd *CallDescriptor, ub *Account, count bool, dryRun
 				// go to nextincrement
 				continue
 			}
+			if strategy == utils.MAX_COST_FREE && maxCost.Sign() > 0 && cd.MaxCostSoFar.Add(amount).Cmp(maxCost) > 0 {
+				// pay only what is left up to the max cost
+				amount = maxCost.Sub(cd.MaxCostSoFar)
+				inc.Cost = amount
+			}
 
-			if b.Value >= amount {
-				b.SubstractAmount(amount)
-				cd.MaxCostSoFar += amount
+			// the balance is debited in its own currency
+			balAmount := exr.Convert(amount)
+			if shares, canDebit := b.debitAmount(balAmount); canDebit {
+				cd.MaxCostSoFar = cd.MaxCostSoFar.Add(amount)
 				inc.BalanceInfo.MoneyBalanceUuid = b.Uuid
 				inc.BalanceInfo.AccountId = ub.Id
+				inc.BalanceInfo.ExchangeRate = exr
+				inc.BalanceInfo.Shares = shares
 				inc.paid = true
 				if count {
-					ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: cc.Direction, Balance: &Balance{Value: amount, DestinationIds: cc.Destination}})
+					ub.countUnits(&Action{BalanceType: utils.MONETARY, Direction: cc.Direction, Balance: &Balance{Value: balAmount, DestinationIds: cc.Destination}})
 				}
 			} else {
 				inc.paid = false
@@ -511,6 +538,19 @@ func (b *Balance) DebitMoney(cd *CallDescriptor, ub *Account, count bool, dryRun
 	return cc, nil
 }
 
+// Debits the amount given in the balance currency, from all the members on a *proportional shared group.
+// Nothing is debited if the balance cannot pay all of it.
+func (b *Balance) debitAmount(amount utils.Decimal) (shares []*BalanceShare, ok bool) {
+	if b.proportional != nil {
+		return b.proportional.debit(amount)
+	}
+	if b.Value.Cmp(amount) < 0 {
+		return nil, false
+	}
+	b.SubstractAmount(amount)
+	return nil, true
+}
+
 /*
 Structure to store minute buckets according to weight, precision or price.
 */
@@ -535,27 +575,29 @@ func (bc BalanceChain) Sort() {
 	sort.Sort(bc)
 }
 
-func (bc BalanceChain) GetTotalValue() (total float64) {
+func (bc BalanceChain) GetTotalValue() (total utils.Decimal) {
 	for _, b := range bc {
-		if !b.IsExpired() && b.IsActive() {
-			total += b.Value
+		if b.proportional != nil {
+			total = total.Add(b.proportional.getCredit())
+		} else if !b.IsExpired() && b.IsActive() {
+			total = total.Add(b.Value)
 		}
 	}
 	return
 }
 
-func (bc BalanceChain) Debit(amount float64) float64 {
+func (bc BalanceChain) Debit(amount utils.Decimal) utils.Decimal {
 	bc.Sort()
 	for i, b := range bc {
 		if b.IsExpired() {
 			continue
 		}
-		if b.Value >= amount || i == len(bc)-1 { // if last one go negative
+		if b.Value.Cmp(amount) >= 0 || i == len(bc)-1 { // if last one go negative
 			b.SubstractAmount(amount)
 			break
 		}
-		b.Value = 0
-		amount -= b.Value
+		amount = amount.Sub(b.Value)
+		b.Value = utils.Decimal{}
 	}
 	return bc.GetTotalValue()
 }
@@ -602,8 +644,12 @@ func (bc BalanceChain) HasBalance(balance *Balance) bool {
 
 func (bc BalanceChain) SaveDirtyBalances(acc *Account) {
 	for _, b := range bc {
+		if b.proportional != nil {
+			b.proportional.saveDebits(acc)
+		}
 		// TODO: check if the account was not already saved ?
 		if b.account != nil && b.account != acc && b.dirty {
+			b.account.SetLedgerCause(acc.ledgerCause, acc.ledgerCauseId)
 			accountingStorage.SetAccount(b.account)
 		}
 	}
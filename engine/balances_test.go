@@ -122,7 +122,7 @@ func TestBalanceMatchFilterDiffId(t *testing.T) {
 }
 
 func TestBalanceClone(t *testing.T) {
-	mb1 := &Balance{Value: 1, Weight: 2, RatingSubject: "test", DestinationIds: "5"}
+	mb1 := &Balance{Value: utils.NewDecimalFromFloat(1), Weight: 2, RatingSubject: "test", DestinationIds: "5"}
 	mb2 := mb1.Clone()
 	if mb1 == mb2 || !reflect.DeepEqual(mb1, mb2) {
 		t.Errorf("Cloning failure: \n%v\n%v", mb1, mb2)
@@ -264,3 +264,26 @@ func TestBalanceSortDestinationEmpty(t *testing.T) {
 		t.Error("Error sorting destination ids: ", sortedDestIds)
 	}
 }
+
+func TestBalanceChainDebit(t *testing.T) {
+	bc := BalanceChain{
+		&Balance{Weight: 20, Value: utils.NewDecimalFromFloat(0.1)},
+		&Balance{Weight: 10, Value: utils.NewDecimalFromFloat(0.2)},
+	}
+	if total := bc.Debit(utils.NewDecimalFromFloat(0.25)); !total.Equal(utils.NewDecimalFromFloat(0.05)) {
+		t.Error("Wrong total after debit: ", total)
+	}
+	if !bc[0].Value.IsZero() || !bc[1].Value.Equal(utils.NewDecimalFromFloat(0.05)) {
+		t.Errorf("Wrong balance values: %v, %v", bc[0].Value, bc[1].Value)
+	}
+}
+
+func TestBalanceSubstractAmountNoDrift(t *testing.T) {
+	b := &Balance{Value: utils.NewDecimalFromFloat(10)}
+	for i := 0; i < 1000; i++ {
+		b.SubstractAmount(utils.NewDecimalFromFloat(0.01))
+	}
+	if !b.Value.IsZero() {
+		t.Error("Balance drifted: ", b.Value)
+	}
+}
@@ -29,8 +29,14 @@ import (
 // The output structure that will be returned with the call cost information.
 type CallCost struct {
 	Direction, Category, Tenant, Subject, Account, Destination, TOR string
-	Cost                                                            float64
+	Cost                                                            utils.Decimal
 	Timespans                                                       TimeSpans
+	ExchangeRates                                                   []*ExchangeRate // applied on debit, kept for audit
+	Taxes                                                           TaxLines        // computed on top of the cost, not part of it
+	MinCostCredit                                                   utils.Decimal   // minimum cost debited in advance and not yet consumed
+	Trace                                                           RatingTrace     `json:",omitempty"` // rating decisions, in *debug mode only
+	ResellerCosts                                                   []*CallCost     `json:",omitempty"` // usage debited on the reseller accounts, closest level first
+	Shares                                                          []*BalanceShare `json:",omitempty"` // connect fee and taxes debited on the *proportional shared groups members
 	deductConnectFee                                                bool
 	maxCostDisconect                                                bool
 }
@@ -52,7 +58,69 @@ func (cc *CallCost) Merge(other *CallCost) {
 		// just add all timespans
 		cc.Timespans = append(cc.Timespans, other.Timespans...)
 	}
-	cc.Cost += other.Cost
+	cc.Cost = cc.Cost.Add(other.Cost)
+	cc.Taxes = cc.Taxes.Merge(other.Taxes)
+	for i, rcc := range other.ResellerCosts {
+		if i < len(cc.ResellerCosts) {
+			cc.ResellerCosts[i].Merge(rcc)
+		} else {
+			cc.ResellerCosts = append(cc.ResellerCosts, rcc)
+		}
+	}
+}
+
+func (cc *CallCost) addExchangeRate(exr *ExchangeRate) {
+	if exr == nil {
+		return
+	}
+	for _, existing := range cc.ExchangeRates {
+		if existing.Equal(exr) {
+			return
+		}
+	}
+	cc.ExchangeRates = append(cc.ExchangeRates, exr)
+}
+
+// Collects the exchange rates used while debiting the increments
+func (cc *CallCost) updateExchangeRates() {
+	for _, ts := range cc.Timespans {
+		for _, inc := range ts.Increments {
+			if inc.BalanceInfo != nil {
+				cc.addExchangeRate(inc.BalanceInfo.ExchangeRate)
+			}
+		}
+	}
+}
+
+// Computes the tax lines on the cost out of the taxes defined for tenant and category
+func (cc *CallCost) applyTaxes() error {
+	cc.Taxes = nil
+	taxes, err := getTaxes(cc.Tenant, cc.Category)
+	if err == utils.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	roundingDecimals, roundingMethod := cc.GetLongestRounding()
+	if roundingMethod == "" {
+		roundingDecimals, roundingMethod = globalRoundingDecimals, utils.ROUNDING_MIDDLE
+	}
+	cc.Taxes = taxes.GetTaxLines(cc.Cost, cc.Destination, roundingDecimals, roundingMethod)
+	return nil
+}
+
+// Takes out of the tax lines the taxes on the refunded cost, as given back by the refund
+func (cc *CallCost) RefundTaxes(refundedCost utils.Decimal) {
+	roundingDecimals, roundingMethod := cc.GetLongestRounding()
+	if roundingMethod == "" {
+		roundingDecimals, roundingMethod = globalRoundingDecimals, utils.ROUNDING_MIDDLE
+	}
+	for _, tl := range cc.Taxes {
+		tl.Amount = tl.Amount.Sub(getTax(refundedCost, tl.Rate).Round(roundingDecimals, roundingMethod))
+		if tl.Amount.Sign() < 0 {
+			tl.Amount = utils.Decimal{}
+		}
+	}
 }
 
 func (cc *CallCost) GetStartTime() time.Time {
@@ -76,15 +144,30 @@ func (cc *CallCost) GetDuration() (td time.Duration) {
 	return
 }
 
-func (cc *CallCost) GetConnectFee() float64 {
+func (cc *CallCost) GetConnectFee() utils.Decimal {
 	if len(cc.Timespans) == 0 ||
 		cc.Timespans[0].RateInterval == nil ||
 		cc.Timespans[0].RateInterval.Rating == nil {
-		return 0
+		return utils.Decimal{}
 	}
 	return cc.Timespans[0].RateInterval.Rating.ConnectFee
 }
 
+func (cc *CallCost) GetMinCost() utils.Decimal {
+	if len(cc.Timespans) == 0 {
+		return utils.Decimal{}
+	}
+	return cc.Timespans[0].RateInterval.GetMinCost()
+}
+
+// The currency of the rating, the one the connect fee is expressed in
+func (cc *CallCost) GetCurrency() string {
+	if len(cc.Timespans) == 0 {
+		return ""
+	}
+	return cc.Timespans[0].RateInterval.GetCurrency()
+}
+
 // Creates a CallDescriptor structure copying related data from CallCost
 func (cc *CallCost) CreateCallDescriptor() *CallDescriptor {
 	return &CallDescriptor{
@@ -119,6 +202,7 @@ func (cc *CallCost) ToDataCost() (*DataCost, error) {
 		Destination:      cc.Destination,
 		TOR:              cc.TOR,
 		Cost:             cc.Cost,
+		Taxes:            cc.Taxes,
 		deductConnectFee: cc.deductConnectFee,
 	}
 	dc.DataSpans = make([]*DataSpan, len(cc.Timespans))
@@ -30,7 +30,7 @@ func TestSingleResultMerge(t *testing.T) {
 	t2 := time.Date(2012, time.February, 2, 17, 1, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: OUTBOUND, Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0256", TimeStart: t1, TimeEnd: t2}
 	cc1, _ := cd.getCost()
-	if cc1.Cost != 61 {
+	if cc1.Cost.Float64() != 61 {
 		t.Errorf("expected 61 was %v", cc1.Cost)
 	}
 	/*t1 = time.Date(2012, time.February, 2, 17, 1, 0, 0, time.UTC)
@@ -54,7 +54,7 @@ func TestMultipleResultMerge(t *testing.T) {
 	t2 := time.Date(2012, time.February, 2, 18, 0, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: OUTBOUND, Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0256", TimeStart: t1, TimeEnd: t2}
 	cc1, _ := cd.getCost()
-	if cc1.Cost != 61 {
+	if cc1.Cost.Float64() != 61 {
 		t.Errorf("expected 61 was %v", cc1.Cost)
 		for _, ts := range cc1.Timespans {
 			t.Log(ts.RateInterval)
@@ -64,7 +64,7 @@ func TestMultipleResultMerge(t *testing.T) {
 	t2 = time.Date(2012, time.February, 2, 18, 01, 0, 0, time.UTC)
 	cd = &CallDescriptor{Direction: OUTBOUND, Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0256", TimeStart: t1, TimeEnd: t2}
 	cc2, _ := cd.getCost()
-	if cc2.Cost != 30 {
+	if cc2.Cost.Float64() != 30 {
 		t.Errorf("expected 30 was %v", cc2.Cost)
 		for _, ts := range cc1.Timespans {
 			t.Log(ts.RateInterval)
@@ -74,7 +74,7 @@ func TestMultipleResultMerge(t *testing.T) {
 	if len(cc1.Timespans) != 2 || cc1.Timespans[0].GetDuration().Seconds() != 60 {
 		t.Error("wrong resulted timespan: ", len(cc1.Timespans))
 	}
-	if cc1.Cost != 91 {
+	if cc1.Cost.Float64() != 91 {
 		t.Errorf("Exdpected 91 was %v", cc1.Cost)
 	}
 }
@@ -86,7 +86,7 @@ func TestMultipleInputLeftMerge(t *testing.T) {
 	cc1, _ := cd.getCost()
 	//log.Printf("Timing: %+v", cc1.Timespans[1].RateInterval.Timing)
 	//log.Printf("Rating: %+v", cc1.Timespans[1].RateInterval.Rating)
-	if cc1.Cost != 91 {
+	if cc1.Cost.Float64() != 91 {
 		t.Errorf("expected 91 was %v", cc1.Cost)
 	}
 	/*t1 = time.Date(2012, time.February, 2, 18, 01, 0, 0, time.UTC)
@@ -110,21 +110,21 @@ func TestMultipleInputRightMerge(t *testing.T) {
 	t2 := time.Date(2012, time.February, 2, 17, 59, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: OUTBOUND, Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0256", TimeStart: t1, TimeEnd: t2}
 	cc1, _ := cd.getCost()
-	if cc1.Cost != 61 {
+	if cc1.Cost.Float64() != 61 {
 		t.Errorf("expected 61 was %v", cc1.Cost)
 	}
 	t1 = time.Date(2012, time.February, 2, 17, 59, 0, 0, time.UTC)
 	t2 = time.Date(2012, time.February, 2, 18, 01, 0, 0, time.UTC)
 	cd = &CallDescriptor{Direction: OUTBOUND, Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0256", TimeStart: t1, TimeEnd: t2}
 	cc2, _ := cd.getCost()
-	if cc2.Cost != 91 {
+	if cc2.Cost.Float64() != 91 {
 		t.Errorf("expected 91 was %v", cc2.Cost)
 	}
 	cc1.Merge(cc2)
 	if len(cc1.Timespans) != 2 || cc1.Timespans[0].GetDuration().Seconds() != 120 {
 		t.Error("wrong resulted timespan: ", len(cc1.Timespans))
 	}
-	if cc1.Cost != 152 {
+	if cc1.Cost.Float64() != 152 {
 		t.Errorf("Exdpected 152 was %v", cc1.Cost)
 	}
 }
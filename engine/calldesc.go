@@ -118,12 +118,24 @@ type CallDescriptor struct {
 	RatingInfos                           RatingInfos
 	Increments                            Increments
 	TOR                                   string // used unit balances selector
+	Debug                                 bool   // *debug mode, the rating decisions are traced in the call cost
+	DialledNumber                         string // original destination when rated on a translated (ported) number
+	Origin                                string // A-number or location of the caller, selects the destination rates qualified by origin
+	CgrId                                 string // the CDR or session charged, recorded in the account ledger
 	// session limits
 	MaxRate      float64
 	MaxRateUnit  time.Duration
-	MaxCostSoFar float64
-	account      *Account
-	testCallcost *CallCost // testing purpose only!
+	MaxCostSoFar utils.Decimal
+	// part of the minimum cost debited by the previous requests in the loop and not yet consumed
+	MinCostCredit utils.Decimal
+	account       *Account
+	forceDebit    bool // the usage already happened, the account goes over its credit limit if needed
+	// account usage in the billing period at periodUsageStart, selects the rate tiers
+	periodUsage      time.Duration
+	periodUsageStart time.Time
+	trace            RatingTrace
+	sandbox          *RatingSandbox // rating data used instead of the live one
+	testCallcost     *CallCost      // testing purpose only!
 }
 
 func (cd *CallDescriptor) ValidateCallData() error {
@@ -159,6 +171,7 @@ func (cd *CallDescriptor) LoadRatingPlans() (err error) {
 	err = cd.getRatingPlansForPrefix(cd.GetKey(cd.Subject), 1)
 	if err != nil || !cd.continousRatingInfos() {
 		// use the default subject
+		cd.addTrace(TRACE_FALLBACK, cd.GetKey(FALLBACK_SUBJECT), true, "default subject for %s", cd.Subject)
 		err = cd.getRatingPlansForPrefix(cd.GetKey(FALLBACK_SUBJECT), 1)
 	}
 	//load the rating plans
@@ -174,12 +187,15 @@ func (cd *CallDescriptor) LoadRatingPlans() (err error) {
 func (cd *CallDescriptor) getRatingPlansForPrefix(key string, recursionDepth int) (err error) {
 	if recursionDepth > RECURSION_MAX_DEPTH {
 		err = errors.New("Max fallback recursion depth reached!" + key)
+		cd.addTrace(TRACE_RATING_PROFILE, key, false, "%v", err)
 		return
 	}
-	rpf, err := ratingStorage.GetRatingProfile(key, false)
+	rpf, err := cd.getRatingProfile(key)
 	if err != nil || rpf == nil {
+		cd.addTrace(TRACE_RATING_PROFILE, key, false, "%v", err)
 		return err
 	}
+	cd.addTrace(TRACE_RATING_PROFILE, key, true, "%d activations", len(rpf.RatingPlanActivations))
 	if err = rpf.GetRatingPlansForPrefix(cd); err != nil || !cd.continousRatingInfos() {
 		// try rating profile fallback
 		recursionDepth++
@@ -195,6 +211,9 @@ func (cd *CallDescriptor) getRatingPlansForPrefix(key string, recursionDepth int
 					Direction:   cd.Direction,
 					Tenant:      cd.Tenant,
 					Destination: cd.Destination,
+					Origin:      cd.Origin,
+					Debug:       cd.Debug,
+					sandbox:     cd.sandbox,
 				}
 				if index == 0 {
 					tempCD.TimeStart = cd.TimeStart
@@ -207,7 +226,11 @@ func (cd *CallDescriptor) getRatingPlansForPrefix(key string, recursionDepth int
 					tempCD.TimeEnd = cd.RatingInfos[index+1].ActivationTime
 				}
 				for _, fbk := range ri.FallbackKeys {
-					if err := tempCD.getRatingPlansForPrefix(fbk, recursionDepth); err != nil {
+					cd.addTrace(TRACE_FALLBACK, fbk, true, "%v - %v not covered by %s", tempCD.TimeStart, tempCD.TimeEnd, key)
+					err := tempCD.getRatingPlansForPrefix(fbk, recursionDepth)
+					cd.trace = append(cd.trace, tempCD.trace...)
+					tempCD.trace = nil
+					if err != nil {
 						continue
 					}
 					// extract the rate infos and break
@@ -238,6 +261,39 @@ func (cd *CallDescriptor) getRatingPlansForPrefix(key string, recursionDepth int
 	return
 }
 
+func (cd *CallDescriptor) getRatingProfile(key string) (*RatingProfile, error) {
+	if cd.sandbox != nil {
+		return cd.sandbox.GetRatingProfile(key)
+	}
+	return ratingStorage.GetRatingProfile(key, false)
+}
+
+func (cd *CallDescriptor) getRatingPlan(id string) (*RatingPlan, error) {
+	if cd.sandbox != nil {
+		return cd.sandbox.GetRatingPlan(id)
+	}
+	return ratingStorage.GetRatingPlan(id, false)
+}
+
+// Returns the prefixes of the destination matched on the rating data, longest first
+func (cd *CallDescriptor) matchDestination() []*utils.PrefixMatch {
+	if cd.sandbox != nil {
+		return cd.sandbox.destIndex.Match(cd.Destination)
+	}
+	return destIndex.Match(cd.Destination)
+}
+
+// Returns the prefixes of the origin matched on the rating data, longest first
+func (cd *CallDescriptor) matchOrigin() []*utils.PrefixMatch {
+	if cd.Origin == "" {
+		return nil
+	}
+	if cd.sandbox != nil {
+		return cd.sandbox.destIndex.Match(cd.Origin)
+	}
+	return destIndex.Match(cd.Origin)
+}
+
 // checks if there is rating info for the entire call duration
 func (cd *CallDescriptor) continousRatingInfos() bool {
 	if len(cd.RatingInfos) == 0 || cd.RatingInfos[0].ActivationTime.After(cd.TimeStart) {
@@ -334,10 +390,17 @@ func (cd *CallDescriptor) splitInTimeSpans() (timespans []*TimeSpan) {
 	}
 	// Logger.Debug(fmt.Sprintf("After SplitByRatingPlan: %+v", timespans))
 	// split on rate intervals
+	inOtherLocation := false
 	for i := 0; i < len(timespans); i++ {
 		//log.Printf("==============%v==================", i)
 		//log.Printf("TS: %+v", timespans[i])
 		rp := timespans[i].ratingInfo
+		// the timings are matched on the wall clock of the rating profile's timezone
+		if loc := rp.getLocation(); loc != nil {
+			timespans[i].TimeStart = timespans[i].TimeStart.In(loc)
+			timespans[i].TimeEnd = timespans[i].TimeEnd.In(loc)
+			inOtherLocation = true
+		}
 		// Logger.Debug(fmt.Sprintf("rp: %+v", rp))
 		//timespans[i].RatingPlan = nil
 		rp.RateIntervals.Sort()
@@ -364,14 +427,49 @@ func (cd *CallDescriptor) splitInTimeSpans() (timespans []*TimeSpan) {
 		}
 	}
 
+	// split on the rate tiers reached by the usage in the billing period
+	for i := 0; i < len(timespans); i++ {
+		if timespans[i].RateInterval == nil || len(timespans[i].RateInterval.Tiers) == 0 {
+			continue
+		}
+		usage := cd.getPeriodUsage(timespans[i].TimeStart)
+		newTs := timespans[i].SplitByRateTier(usage)
+		cd.addTrace(TRACE_RATE_TIER, timespans[i].RatingPlanId, newTs != nil, "usage %v in the billing period at %v", usage, timespans[i].TimeStart)
+		if newTs != nil {
+			index := i + 1
+			timespans = append(timespans, nil)
+			copy(timespans[index+1:], timespans[index:])
+			timespans[index] = newTs
+		}
+	}
+	if inOtherLocation {
+		loc := cd.TimeStart.Location()
+		for _, ts := range timespans {
+			ts.TimeStart = ts.TimeStart.In(loc)
+			ts.TimeEnd = ts.TimeEnd.In(loc)
+		}
+	}
 	//Logger.Debug(fmt.Sprintf("After SplitByRateInterval: %+v", timespans))
 	//log.Printf("After SplitByRateInterval: %+v", timespans[0].RateInterval.Timing)
 	timespans = cd.roundTimeSpansToIncrement(timespans)
+	cd.traceTimeSpans(timespans)
 	// Logger.Debug(fmt.Sprintf("After round: %+v", timespans))
 	//log.Printf("After round: %+v", timespans[0].RateInterval.Timing)
 	return
 }
 
+// Returns the usage in the billing period reached at the time t of the call.
+// Unless set by the account debit, the usage before the call is taken from the account, if any.
+func (cd *CallDescriptor) getPeriodUsage(t time.Time) time.Duration {
+	if cd.periodUsageStart.IsZero() {
+		cd.periodUsageStart = cd.TimeStart
+		if account, err := cd.getAccount(); err == nil && account != nil {
+			cd.periodUsage = account.GetPeriodUsage(cd.Direction, cd.TOR, cd.TimeStart)
+		}
+	}
+	return cd.periodUsage + t.Sub(cd.periodUsageStart)
+}
+
 // if the rate interval for any timespan has a RatingIncrement larger than the timespan duration
 // the timespan must expand potentially overlaping folowing timespans and may exceed call
 // descriptor's initial duration
@@ -405,41 +503,50 @@ func (cd *CallDescriptor) GetDuration() time.Duration {
 Creates a CallCost structure with the cost information calculated for the received CallDescriptor.
 */
 func (cd *CallDescriptor) GetCost() (*CallCost, error) {
+	if err := cd.translateDestination(); err != nil {
+		return nil, err
+	}
 	cc, err := cd.getCost()
 	if err != nil {
 		return nil, err
 	}
+	if err := cd.finishCost(cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
 
-	cost := 0.0
+// Applies the cost limits of the rates, the global rounding and the taxes on the rated call cost
+func (cd *CallDescriptor) finishCost(cc *CallCost) error {
+	costSoFar := cd.MaxCostSoFar // the cost of the previous requests in the loop
+	var cost utils.Decimal
 	for i, ts := range cc.Timespans {
 		// only add connect fee if this is the first/only call cost request
 		//log.Printf("Interval: %+v", ts.RateInterval.Timing)
 		if cd.LoopIndex == 0 && i == 0 && ts.RateInterval != nil {
-			cost += ts.RateInterval.Rating.ConnectFee
+			cost = cost.Add(ts.RateInterval.Rating.ConnectFee)
 		}
-		//log.Printf("TS: %+v", ts)
+		cost = cost.Add(ts.getCost())
 		// handle max cost
 		maxCost, strategy := ts.RateInterval.GetMaxCost()
-
-		cost += ts.getCost()
-		cd.MaxCostSoFar += cost
-		//log.Print("Before: ", cost)
-		if strategy != "" && maxCost > 0 {
-			//log.Print("HERE: ", strategy, maxCost)
-			if strategy == utils.MAX_COST_FREE && cd.MaxCostSoFar >= maxCost {
-				cost = maxCost
-				cd.MaxCostSoFar = maxCost
+		if strategy == utils.MAX_COST_FREE && maxCost.Sign() > 0 && costSoFar.Add(cost).Cmp(maxCost) >= 0 {
+			if cost = maxCost.Sub(costSoFar); cost.Sign() < 0 {
+				cost = utils.Decimal{}
 			}
-
 		}
-		//log.Print("Cost: ", cost)
 	}
+	// the minimum cost is for the whole call so it goes on the first/only call cost request
+	if cd.LoopIndex == 0 {
+		if minCost := cc.GetMinCost(); cost.Cmp(minCost) < 0 {
+			cost = minCost
+		}
+	}
+	cd.MaxCostSoFar = costSoFar.Add(cost)
 	cc.Cost = cost
 	// global rounding
 	roundingDecimals, roundingMethod := cc.GetLongestRounding()
-	cc.Cost = utils.Round(cc.Cost, roundingDecimals, roundingMethod)
-
-	return cc, nil
+	cc.Cost = cc.Cost.Round(roundingDecimals, roundingMethod)
+	return cc.applyTaxes()
 }
 
 func (cd *CallDescriptor) getCost() (*CallCost, error) {
@@ -453,34 +560,41 @@ func (cd *CallDescriptor) getCost() (*CallCost, error) {
 	if cd.TOR == "" {
 		cd.TOR = utils.VOICE
 	}
+	cd.trace = nil
 	err := cd.LoadRatingPlans()
 	if err != nil {
 		Logger.Err(fmt.Sprintf("error getting cost for key <%s>: %s", cd.GetKey(cd.Subject), err.Error()))
-		return &CallCost{Cost: -1}, err
+		return &CallCost{Cost: utils.NewDecimalFromInt(-1), Trace: cd.trace}, err
 	}
+	return cd.rateTimeSpans(), nil
+}
+
+// Rates the call on the already loaded rating infos
+func (cd *CallDescriptor) rateTimeSpans() *CallCost {
 	timespans := cd.splitInTimeSpans()
-	cost := 0.0
+	var cost utils.Decimal
 
 	for i, ts := range timespans {
 		// only add connect fee if this is the first/only call cost request
 		//log.Printf("Interval: %+v", ts.RateInterval.Timing)
 		if cd.LoopIndex == 0 && i == 0 && ts.RateInterval != nil {
-			cost += ts.RateInterval.Rating.ConnectFee
+			cost = cost.Add(ts.RateInterval.Rating.ConnectFee)
 		}
-		cost += ts.getCost()
+		cost = cost.Add(ts.getCost())
 	}
 
 	//startIndex := len(fmt.Sprintf("%s:%s:%s:", cd.Direction, cd.Tenant, cd.Category))
 	cc := cd.CreateCallCost()
 	cc.Cost = cost
 	cc.Timespans = timespans
+	cc.Trace = cd.trace
 
 	// global rounding
 	roundingDecimals, roundingMethod := cc.GetLongestRounding()
-	cc.Cost = utils.Round(cc.Cost, roundingDecimals, roundingMethod)
+	cc.Cost = cc.Cost.Round(roundingDecimals, roundingMethod)
 	//Logger.Info(fmt.Sprintf("<Rater> Get Cost: %s => %v", cd.GetKey(), cc))
 	cc.Timespans.Compress()
-	return cc, err
+	return cc
 }
 
 /*
@@ -489,12 +603,29 @@ If the user has no credit then it will return 0.
 If the user has postpayed plan it returns -1.
 */
 func (origCD *CallDescriptor) getMaxSessionDuration(origAcc *Account) (time.Duration, error) {
+	limitDuration, err := origAcc.getSpendingLimitDuration(origCD)
+	if err != nil || limitDuration == 0 {
+		return 0, err
+	}
+	duration, err := origCD.getCreditMaxSessionDuration(origAcc)
+	if err != nil || limitDuration < 0 {
+		return duration, err
+	}
+	if duration < 0 || duration > limitDuration { // unlimited credit is still bound by the spending limits
+		return limitDuration, nil
+	}
+	return duration, nil
+}
+
+func (origCD *CallDescriptor) getCreditMaxSessionDuration(origAcc *Account) (time.Duration, error) {
 	// clone the account for discarding chenges on debit dry run
 	//log.Printf("ORIG CD: %+v", origCD)
 	account := origAcc.Clone()
-	if account.AllowNegative {
+	if _, unlimited := account.getCreditLimitAmount(origCD.Direction); unlimited {
 		return -1, nil
 	}
+	account.applyReservations(origCD.Direction) // the held credit is not available for the session
+	account.applyCreditLimit(origCD.Direction)  // while the debt up to the limit is
 	if origCD.DurationIndex < origCD.TimeEnd.Sub(origCD.TimeStart) {
 		origCD.DurationIndex = origCD.TimeEnd.Sub(origCD.TimeStart)
 	}
@@ -510,7 +641,7 @@ func (origCD *CallDescriptor) getMaxSessionDuration(origAcc *Account) (time.Dura
 
 	//log.Printf("CC: %+v", cc)
 
-	var totalCost float64
+	var totalCost utils.Decimal
 	var totalDuration time.Duration
 	defaultBalance := account.GetDefaultMoneyBalance(cd.Direction)
 	cc.Timespans.Decompress()
@@ -521,13 +652,13 @@ func (origCD *CallDescriptor) getMaxSessionDuration(origAcc *Account) (time.Dura
 		//}
 		if cd.MaxRate > 0 && cd.MaxRateUnit > 0 {
 			rate, _, rateUnit := ts.RateInterval.GetRateParameters(ts.GetGroupStart())
-			if rate/rateUnit.Seconds() > cd.MaxRate/cd.MaxRateUnit.Seconds() {
+			if rate.Float64()/rateUnit.Seconds() > cd.MaxRate/cd.MaxRateUnit.Seconds() {
 				return utils.MinDuration(initialDuration, totalDuration), nil
 			}
 		}
 		for _, incr := range ts.Increments {
-			totalCost += incr.Cost
-			if defaultBalance.Value < 0 && incr.BalanceInfo.MoneyBalanceUuid == defaultBalance.Uuid {
+			totalCost = totalCost.Add(incr.Cost)
+			if defaultBalance.Value.Sign() < 0 && incr.BalanceInfo.MoneyBalanceUuid == defaultBalance.Uuid {
 				// this increment was payed with debt
 				// TODO: improve this check
 				return utils.MinDuration(initialDuration, totalDuration), nil
@@ -544,6 +675,9 @@ func (origCD *CallDescriptor) getMaxSessionDuration(origAcc *Account) (time.Dura
 }
 
 func (cd *CallDescriptor) GetMaxSessionDuration() (duration time.Duration, err error) {
+	if err := cd.translateDestination(); err != nil {
+		return 0, err
+	}
 	if account, err := cd.getAccount(); err != nil || account == nil {
 		Logger.Err(fmt.Sprintf("Could not get user balance for <%s>: %s.", cd.GetAccountKey(), err.Error()))
 		return 0, err
@@ -569,11 +703,14 @@ func (cd *CallDescriptor) debit(account *Account, dryRun bool, goNegative bool)
 		return cd.CreateCallCost(), nil
 	}
 	if !dryRun {
+		account.SetLedgerCause(LEDGER_CDR, cd.CgrId)
 		defer accountingStorage.SetAccount(account)
 	}
 	if cd.TOR == "" {
 		cd.TOR = utils.VOICE
 	}
+	account.CleanExpiredBalances() // rolls over the unused units before using the balances
+	callStart := cd.TimeStart
 	//log.Printf("Debit CD: %+v", cd)
 	cc, err = account.debitCreditBalance(cd, !dryRun, dryRun, goNegative)
 	//log.Printf("HERE: %+v %v", cc, err)
@@ -581,32 +718,67 @@ func (cd *CallDescriptor) debit(account *Account, dryRun bool, goNegative bool)
 		Logger.Err(fmt.Sprintf("<Rater> Error getting cost for account key <%s>: %s", cd.GetAccountKey(), err.Error()))
 		return nil, err
 	}
-	cost := 0.0
+	var cost utils.Decimal
 	// calculate call cost after balances
 	if cc.deductConnectFee { // add back the connectFee
-		cost += cc.GetConnectFee()
+		cost = cost.Add(cc.GetConnectFee())
 	}
 	for _, ts := range cc.Timespans {
-		cost += ts.getCost()
-		cost = utils.Round(cost, globalRoundingDecimals, utils.ROUNDING_MIDDLE) // just get rid of the extra decimals
+		cost = cost.Add(ts.getCost()).Round(globalRoundingDecimals, utils.ROUNDING_MIDDLE) // just get rid of the extra decimals
+	}
+	// the minimum cost is debited with the first request and consumed by the next ones in the loop
+	if cc.deductConnectFee {
+		if minCost := cc.GetMinCost(); cost.Cmp(minCost) < 0 {
+			cc.MinCostCredit = minCost.Sub(cost)
+			if err = account.debitMinCost(cc, cc.MinCostCredit, !dryRun); err != nil {
+				return nil, err
+			}
+			cost = minCost
+		}
+	} else if cd.MinCostCredit.Sign() > 0 {
+		consumed := utils.MinDecimal(cost, cd.MinCostCredit)
+		cc.MinCostCredit = cd.MinCostCredit.Sub(consumed)
+		if err = account.debitMinCost(cc, consumed.Neg(), !dryRun); err != nil {
+			return nil, err
+		}
+		cost = cost.Sub(consumed)
 	}
 	cc.Cost = cost
+	if !dryRun {
+		account.countSpending(cd, cost, cc.GetDuration(), callStart)
+	}
+	if err = cc.applyTaxes(); err != nil {
+		Logger.Err(fmt.Sprintf("<Rater> Error getting taxes for account key <%s>: %s", cd.GetAccountKey(), err.Error()))
+		return nil, err
+	}
+	if err = account.debitTaxes(cc, !dryRun); err != nil {
+		Logger.Err(fmt.Sprintf("<Rater> Error debiting taxes for account key <%s>: %s", cd.GetAccountKey(), err.Error()))
+		return nil, err
+	}
+	cc.updateExchangeRates()
 	cc.Timespans.Compress()
 	//log.Printf("OUT CC: ", cc)
 	return
 }
 
 func (cd *CallDescriptor) Debit() (cc *CallCost, err error) {
+	if err := cd.translateDestination(); err != nil {
+		return nil, err
+	}
 	// lock all group members
 	if account, err := cd.getAccount(); err != nil || account == nil {
 		Logger.Err(fmt.Sprintf("Could not get user balance for <%s>: %s.", cd.GetAccountKey(), err.Error()))
 		return nil, err
 	} else {
 		if memberIds, err := account.GetUniqueSharedGroupMembers(cd); err == nil {
+			lockIds, resellerIds := account.getDebitLockIds(memberIds)
 			AccLock.Guard(func() (interface{}, error) {
-				cc, err = cd.debit(account, false, true)
+				resellerCD := cd.Clone() // the debit consumes the call descriptor
+				if cc, err = cd.debit(account, false, true); err == nil {
+					resellerCD.debitResellers(cc, resellerIds)
+				}
 				return 0, err
-			}, memberIds...)
+			}, lockIds...)
 		} else {
 			return nil, err
 		}
@@ -619,12 +791,16 @@ func (cd *CallDescriptor) Debit() (cc *CallCost, err error) {
 // This methods combines the Debit and GetMaxSessionDuration and will debit the max available time as returned
 // by the GetMaxSessionDuration method. The amount filed has to be filled in call descriptor.
 func (cd *CallDescriptor) MaxDebit() (cc *CallCost, err error) {
+	if err := cd.translateDestination(); err != nil {
+		return nil, err
+	}
 	if account, err := cd.getAccount(); err != nil || account == nil {
 		Logger.Err(fmt.Sprintf("Could not get user balance for <%s>: %s.", cd.GetAccountKey(), err.Error()))
 		return nil, err
 	} else {
 		//log.Printf("ACC: %+v", account)
 		if memberIds, err := account.GetUniqueSharedGroupMembers(cd); err == nil {
+			lockIds, resellerIds := account.getDebitLockIds(memberIds)
 			AccLock.Guard(func() (interface{}, error) {
 				remainingDuration, err := cd.getMaxSessionDuration(account)
 				//log.Print("AFTER MAX SESSION: ", cd)
@@ -638,10 +814,13 @@ func (cd *CallDescriptor) MaxDebit() (cc *CallCost, err error) {
 					cd.TimeEnd = cd.TimeStart.Add(remainingDuration)
 					cd.DurationIndex -= initialDuration - remainingDuration
 				}
-				cc, err = cd.debit(account, false, true)
+				resellerCD := cd.Clone() // the debit consumes the call descriptor
+				if cc, err = cd.debit(account, false, true); err == nil {
+					resellerCD.debitResellers(cc, resellerIds)
+				}
 				//log.Print(balanceMap[0].Value, balanceMap[1].Value)
 				return 0, err
-			}, memberIds...)
+			}, lockIds...)
 		} else {
 			return nil, err
 		}
@@ -651,23 +830,81 @@ func (cd *CallDescriptor) MaxDebit() (cc *CallCost, err error) {
 
 func (cd *CallDescriptor) RefundIncrements() (left float64, err error) {
 	accountsCache := make(map[string]*Account)
+	var refundedCost utils.Decimal
+	var refundedUsage time.Duration
+	// the taxes debited on top of the refunded cost go back with it
+	taxRate := getDebitTaxRate(cd.Tenant, cd.Category, cd.Destination)
 	for _, increment := range cd.Increments {
+		refundedCost, refundedUsage = refundedCost.Add(increment.Cost), refundedUsage+increment.Duration
 		account, found := accountsCache[increment.BalanceInfo.AccountId]
 		if !found {
 			if acc, err := accountingStorage.GetAccount(increment.BalanceInfo.AccountId); err == nil && acc != nil {
 				account = acc
+				account.SetLedgerCause(LEDGER_REFUND, cd.CgrId)
 				accountsCache[increment.BalanceInfo.AccountId] = account
 				defer accountingStorage.SetAccount(account)
 			}
 		}
 		account.refundIncrement(increment, cd.Direction, cd.TOR, true)
+		account.refundIncrementTaxes(increment, cd.Direction, taxRate, true)
+		for _, share := range increment.BalanceInfo.Shares {
+			shareAccount, found := accountsCache[share.AccountId]
+			if !found {
+				if acc, err := accountingStorage.GetAccount(share.AccountId); err == nil && acc != nil {
+					shareAccount = acc
+					shareAccount.SetLedgerCause(LEDGER_REFUND, cd.CgrId)
+					accountsCache[share.AccountId] = shareAccount
+					defer accountingStorage.SetAccount(shareAccount)
+				} else {
+					continue
+				}
+			}
+			shareAccount.refundShare(share, cd.Direction, taxRate)
+		}
+	}
+	// give back the refunded usage to the spending limits
+	account, found := accountsCache[cd.GetAccountKey()]
+	if !found {
+		if acc, err := accountingStorage.GetAccount(cd.GetAccountKey()); err == nil && acc != nil && len(acc.SpendingLimits) > 0 {
+			account = acc
+			account.SetLedgerCause(LEDGER_REFUND, cd.CgrId)
+			defer accountingStorage.SetAccount(account)
+		}
+	}
+	if account != nil {
+		account.countSpending(cd, refundedCost.Neg(), -refundedUsage, cd.TimeStart)
 	}
 	return 0.0, err
 }
 
+// Returns the accounts the refund gives back to, the shared groups members and the resellers
+// of the account included, so they are locked together
+func (cd *CallDescriptor) getRefundLockIds() (lockIds []string) {
+	seen := make(map[string]bool)
+	addId := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			lockIds = append(lockIds, id)
+		}
+	}
+	addId(cd.GetAccountKey())
+	for _, increment := range cd.Increments {
+		addId(increment.BalanceInfo.AccountId)
+		for _, share := range increment.BalanceInfo.Shares {
+			addId(share.AccountId)
+		}
+	}
+	if account, err := accountingStorage.GetAccount(cd.GetAccountKey()); err == nil && account != nil {
+		for _, resellerId := range account.getResellerIds() {
+			addId(resellerId)
+		}
+	}
+	return
+}
+
 func (cd *CallDescriptor) FlushCache() (err error) {
 	cache2go.Flush()
-	ratingStorage.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingStorage.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	accountingStorage.CacheAccounting(nil, nil, nil)
 	return nil
 
@@ -702,10 +939,17 @@ func (cd *CallDescriptor) Clone() *CallDescriptor {
 		MaxRate:         cd.MaxRate,
 		MaxRateUnit:     cd.MaxRateUnit,
 		MaxCostSoFar:    cd.MaxCostSoFar,
+		MinCostCredit:   cd.MinCostCredit,
 		FallbackSubject: cd.FallbackSubject,
+		DialledNumber:   cd.DialledNumber,
+		Origin:          cd.Origin,
+		CgrId:           cd.CgrId,
 		//RatingInfos:     cd.RatingInfos,
 		//Increments:      cd.Increments,
 		TOR: cd.TOR,
+		// the rate tiers keep counting from the usage the call started with
+		periodUsage:      cd.periodUsage,
+		periodUsageStart: cd.periodUsageStart,
 	}
 }
 
@@ -729,6 +973,9 @@ func (cd *CallDescriptor) GetLCRFromStorage() (*LCR, error) {
 }
 
 func (cd *CallDescriptor) GetLCR(stats StatsInterface) (*LCRCost, error) {
+	if err := cd.translateDestination(); err != nil {
+		return nil, err
+	}
 	lcr, err := cd.GetLCRFromStorage()
 	if err != nil {
 		return nil, err
@@ -21,6 +21,7 @@ package engine
 import (
 	"log"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -41,37 +42,37 @@ func init() {
 
 func populateDB() {
 	ats := []*Action{
-		&Action{ActionType: "*topup", BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}},
-		&Action{ActionType: "*topup", BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: &Balance{Weight: 20, Value: 10, DestinationIds: "NAT"}},
+		&Action{ActionType: "*topup", BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}},
+		&Action{ActionType: "*topup", BalanceType: utils.VOICE, Direction: OUTBOUND, Balance: &Balance{Weight: 20, Value: utils.NewDecimalFromFloat(10), DestinationIds: "NAT"}},
 	}
 
 	ats1 := []*Action{
-		&Action{ActionType: "*topup", BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: 10}, Weight: 10},
+		&Action{ActionType: "*topup", BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}, Weight: 10},
 		&Action{ActionType: "*reset_account", Weight: 20},
 	}
 
 	minu := &Account{
 		Id: "*out:vdf:minu",
 		BalanceMap: map[string]BalanceChain{
-			utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: 50}},
+			utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(50)}},
 			utils.VOICE + OUTBOUND: BalanceChain{
-				&Balance{Value: 200, DestinationIds: "NAT", Weight: 10},
-				&Balance{Value: 100, DestinationIds: "RET", Weight: 20},
+				&Balance{Value: utils.NewDecimalFromFloat(200), DestinationIds: "NAT", Weight: 10},
+				&Balance{Value: utils.NewDecimalFromFloat(100), DestinationIds: "RET", Weight: 20},
 			}},
 	}
 	broker := &Account{
 		Id: "*out:vdf:broker",
 		BalanceMap: map[string]BalanceChain{
 			utils.VOICE + OUTBOUND: BalanceChain{
-				&Balance{Value: 20, DestinationIds: "NAT", Weight: 10, RatingSubject: "rif"},
-				&Balance{Value: 100, DestinationIds: "RET", Weight: 20},
+				&Balance{Value: utils.NewDecimalFromFloat(20), DestinationIds: "NAT", Weight: 10, RatingSubject: "rif"},
+				&Balance{Value: utils.NewDecimalFromFloat(100), DestinationIds: "RET", Weight: 20},
 			}},
 	}
 	luna := &Account{
 		Id: "*out:vdf:luna",
 		BalanceMap: map[string]BalanceChain{
 			utils.MONETARY + OUTBOUND: BalanceChain{
-				&Balance{Value: 0, Weight: 20},
+				&Balance{Value: utils.NewDecimalFromFloat(0), Weight: 20},
 			}},
 	}
 	// this is added to test if csv load tests account will not overwrite balances
@@ -79,11 +80,11 @@ func populateDB() {
 		Id: "*out:vdf:minitsboy",
 		BalanceMap: map[string]BalanceChain{
 			utils.VOICE + OUTBOUND: BalanceChain{
-				&Balance{Value: 20, DestinationIds: "NAT", Weight: 10, RatingSubject: "rif"},
-				&Balance{Value: 100, DestinationIds: "RET", Weight: 20},
+				&Balance{Value: utils.NewDecimalFromFloat(20), DestinationIds: "NAT", Weight: 10, RatingSubject: "rif"},
+				&Balance{Value: utils.NewDecimalFromFloat(100), DestinationIds: "RET", Weight: 20},
 			},
 			utils.MONETARY + OUTBOUND: BalanceChain{
-				&Balance{Value: 100, Weight: 10},
+				&Balance{Value: utils.NewDecimalFromFloat(100), Weight: 10},
 			},
 		},
 	}
@@ -91,7 +92,7 @@ func populateDB() {
 		Id: "*out:cgrates.org:max",
 		BalanceMap: map[string]BalanceChain{
 			utils.MONETARY + OUTBOUND: BalanceChain{
-				&Balance{Value: 11, Weight: 20},
+				&Balance{Value: utils.NewDecimalFromFloat(11), Weight: 20},
 			}},
 	}
 	if accountingStorage != nil {
@@ -146,11 +147,11 @@ func TestSplitSpansWeekend(t *testing.T) {
 							StartTime: "08:00:00",
 						},
 						Rating: &RIRate{
-							ConnectFee:       0,
+							ConnectFee:       utils.NewDecimalFromFloat(0),
 							RoundingMethod:   "*up",
 							RoundingDecimals: 6,
 							Rates: RateGroups{
-								&Rate{Value: 1, RateIncrement: 1 * time.Second, RateUnit: 1 * time.Second},
+								&Rate{Value: utils.NewDecimalFromFloat(1), RateIncrement: 1 * time.Second, RateUnit: 1 * time.Second},
 							},
 						},
 					},
@@ -160,11 +161,11 @@ func TestSplitSpansWeekend(t *testing.T) {
 							StartTime: "00:00:00",
 						},
 						Rating: &RIRate{
-							ConnectFee:       0,
+							ConnectFee:       utils.NewDecimalFromFloat(0),
 							RoundingMethod:   "*up",
 							RoundingDecimals: 6,
 							Rates: RateGroups{
-								&Rate{Value: 1, RateIncrement: 1 * time.Second, RateUnit: 1 * time.Second},
+								&Rate{Value: utils.NewDecimalFromFloat(1), RateIncrement: 1 * time.Second, RateUnit: 1 * time.Second},
 							},
 						},
 					},
@@ -174,11 +175,11 @@ func TestSplitSpansWeekend(t *testing.T) {
 							StartTime: "00:00:00",
 						},
 						Rating: &RIRate{
-							ConnectFee:       0,
+							ConnectFee:       utils.NewDecimalFromFloat(0),
 							RoundingMethod:   "*up",
 							RoundingDecimals: 6,
 							Rates: RateGroups{
-								&Rate{Value: 1, RateIncrement: 1 * time.Second, RateUnit: 1 * time.Second},
+								&Rate{Value: utils.NewDecimalFromFloat(1), RateIncrement: 1 * time.Second, RateUnit: 1 * time.Second},
 							},
 						},
 					},
@@ -254,13 +255,191 @@ func TestCalldescHolliday(t *testing.T) {
 	}
 }
 
+func timezoneRatingInfo(timezone, peakStart string) *RatingInfo {
+	return &RatingInfo{
+		Timezone: timezone,
+		RateIntervals: RateIntervalList{
+			&RateInterval{
+				Timing: &RITiming{StartTime: "00:00:00"},
+				Rating: &RIRate{Rates: RateGroups{&Rate{Value: utils.NewDecimalFromFloat(1), RateIncrement: time.Second, RateUnit: time.Second}}},
+				Weight: 10,
+			},
+			&RateInterval{
+				Timing: &RITiming{StartTime: peakStart},
+				Rating: &RIRate{Rates: RateGroups{&Rate{Value: utils.NewDecimalFromFloat(2), RateIncrement: time.Second, RateUnit: time.Second}}},
+				Weight: 10,
+			},
+		},
+	}
+}
+
+func TestCalldescTimezoneSplit(t *testing.T) {
+	// 07:50 - 08:10 in Tokyo
+	cd := &CallDescriptor{
+		TOR:         utils.VOICE,
+		TimeStart:   time.Date(2015, time.June, 1, 22, 50, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2015, time.June, 1, 23, 10, 0, 0, time.UTC),
+		RatingInfos: RatingInfos{timezoneRatingInfo("Asia/Tokyo", "08:00:00")},
+	}
+	timespans := cd.splitInTimeSpans()
+	if len(timespans) != 2 ||
+		!timespans[0].TimeEnd.Equal(time.Date(2015, time.June, 1, 23, 0, 0, 0, time.UTC)) ||
+		timespans[0].RateInterval.Timing.StartTime != "00:00:00" ||
+		timespans[1].RateInterval.Timing.StartTime != "08:00:00" {
+		t.Errorf("Error splitting on timezone: %+v", timespans)
+	}
+	if timespans[0].TimeStart.Location() != time.UTC || timespans[1].TimeEnd.Location() != time.UTC {
+		t.Error("Timespans not returned in the call's location: ", timespans[0].TimeStart, timespans[1].TimeEnd)
+	}
+	// same call without timezone stays in the off-peak interval
+	cd.RatingInfos = RatingInfos{timezoneRatingInfo("", "08:00:00")}
+	if timespans := cd.splitInTimeSpans(); len(timespans) != 1 {
+		t.Errorf("Error splitting without timezone: %+v", timespans)
+	}
+}
+
+func TestCalldescTimezoneDST(t *testing.T) {
+	for _, test := range []struct {
+		start, end, split time.Time
+		peakStart         string
+	}{
+		// summer time started: 07:50 - 08:10 CEST
+		{time.Date(2015, time.March, 29, 5, 50, 0, 0, time.UTC), time.Date(2015, time.March, 29, 6, 10, 0, 0, time.UTC), time.Date(2015, time.March, 29, 6, 0, 0, 0, time.UTC), "08:00:00"},
+		// winter time started: 07:50 - 08:10 CET
+		{time.Date(2015, time.October, 25, 6, 50, 0, 0, time.UTC), time.Date(2015, time.October, 25, 7, 10, 0, 0, time.UTC), time.Date(2015, time.October, 25, 7, 0, 0, 0, time.UTC), "08:00:00"},
+		// across the transition: 01:30 CET - 03:30 CEST, one hour long
+		{time.Date(2015, time.March, 29, 0, 30, 0, 0, time.UTC), time.Date(2015, time.March, 29, 1, 30, 0, 0, time.UTC), time.Date(2015, time.March, 29, 1, 0, 0, 0, time.UTC), "03:00:00"},
+		// across the transition: 02:30 CEST - 03:30 CET, two hours long
+		{time.Date(2015, time.October, 25, 0, 30, 0, 0, time.UTC), time.Date(2015, time.October, 25, 2, 30, 0, 0, time.UTC), time.Date(2015, time.October, 25, 2, 0, 0, 0, time.UTC), "03:00:00"},
+	} {
+		cd := &CallDescriptor{
+			TOR:         utils.VOICE,
+			TimeStart:   test.start,
+			TimeEnd:     test.end,
+			RatingInfos: RatingInfos{timezoneRatingInfo("Europe/Berlin", test.peakStart)},
+		}
+		timespans := cd.splitInTimeSpans()
+		if len(timespans) != 2 ||
+			!timespans[0].TimeEnd.Equal(test.split) || !timespans[1].TimeStart.Equal(test.split) ||
+			timespans[1].RateInterval.Timing.StartTime != test.peakStart {
+			t.Errorf("Error splitting %v - %v: %+v", test.start, test.end, timespans)
+			continue
+		}
+		if timespans[0].GetDuration()+timespans[1].GetDuration() != test.end.Sub(test.start) {
+			t.Errorf("Wrong duration splitting %v - %v: %v, %v", test.start, test.end, timespans[0].GetDuration(), timespans[1].GetDuration())
+		}
+	}
+}
+
+func TestGetCostTieredUsage(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:tiered", UsageCounters: []*UsageCounter{
+		&UsageCounter{Direction: OUTBOUND, TOR: utils.VOICE, PeriodStart: time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC), Usage: 999 * time.Minute},
+	}}
+	accountingStorage.SetAccount(acc)
+	cd := &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "tiered", Account: "tiered", Destination: "0723045326",
+		TimeStart: time.Date(2015, 6, 30, 10, 0, 0, 0, time.UTC), TimeEnd: time.Date(2015, 6, 30, 10, 2, 0, 0, time.UTC)}
+	if cc, err := cd.GetCost(); err != nil || cc.Cost.String() != "91" {
+		t.Errorf("Wrong tiered cost: %+v, %v", cc, err)
+	}
+	// next billing period starts again on the first tier
+	cd = &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "tiered", Account: "tiered", Destination: "0723045326",
+		TimeStart: time.Date(2015, 7, 1, 10, 0, 0, 0, time.UTC), TimeEnd: time.Date(2015, 7, 1, 10, 2, 0, 0, time.UTC)}
+	if cc, err := cd.GetCost(); err != nil || cc.Cost.String() != "121" {
+		t.Errorf("Wrong cost in the new billing period: %+v, %v", cc, err)
+	}
+}
+
+func TestCallDescriptorClonePeriodUsage(t *testing.T) {
+	accountingStorage.SetAccount(&Account{Id: "*out:cgrates.org:tiered_clone", UsageCounters: []*UsageCounter{
+		&UsageCounter{Direction: OUTBOUND, TOR: utils.VOICE, PeriodStart: time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC), Usage: 999 * time.Minute},
+	}})
+	cd := &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "tiered", Account: "tiered_clone", Destination: "0723045326",
+		TimeStart: time.Date(2015, 6, 30, 10, 0, 0, 0, time.UTC), TimeEnd: time.Date(2015, 6, 30, 10, 2, 0, 0, time.UTC), TOR: utils.VOICE}
+	cd.getPeriodUsage(cd.TimeStart)
+	// a clone rating the rest of the call keeps counting the usage already rated
+	clone := cd.Clone()
+	clone.TimeStart = cd.TimeStart.Add(time.Minute)
+	clone.Account = "tiered_other"
+	if usage := clone.getPeriodUsage(clone.TimeStart); usage != 1000*time.Minute {
+		t.Error("Wrong usage on the clone: ", usage)
+	}
+}
+
+func TestGetCostOrigin(t *testing.T) {
+	t1 := time.Date(2015, 6, 30, 10, 0, 0, 0, time.UTC)
+	cd := &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "minmax", Destination: "0723045326",
+		Origin: "+4971123456", TimeStart: t1, TimeEnd: t1.Add(30 * time.Second), Debug: true}
+	cc, err := cd.GetCost()
+	if err != nil || cc.Cost.String() != "0.05" {
+		t.Fatalf("Origin rates not applied: %+v, %v", cc, err)
+	}
+	if dests := cc.Trace.GetSteps(TRACE_DESTINATION); len(dests) != 1 || !strings.Contains(dests[0].Details, `origin "PSTN_71"`) {
+		t.Errorf("Origin not traced: %+v", dests)
+	}
+	// other origins get the rates of the destination
+	cd = &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "minmax", Destination: "0723045326",
+		Origin: "+4972123456", TimeStart: t1, TimeEnd: t1.Add(30 * time.Second)}
+	if cc, err := cd.GetCost(); err != nil || cc.Cost.String() != "1" {
+		t.Errorf("Wrong cost for other origin: %+v, %v", cc, err)
+	}
+}
+
+func TestGetCostMinMaxCost(t *testing.T) {
+	t1 := time.Date(2015, 6, 30, 10, 0, 0, 0, time.UTC)
+	cd := &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "minmax", Destination: "0723045326",
+		TimeStart: t1, TimeEnd: t1.Add(3 * time.Second)}
+	if cc, err := cd.GetCost(); err != nil || cc.Cost.String() != "0.5" {
+		t.Errorf("Minimum cost not applied: %+v, %v", cc, err)
+	}
+	cd = &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "minmax", Destination: "0723045326",
+		TimeStart: t1, TimeEnd: t1.Add(30 * time.Second)}
+	if cc, err := cd.GetCost(); err != nil || cc.Cost.String() != "1" {
+		t.Errorf("Maximum cost not applied: %+v, %v", cc, err)
+	}
+	// next request in the loop gets only what is left up to the maximum
+	cd = &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "minmax", Destination: "0723045326",
+		TimeStart: t1.Add(8 * time.Second), TimeEnd: t1.Add(13 * time.Second), LoopIndex: 1, MaxCostSoFar: utils.NewDecimalFromFloat(0.8)}
+	if cc, err := cd.GetCost(); err != nil || cc.Cost.String() != "0.2" || cd.MaxCostSoFar.String() != "1" {
+		t.Errorf("Maximum cost not applied in the loop: %+v, %v", cc, err)
+	}
+}
+
+func TestMaxDebitMinMaxCost(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:minmax", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "minmax", Value: utils.NewDecimalFromFloat(10)}},
+	}}
+	accountingStorage.SetAccount(acc)
+	t1 := time.Date(2015, 6, 30, 10, 0, 0, 0, time.UTC)
+	cd := &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "minmax", Account: "minmax", Destination: "0723045326",
+		TimeStart: t1, TimeEnd: t1.Add(3 * time.Second)}
+	cc, err := cd.MaxDebit()
+	if err != nil || cc.Cost.String() != "0.5" || cc.MinCostCredit.String() != "0.2" {
+		t.Fatalf("Minimum cost not debited: %+v, %v", cc, err)
+	}
+	// the minimum debited in advance covers the next request
+	cd = &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "minmax", Account: "minmax", Destination: "0723045326",
+		TimeStart: t1.Add(3 * time.Second), TimeEnd: t1.Add(6 * time.Second), LoopIndex: 1, MaxCostSoFar: cc.Cost, MinCostCredit: cc.MinCostCredit}
+	if cc, err = cd.MaxDebit(); err != nil || cc.Cost.String() != "0.1" || !cc.MinCostCredit.IsZero() {
+		t.Fatalf("Minimum cost credit not consumed: %+v, %v", cc, err)
+	}
+	// nothing is debited once the maximum cost is reached
+	cd = &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "minmax", Account: "minmax", Destination: "0723045326",
+		TimeStart: t1.Add(6 * time.Second), TimeEnd: t1.Add(16 * time.Second), LoopIndex: 2, MaxCostSoFar: utils.NewDecimalFromFloat(0.6)}
+	if cc, err = cd.MaxDebit(); err != nil || cc.Cost.String() != "0.4" || cc.GetDuration() != 10*time.Second {
+		t.Errorf("Maximum cost not applied: %+v, %v", cc, err)
+	}
+	if acc, err = accountingStorage.GetAccount(acc.Id); err != nil || acc.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().String() != "9" {
+		t.Errorf("Wrong balance after the min/max debits: %+v, %v", acc, err)
+	}
+}
+
 func TestGetCost(t *testing.T) {
 	t1 := time.Date(2012, time.February, 2, 17, 30, 0, 0, time.UTC)
 	t2 := time.Date(2012, time.February, 2, 18, 30, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0256", TimeStart: t1, TimeEnd: t2, LoopIndex: 0}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0256", Cost: 2701}
-	if result.Cost != expected.Cost || result.GetConnectFee() != 1 {
+	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0256", Cost: utils.NewDecimalFromFloat(2701)}
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 1 {
 		t.Errorf("Expected %v was %v", expected, result)
 	}
 }
@@ -270,8 +449,8 @@ func TestGetCostZero(t *testing.T) {
 	t2 := time.Date(2012, time.February, 2, 17, 30, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0256", TimeStart: t1, TimeEnd: t2, LoopIndex: 0}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0256", Cost: 0}
-	if result.Cost != expected.Cost || result.GetConnectFee() != 0 {
+	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0256", Cost: utils.NewDecimalFromFloat(0)}
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 0 {
 		t.Errorf("Expected %v was %v", expected, result)
 	}
 }
@@ -281,8 +460,8 @@ func TestGetCostTimespans(t *testing.T) {
 	t2 := time.Date(2013, time.October, 8, 9, 24, 27, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "test", Subject: "trp", Destination: "0256", TimeStart: t1, TimeEnd: t2, LoopIndex: 0, DurationIndex: 85 * time.Second}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "test", Subject: "trp", Destination: "0256", Cost: 85}
-	if result.Cost != expected.Cost || result.GetConnectFee() != 0 || len(result.Timespans) != 2 {
+	expected := &CallCost{Tenant: "test", Subject: "trp", Destination: "0256", Cost: utils.NewDecimalFromFloat(85)}
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 0 || len(result.Timespans) != 2 {
 		t.Errorf("Expected %+v was %+v", expected, result)
 	}
 
@@ -328,7 +507,7 @@ func TestGetCostRateGroups(t *testing.T) {
 	if err != nil {
 		t.Error("Error getting cost: ", err)
 	}
-	if result.Cost != 132 {
+	if result.Cost.Float64() != 132 {
 		t.Error("Error calculating cost: ", result.Timespans)
 	}
 }
@@ -338,9 +517,9 @@ func TestGetCostNoConnectFee(t *testing.T) {
 	t2 := time.Date(2012, time.February, 2, 18, 30, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0256", TimeStart: t1, TimeEnd: t2, LoopIndex: 1}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0256", Cost: 2700}
+	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0256", Cost: utils.NewDecimalFromFloat(2700)}
 	// connect fee is not added because LoopIndex is 1
-	if result.Cost != expected.Cost || result.GetConnectFee() != 1 {
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 1 {
 		t.Errorf("Expected %v was %v", expected, result)
 	}
 }
@@ -350,8 +529,8 @@ func TestGetCostAccount(t *testing.T) {
 	t2 := time.Date(2012, time.February, 2, 18, 30, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Account: "rif", Destination: "0256", TimeStart: t1, TimeEnd: t2}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0256", Cost: 2701}
-	if result.Cost != expected.Cost || result.GetConnectFee() != 1 {
+	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0256", Cost: utils.NewDecimalFromFloat(2701)}
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 1 {
 		t.Errorf("Expected %v was %v", expected, result)
 	}
 }
@@ -361,8 +540,8 @@ func TestFullDestNotFound(t *testing.T) {
 	t2 := time.Date(2012, time.February, 2, 18, 30, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0256308200", TimeStart: t1, TimeEnd: t2}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0256", Cost: 2701}
-	if result.Cost != expected.Cost || result.GetConnectFee() != 1 {
+	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0256", Cost: utils.NewDecimalFromFloat(2701)}
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 1 {
 		t.Log(cd.RatingInfos)
 		t.Errorf("Expected %v was %v", expected, result)
 	}
@@ -373,8 +552,8 @@ func TestSubjectNotFound(t *testing.T) {
 	t2 := time.Date(2013, time.February, 1, 18, 30, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "not_exiting", Destination: "025740532", TimeStart: t1, TimeEnd: t2}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0257", Cost: 2701}
-	if result.Cost != expected.Cost || result.GetConnectFee() != 1 {
+	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0257", Cost: utils.NewDecimalFromFloat(2701)}
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 1 {
 		//t.Logf("%+v", result.Timespans[0].RateInterval)
 		t.Errorf("Expected %v was %v", expected, result)
 	}
@@ -385,8 +564,8 @@ func TestMultipleRatingPlans(t *testing.T) {
 	t2 := time.Date(2012, time.February, 8, 18, 30, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0257308200", TimeStart: t1, TimeEnd: t2}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0257", Cost: 2701}
-	if result.Cost != expected.Cost || result.GetConnectFee() != 1 {
+	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0257", Cost: utils.NewDecimalFromFloat(2701)}
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 1 {
 		t.Log(result.Timespans)
 		t.Errorf("Expected %v was %v", expected, result)
 	}
@@ -397,7 +576,7 @@ func TestSpansMultipleRatingPlans(t *testing.T) {
 	t2 := time.Date(2012, time.February, 8, 0, 30, 0, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0257308200", TimeStart: t1, TimeEnd: t2}
 	result, _ := cd.GetCost()
-	if result.Cost != 1200 || result.GetConnectFee() != 0 {
+	if result.Cost.Float64() != 1200 || result.GetConnectFee().Float64() != 0 {
 		t.Errorf("Expected %v was %v", 1200, result)
 	}
 }
@@ -407,8 +586,8 @@ func TestLessThanAMinute(t *testing.T) {
 	t2 := time.Date(2012, time.February, 8, 23, 50, 30, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0257308200", TimeStart: t1, TimeEnd: t2}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0257", Cost: 15}
-	if result.Cost != expected.Cost || result.GetConnectFee() != 0 {
+	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0257", Cost: utils.NewDecimalFromFloat(15)}
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 0 {
 		t.Errorf("Expected %v was %v", expected, result)
 	}
 }
@@ -418,8 +597,8 @@ func TestUniquePrice(t *testing.T) {
 	t2 := time.Date(2012, time.February, 8, 23, 50, 21, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0723045326", TimeStart: t1, TimeEnd: t2}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0723", Cost: 1810.5}
-	if result.Cost != expected.Cost || result.GetConnectFee() != 0 {
+	expected := &CallCost{Tenant: "vdf", Subject: "rif", Destination: "0723", Cost: utils.NewDecimalFromFloat(1810.5)}
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 0 {
 		t.Errorf("Expected %v was %v", expected, result)
 	}
 }
@@ -429,8 +608,8 @@ func TestMinutesCost(t *testing.T) {
 	t2 := time.Date(2012, time.February, 8, 22, 51, 50, 0, time.UTC)
 	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Destination: "0723", TimeStart: t1, TimeEnd: t2}
 	result, _ := cd.GetCost()
-	expected := &CallCost{Tenant: "vdf", Subject: "minutosu", Destination: "0723", Cost: 55}
-	if result.Cost != expected.Cost || result.GetConnectFee() != 0 {
+	expected := &CallCost{Tenant: "vdf", Subject: "minutosu", Destination: "0723", Cost: utils.NewDecimalFromFloat(55)}
+	if !result.Cost.Equal(expected.Cost) || result.GetConnectFee().Float64() != 0 {
 		t.Errorf("Expected %v was %v", expected, result)
 	}
 }
@@ -505,7 +684,7 @@ func TestMaxSessionTimeWithMaxCost(t *testing.T) {
 		Destination:  "0723123113",
 		TimeStart:    time.Date(2015, 3, 23, 6, 0, 0, 0, time.UTC),
 		TimeEnd:      time.Date(2015, 3, 23, 6, 30, 0, 0, time.UTC),
-		MaxCostSoFar: 0,
+		MaxCostSoFar: utils.NewDecimalFromFloat(0),
 	}
 	result, err := cd.GetMaxSessionDuration()
 	expected := 10 * time.Second
@@ -528,11 +707,11 @@ func TestGetCostWithMaxCost(t *testing.T) {
 		Destination:  "0723123113",
 		TimeStart:    time.Date(2015, 3, 23, 6, 0, 0, 0, time.UTC),
 		TimeEnd:      time.Date(2015, 3, 23, 6, 30, 0, 0, time.UTC),
-		MaxCostSoFar: 0,
+		MaxCostSoFar: utils.NewDecimalFromFloat(0),
 	}
 	cc, err := cd.GetCost()
 	expected := 1800.0
-	if cc.Cost != expected || err != nil {
+	if cc.Cost.Float64() != expected || err != nil {
 		t.Errorf("Expected %v was %v", expected, cc.Cost)
 	}
 }
@@ -551,7 +730,7 @@ func TestMaxSessionTimeWithMaxCostFree(t *testing.T) {
 		Destination:  "0723123113",
 		TimeStart:    time.Date(2015, 3, 23, 19, 0, 0, 0, time.UTC),
 		TimeEnd:      time.Date(2015, 3, 23, 19, 30, 0, 0, time.UTC),
-		MaxCostSoFar: 0,
+		MaxCostSoFar: utils.NewDecimalFromFloat(0),
 	}
 	result, err := cd.GetMaxSessionDuration()
 	expected := 30 * time.Minute
@@ -574,11 +753,11 @@ func TestMaxDebitWithMaxCostFree(t *testing.T) {
 		Destination:  "0723123113",
 		TimeStart:    time.Date(2015, 3, 23, 19, 0, 0, 0, time.UTC),
 		TimeEnd:      time.Date(2015, 3, 23, 19, 30, 0, 0, time.UTC),
-		MaxCostSoFar: 0,
+		MaxCostSoFar: utils.NewDecimalFromFloat(0),
 	}
 	cc, err := cd.MaxDebit()
 	expected := 10.0
-	if cc.Cost != expected || err != nil {
+	if cc.Cost.Float64() != expected || err != nil {
 		t.Errorf("Expected %v was %v", expected, cc.Cost)
 	}
 }
@@ -597,12 +776,12 @@ func TestGetCostWithMaxCostFree(t *testing.T) {
 		Destination:  "0723123113",
 		TimeStart:    time.Date(2015, 3, 23, 19, 0, 0, 0, time.UTC),
 		TimeEnd:      time.Date(2015, 3, 23, 19, 30, 0, 0, time.UTC),
-		MaxCostSoFar: 0,
+		MaxCostSoFar: utils.NewDecimalFromFloat(0),
 	}
 
 	cc, err := cd.GetCost()
 	expected := 10.0
-	if cc.Cost != expected || err != nil {
+	if cc.Cost.Float64() != expected || err != nil {
 		t.Errorf("Expected %v was %v", expected, cc.Cost)
 	}
 }
@@ -686,16 +865,16 @@ func TestMaxDebitWithAccountShared(t *testing.T) {
 	}
 
 	cc, err := cd.MaxDebit()
-	if err != nil || cc.Cost != 2.5 {
+	if err != nil || cc.Cost.Float64() != 2.5 {
 		t.Errorf("Wrong callcost in shared debit: %+v, %v", cc, err)
 	}
 	acc, _ := cd.getAccount()
 	balanceMap := acc.BalanceMap[utils.MONETARY+OUTBOUND]
-	if len(balanceMap) != 1 || balanceMap[0].Value != 0 {
+	if len(balanceMap) != 1 || balanceMap[0].Value.Float64() != 0 {
 		t.Errorf("Wrong shared balance debited: %+v", balanceMap[0])
 	}
 	other, err := accountingStorage.GetAccount("*out:vdf:empty10")
-	if err != nil || other.BalanceMap[utils.MONETARY+OUTBOUND][0].Value != 7.5 {
+	if err != nil || other.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Float64() != 7.5 {
 		t.Errorf("Error debiting shared balance: %+v", other.BalanceMap[utils.MONETARY+OUTBOUND][0])
 	}
 }
@@ -869,11 +1048,11 @@ func TestDebitFromShareAndNormal(t *testing.T) {
 	cc, err := cd.MaxDebit()
 	acc, _ := cd.getAccount()
 	balanceMap := acc.BalanceMap[utils.MONETARY+OUTBOUND]
-	if err != nil || cc.Cost != 2.5 {
+	if err != nil || cc.Cost.Float64() != 2.5 {
 		t.Errorf("Debit from share and normal error: %+v, %v", cc, err)
 	}
 
-	if balanceMap[0].Value != 10 || balanceMap[1].Value != 27.5 {
+	if balanceMap[0].Value.Float64() != 10 || balanceMap[1].Value.Float64() != 27.5 {
 		t.Errorf("Error debiting from right balance: %v %v", balanceMap[0].Value, balanceMap[1].Value)
 	}
 }
@@ -896,12 +1075,12 @@ func TestDebitFromEmptyShare(t *testing.T) {
 	}
 
 	cc, err := cd.MaxDebit()
-	if err != nil || cc.Cost != 2.5 {
+	if err != nil || cc.Cost.Float64() != 2.5 {
 		t.Errorf("Debit from empty share error: %+v, %v", cc, err)
 	}
 	acc, _ := cd.getAccount()
 	balanceMap := acc.BalanceMap[utils.MONETARY+OUTBOUND]
-	if len(balanceMap) != 2 || balanceMap[0].Value != 0 || balanceMap[1].Value != -2.5 {
+	if len(balanceMap) != 2 || balanceMap[0].Value.Float64() != 0 || balanceMap[1].Value.Float64() != -2.5 {
 		t.Errorf("Error debiting from empty share: %+v", balanceMap[1].Value)
 	}
 }
@@ -929,7 +1108,7 @@ func TestMaxDebitZeroDefinedRate(t *testing.T) {
 	if cc.GetDuration() != 49*time.Second {
 		t.Error("Error obtaining max debit duration: ", cc.GetDuration())
 	}
-	if cc.Cost != 0.91 {
+	if cc.Cost.Float64() != 0.91 {
 		t.Error("Error in max debit cost: ", cc.Cost)
 	}
 }
@@ -957,7 +1136,7 @@ func TestMaxDebitZeroDefinedRateOnlyMinutes(t *testing.T) {
 	if cc.GetDuration() != 40*time.Second {
 		t.Error("Error obtaining max debit duration: ", cc.GetDuration())
 	}
-	if cc.Cost != 0.01 {
+	if cc.Cost.Float64() != 0.01 {
 		t.Error("Error in max debit cost: ", cc.Cost)
 	}
 }
@@ -979,7 +1158,7 @@ func TestMaxDebitConsumesMinutes(t *testing.T) {
 		LoopIndex:     0,
 		DurationIndex: 0}
 	cd1.MaxDebit()
-	if cd1.account.BalanceMap[utils.VOICE+OUTBOUND][0].Value != 20 {
+	if cd1.account.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Float64() != 20 {
 		t.Error("Error using minutes: ", cd1.account.BalanceMap[utils.VOICE+OUTBOUND][0].Value)
 	}
 }
@@ -996,7 +1175,7 @@ func TestCDGetCostANY(t *testing.T) {
 		TOR:         utils.DATA,
 	}
 	cc, err := cd1.GetCost()
-	if err != nil || cc.Cost != 60 {
+	if err != nil || cc.Cost.Float64() != 60 {
 		t.Errorf("Error getting *any dest: %+v %v", cc, err)
 	}
 }
@@ -1033,7 +1212,7 @@ func TestCDDataGetCost(t *testing.T) {
 		TOR:         utils.DATA,
 	}
 	cc, err := cd.GetCost()
-	if err != nil || cc.Cost != 65 {
+	if err != nil || cc.Cost.Float64() != 65 {
 		t.Errorf("Error getting *any dest: %+v %v", cc, err)
 	}
 }
@@ -1101,3 +1280,18 @@ func BenchmarkStorageMultipleGetSessionTime(b *testing.B) {
 		cd.GetMaxSessionDuration()
 	}
 }
+
+func TestCallDescriptorRefundLockIds(t *testing.T) {
+	accountingStorage.SetAccount(&Account{Id: "*out:cgrates.org:refund_reseller"})
+	accountingStorage.SetAccount(&Account{Id: "*out:cgrates.org:refund_member", ParentId: "*out:cgrates.org:refund_reseller"})
+	cd := &CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Account: "refund_member", Increments: Increments{
+		&Increment{BalanceInfo: &BalanceInfo{AccountId: "*out:cgrates.org:refund_member", Shares: []*BalanceShare{
+			&BalanceShare{AccountId: "*out:cgrates.org:refund_member"}, &BalanceShare{AccountId: "*out:cgrates.org:refund_company"}}}},
+		&Increment{BalanceInfo: &BalanceInfo{AccountId: "*out:cgrates.org:refund_shared"}},
+	}}
+	expected := []string{"*out:cgrates.org:refund_member", "*out:cgrates.org:refund_company",
+		"*out:cgrates.org:refund_shared", "*out:cgrates.org:refund_reseller"}
+	if lockIds := cd.getRefundLockIds(); !reflect.DeepEqual(lockIds, expected) {
+		t.Errorf("Wrong refund lock ids: %v", lockIds)
+	}
+}
@@ -203,13 +203,16 @@ func (self *CdrServer) deriveAndRateCdr(storedCdr *StoredCdr) ([]*StoredCdr, err
 	if err != nil {
 		return nil, err
 	}
+	var resellerCdrs []*StoredCdr
 	for _, cdr := range cdrRuns {
 		if err := self.rateCDR(cdr); err != nil {
-			cdr.Cost = -1.0 // If there was an error, mark the CDR
+			cdr.Cost = utils.NewDecimalFromInt(-1) // If there was an error, mark the CDR
 			cdr.ExtraInfo = err.Error()
+			continue
 		}
+		resellerCdrs = append(resellerCdrs, cdr.resellerCdrs()...)
 	}
-	return cdrRuns, nil
+	return append(cdrRuns, resellerCdrs...), nil
 }
 
 // Retrive the cost from logging database, nil in case of no log
@@ -239,6 +242,8 @@ func (self *CdrServer) getCostFromRater(storedCdr *StoredCdr) (*CallCost, error)
 		Subject:       storedCdr.Subject,
 		Account:       storedCdr.Account,
 		Destination:   storedCdr.Destination,
+		Origin:        storedCdr.Origin,
+		CgrId:         storedCdr.CgrId,
 		TimeStart:     storedCdr.AnswerTime,
 		TimeEnd:       storedCdr.AnswerTime.Add(storedCdr.Usage),
 		DurationIndex: storedCdr.Usage,
@@ -296,8 +301,9 @@ func (self *CdrServer) deriveCdrs(storedCdr *StoredCdr) ([]*StoredCdr, error) {
 		dcDurFld, _ := utils.NewRSRField(dc.UsageField)
 		dcSupplFld, _ := utils.NewRSRField(dc.SupplierField)
 		dcDCausseld, _ := utils.NewRSRField(dc.DisconnectCauseField)
+		dcOriginFld, _ := utils.NewRSRField(dc.OriginField)
 		forkedCdr, err := storedCdr.ForkCdr(dc.RunId, dcReqTypeFld, dcDirFld, dcTenantFld, dcCategoryFld, dcAcntFld, dcSubjFld, dcDstFld,
-			dcSTimeFld, dcPddFld, dcATimeFld, dcDurFld, dcSupplFld, dcDCausseld, []*utils.RSRField{}, true)
+			dcSTimeFld, dcPddFld, dcATimeFld, dcDurFld, dcSupplFld, dcDCausseld, dcOriginFld, []*utils.RSRField{}, true)
 		if err != nil {
 			Logger.Err(fmt.Sprintf("Could not fork CGR with cgrid %s, run: %s, error: %s", storedCdr.CgrId, dc.RunId, err.Error()))
 			continue // do not add it to the forked CDR list
@@ -323,6 +329,8 @@ func (self *CdrServer) rateCDR(storedCdr *StoredCdr) error {
 		}
 		if err != nil { //calculate CDR as for pseudoprepaid
 			qryCC, errCost = self.getCostFromRater(storedCdr)
+		} else if errCost == nil && qryCC != nil && len(qryCC.Taxes) == 0 { // taxes are not logged together with the costs
+			errCost = qryCC.applyTaxes()
 		}
 
 	} else {
@@ -333,6 +341,10 @@ func (self *CdrServer) rateCDR(storedCdr *StoredCdr) error {
 	} else if qryCC != nil {
 		storedCdr.Cost = qryCC.Cost
 		storedCdr.CostDetails = qryCC
+		storedCdr.Taxes = qryCC.Taxes
+		if qryCC.Destination != "" && qryCC.Destination != storedCdr.Destination { // ported number, rated on the routing one
+			storedCdr.RoutingNumber = qryCC.Destination
+		}
 	}
 	return nil
 }
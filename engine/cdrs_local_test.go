@@ -96,7 +96,7 @@ func TestCdrsHttpJsonRpcCdrReplication(t *testing.T) {
 		Direction: "*out", Tenant: "cgrates.org", Category: "call", Account: "1001", Subject: "1001", Destination: "1002",
 		SetupTime: time.Date(2013, 12, 7, 8, 42, 24, 0, time.UTC), AnswerTime: time.Date(2013, 12, 7, 8, 42, 26, 0, time.UTC),
 		Usage: time.Duration(10) * time.Second, ExtraFields: map[string]string{"field_extr1": "val_extr1", "fieldextr2": "valextr2"},
-		MediationRunId: utils.DEFAULT_RUNID, Cost: 1.201, Rated: true}
+		MediationRunId: utils.DEFAULT_RUNID, Cost: utils.NewDecimalFromFloat(1.201), Rated: true}
 	var reply string
 	if err := cdrsHttpJsonRpc.Call("CdrsV2.ProcessCdr", testCdr1, &reply); err != nil {
 		t.Error("Unexpected error: ", err.Error())
@@ -127,7 +127,7 @@ func TestCdrsHttpJsonRpcCdrReplication(t *testing.T) {
 			!rcvAnswerTime.Equal(testCdr1.AnswerTime) ||
 			rcvUsage != testCdr1.Usage ||
 			rcvedCdrs[0].MediationRunId != testCdr1.MediationRunId ||
-			rcvedCdrs[0].Cost != testCdr1.Cost ||
+			!rcvedCdrs[0].Cost.Equal(testCdr1.Cost) ||
 			!reflect.DeepEqual(rcvedCdrs[0].ExtraFields, testCdr1.ExtraFields) {
 			t.Error("Received: ", rcvedCdrs[0])
 		}
@@ -19,7 +19,6 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
-	"strings"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -78,6 +77,7 @@ type CdrStats struct {
 	RatedSubject      []string        // CDRFieldFilter on RatedSubjects
 	CostInterval      []float64       // CDRFieldFilter on CostInterval, 2 or less items, (>=Cost, <Cost)
 	Triggers          ActionTriggerPriotityList
+	destPrefixIdx     *utils.PrefixTrie // DestinationPrefix indexed on first CDR filtered
 }
 
 func (cs *CdrStats) AcceptCdr(cdr *StoredCdr) bool {
@@ -120,14 +120,13 @@ func (cs *CdrStats) AcceptCdr(cdr *StoredCdr) bool {
 		return false
 	}
 	if len(cs.DestinationPrefix) > 0 {
-		found := false
-		for _, prefix := range cs.DestinationPrefix {
-			if strings.HasPrefix(cdr.Destination, prefix) {
-				found = true
-				break
+		if cs.destPrefixIdx == nil {
+			cs.destPrefixIdx = utils.NewPrefixTrie()
+			for _, prefix := range cs.DestinationPrefix {
+				cs.destPrefixIdx.Add(prefix, prefix)
 			}
 		}
-		if !found {
+		if cs.destPrefixIdx.LongestPrefix(cdr.Destination) == "" && cs.destPrefixIdx.Get("") == nil {
 			return false
 		}
 	}
@@ -157,10 +156,11 @@ func (cs *CdrStats) AcceptCdr(cdr *StoredCdr) bool {
 		return false
 	}
 	if len(cs.CostInterval) > 0 {
-		if cdr.Cost < cs.CostInterval[0] {
+		cost := cdr.Cost.Float64()
+		if cost < cs.CostInterval[0] {
 			return false
 		}
-		if len(cs.CostInterval) > 1 && cdr.Cost >= cs.CostInterval[1] {
+		if len(cs.CostInterval) > 1 && cost >= cs.CostInterval[1] {
 			return false
 		}
 	}
@@ -72,7 +72,8 @@ func (cgrCdr CgrCdr) AsStoredCdr() *StoredCdr {
 	storCdr.AnswerTime, _ = utils.ParseTimeDetectLayout(cgrCdr[utils.ANSWER_TIME])
 	storCdr.Usage, _ = utils.ParseDurationWithSecs(cgrCdr[utils.USAGE])
 	storCdr.Supplier = cgrCdr[utils.SUPPLIER]
+	storCdr.Origin = cgrCdr[utils.ORIGIN]
 	storCdr.ExtraFields = cgrCdr.getExtraFields()
-	storCdr.Cost = -1
+	storCdr.Cost = utils.NewDecimalFromInt(-1)
 	return storCdr
 }
@@ -0,0 +1,157 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Periods after which the counters reset themselves
+const (
+	WINDOW_DAILY   = "*daily"
+	WINDOW_WEEKLY  = "*weekly"
+	WINDOW_MONTHLY = "*monthly" // *monthly:<day> starts the window on the day of the month
+	WINDOW_ROLLING = "*rolling" // *rolling:<days> windows counted from the first one
+)
+
+// Reset window of a counter
+type counterWindow struct {
+	period string
+	value  int // day of the month or number of days
+}
+
+func parseCounterWindow(window string) (*counterWindow, error) {
+	cw := &counterWindow{period: strings.TrimSpace(window)}
+	if idx := strings.Index(cw.period, utils.CONCATENATED_KEY_SEP); idx != -1 {
+		value, err := strconv.Atoi(cw.period[idx+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid counter window: %s", window)
+		}
+		cw.period, cw.value = cw.period[:idx], value
+	}
+	switch cw.period {
+	case WINDOW_DAILY, WINDOW_WEEKLY:
+		if cw.value != 0 {
+			return nil, fmt.Errorf("invalid counter window: %s", window)
+		}
+	case WINDOW_MONTHLY:
+		if cw.value == 0 {
+			cw.value = 1
+		}
+		if cw.value < 1 || cw.value > 28 { // present in every month
+			return nil, fmt.Errorf("invalid counter window day: %s", window)
+		}
+	case WINDOW_ROLLING:
+		if cw.value < 1 {
+			return nil, fmt.Errorf("invalid counter window days: %s", window)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported counter window: %s", window)
+	}
+	return cw, nil
+}
+
+// Returns the start of the window containing t, the rolling windows are counted from the anchor
+func (cw *counterWindow) getStart(t, anchor time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch cw.period {
+	case WINDOW_WEEKLY:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)) // weeks start on monday
+	case WINDOW_MONTHLY:
+		start := time.Date(t.Year(), t.Month(), cw.value, 0, 0, 0, 0, t.Location())
+		if start.After(t) {
+			start = start.AddDate(0, -1, 0)
+		}
+		return start
+	case WINDOW_ROLLING:
+		if anchor.IsZero() {
+			return day
+		}
+		length := time.Duration(cw.value) * 24 * time.Hour
+		windows := t.Sub(anchor) / length
+		if t.Before(anchor) && t.Sub(anchor)%length != 0 {
+			windows--
+		}
+		return anchor.Add(windows * length)
+	}
+	return day
+}
+
+// Parameters of the *reset_counter(s) actions
+type counterParameters struct {
+	ResetWindow string // empty leaves the counter reset only through the actions
+}
+
+// Sets the reset window from the action extra parameters, starting it now
+func (uc *UnitsCounter) setResetWindow(extraParameters string) error {
+	var params counterParameters
+	if err := json.Unmarshal([]byte(extraParameters), &params); err != nil {
+		return err
+	}
+	uc.ResetWindow, uc.WindowStart = "", time.Time{}
+	if params.ResetWindow == "" {
+		return nil
+	}
+	cw, err := parseCounterWindow(params.ResetWindow)
+	if err != nil {
+		return err
+	}
+	uc.ResetWindow, uc.WindowStart = params.ResetWindow, cw.getStart(time.Now(), time.Time{})
+	return nil
+}
+
+// Clears the counted units if t is past the current window, returns true if it did
+func (uc *UnitsCounter) checkWindow(t time.Time) bool {
+	if uc.ResetWindow == "" {
+		return false
+	}
+	cw, err := parseCounterWindow(uc.ResetWindow)
+	if err != nil {
+		return false
+	}
+	start := cw.getStart(t, uc.WindowStart)
+	if !start.After(uc.WindowStart) {
+		return false
+	}
+	for _, b := range uc.Balances {
+		b.Value = utils.Decimal{}
+	}
+	uc.WindowStart = start
+	return true
+}
+
+// Starts new windows on the counters past their boundary, re-arming their counter triggers
+func (ub *Account) checkCounterWindows(t time.Time) {
+	for _, uc := range ub.UnitCounters {
+		if !uc.checkWindow(t) {
+			continue
+		}
+		for _, at := range ub.ActionTriggers {
+			if strings.Contains(at.ThresholdType, "counter") && at.BalanceType == uc.BalanceType &&
+				(at.BalanceDirection == uc.Direction || at.BalanceDirection == "") {
+				at.Executed = false
+			}
+		}
+	}
+}
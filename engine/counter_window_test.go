@@ -0,0 +1,99 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestCounterWindowGetStart(t *testing.T) {
+	now := time.Date(2015, time.March, 11, 14, 30, 0, 0, time.UTC) // wednesday
+	for window, expected := range map[string]time.Time{
+		"*daily":      time.Date(2015, time.March, 11, 0, 0, 0, 0, time.UTC),
+		"*weekly":     time.Date(2015, time.March, 9, 0, 0, 0, 0, time.UTC),
+		"*monthly":    time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC),
+		"*monthly:15": time.Date(2015, time.February, 15, 0, 0, 0, 0, time.UTC),
+		"*rolling:7":  time.Date(2015, time.March, 8, 0, 0, 0, 0, time.UTC),
+	} {
+		cw, err := parseCounterWindow(window)
+		if err != nil {
+			t.Fatal("Error parsing window: ", err)
+		}
+		if start := cw.getStart(now, time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC)); !start.Equal(expected) {
+			t.Errorf("Wrong start for %s: %v", window, start)
+		}
+	}
+	for _, window := range []string{"*hourly", "*monthly:31", "*rolling", "*daily:2", "*rolling:x"} {
+		if _, err := parseCounterWindow(window); err == nil {
+			t.Error("Expecting error for window: ", window)
+		}
+	}
+}
+
+func TestCounterWindowCheck(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:counted",
+		UnitCounters: []*UnitsCounter{&UnitsCounter{BalanceType: utils.MONETARY, Direction: OUTBOUND, ResetWindow: "*daily",
+			WindowStart: time.Now().AddDate(0, 0, -1), Balances: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(10)}}}},
+		ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{ThresholdType: TRIGGER_MAX_COUNTER, BalanceType: utils.MONETARY,
+			BalanceDirection: OUTBOUND, ThresholdValue: 5, Executed: true}},
+	}
+	acc.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(1)}})
+	if uc := acc.UnitCounters[0]; uc.Balances[0].Value.String() != "1" || uc.WindowStart.Before(time.Now().AddDate(0, 0, -1)) {
+		t.Errorf("Counter not reset on the new window: %+v", uc.Balances[0])
+	}
+	if acc.ActionTriggers[0].Executed {
+		t.Error("Counter trigger not re-armed on the new window")
+	}
+	acc.countUnits(&Action{BalanceType: utils.MONETARY, Direction: OUTBOUND, Balance: &Balance{Value: utils.NewDecimalFromFloat(2)}})
+	if uc := acc.UnitCounters[0]; uc.Balances[0].Value.String() != "3" {
+		t.Errorf("Counter reset inside the window: %+v", uc.Balances[0])
+	}
+}
+
+func TestCounterWindowResetAction(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:counted"}
+	a := &Action{ActionType: RESET_COUNTER, BalanceType: utils.VOICE, Direction: OUTBOUND, ExtraParameters: `{"ResetWindow":"*rolling:30"}`}
+	if err := resetCounterAction(acc, nil, a, nil); err != nil {
+		t.Fatal("Error resetting counter: ", err)
+	}
+	if uc := acc.getUnitCounter(a); uc == nil || uc.ResetWindow != "*rolling:30" || uc.WindowStart.IsZero() {
+		t.Errorf("Wrong counter window: %+v", uc)
+	}
+	if uc := acc.getUsageCounter(OUTBOUND, utils.VOICE); uc == nil || uc.ResetWindow != "*rolling:30" {
+		t.Errorf("Wrong usage counter window: %+v", uc)
+	}
+	a.ExtraParameters = `{"ResetWindow":"*yearly"}`
+	if err := resetCounterAction(acc, nil, a, nil); err == nil {
+		t.Error("Expecting error on unsupported window")
+	}
+}
+
+func TestCounterWindowUsage(t *testing.T) {
+	uc := &UsageCounter{Direction: OUTBOUND, TOR: utils.VOICE, ResetWindow: "*monthly:15"}
+	uc.addUsage(time.Minute, time.Date(2015, time.March, 14, 10, 0, 0, 0, time.UTC))
+	uc.addUsage(time.Minute, time.Date(2015, time.March, 15, 10, 0, 0, 0, time.UTC))
+	if usage := uc.getUsage(time.Date(2015, time.April, 1, 0, 0, 0, 0, time.UTC)); usage != time.Minute {
+		t.Error("Wrong usage in the window: ", usage)
+	}
+	if usage := uc.getUsage(time.Date(2015, time.April, 15, 0, 0, 0, 0, time.UTC)); usage != 0 {
+		t.Error("Wrong usage in the next window: ", usage)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// How far the monetary balances of one direction can go below zero
+type CreditLimit struct {
+	Amount    utils.Decimal // the maximum debt
+	Unlimited bool          // postpaid without limit
+}
+
+// Returns the credit limit on the direction, nil if the account cannot go negative
+func (acc *Account) GetCreditLimit(direction string) *CreditLimit {
+	if cl, found := acc.CreditLimits[direction]; found && (cl.Unlimited || cl.Amount.Sign() > 0) {
+		return cl
+	}
+	return nil
+}
+
+// Sets the credit limit on the direction, nil or zero removes it
+func (acc *Account) SetCreditLimit(direction string, cl *CreditLimit) {
+	if cl == nil || (!cl.Unlimited && cl.Amount.Sign() <= 0) {
+		delete(acc.CreditLimits, direction)
+		return
+	}
+	if acc.CreditLimits == nil {
+		acc.CreditLimits = make(map[string]*CreditLimit)
+	}
+	acc.CreditLimits[direction] = cl
+}
+
+// The balances without value are considered only for the directions allowed to go negative
+func (acc *Account) allowsNegative(direction string) bool {
+	return acc.GetCreditLimit(direction) != nil
+}
+
+// Returns the debt allowed on the direction, unlimited is true for the postpaid accounts without limit
+func (acc *Account) getCreditLimitAmount(direction string) (amount utils.Decimal, unlimited bool) {
+	if cl := acc.GetCreditLimit(direction); cl != nil {
+		return cl.Amount, cl.Unlimited
+	}
+	return
+}
+
+// Money that can still be spent: the monetary balances plus the credit limit minus the holds
+func (acc *Account) getAvailableCredit(direction string) (credit utils.Decimal, unlimited bool) {
+	limit, unlimited := acc.getCreditLimitAmount(direction)
+	credit = acc.BalanceMap[utils.MONETARY+direction].GetTotalValue().Add(limit).Sub(acc.Reservations.GetTotal(direction))
+	return
+}
+
+// The debt on the default balance went over the credit limit
+func (acc *Account) isOverCreditLimit(direction string) bool {
+	limit, unlimited := acc.getCreditLimitAmount(direction)
+	if unlimited {
+		return false
+	}
+	return acc.GetDefaultMoneyBalance(direction).Value.Add(limit).Sign() < 0
+}
+
+// Adds the credit limit to the default balance, used on the cloned accounts so the dry runs can spend it
+func (acc *Account) applyCreditLimit(direction string) {
+	if limit, _ := acc.getCreditLimitAmount(direction); limit.Sign() > 0 {
+		b := acc.GetDefaultMoneyBalance(direction)
+		b.Value = b.Value.Add(limit)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/cache2go"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestCreditLimitAvailableCredit(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:limited", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(-3)}},
+	}}
+	if acc.GetCreditLimit(OUTBOUND) != nil || !acc.isOverCreditLimit(OUTBOUND) {
+		t.Error("Not expecting credit limit")
+	}
+	acc.SetCreditLimit(OUTBOUND, &CreditLimit{Amount: utils.NewDecimalFromFloat(5)})
+	if credit, unlimited := acc.getAvailableCredit(OUTBOUND); unlimited || credit.String() != "2" {
+		t.Errorf("Wrong available credit: %v, %v", credit, unlimited)
+	}
+	if acc.isOverCreditLimit(OUTBOUND) {
+		t.Error("Not expecting to be over the credit limit")
+	}
+	cd := &CallDescriptor{Direction: OUTBOUND, Category: "0", Destination: "0723", TOR: utils.VOICE}
+	if _, credit, _ := acc.getCreditForPrefix(cd); credit.String() != "2" {
+		t.Error("Wrong credit with the limit: ", credit)
+	}
+	acc.SetCreditLimit(OUTBOUND, &CreditLimit{})
+	if len(acc.CreditLimits) != 0 {
+		t.Errorf("Zero credit limit not removed: %+v", acc.CreditLimits)
+	}
+}
+
+func TestCreditLimitMaxSessionDuration(t *testing.T) {
+	cd := &CallDescriptor{
+		TimeStart:   time.Date(2013, 10, 21, 18, 34, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2013, 10, 21, 18, 35, 0, 0, time.UTC),
+		Direction:   OUTBOUND,
+		Category:    "0",
+		Tenant:      "vdf",
+		Subject:     "minu_from_tm",
+		Account:     "luna",
+		Destination: "0723",
+	}
+	acc, _ := accountingStorage.GetAccount("*out:vdf:luna")
+	acc.SetCreditLimit(OUTBOUND, &CreditLimit{Amount: utils.NewDecimalFromFloat(100)})
+	if allowedTime, err := cd.getMaxSessionDuration(acc); err != nil || allowedTime == 0 {
+		t.Errorf("Wrong max session duration with credit limit: %v, %v", allowedTime, err)
+	}
+	acc.SetCreditLimit(OUTBOUND, &CreditLimit{Unlimited: true})
+	if allowedTime, err := cd.getMaxSessionDuration(acc); err != nil || allowedTime != -1 {
+		t.Errorf("Wrong max session duration without limit: %v, %v", allowedTime, err)
+	}
+}
+
+func TestCreditLimitAllowNegativeAction(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:limited"}
+	allowNegativeAction(acc, nil, &Action{ActionType: ALLOW_NEGATIVE, Balance: &Balance{Value: utils.NewDecimalFromFloat(10)}}, nil)
+	if cl := acc.GetCreditLimit(OUTBOUND); cl == nil || cl.Unlimited || cl.Amount.String() != "10" {
+		t.Errorf("Wrong credit limit: %+v", cl)
+	}
+	allowNegativeAction(acc, nil, &Action{ActionType: ALLOW_NEGATIVE, Direction: INBOUND}, nil)
+	if cl := acc.GetCreditLimit(INBOUND); cl == nil || !cl.Unlimited {
+		t.Errorf("Wrong inbound credit limit: %+v", cl)
+	}
+	denyNegativeAction(acc, nil, &Action{ActionType: DENY_NEGATIVE}, nil)
+	if acc.GetCreditLimit(OUTBOUND) != nil || acc.GetCreditLimit(INBOUND) == nil {
+		t.Errorf("Wrong credit limits after deny: %+v", acc.CreditLimits)
+	}
+}
+
+func TestCreditLimitTrigger(t *testing.T) {
+	accountingStorage.SetActions("CREDIT_LOW", Actions{&Action{ActionType: LOG}})
+	accountingStorage.GetActions("CREDIT_LOW", true) // cache it
+	defer cache2go.RemKey(ACTION_PREFIX + "CREDIT_LOW")
+	acc := &Account{Id: "*out:cgrates.org:limited", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(-8), dirty: true}},
+	}, ActionTriggers: ActionTriggerPriotityList{&ActionTrigger{Id: "low", ThresholdType: TRIGGER_MIN_CREDIT, ThresholdValue: 2,
+		BalanceType: utils.MONETARY, BalanceDirection: OUTBOUND, ActionsId: "CREDIT_LOW"}}}
+	acc.SetCreditLimit(OUTBOUND, &CreditLimit{Amount: utils.NewDecimalFromFloat(20)})
+	acc.executeActionTriggers(nil)
+	if acc.ActionTriggers[0].Executed {
+		t.Error("Trigger executed with credit left")
+	}
+	acc.BalanceMap[utils.MONETARY+OUTBOUND][0].Value = utils.NewDecimalFromFloat(-18.5)
+	acc.executeActionTriggers(nil)
+	if !acc.ActionTriggers[0].Executed {
+		t.Error("Trigger not executed on low credit")
+	}
+}
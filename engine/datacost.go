@@ -17,16 +17,19 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 */
 package engine
 
+import "github.com/cgrates/cgrates/utils"
+
 // type used for showing sane data cost
 type DataCost struct {
 	Direction, Category, Tenant, Subject, Account, Destination, TOR string
-	Cost                                                            float64
+	Cost                                                            utils.Decimal
 	DataSpans                                                       []*DataSpan
+	Taxes                                                           TaxLines
 	deductConnectFee                                                bool
 }
 type DataSpan struct {
 	DataStart, DataEnd                                         float64
-	Cost                                                       float64
+	Cost                                                       utils.Decimal
 	ratingInfo                                                 *RatingInfo
 	RateInterval                                               *RateInterval
 	DataIndex                                                  float64 // the data transfer so far till DataEnd
@@ -36,7 +39,7 @@ type DataSpan struct {
 
 type DataIncrement struct {
 	Amount              float64
-	Cost                float64
+	Cost                utils.Decimal
 	BalanceInfo         *BalanceInfo // need more than one for units with cost
 	BalanceRateInterval *RateInterval
 	UnitInfo            *UnitInfo
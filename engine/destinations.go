@@ -20,32 +20,58 @@ package engine
 
 import (
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
-	"github.com/cgrates/cgrates/cache2go"
+	"github.com/cgrates/cgrates/utils"
 
 	"github.com/cgrates/cgrates/history"
 )
 
+const (
+	DESTINATION_RANGE_SEP = "-"
+)
+
 /*
 Structure that gathers multiple destination prefixes under a common id.
+Besides the prefixes, the entries can be number ranges (4420700000-4420799999)
+or anchored regular expressions marked with ~ (~^1\d{2}$).
 */
 type Destination struct {
 	Id       string
 	Prefixes []string
 }
 
-// returns prefix precision
-func (d *Destination) containsPrefix(prefix string) int {
+// returns the highest precision of the entries matching the prefix, 0 if none matches
+func (d *Destination) containsPrefix(prefix string) (precision int) {
 	if d == nil {
 		return 0
 	}
 	for _, p := range d.Prefixes {
-		if strings.Index(prefix, p) == 0 {
-			return len(p)
+		if isNumberPattern(p) {
+			if np, err := newNumberPattern(p, d.Id); err == nil && np.match(prefix) && np.precision > precision {
+				precision = np.precision
+			}
+		} else if strings.HasPrefix(prefix, p) && len(p) > precision {
+			precision = len(p)
+		}
+	}
+	return
+}
+
+// Returns the first entry which can not be parsed, empty if all are valid
+func (d *Destination) getFirstInvalidPrefix() string {
+	for _, p := range d.Prefixes {
+		if isNumberPattern(p) {
+			if _, err := newNumberPattern(p, d.Id); err != nil {
+				return p
+			}
 		}
 	}
-	return 0
+	return ""
 }
 
 func (d *Destination) String() (result string) {
@@ -71,37 +97,225 @@ func (d *Destination) GetHistoryRecord() history.Record {
 	}
 }
 
-// Reverse search in cache to see if prefix belongs to destination id
+// Reverse search in destinations index to see if prefix belongs to destination id
 func CachedDestHasPrefix(destId, prefix string) bool {
-	if cached, err := cache2go.GetCached(DESTINATION_PREFIX + prefix); err == nil {
-		_, found := cached.(map[interface{}]struct{})[destId]
-		return found
+	return destIndex.HasPrefix(destId, prefix)
+}
+
+// Number of prefixes and patterns of the cached destinations
+func CachedDestinationsCount() int {
+	return destIndex.Len()
+}
+
+// Destination entry matched on the whole number instead of the prefix
+type numberPattern struct {
+	entry      string
+	destId     string
+	start, end string         // number range bounds, same length
+	re         *regexp.Regexp // anchored regular expression
+	precision  int            // digits fixed by the pattern, compared with the prefix lengths
+}
+
+func isNumberPattern(entry string) bool {
+	return strings.HasPrefix(entry, utils.REGEXP_PREFIX) || strings.Contains(entry, DESTINATION_RANGE_SEP)
+}
+
+// Parses a number range or a regular expression entry of the destination
+func newNumberPattern(entry, destId string) (*numberPattern, error) {
+	np := &numberPattern{entry: entry, destId: destId}
+	if strings.HasPrefix(entry, utils.REGEXP_PREFIX) {
+		expr := entry[len(utils.REGEXP_PREFIX):]
+		if !strings.HasPrefix(expr, "^") || !strings.HasSuffix(expr, "$") {
+			return nil, fmt.Errorf("regular expression not anchored: %s", entry)
+		}
+		var err error
+		if np.re, err = regexp.Compile(expr); err != nil {
+			return nil, err
+		}
+		literal, _ := np.re.LiteralPrefix()
+		np.precision = len(literal)
+	} else {
+		bounds := strings.Split(entry, DESTINATION_RANGE_SEP)
+		if len(bounds) != 2 || len(bounds[0]) != len(bounds[1]) {
+			return nil, fmt.Errorf("invalid number range: %s", entry)
+		}
+		np.start, np.end = bounds[0], bounds[1]
+		startDigits, endDigits := strings.TrimPrefix(np.start, "+"), strings.TrimPrefix(np.end, "+")
+		if len(np.start)-len(startDigits) != len(np.end)-len(endDigits) {
+			return nil, fmt.Errorf("invalid number range: %s", entry)
+		}
+		start, err := strconv.ParseUint(startDigits, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number range: %s", entry)
+		}
+		end, err := strconv.ParseUint(endDigits, 10, 64)
+		if err != nil || end < start {
+			return nil, fmt.Errorf("invalid number range: %s", entry)
+		}
+		// 4420700000-4420799999 is as precise as the 44207 prefix
+		np.precision = len(np.start) - len(strconv.FormatUint(end-start, 10))
 	}
-	return false
+	// patterns without fixed digits still count as matches
+	if np.precision < MIN_PREFIX_MATCH {
+		np.precision = MIN_PREFIX_MATCH
+	}
+	return np, nil
+}
+
+// Ranges match the numbers starting with a value between the bounds, regular expressions the whole number
+func (np *numberPattern) match(number string) bool {
+	if np.re != nil {
+		return np.re.MatchString(number)
+	}
+	if len(number) < len(np.start) {
+		return false
+	}
+	number = number[:len(np.start)]
+	return number >= np.start && number <= np.end
+}
+
+// regular expressions win over ranges having the same precision
+func (np *numberPattern) rank() int {
+	if np.re != nil {
+		return 2
+	}
+	return 1
+}
+
+// Index over the cached destinations used by the rating path
+var destIndex = NewDestinationIndex()
+
+// Prefix trie mapping the prefixes to the ids of destinations containing them,
+// the number ranges and regular expressions are checked one by one.
+// Rebuilt by CacheRating and updated incrementally on SetDestination.
+type DestinationIndex struct {
+	mux      sync.RWMutex
+	trie     *utils.PrefixTrie
+	patterns []*numberPattern
+	prefixes map[string][]string // indexed prefixes for each destination id, needed on updates
+}
+
+func NewDestinationIndex() *DestinationIndex {
+	return &DestinationIndex{trie: utils.NewPrefixTrie(), prefixes: make(map[string][]string)}
 }
 
-func CleanStalePrefixes(destIds []string) {
-	prefixMap, err := cache2go.GetAllEntries(DESTINATION_PREFIX)
+// Replaces the whole index content with the destinations received
+func (di *DestinationIndex) Reset(dests []*Destination) {
+	trie := utils.NewPrefixTrie()
+	var patterns []*numberPattern
+	prefixes := make(map[string][]string, len(dests))
+	for _, dest := range dests {
+		for _, p := range dest.Prefixes {
+			if np := parseIndexedPattern(p, dest.Id); np != nil {
+				patterns = append(patterns, np)
+			} else if !isNumberPattern(p) {
+				trie.Add(p, dest.Id)
+			}
+		}
+		prefixes[dest.Id] = dest.Prefixes
+	}
+	di.mux.Lock()
+	di.trie, di.patterns, di.prefixes = trie, patterns, prefixes
+	di.mux.Unlock()
+}
+
+// Indexes the destination, replacing the prefixes previously indexed for it
+func (di *DestinationIndex) SetDestination(dest *Destination) {
+	di.mux.Lock()
+	defer di.mux.Unlock()
+	di.removeDestination(dest.Id)
+	for _, p := range dest.Prefixes {
+		if np := parseIndexedPattern(p, dest.Id); np != nil {
+			di.patterns = append(di.patterns, np)
+		} else if !isNumberPattern(p) {
+			di.trie.Add(p, dest.Id)
+		}
+	}
+	di.prefixes[dest.Id] = dest.Prefixes
+}
+
+// Returns nil for the plain prefixes and for the invalid patterns, the later ones are logged
+func parseIndexedPattern(entry, destId string) *numberPattern {
+	if !isNumberPattern(entry) {
+		return nil
+	}
+	np, err := newNumberPattern(entry, destId)
 	if err != nil {
-		return
-	}
-	for prefix, idIDs := range prefixMap {
-		dIDs := idIDs.Value().(map[interface{}]struct{})
-		changed := false
-		for _, searchedDID := range destIds {
-			if _, found := dIDs[searchedDID]; found {
-				if len(dIDs) == 1 {
-					// remove de prefix from cache
-					cache2go.RemKey(DESTINATION_PREFIX + prefix)
-				} else {
-					// delete the destination from list and put the new list in chache
-					delete(dIDs, searchedDID)
-					changed = true
-				}
+		Logger.Warning(fmt.Sprintf("Ignoring entry of destination %s: %v", destId, err))
+		return nil
+	}
+	return np
+}
+
+func (di *DestinationIndex) RemoveDestination(destId string) {
+	di.mux.Lock()
+	defer di.mux.Unlock()
+	di.removeDestination(destId)
+}
+
+func (di *DestinationIndex) removeDestination(destId string) {
+	for _, p := range di.prefixes[destId] {
+		if !isNumberPattern(p) {
+			di.trie.Remove(p, destId)
+		}
+	}
+	if len(di.patterns) > 0 {
+		patterns := make([]*numberPattern, 0, len(di.patterns)) // copy, the readers may still range over the old ones
+		for _, np := range di.patterns {
+			if np.destId != destId {
+				patterns = append(patterns, np)
 			}
 		}
-		if changed {
-			cache2go.Cache(DESTINATION_PREFIX+prefix, dIDs)
+		di.patterns = patterns
+	}
+	delete(di.prefixes, destId)
+}
+
+// Returns the matching entries of the number, most precise first, as the rating path expects them.
+// On the same precision the regular expressions come first, followed by the ranges and the prefixes.
+func (di *DestinationIndex) Match(number string) []*utils.PrefixMatch {
+	di.mux.RLock()
+	defer di.mux.RUnlock()
+	matches := di.trie.Match(number, MIN_PREFIX_MATCH)
+	var ranks []int // ranks of the matches, the prefixes have 0
+	for _, np := range di.patterns {
+		if !np.match(number) {
+			continue
+		}
+		if ranks == nil {
+			ranks = make([]int, len(matches))
 		}
+		idx := 0
+		for idx < len(matches) && (matches[idx].Precision > np.precision ||
+			(matches[idx].Precision == np.precision && ranks[idx] >= np.rank())) {
+			idx++
+		}
+		match := &utils.PrefixMatch{Prefix: np.entry, Ids: []string{np.destId}, Precision: np.precision}
+		matches = append(matches[:idx], append([]*utils.PrefixMatch{match}, matches[idx:]...)...)
+		ranks = append(ranks[:idx], append([]int{np.rank()}, ranks[idx:]...)...)
 	}
+	return matches
+}
+
+func (di *DestinationIndex) HasPrefix(destId, prefix string) bool {
+	di.mux.RLock()
+	defer di.mux.RUnlock()
+	for _, dId := range di.trie.Get(prefix) {
+		if dId == destId {
+			return true
+		}
+	}
+	for _, np := range di.patterns {
+		if np.destId == destId && np.entry == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// Number of distinct prefixes and patterns indexed
+func (di *DestinationIndex) Len() int {
+	di.mux.RLock()
+	defer di.mux.RUnlock()
+	return di.trie.Len() + len(di.patterns)
 }
@@ -20,8 +20,10 @@ package engine
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/cgrates/cgrates/cache2go"
+	"github.com/cgrates/cgrates/utils"
 
 	"testing"
 )
@@ -81,9 +83,12 @@ func TestDestinationGetExists(t *testing.T) {
 }
 
 func TestDestinationGetExistsCache(t *testing.T) {
-	ratingStorage.GetDestination("NAT")
-	if _, err := cache2go.GetCached(DESTINATION_PREFIX + "0256"); err != nil {
-		t.Error("Destination not cached:", err)
+	ratingStorage.SetDestination(&Destination{Id: "CACHED", Prefixes: []string{"0259"}})
+	if d, err := ratingStorage.GetDestination("CACHED"); err != nil || d.containsPrefix("0259") == 0 {
+		t.Error("Could not get destination: ", d, err)
+	}
+	if !CachedDestHasPrefix("CACHED", "0259") {
+		t.Error("Destination not cached")
 	}
 }
 
@@ -125,25 +130,134 @@ func TestNonCachedDestWrongPrefix(t *testing.T) {
 	}
 }
 
-func TestCleanStalePrefixes(t *testing.T) {
-	x := struct{}{}
-	cache2go.Cache(DESTINATION_PREFIX+"1", map[interface{}]struct{}{"D1": x, "D2": x})
-	cache2go.Cache(DESTINATION_PREFIX+"2", map[interface{}]struct{}{"D1": x})
-	cache2go.Cache(DESTINATION_PREFIX+"3", map[interface{}]struct{}{"D2": x})
-	CleanStalePrefixes([]string{"D1"})
-	if r, err := cache2go.GetCached(DESTINATION_PREFIX + "1"); err != nil || len(r.(map[interface{}]struct{})) != 1 {
-		t.Error("Error cleaning stale destination ids", r)
+func TestDestinationIndexMatch(t *testing.T) {
+	di := NewDestinationIndex()
+	di.Reset([]*Destination{
+		&Destination{Id: "GERMANY", Prefixes: []string{"49"}},
+		&Destination{Id: "GERMANY_MOBILE", Prefixes: []string{"4915", "4916"}},
+	})
+	matches := di.Match("4915123")
+	if len(matches) != 2 || matches[0].Prefix != "4915" || matches[0].Ids[0] != "GERMANY_MOBILE" ||
+		matches[1].Prefix != "49" || matches[1].Ids[0] != "GERMANY" {
+		t.Errorf("Unexpected matches: %+v", matches)
+	}
+	if !di.HasPrefix("GERMANY_MOBILE", "4916") || di.HasPrefix("GERMANY", "4916") {
+		t.Error("Wrong prefix ownership")
+	}
+}
+
+func TestDestinationIndexSetDestination(t *testing.T) {
+	di := NewDestinationIndex()
+	di.SetDestination(&Destination{Id: "GERMANY_MOBILE", Prefixes: []string{"4915", "4916"}})
+	di.SetDestination(&Destination{Id: "GERMANY_MOBILE", Prefixes: []string{"4917"}})
+	if di.HasPrefix("GERMANY_MOBILE", "4915") || !di.HasPrefix("GERMANY_MOBILE", "4917") {
+		t.Error("Destination prefixes not replaced")
 	}
-	if r, err := cache2go.GetCached(DESTINATION_PREFIX + "2"); err == nil {
-		t.Error("Error removing stale prefix: ", r)
+	if di.Len() != 1 {
+		t.Error("Wrong number of indexed prefixes: ", di.Len())
 	}
-	if r, err := cache2go.GetCached(DESTINATION_PREFIX + "3"); err != nil || len(r.(map[interface{}]struct{})) != 1 {
-		t.Error("Error performing stale cleaning: ", r)
+	di.RemoveDestination("GERMANY_MOBILE")
+	if matches := di.Match("4917"); len(matches) != 0 {
+		t.Errorf("Unexpected matches: %+v", matches)
+	}
+}
+
+func TestDestinationContainsPrefixPatterns(t *testing.T) {
+	london := &Destination{Id: "LONDON", Prefixes: []string{"44", "4420700000-4420799999", `~^4420\d{6}$`}}
+	if precision := london.containsPrefix("4420712345"); precision != 5 {
+		t.Error("Wrong range precision: ", precision)
+	}
+	if precision := london.containsPrefix("4420612345"); precision != 4 {
+		t.Error("Wrong regexp precision: ", precision)
+	}
+	if precision := london.containsPrefix("442061234"); precision != 2 {
+		t.Error("Wrong prefix precision: ", precision)
+	}
+	if precision := london.containsPrefix("4520712345"); precision != 0 {
+		t.Error("Unexpected precision: ", precision)
+	}
+}
+
+func TestDestinationInvalidPatterns(t *testing.T) {
+	for _, entry := range []string{"4420700000-442079999", "4420799999-4420700000", "+4420700000-4420799999", "44a-44b", "~4420\\d+", "~^44(20$"} {
+		if _, err := newNumberPattern(entry, "TEST"); err == nil {
+			t.Error("Expecting error for: ", entry)
+		}
+	}
+	dest := &Destination{Id: "TEST", Prefixes: []string{"44", "+4420700000-+4420799999", "~^112$", "~44"}}
+	if invalid := dest.getFirstInvalidPrefix(); invalid != "~44" {
+		t.Error("Wrong invalid prefix: ", invalid)
+	}
+}
+
+func TestDestinationIndexMatchNumberPatterns(t *testing.T) {
+	di := NewDestinationIndex()
+	di.Reset([]*Destination{
+		&Destination{Id: "UK", Prefixes: []string{"44"}},
+		&Destination{Id: "LONDON", Prefixes: []string{"44207"}},
+		&Destination{Id: "LONDON_BLOCK", Prefixes: []string{"4420700000-4420799999"}},
+		&Destination{Id: "LONDON_SUBBLOCK", Prefixes: []string{"4420710000-4420712345"}},
+		&Destination{Id: "SHORT", Prefixes: []string{`~^44\d{3}$`, "~broken"}},
+	})
+	matches := di.Match("4420712000")
+	if len(matches) != 4 || matches[0].Ids[0] != "LONDON_SUBBLOCK" || matches[0].Precision != 6 ||
+		matches[1].Ids[0] != "LONDON_BLOCK" || matches[1].Prefix != "4420700000-4420799999" ||
+		matches[2].Ids[0] != "LONDON" || matches[2].Precision != 5 || matches[3].Ids[0] != "UK" {
+		t.Errorf("Unexpected matches: %+v", matches)
+	}
+	matches = di.Match("44123")
+	if len(matches) != 2 || matches[0].Ids[0] != "SHORT" || matches[1].Ids[0] != "UK" {
+		t.Errorf("Unexpected matches: %+v", matches)
+	}
+	if !di.HasPrefix("LONDON_BLOCK", "4420700000-4420799999") || di.Len() != 5 {
+		t.Error("Wrong patterns indexed: ", di.Len())
+	}
+	di.SetDestination(&Destination{Id: "LONDON_SUBBLOCK", Prefixes: []string{"44208"}})
+	if matches = di.Match("4420712000"); len(matches) != 3 || matches[0].Ids[0] != "LONDON_BLOCK" {
+		t.Errorf("Unexpected matches: %+v", matches)
 	}
 }
 
 /********************************* Benchmarks **********************************/
 
+// loads a deck of 400k prefixes both in the index and in the cache reverse lookup
+func benchDestinationDeck() *DestinationIndex {
+	dests := make([]*Destination, 1000)
+	for i := range dests {
+		dests[i] = &Destination{Id: "DST_" + strconv.Itoa(i)}
+	}
+	for i := 0; i < 400000; i++ {
+		pfx := strconv.Itoa(4400000 + i)
+		dests[i%1000].AddPrefix(pfx)
+		cache2go.CachePush("bdst"+pfx, dests[i%1000].Id)
+	}
+	di := NewDestinationIndex()
+	di.Reset(dests)
+	return di
+}
+
+func BenchmarkDestinationIndexMatch(b *testing.B) {
+	di := benchDestinationDeck()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, match := range di.Match("440012345678") {
+			_ = match.Ids
+		}
+	}
+}
+
+func BenchmarkDestinationCacheSplitPrefix(b *testing.B) {
+	benchDestinationDeck()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range utils.SplitPrefix("440012345678", MIN_PREFIX_MATCH) {
+			if x, err := cache2go.GetCached("bdst" + p); err == nil {
+				_ = x.(map[interface{}]struct{})
+			}
+		}
+	}
+}
+
 func BenchmarkDestinationStorageStoreRestore(b *testing.B) {
 	nationale := &Destination{Id: "nat", Prefixes: []string{"0257", "0256", "0723"}}
 	for i := 0; i < b.N; i++ {
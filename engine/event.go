@@ -43,6 +43,7 @@ type Event interface {
 	GetPdd(string) (time.Duration, error)
 	GetSupplier(string) string
 	GetDisconnectCause(string) string
+	GetOrigin(string) string
 	GetOriginatorIP(string) string
 	GetExtraFields() map[string]string
 	MissingParameter() bool
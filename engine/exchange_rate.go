@@ -0,0 +1,73 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Number of ToCurrency units paid for one unit of FromCurrency
+type ExchangeRate struct {
+	FromCurrency string
+	ToCurrency   string
+	Rate         utils.Decimal
+}
+
+func (exr *ExchangeRate) GetId() string {
+	return utils.ConcatenatedKey(exr.FromCurrency, exr.ToCurrency)
+}
+
+func (exr *ExchangeRate) Equal(o *ExchangeRate) bool {
+	if exr == nil || o == nil {
+		return exr == o
+	}
+	return exr.FromCurrency == o.FromCurrency &&
+		exr.ToCurrency == o.ToCurrency &&
+		exr.Rate.Equal(o.Rate)
+}
+
+// Converts an amount from FromCurrency into ToCurrency, nil rate leaves it unchanged
+func (exr *ExchangeRate) Convert(amount utils.Decimal) utils.Decimal {
+	if exr == nil {
+		return amount
+	}
+	return amount.Mul(exr.Rate)
+}
+
+// Returns the rate to be applied when moving from one currency to another.
+// Empty or equal currencies need no conversion so nil is returned,
+// if the direct pair is not defined the inverse one is used.
+func getExchangeRate(from, to string) (*ExchangeRate, error) {
+	if from == "" || to == "" || from == to {
+		return nil, nil
+	}
+	if exr, err := ratingStorage.GetExchangeRate(utils.ConcatenatedKey(from, to), false); err == nil {
+		return exr, nil
+	}
+	if exr, err := ratingStorage.GetExchangeRate(utils.ConcatenatedKey(to, from), false); err == nil && !exr.Rate.IsZero() {
+		return &ExchangeRate{
+			FromCurrency: from,
+			ToCurrency:   to,
+			Rate:         utils.NewDecimalFromInt(1).Div(exr.Rate),
+		}, nil
+	}
+	return nil, fmt.Errorf("no exchange rate from %s to %s", from, to)
+}
@@ -139,6 +139,6 @@ func (fsCdr FSCdr) AsStoredCdr() *StoredCdr {
 	storCdr.AnswerTime, _ = utils.ParseTimeDetectLayout(fsCdr.vars[FS_ANSWER_TIME])
 	storCdr.Usage, _ = utils.ParseDurationWithSecs(fsCdr.vars[FS_DURATION])
 	storCdr.ExtraFields = fsCdr.getExtraFields()
-	storCdr.Cost = -1
+	storCdr.Cost = utils.NewDecimalFromInt(-1)
 	return storCdr
 }
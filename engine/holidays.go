@@ -0,0 +1,170 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	EASTER           = "*easter"
+	ORTHODOX_EASTER  = "*orthodox_easter"
+	HOLIDAY_DATE_SEP = "-"
+)
+
+// Named set of public holidays, referenced from the timings month days
+type HolidayCalendar struct {
+	Id       string
+	Holidays []*Holiday
+}
+
+// One holiday, either on a fixed date or relative to the Easter Sunday
+type Holiday struct {
+	Name     string
+	Year     int        // 0 for every year
+	Month    time.Month // 0 for the holidays relative to Easter
+	MonthDay int
+	Easter   string // *easter or *orthodox_easter
+	Offset   int    // days after the Easter Sunday, negative for the ones before
+}
+
+// Parses the holiday date in one of the formats:
+// 2015-12-25 (one time), 12-25 (every year), *easter+1 or *orthodox_easter-2 (relative to Easter Sunday)
+func NewHoliday(date, name string) (*Holiday, error) {
+	date = strings.TrimSpace(date)
+	h := &Holiday{Name: name}
+	if strings.HasPrefix(date, "*") {
+		h.Easter = date
+		if idx := strings.IndexAny(date, "+-"); idx != -1 {
+			h.Easter = date[:idx]
+			offset, err := strconv.Atoi(strings.TrimPrefix(date[idx:], "+"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid holiday offset: %s", date)
+			}
+			h.Offset = offset
+		}
+		if h.Easter != EASTER && h.Easter != ORTHODOX_EASTER {
+			return nil, fmt.Errorf("unsupported holiday rule: %s", date)
+		}
+		return h, nil
+	}
+	var err error
+	var month int
+	elements := strings.Split(date, HOLIDAY_DATE_SEP)
+	switch len(elements) {
+	case 2:
+		month, err = strconv.Atoi(elements[0])
+		if err == nil {
+			h.MonthDay, err = strconv.Atoi(elements[1])
+		}
+	case 3:
+		if h.Year, err = strconv.Atoi(elements[0]); err == nil {
+			month, err = strconv.Atoi(elements[1])
+		}
+		if err == nil {
+			h.MonthDay, err = strconv.Atoi(elements[2])
+		}
+	default:
+		return nil, fmt.Errorf("invalid holiday date: %s", date)
+	}
+	if err != nil || month < 1 || month > 12 || h.MonthDay < 1 || h.MonthDay > 31 {
+		return nil, fmt.Errorf("invalid holiday date: %s", date)
+	}
+	h.Month = time.Month(month)
+	return h, nil
+}
+
+// Returns the date of the holiday in the specified year, ok is false if the holiday does not happen that year
+func (h *Holiday) DateIn(year int) (month time.Month, day int, ok bool) {
+	if h.Year != 0 && h.Year != year {
+		return
+	}
+	if h.Easter == "" {
+		return h.Month, h.MonthDay, true
+	}
+	var easter time.Time
+	if h.Easter == ORTHODOX_EASTER {
+		easter = OrthodoxEasterSunday(year)
+	} else {
+		easter = EasterSunday(year)
+	}
+	date := easter.AddDate(0, 0, h.Offset)
+	if date.Year() != year {
+		return
+	}
+	return date.Month(), date.Day(), true
+}
+
+func (h *Holiday) IsOn(t time.Time) bool {
+	month, day, ok := h.DateIn(t.Year())
+	return ok && month == t.Month() && day == t.Day()
+}
+
+func (hc *HolidayCalendar) IsHoliday(t time.Time) bool {
+	for _, h := range hc.Holidays {
+		if h.IsOn(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Western Easter Sunday, anonymous gregorian algorithm
+func EasterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// Orthodox Easter Sunday, computed on the julian calendar and moved to the gregorian one
+func OrthodoxEasterSunday(year int) time.Time {
+	a := year % 4
+	b := year % 7
+	c := year % 19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+	julianShift := year/100 - year/400 - 2
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, julianShift)
+}
+
+// Returns true if the time is a holiday in any of the calendars, the missing calendars are ignored
+func isHoliday(calendarIds []string, t time.Time) bool {
+	for _, calId := range calendarIds {
+		if hc, err := ratingStorage.GetHolidayCalendar(calId, false); err == nil && hc.IsHoliday(t) {
+			return true
+		}
+	}
+	return false
+}
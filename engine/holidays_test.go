@@ -0,0 +1,130 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestHolidayEasterSunday(t *testing.T) {
+	for year, expected := range map[int]time.Time{
+		2015: time.Date(2015, time.April, 5, 0, 0, 0, 0, time.UTC),
+		2016: time.Date(2016, time.March, 27, 0, 0, 0, 0, time.UTC),
+		2019: time.Date(2019, time.April, 21, 0, 0, 0, 0, time.UTC),
+	} {
+		if easter := EasterSunday(year); !easter.Equal(expected) {
+			t.Errorf("Expected easter %v was %v", expected, easter)
+		}
+	}
+	for year, expected := range map[int]time.Time{
+		2015: time.Date(2015, time.April, 12, 0, 0, 0, 0, time.UTC),
+		2016: time.Date(2016, time.May, 1, 0, 0, 0, 0, time.UTC),
+		2019: time.Date(2019, time.April, 28, 0, 0, 0, 0, time.UTC),
+	} {
+		if easter := OrthodoxEasterSunday(year); !easter.Equal(expected) {
+			t.Errorf("Expected orthodox easter %v was %v", expected, easter)
+		}
+	}
+}
+
+func TestHolidayParse(t *testing.T) {
+	if h, err := NewHoliday("2015-12-24", "Christmas Eve"); err != nil ||
+		!reflect.DeepEqual(h, &Holiday{Name: "Christmas Eve", Year: 2015, Month: time.December, MonthDay: 24}) {
+		t.Errorf("Error parsing holiday: %+v, %v", h, err)
+	}
+	if h, err := NewHoliday("05-01", ""); err != nil ||
+		!reflect.DeepEqual(h, &Holiday{Month: time.May, MonthDay: 1}) {
+		t.Errorf("Error parsing holiday: %+v, %v", h, err)
+	}
+	if h, err := NewHoliday("*orthodox_easter+50", ""); err != nil ||
+		!reflect.DeepEqual(h, &Holiday{Easter: ORTHODOX_EASTER, Offset: 50}) {
+		t.Errorf("Error parsing holiday: %+v, %v", h, err)
+	}
+	for _, date := range []string{"13-01", "2015-02", "*christmas+1", "*easter+x", ""} {
+		if _, err := NewHoliday(date, ""); err == nil {
+			t.Error("Expecting error for holiday date: ", date)
+		}
+	}
+}
+
+func TestHolidayCalendarIsHoliday(t *testing.T) {
+	hc := &HolidayCalendar{Id: "RO", Holidays: []*Holiday{
+		&Holiday{Month: time.December, MonthDay: 1},
+		&Holiday{Easter: ORTHODOX_EASTER, Offset: 1},
+		&Holiday{Year: 2016, Month: time.June, MonthDay: 5},
+	}}
+	for _, date := range []time.Time{
+		time.Date(2015, time.December, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2015, time.April, 13, 23, 59, 0, 0, time.UTC),
+		time.Date(2016, time.May, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2016, time.June, 5, 0, 0, 0, 0, time.UTC),
+	} {
+		if !hc.IsHoliday(date) {
+			t.Error("Expecting holiday on: ", date)
+		}
+	}
+	for _, date := range []time.Time{
+		time.Date(2015, time.April, 6, 10, 0, 0, 0, time.UTC),
+		time.Date(2015, time.June, 5, 0, 0, 0, 0, time.UTC),
+	} {
+		if hc.IsHoliday(date) {
+			t.Error("Not expecting holiday on: ", date)
+		}
+	}
+}
+
+func TestHolidayRateIntervalContains(t *testing.T) {
+	i := &RateInterval{Timing: &RITiming{Holidays: utils.HolidayCalendars{"*holidays_de"}, StartTime: "00:00:00"}}
+	if !i.Contains(time.Date(2015, time.April, 6, 10, 0, 0, 0, time.UTC), false) {
+		t.Error("Easter monday not matched")
+	}
+	if i.Contains(time.Date(2015, time.April, 7, 10, 0, 0, 0, time.UTC), false) {
+		t.Error("Easter tuesday matched")
+	}
+	i.Timing.MonthDays = utils.MonthDays{7}
+	if !i.Contains(time.Date(2015, time.April, 7, 10, 0, 0, 0, time.UTC), false) {
+		t.Error("Month day not matched along the holidays")
+	}
+	i = &RateInterval{Timing: &RITiming{WeekDays: utils.WeekDays{time.Monday}, Holidays: utils.HolidayCalendars{"*holidays_de"}}}
+	if i.Contains(time.Date(2015, time.April, 3, 10, 0, 0, 0, time.UTC), false) {
+		t.Error("Good friday matched on mondays")
+	}
+}
+
+func TestHolidayActionPlanNextStartTime(t *testing.T) {
+	now := time.Date(2015, time.March, 1, 10, 0, 0, 0, time.UTC)
+	at := &ActionPlan{Timing: &RateInterval{Timing: &RITiming{Holidays: utils.HolidayCalendars{"*holidays_de"}, StartTime: "08:00:00"}}}
+	expected := time.Date(2015, time.April, 3, 8, 0, 0, 0, time.UTC)
+	if st := at.GetNextStartTime(now); !st.Equal(expected) {
+		t.Errorf("Expected %v was %v", expected, st)
+	}
+	at = &ActionPlan{Timing: &RateInterval{Timing: &RITiming{Holidays: utils.HolidayCalendars{"*holidays_de"}, StartTime: "08:00:00"}}}
+	expected = time.Date(2015, time.April, 6, 8, 0, 0, 0, time.UTC)
+	if st := at.GetNextStartTime(time.Date(2015, time.April, 3, 8, 0, 0, 0, time.UTC)); !st.Equal(expected) {
+		t.Errorf("Expected %v was %v", expected, st)
+	}
+	at = &ActionPlan{Timing: &RateInterval{Timing: &RITiming{MonthDays: utils.MonthDays{15}, Holidays: utils.HolidayCalendars{"*holidays_de"}}}}
+	expected = time.Date(2015, time.March, 15, 0, 0, 0, 0, time.UTC)
+	if st := at.GetNextStartTime(now); !st.Equal(expected) {
+		t.Errorf("Expected %v was %v", expected, st)
+	}
+}
@@ -25,7 +25,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/cgrates/cgrates/cache2go"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -103,7 +102,7 @@ type LcrReply struct {
 // One supplier out of LCR reply
 type LcrSupplier struct {
 	Supplier string
-	Cost     float64
+	Cost     utils.Decimal
 	QOS      map[string]float64
 }
 
@@ -135,7 +134,7 @@ type LCRCost struct {
 
 type LCRSupplierCost struct {
 	Supplier      string
-	Cost          float64
+	Cost          utils.Decimal
 	Duration      time.Duration
 	Error         string // Not error due to JSON automatic serialization into struct
 	QOS           map[string]float64
@@ -248,17 +247,12 @@ func (es LCREntriesSorter) Sort() {
 
 func (lcra *LCRActivation) GetLCREntryForPrefix(destination string) *LCREntry {
 	var potentials LCREntriesSorter
-	for _, p := range utils.SplitPrefix(destination, MIN_PREFIX_MATCH) {
-		if x, err := cache2go.GetCached(DESTINATION_PREFIX + p); err == nil {
-
-			destIds := x.(map[interface{}]struct{})
-			for idId := range destIds {
-				dId := idId.(string)
-				for _, entry := range lcra.Entries {
-					if entry.DestinationId == dId {
-						entry.precision = len(p)
-						potentials = append(potentials, entry)
-					}
+	for _, match := range destIndex.Match(destination) {
+		for _, dId := range match.Ids {
+			for _, entry := range lcra.Entries {
+				if entry.DestinationId == dId {
+					entry.precision = match.Precision
+					potentials = append(potentials, entry)
 				}
 			}
 		}
@@ -350,7 +344,7 @@ func (lscs LowestSupplierCostSorter) Swap(i, j int) {
 }
 
 func (lscs LowestSupplierCostSorter) Less(i, j int) bool {
-	return lscs[i].Cost < lscs[j].Cost
+	return lscs[i].Cost.Cmp(lscs[j].Cost) < 0
 }
 
 type HighestSupplierCostSorter []*LCRSupplierCost
@@ -364,7 +358,7 @@ func (hscs HighestSupplierCostSorter) Swap(i, j int) {
 }
 
 func (hscs HighestSupplierCostSorter) Less(i, j int) bool {
-	return hscs[i].Cost > hscs[j].Cost
+	return hscs[i].Cost.Cmp(hscs[j].Cost) > 0
 }
 
 type QOSSorter []*LCRSupplierCost
@@ -242,9 +242,9 @@ func TestLCRCostSuppliersSlice(t *testing.T) {
 	lcrCost = &LCRCost{
 		Entry: &LCREntry{DestinationId: utils.ANY, RPCategory: "call", Strategy: LCR_STRATEGY_STATIC, StrategyParams: "ivo12;dan12;rif12", Weight: 10.0},
 		SupplierCosts: []*LCRSupplierCost{
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: 1.8, Duration: 60 * time.Second},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: 0.6, Duration: 60 * time.Second},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: 1.2, Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: utils.NewDecimalFromFloat(1.8), Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: utils.NewDecimalFromFloat(0.6), Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: utils.NewDecimalFromFloat(1.2), Duration: 60 * time.Second},
 		},
 	}
 	eSuppls := []string{"ivo12", "dan12", "rif12"}
@@ -263,9 +263,9 @@ func TestLCRCostSuppliersString(t *testing.T) {
 	lcrCost = &LCRCost{
 		Entry: &LCREntry{DestinationId: utils.ANY, RPCategory: "call", Strategy: LCR_STRATEGY_STATIC, StrategyParams: "ivo12;dan12;rif12", Weight: 10.0},
 		SupplierCosts: []*LCRSupplierCost{
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: 1.8, Duration: 60 * time.Second},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: 0.6, Duration: 60 * time.Second},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: 1.2, Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: utils.NewDecimalFromFloat(1.8), Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: utils.NewDecimalFromFloat(0.6), Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: utils.NewDecimalFromFloat(1.2), Duration: 60 * time.Second},
 		},
 	}
 	eSupplStr := "ivo12,dan12,rif12"
@@ -275,3 +275,20 @@ func TestLCRCostSuppliersString(t *testing.T) {
 		t.Errorf("Expecting: %s, received: %s", eSupplStr, supplStr)
 	}
 }
+
+func TestLcrGetEntryForNumberRange(t *testing.T) {
+	destIndex.SetDestination(&Destination{Id: "LCR_UK", Prefixes: []string{"44"}})
+	destIndex.SetDestination(&Destination{Id: "LCR_LONDON", Prefixes: []string{"4420700000-4420799999"}})
+	defer destIndex.RemoveDestination("LCR_UK")
+	defer destIndex.RemoveDestination("LCR_LONDON")
+	lcra := &LCRActivation{Entries: []*LCREntry{
+		&LCREntry{DestinationId: "LCR_UK", RPCategory: "uk", Weight: 10},
+		&LCREntry{DestinationId: "LCR_LONDON", RPCategory: "london", Weight: 10},
+	}}
+	if entry := lcra.GetLCREntryForPrefix("4420712345"); entry == nil || entry.RPCategory != "london" {
+		t.Errorf("Wrong entry for the number range: %+v", entry)
+	}
+	if entry := lcra.GetLCREntryForPrefix("4420812345"); entry == nil || entry.RPCategory != "uk" {
+		t.Errorf("Wrong entry outside the number range: %+v", entry)
+	}
+}
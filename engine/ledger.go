@@ -0,0 +1,144 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Causes of the balance movements recorded in the ledger
+const (
+	LEDGER_CDR         = "*cdr"
+	LEDGER_REFUND      = "*refund"
+	LEDGER_ACTION      = "*action"
+	LEDGER_RESERVATION = "*reservation"
+	LEDGER_API         = "*api"
+	LEDGER_TRANSFER    = "*transfer"
+	LEDGER_RESTORE     = "*restore"
+)
+
+// One movement on a balance, the ledger entries are never changed once recorded
+type LedgerEntry struct {
+	Id          int64 // order of recording, set by the storage
+	Time        time.Time
+	AccountId   string
+	BalanceType string // key in the balance map, eg: *monetary*out
+	BalanceUuid string
+	BalanceId   string
+	Delta       utils.Decimal
+	Value       utils.Decimal // balance value after the movement
+	Cause       string        // one of the LEDGER_* causes
+	CauseId     string        // the CDR cgrid, the actions id or the API method
+}
+
+// Balance as it was at the last ledger snapshot
+type ledgerBalance struct {
+	balanceType string
+	id          string
+	value       utils.Decimal
+}
+
+// Sets what the next balance changes are recorded with, returns the previous cause so it can be restored
+func (acc *Account) SetLedgerCause(cause, causeId string) (prevCause, prevCauseId string) {
+	prevCause, prevCauseId = acc.ledgerCause, acc.ledgerCauseId
+	acc.ledgerCause, acc.ledgerCauseId = cause, causeId
+	return
+}
+
+// Remembers the balance values, the next ledger entries are the differences to them
+func (acc *Account) snapshotLedger() {
+	if cdrStorage == nil {
+		return
+	}
+	acc.ledgerBalances = make(map[string]*ledgerBalance)
+	for key, bc := range acc.BalanceMap {
+		for _, b := range bc {
+			acc.ledgerBalances[b.Uuid] = &ledgerBalance{balanceType: key, id: b.Id, value: b.Value}
+		}
+	}
+}
+
+// Builds the entries for the balances changed since the last snapshot, the removed balances go to zero
+func (acc *Account) getLedgerEntries(t time.Time) (entries []*LedgerEntry) {
+	seen := make(map[string]bool)
+	for key, bc := range acc.BalanceMap {
+		for _, b := range bc {
+			seen[b.Uuid] = true
+			var prevValue utils.Decimal // zero for the new balances
+			if lb, found := acc.ledgerBalances[b.Uuid]; found {
+				prevValue = lb.value
+			}
+			if delta := b.Value.Sub(prevValue); !delta.IsZero() {
+				entries = append(entries, &LedgerEntry{Time: t, AccountId: acc.Id, BalanceType: key, BalanceUuid: b.Uuid, BalanceId: b.Id,
+					Delta: delta, Value: b.Value, Cause: acc.ledgerCause, CauseId: acc.ledgerCauseId})
+			}
+		}
+	}
+	for uuid, lb := range acc.ledgerBalances {
+		if !seen[uuid] && !lb.value.IsZero() {
+			entries = append(entries, &LedgerEntry{Time: t, AccountId: acc.Id, BalanceType: lb.balanceType, BalanceUuid: uuid, BalanceId: lb.id,
+				Delta: lb.value.Neg(), Cause: acc.ledgerCause, CauseId: acc.ledgerCauseId})
+		}
+	}
+	sort.Sort(ledgerEntriesByBalance(entries))
+	return
+}
+
+// Keeps the balance movements since the last snapshot with their current cause, recorded on the next save
+func (acc *Account) collectLedger() {
+	if cdrStorage == nil {
+		return
+	}
+	acc.ledgerEntries = append(acc.ledgerEntries, acc.getLedgerEntries(time.Now())...)
+	acc.snapshotLedger()
+}
+
+// Writes the collected balance movements into the ledger, called after the account was saved
+func (acc *Account) recordLedger() {
+	if cdrStorage == nil {
+		return
+	}
+	acc.collectLedger()
+	entries := acc.ledgerEntries
+	acc.ledgerEntries = nil
+	if len(entries) == 0 {
+		return
+	}
+	if err := cdrStorage.SetLedgerEntries(entries); err != nil {
+		Logger.Err(fmt.Sprintf("<Ledger> Error recording the balance movements of account %s: %v", acc.Id, err))
+	}
+}
+
+type ledgerEntriesByBalance []*LedgerEntry
+
+func (les ledgerEntriesByBalance) Len() int {
+	return len(les)
+}
+
+func (les ledgerEntriesByBalance) Swap(i, j int) {
+	les[i], les[j] = les[j], les[i]
+}
+
+func (les ledgerEntriesByBalance) Less(i, j int) bool {
+	return les[i].BalanceType < les[j].BalanceType ||
+		(les[i].BalanceType == les[j].BalanceType && les[i].BalanceUuid < les[j].BalanceUuid)
+}
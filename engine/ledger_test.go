@@ -0,0 +1,79 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestLedgerGetEntries(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:ledger", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{
+			&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)},
+			&Balance{Uuid: "bonus", Id: "BONUS", Value: utils.NewDecimalFromFloat(5), Weight: 10},
+		},
+		utils.VOICE + OUTBOUND: BalanceChain{&Balance{Uuid: "minutes", Value: utils.NewDecimalFromFloat(60)}},
+	}}
+	acc.ledgerBalances = map[string]*ledgerBalance{
+		"money":   &ledgerBalance{balanceType: utils.MONETARY + OUTBOUND, value: utils.NewDecimalFromFloat(12)},
+		"minutes": &ledgerBalance{balanceType: utils.VOICE + OUTBOUND, value: utils.NewDecimalFromFloat(60)},
+		"old":     &ledgerBalance{balanceType: utils.VOICE + OUTBOUND, id: "OLD", value: utils.NewDecimalFromFloat(30)},
+	}
+	acc.SetLedgerCause(LEDGER_CDR, "cgrid1")
+	now := time.Now()
+	entries := acc.getLedgerEntries(now)
+	if len(entries) != 3 {
+		t.Fatalf("Wrong ledger entries: %+v", entries)
+	}
+	if entries[0].BalanceUuid != "bonus" || entries[0].BalanceId != "BONUS" || entries[0].Delta.String() != "5" || entries[0].Value.String() != "5" {
+		t.Errorf("Wrong entry for the new balance: %+v", entries[0])
+	}
+	if entries[1].BalanceUuid != "money" || entries[1].Delta.String() != "-2" || entries[1].Value.String() != "10" ||
+		entries[1].Cause != LEDGER_CDR || entries[1].CauseId != "cgrid1" || entries[1].AccountId != acc.Id || !entries[1].Time.Equal(now) {
+		t.Errorf("Wrong entry for the debited balance: %+v", entries[1])
+	}
+	if entries[2].BalanceUuid != "old" || entries[2].BalanceId != "OLD" || entries[2].Delta.String() != "-30" || !entries[2].Value.IsZero() {
+		t.Errorf("Wrong entry for the removed balance: %+v", entries[2])
+	}
+}
+
+func TestLedgerSetCause(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:ledger"}
+	acc.SetLedgerCause(LEDGER_CDR, "cgrid1")
+	if prevCause, prevCauseId := acc.SetLedgerCause(LEDGER_ACTION, "TOPUP"); prevCause != LEDGER_CDR || prevCauseId != "cgrid1" {
+		t.Errorf("Wrong previous cause: %s, %s", prevCause, prevCauseId)
+	}
+	if acc.ledgerCause != LEDGER_ACTION || acc.ledgerCauseId != "TOPUP" {
+		t.Errorf("Wrong cause: %s, %s", acc.ledgerCause, acc.ledgerCauseId)
+	}
+}
+
+func TestLedgerActionPlanCause(t *testing.T) {
+	at := &ActionPlan{ActionsId: "TOPUP"}
+	a := &Action{ActionType: TOPUP}
+	if cause, causeId := at.getLedgerCause(), at.getLedgerCauseId(a); cause != LEDGER_ACTION || causeId != "TOPUP" {
+		t.Errorf("Wrong actions cause: %s, %s", cause, causeId)
+	}
+	at.SetLedgerCause(LEDGER_API, "ApierV1.AddBalance")
+	if cause, causeId := at.getLedgerCause(), at.getLedgerCauseId(a); cause != LEDGER_API || causeId != "ApierV1.AddBalance" {
+		t.Errorf("Wrong API cause: %s, %s", cause, causeId)
+	}
+}
@@ -47,7 +47,7 @@ func InitDataDb(cfg *config.CGRConfig) error {
 			return err
 		}
 	}
-	ratingDb.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingDb.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	accountDb.CacheAccounting(nil, nil, nil)
 	return nil
 }
@@ -106,7 +106,11 @@ func LoadTariffPlanFromFolder(tpPath string, ratingDb RatingStorage, accountingD
 		path.Join(tpPath, utils.ACTION_TRIGGERS_CSV),
 		path.Join(tpPath, utils.ACCOUNT_ACTIONS_CSV),
 		path.Join(tpPath, utils.DERIVED_CHARGERS_CSV),
-		path.Join(tpPath, utils.CDR_STATS_CSV)), "")
+		path.Join(tpPath, utils.CDR_STATS_CSV),
+		path.Join(tpPath, utils.EXCHANGE_RATES_CSV),
+		path.Join(tpPath, utils.TAXES_CSV),
+		path.Join(tpPath, utils.HOLIDAYS_CSV),
+		path.Join(tpPath, utils.NUMBER_PORTABILITY_CSV)), "")
 	if err := loader.LoadAll(); err != nil {
 		return utils.NewErrServerError(err)
 	}
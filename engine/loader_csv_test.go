@@ -54,42 +54,49 @@ WORKDAYS_00,*any,*any,*any,1;2;3;4;5,00:00:00
 WORKDAYS_18,*any,*any,*any,1;2;3;4;5,18:00:00
 WEEKENDS,*any,*any,*any,6;7,00:00:00
 ONE_TIME_RUN,2012,,,,*asap
+HOLIDAYS_DE,*any,*any,*holidays_de,*any,00:00:00
 `
 	rates = `
-R1,0,0.2,60,1,0
-R2,0,0.1,60s,1s,0
-R3,0,0.05,60s,1s,0
-R4,1,1,1s,1s,0
-R5,0,0.5,1s,1s,0
-LANDLINE_OFFPEAK,0,1,1,60,0
-LANDLINE_OFFPEAK,0,1,1,1,60
-GBP_71,0.000000,5.55555,1s,1s,0s
-GBP_72,0.000000,7.77777,1s,1s,0s
-GBP_70,0.000000,1,1,1,0
-RT_UK_Mobile_BIG5_PKG,0.01,0,20s,20s,0s
-RT_UK_Mobile_BIG5,0.01,0.10,1s,1s,0s
-R_URG,0,0,1,1,0
-MX,0,1,1s,1s,0
+R1,0,0.2,60,1,0,,
+R2,0,0.1,60s,1s,0,,
+R3,0,0.05,60s,1s,0,,
+R4,1,1,1s,1s,0,,
+R5,0,0.5,1s,1s,0,,
+LANDLINE_OFFPEAK,0,1,1,60,0,,
+LANDLINE_OFFPEAK,0,1,1,1,60,,
+GBP_71,0.000000,5.55555,1s,1s,0s,,
+GBP_72,0.000000,7.77777,1s,1s,0s,,
+GBP_70,0.000000,1,1,1,0,,
+RT_UK_Mobile_BIG5_PKG,0.01,0,20s,20s,0s,,
+RT_UK_Mobile_BIG5,0.01,0.10,1s,1s,0s,,
+R_URG,0,0,1,1,0,,
+MX,0,1,1s,1s,0,,
+R_TIER_NEXT,0,0.5,1s,1s,0s,,
+R_MINMAX,0,0.1,1s,1s,0s,0.5,1
 `
 	destinationRates = `
-RT_STANDARD,GERMANY,R1,*middle,4,0,
-RT_STANDARD,GERMANY_O2,R2,*middle,4,0,
-RT_STANDARD,GERMANY_PREMIUM,R2,*middle,4,0,
-RT_DEFAULT,ALL,R2,*middle,4,0,
-RT_STD_WEEKEND,GERMANY,R2,*middle,4,0,
-RT_STD_WEEKEND,GERMANY_O2,R3,*middle,4,0,
-P1,NAT,R4,*middle,4,0,
-P2,NAT,R5,*middle,4,0,
-T1,NAT,LANDLINE_OFFPEAK,*middle,4,0,
-T2,GERMANY,GBP_72,*middle,4,0,
-T2,GERMANY_O2,GBP_70,*middle,4,0,
-T2,GERMANY_PREMIUM,GBP_71,*middle,4,0,
-DR_UK_Mobile_BIG5_PKG,DST_UK_Mobile_BIG5,RT_UK_Mobile_BIG5_PKG,*middle,4,,
-DR_UK_Mobile_BIG5,DST_UK_Mobile_BIG5,RT_UK_Mobile_BIG5,*middle,4,,
-DATA_RATE,*any,LANDLINE_OFFPEAK,*middle,4,0,
-RT_URG,URG,R_URG,*middle,4,0,
-MX_FREE,RET,MX,*middle,4,10,*free
-MX_DISC,RET,MX,*middle,4,10,*disconnect
+RT_STANDARD,GERMANY,R1,*middle,4,0,,,,
+RT_STANDARD,GERMANY_O2,R2,*middle,4,0,,,,
+RT_STANDARD,GERMANY_PREMIUM,R2,*middle,4,0,,,,
+RT_DEFAULT,ALL,R2,*middle,4,0,,,,
+RT_STD_WEEKEND,GERMANY,R2,*middle,4,0,,,,
+RT_STD_WEEKEND,GERMANY_O2,R3,*middle,4,0,,,,
+P1,NAT,R4,*middle,4,0,,,,
+P2,NAT,R5,*middle,4,0,,,,
+T1,NAT,LANDLINE_OFFPEAK,*middle,4,0,,,,
+T2,GERMANY,GBP_72,*middle,4,0,,,,
+T2,GERMANY_O2,GBP_70,*middle,4,0,,,,
+T2,GERMANY_PREMIUM,GBP_71,*middle,4,0,,,,
+DR_UK_Mobile_BIG5_PKG,DST_UK_Mobile_BIG5,RT_UK_Mobile_BIG5_PKG,*middle,4,,,,,
+DR_UK_Mobile_BIG5,DST_UK_Mobile_BIG5,RT_UK_Mobile_BIG5,*middle,4,,,,,
+DATA_RATE,*any,LANDLINE_OFFPEAK,*middle,4,0,,,,
+RT_URG,URG,R_URG,*middle,4,0,,,,
+MX_FREE,RET,MX,*middle,4,10,*free,,,
+MX_DISC,RET,MX,*middle,4,10,*disconnect,,,
+DR_TIERED,NAT,R4,*middle,4,0,,,,
+DR_TIERED,NAT,R_TIER_NEXT,*middle,4,0,,,1000m,
+DR_MINMAX,NAT,R_MINMAX,*middle,4,0,,,,
+DR_MINMAX,NAT,R2,*middle,4,0,,,,PSTN_71
 `
 	ratingPlans = `
 STANDARD,RT_STANDARD,WORKDAYS_00,10
@@ -113,30 +120,35 @@ RP_DATA,DATA_RATE,*any,10
 RP_MX,MX_DISC,WORKDAYS_00,10
 RP_MX,MX_FREE,WORKDAYS_18,10
 ANY_PLAN,DATA_RATE,*any,10
+RP_TIERED,DR_TIERED,*any,10
+RP_MINMAX,DR_MINMAX,*any,10
 `
 	ratingProfiles = `
-*out,CUSTOMER_1,0,rif:from:tm,2012-01-01T00:00:00Z,PREMIUM,danb,
-*out,CUSTOMER_1,0,rif:from:tm,2012-02-28T00:00:00Z,STANDARD,danb,
-*out,CUSTOMER_2,0,danb:87.139.12.167,2012-01-01T00:00:00Z,STANDARD,danb,
-*out,CUSTOMER_1,0,danb,2012-01-01T00:00:00Z,PREMIUM,,
-*out,vdf,0,rif,2012-01-01T00:00:00Z,EVENING,,
-*out,vdf,call,rif,2012-02-28T00:00:00Z,EVENING,,
-*out,vdf,call,dan,2012-01-01T00:00:00Z,EVENING,,
-*out,vdf,0,minu;a1;a2;a3,2012-01-01T00:00:00Z,EVENING,,
-*out,vdf,0,*any,2012-02-28T00:00:00Z,EVENING,,
-*out,vdf,0,one,2012-02-28T00:00:00Z,STANDARD,,
-*out,vdf,0,inf,2012-02-28T00:00:00Z,STANDARD,inf,
-*out,vdf,0,fall,2012-02-28T00:00:00Z,PREMIUM,rif,
-*out,test,0,trp,2013-10-01T00:00:00Z,TDRT,rif;danb,
-*out,vdf,0,fallback1,2013-11-18T13:45:00Z,G,fallback2,
-*out,vdf,0,fallback1,2013-11-18T13:46:00Z,G,fallback2,
-*out,vdf,0,fallback1,2013-11-18T13:47:00Z,G,fallback2,
-*out,vdf,0,fallback2,2013-11-18T13:45:00Z,R,rif,
-*out,cgrates.org,call,*any,2013-01-06T00:00:00Z,RP_UK,,
-*out,cgrates.org,call,discounted_minutes,2013-01-06T00:00:00Z,RP_UK_Mobile_BIG5_PKG,,
-*out,cgrates.org,data,rif,2013-01-06T00:00:00Z,RP_DATA,,
-*out,cgrates.org,call,max,2013-03-23T00:00:00Z,RP_MX,,
-*in,cgrates.org,LCR_STANDARD,max,2013-03-23T00:00:00Z,RP_MX,,
+*out,CUSTOMER_1,0,rif:from:tm,2012-01-01T00:00:00Z,PREMIUM,danb,,
+*out,CUSTOMER_1,0,rif:from:tm,2012-02-28T00:00:00Z,STANDARD,danb,,
+*out,CUSTOMER_2,0,danb:87.139.12.167,2012-01-01T00:00:00Z,STANDARD,danb,,
+*out,CUSTOMER_1,0,danb,2012-01-01T00:00:00Z,PREMIUM,,,
+*out,vdf,0,rif,2012-01-01T00:00:00Z,EVENING,,,
+*out,vdf,call,rif,2012-02-28T00:00:00Z,EVENING,,,
+*out,vdf,call,dan,2012-01-01T00:00:00Z,EVENING,,,
+*out,vdf,0,minu;a1;a2;a3,2012-01-01T00:00:00Z,EVENING,,,
+*out,vdf,0,*any,2012-02-28T00:00:00Z,EVENING,,,
+*out,vdf,0,one,2012-02-28T00:00:00Z,STANDARD,,,
+*out,vdf,0,inf,2012-02-28T00:00:00Z,STANDARD,inf,,
+*out,vdf,0,fall,2012-02-28T00:00:00Z,PREMIUM,rif,,
+*out,test,0,trp,2013-10-01T00:00:00Z,TDRT,rif;danb,,
+*out,vdf,0,fallback1,2013-11-18T13:45:00Z,G,fallback2,,
+*out,vdf,0,fallback1,2013-11-18T13:46:00Z,G,fallback2,,
+*out,vdf,0,fallback1,2013-11-18T13:47:00Z,G,fallback2,,
+*out,vdf,0,fallback2,2013-11-18T13:45:00Z,R,rif,,
+*out,cgrates.org,call,*any,2013-01-06T00:00:00Z,RP_UK,,,
+*out,cgrates.org,call,discounted_minutes,2013-01-06T00:00:00Z,RP_UK_Mobile_BIG5_PKG,,,
+*out,cgrates.org,data,rif,2013-01-06T00:00:00Z,RP_DATA,,,
+*out,cgrates.org,call,max,2013-03-23T00:00:00Z,RP_MX,,,
+*in,cgrates.org,LCR_STANDARD,max,2013-03-23T00:00:00Z,RP_MX,,,
+*out,cgrates.org,call,tokyo,2013-01-06T00:00:00Z,RP_UK,,,Asia/Tokyo
+*out,cgrates.org,call,tiered,2013-01-06T00:00:00Z,RP_TIERED,,,
+*out,cgrates.org,call,minmax,2013-01-06T00:00:00Z,RP_MINMAX,,,
 `
 	sharedGroups = `
 SG1,*any,*lowest,
@@ -149,17 +161,17 @@ SG3,*any,*lowest,
 *in,cgrates.org,call,*any,*any,*any,LCR_STANDARD,*lowest_cost,,2012-01-01T00:00:00Z,20
 `
 	actions = `
-MINI,*topup_reset,,,*monetary,*out,,,,,*unlimited,,10,10,10
-MINI,*topup,,,*voice,*out,,NAT,test,,*unlimited,,100,10,10
-SHARED,*topup,,,*monetary,*out,,,,SG1,*unlimited,,100,10,10
-TOPUP10_AC,*topup_reset,,,*monetary,*out,,*any,,,*unlimited,,1,10,10
-TOPUP10_AC1,*topup_reset,,,*voice,*out,,DST_UK_Mobile_BIG5,discounted_minutes,,*unlimited,,40,10,10
-SE0,*topup_reset,,,*monetary,*out,,,,SG2,*unlimited,,0,10,10
-SE10,*topup_reset,,,*monetary,*out,,,,SG2,*unlimited,,10,5,10
-SE10,*topup,,,*monetary,*out,,,,,*unlimited,,10,10,10
-EE0,*topup_reset,,,*monetary,*out,,,,SG3,*unlimited,,0,10,10
-EE0,*allow_negative,,,*monetary,*out,,,,,*unlimited,,0,10,10
-DEFEE,*cdrlog,"{""Category"":""^ddi"",""MediationRunId"":""^did_run""}",,,,,,,,,,,,10
+MINI,*topup_reset,,,*monetary,*out,,,,,*unlimited,,10,10,10,
+MINI,*topup,,,*voice,*out,,NAT,test,,*unlimited,,100,10,10,
+SHARED,*topup,,,*monetary,*out,,,,SG1,*unlimited,,100,10,10,
+TOPUP10_AC,*topup_reset,,,*monetary,*out,,*any,,,*unlimited,,1,10,10,
+TOPUP10_AC1,*topup_reset,,,*voice,*out,,DST_UK_Mobile_BIG5,discounted_minutes,,*unlimited,,40,10,10,
+SE0,*topup_reset,,,*monetary,*out,,,,SG2,*unlimited,,0,10,10,
+SE10,*topup_reset,,,*monetary,*out,,,,SG2,*unlimited,,10,5,10,
+SE10,*topup,,,*monetary,*out,,,,,*unlimited,,10,10,10,
+EE0,*topup_reset,,,*monetary,*out,,,,SG3,*unlimited,,0,10,10,
+EE0,*allow_negative,,,*monetary,*out,,,,,*unlimited,,0,10,10,
+DEFEE,*cdrlog,"{""Category"":""^ddi"",""MediationRunId"":""^did_run""}",,,,,,,,,,,,10,
 `
 	actionTimings = `
 MORE_MINUTES,MINI,ONE_TIME_RUN,10
@@ -194,9 +206,9 @@ vdf,emptyY,*out,TOPUP_EMPTY_AT,
 
 	derivedCharges = `
 #Direction,Tenant,Category,Account,Subject,RunId,RunFilter,ReqTypeField,DirectionField,TenantField,TorField,AccountField,SubjectField,DestinationField,SetupTimeField,PddField,AnswerTimeField,UsageField
-*out,cgrates.org,call,dan,dan,extra1,^filteredHeader1/filterValue1/,^prepaid,,,,rif,rif,,,,,,,
-*out,cgrates.org,call,dan,dan,extra2,,,,,,ivo,ivo,,,,,,,
-*out,cgrates.org,call,dan,*any,extra1,,,,,,rif2,rif2,,,,,,,
+*out,cgrates.org,call,dan,dan,extra1,^filteredHeader1/filterValue1/,^prepaid,,,,rif,rif,,,,,,,,
+*out,cgrates.org,call,dan,dan,extra2,,,,,,ivo,ivo,,,,,,,,
+*out,cgrates.org,call,dan,*any,extra1,,,,,,rif2,rif2,,,,,,,,
 `
 	cdrStats = `
 #Id[0],QueueLength[1],TimeWindow[2],Metric[3],SetupInterval[4],TOR[5],CdrHost[6],CdrSource[7],ReqType[8],Direction[9],Tenant[10],Category[11],Account[12],Subject[13],DestinationPrefix[14],PddInterval[15],UsageInterval[16],Supplier[17],DisconnectCause[18],MediationRunIds[19],RatedAccount[20],RatedSubject[21],CostInterval[22],Triggers[23]CDRST1,5,60m,ASR,2014-07-29T15:00:00Z;2014-07-29T16:00:00Z,*voice,87.139.12.167,FS_JSON,*rated,*out,cgrates.org,call,dan,dan,49,5m;10m,suppl1,NORMAL_CLEARING,default,rif,rif,0;2,STANDARD_TRIGGERS
@@ -205,6 +217,30 @@ CDRST1,,,ACD,,,,,,,,,,,,,,,,,,,,STANDARD_TRIGGER
 CDRST1,,,ACC,,,,,,,,,,,,,,,,,,,,
 CDRST2,10,10m,ASR,,,,,,,cgrates.org,call,,,,,,,,,,,,
 CDRST2,,,ACD,,,,,,,,,,,,,,,,,,,,
+`
+	exchangeRates = `
+#Tag,FromCurrency,ToCurrency,Rate
+EXR_STD,EUR,USD,1.12
+EXR_STD,GBP,EUR,1.4
+`
+	holidays = `
+#Tag,Date,Name
+*holidays_de,01-01,Neujahr
+*holidays_de,*easter-2,Karfreitag
+*holidays_de,*easter+1,Ostermontag
+*holidays_de,12-25,Weihnachten
+*holidays_de,2017-10-31,Reformationstag
+`
+	taxes = `
+#Tenant,Category,DestinationTag,TaxId,Rate,Debit,Weight
+cgrates.org,taxes,*any,VAT,19,true,10
+cgrates.org,taxes,NAT,VAT,24,true,10
+cgrates.org,taxes,GERMANY,REGIONAL,1.5,false,20
+`
+	numberPortability = `
+#Number,RoutingNumber
+0724111222,0256111222
++49151123456,+49160123456
 `
 )
 
@@ -212,10 +248,13 @@ var csvr *TpReader
 
 func init() {
 	csvr = NewTpReader(ratingStorage, accountingStorage, NewStringCSVStorage(',', destinations, timings, rates, destinationRates, ratingPlans, ratingProfiles,
-		sharedGroups, lcrs, actions, actionTimings, actionTriggers, accountActions, derivedCharges, cdrStats), "")
+		sharedGroups, lcrs, actions, actionTimings, actionTriggers, accountActions, derivedCharges, cdrStats, exchangeRates, taxes, holidays, numberPortability), "")
 	if err := csvr.LoadDestinations(); err != nil {
 		log.Print("error in LoadDestinations:", err)
 	}
+	if err := csvr.LoadHolidayCalendars(); err != nil {
+		log.Print("error in LoadHolidayCalendars:", err)
+	}
 	if err := csvr.LoadTimings(); err != nil {
 		log.Print("error in LoadTimings:", err)
 	}
@@ -255,8 +294,17 @@ func init() {
 	if err := csvr.LoadCdrStats(); err != nil {
 		log.Print("error in LoadCdrStats:", err)
 	}
+	if err := csvr.LoadExchangeRates(); err != nil {
+		log.Print("error in LoadExchangeRates:", err)
+	}
+	if err := csvr.LoadTaxes(); err != nil {
+		log.Print("error in LoadTaxes:", err)
+	}
+	if err := csvr.LoadNumberPortability(); err != nil {
+		log.Print("error in LoadNumberPortability:", err)
+	}
 	csvr.WriteToDatabase(false, false)
-	ratingStorage.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingStorage.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	accountingStorage.CacheAccounting(nil, nil, nil)
 }
 
@@ -306,8 +354,27 @@ func TestLoadDestinations(t *testing.T) {
 	}
 }
 
+func TestLoadDestinationPatterns(t *testing.T) {
+	tpr := NewTpReader(ratingStorage, accountingStorage, NewStringCSVStorage(',', `
+#Tag,Prefix
+DST_PATTERNS,~^\d+$
+DST_PATTERNS,0256000-0256999
+DST_PATTERNS,~^\+40(72|73)\d+$
+`, "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", ""), "")
+	if err := tpr.LoadDestinations(); err != nil {
+		t.Fatal("Error loading destinations: ", err)
+	}
+	if d, found := tpr.destinations["DST_PATTERNS"]; !found ||
+		!reflect.DeepEqual(d.Prefixes, []string{`~^\d+$`, `0256000-0256999`, `~^\+40(72|73)\d+$`}) {
+		t.Errorf("Failed to load the destination patterns: %+v", d)
+	}
+	if !tpr.IsValid() {
+		t.Error("Expecting the destination patterns to be valid")
+	}
+}
+
 func TestLoadTimimgs(t *testing.T) {
-	if len(csvr.timings) != 6 {
+	if len(csvr.timings) != 7 {
 		t.Error("Failed to load timings: ", csvr.timings)
 	}
 	timing := csvr.timings["WORKDAYS_00"]
@@ -354,10 +421,42 @@ func TestLoadTimimgs(t *testing.T) {
 	}) {
 		t.Error("Error loading timing: ", timing)
 	}
+	timing = csvr.timings["HOLIDAYS_DE"]
+	if !reflect.DeepEqual(timing, &utils.TPTiming{
+		TimingId:  "HOLIDAYS_DE",
+		Years:     utils.Years{},
+		Months:    utils.Months{},
+		Holidays:  utils.HolidayCalendars{"*holidays_de"},
+		WeekDays:  utils.WeekDays{},
+		StartTime: "00:00:00",
+	}) {
+		t.Error("Error loading timing: ", timing)
+	}
+}
+
+func TestLoadHolidayCalendars(t *testing.T) {
+	if len(csvr.holidayCalendars) != 1 {
+		t.Error("Failed to load holiday calendars: ", csvr.holidayCalendars)
+	}
+	hc := csvr.holidayCalendars["*holidays_de"]
+	if hc == nil || len(hc.Holidays) != 5 {
+		t.Fatalf("Error loading holiday calendar: %+v", hc)
+	}
+	expected := &Holiday{Name: "Ostermontag", Easter: EASTER, Offset: 1}
+	if !reflect.DeepEqual(hc.Holidays[2], expected) {
+		t.Errorf("Expecting: %+v, received: %+v", expected, hc.Holidays[2])
+	}
+	expected = &Holiday{Name: "Reformationstag", Year: 2017, Month: time.October, MonthDay: 31}
+	if !reflect.DeepEqual(hc.Holidays[4], expected) {
+		t.Errorf("Expecting: %+v, received: %+v", expected, hc.Holidays[4])
+	}
+	if cached, err := ratingStorage.GetHolidayCalendar("*holidays_de", false); err != nil || len(cached.Holidays) != 5 {
+		t.Errorf("Error getting cached holiday calendar: %+v, %v", cached, err)
+	}
 }
 
 func TestLoadRates(t *testing.T) {
-	if len(csvr.rates) != 13 {
+	if len(csvr.rates) != 15 {
 		t.Error("Failed to load rates: ", len(csvr.rates))
 	}
 	rate := csvr.rates["R1"].RateSlots[0]
@@ -424,10 +523,14 @@ func TestLoadRates(t *testing.T) {
 		rate.GroupIntervalStartDuration() != expctRs.GroupIntervalStartDuration() {
 		t.Error("Error loading rate: ", rate)
 	}
+	rate = csvr.rates["R_MINMAX"].RateSlots[0]
+	if rate.MinCost != 0.5 || rate.MaxCost != 1 {
+		t.Error("Error loading rate min/max cost: ", rate)
+	}
 }
 
 func TestLoadDestinationRates(t *testing.T) {
-	if len(csvr.destinationRates) != 13 {
+	if len(csvr.destinationRates) != 15 {
 		t.Error("Failed to load destinationrates: ", len(csvr.destinationRates))
 	}
 	drs := csvr.destinationRates["RT_STANDARD"]
@@ -575,7 +678,7 @@ func TestLoadDestinationRates(t *testing.T) {
 }
 
 func TestLoadRatingPlans(t *testing.T) {
-	if len(csvr.ratingPlans) != 12 {
+	if len(csvr.ratingPlans) != 14 {
 		t.Error("Failed to load rating plans: ", len(csvr.ratingPlans))
 	}
 	rplan := csvr.ratingPlans["STANDARD"]
@@ -617,11 +720,11 @@ func TestLoadRatingPlans(t *testing.T) {
 		},
 		Ratings: map[string]*RIRate{
 			"b457f86d": &RIRate{
-				ConnectFee: 0,
+				ConnectFee: utils.NewDecimalFromFloat(0),
 				Rates: []*Rate{
 					&Rate{
 						GroupIntervalStart: 0,
-						Value:              0.2,
+						Value:              utils.NewDecimalFromFloat(0.2),
 						RateIncrement:      time.Second,
 						RateUnit:           time.Minute,
 					},
@@ -631,11 +734,11 @@ func TestLoadRatingPlans(t *testing.T) {
 				tag:              "R1",
 			},
 			"16e9ee19": &RIRate{
-				ConnectFee: 0,
+				ConnectFee: utils.NewDecimalFromFloat(0),
 				Rates: []*Rate{
 					&Rate{
 						GroupIntervalStart: 0,
-						Value:              0.1,
+						Value:              utils.NewDecimalFromFloat(0.1),
 						RateIncrement:      time.Second,
 						RateUnit:           time.Minute,
 					},
@@ -645,11 +748,11 @@ func TestLoadRatingPlans(t *testing.T) {
 				tag:              "R2",
 			},
 			"638dc1ab": &RIRate{
-				ConnectFee: 0,
+				ConnectFee: utils.NewDecimalFromFloat(0),
 				Rates: []*Rate{
 					&Rate{
 						GroupIntervalStart: 0,
-						Value:              0.05,
+						Value:              utils.NewDecimalFromFloat(0.05),
 						RateIncrement:      time.Second,
 						RateUnit:           time.Minute,
 					},
@@ -659,11 +762,11 @@ func TestLoadRatingPlans(t *testing.T) {
 				tag:              "R3",
 			},
 			"3913037f": &RIRate{
-				ConnectFee: 0,
+				ConnectFee: utils.NewDecimalFromFloat(0),
 				Rates: []*Rate{
 					&Rate{
 						GroupIntervalStart: 0,
-						Value:              0,
+						Value:              utils.NewDecimalFromFloat(0),
 						RateIncrement:      time.Second,
 						RateUnit:           time.Second,
 					},
@@ -741,13 +844,27 @@ func TestLoadRatingPlans(t *testing.T) {
 		tag:        utils.ANY,
 	}
 
-	if !reflect.DeepEqual(csvr.ratingPlans["ANY_PLAN"].Timings["1323e132"], anyTiming) {
-		t.Errorf("Error using *any timing in rating plans: %+v : %+v", csvr.ratingPlans["ANY_PLAN"].Timings["1323e132"], anyTiming)
+	if !reflect.DeepEqual(csvr.ratingPlans["ANY_PLAN"].Timings["476ada56"], anyTiming) {
+		t.Errorf("Error using *any timing in rating plans: %+v : %+v", csvr.ratingPlans["ANY_PLAN"].Timings["476ada56"], anyTiming)
+	}
+	rprs := csvr.ratingPlans["RP_TIERED"].DestinationRates["NAT"]
+	if len(rprs) != 1 || len(rprs[0].Tiers) != 1 || rprs[0].Tiers[0].UsageStart != 1000*time.Minute ||
+		csvr.ratingPlans["RP_TIERED"].Ratings[rprs[0].Tiers[0].Rating].Rates[0].Value.String() != "0.5" {
+		t.Errorf("Error loading rate tiers: %+v", rprs)
+	}
+	rprs = csvr.ratingPlans["RP_MINMAX"].DestinationRates["NAT"]
+	if rating := csvr.ratingPlans["RP_MINMAX"].Ratings[rprs[0].Rating]; rating.MinCost.String() != "0.5" ||
+		rating.MaxCost.String() != "1" || rating.MaxCostStrategy != utils.MAX_COST_FREE {
+		t.Errorf("Error loading rate min/max cost: %+v", rating)
+	}
+	rprs = csvr.ratingPlans["RP_MINMAX"].DestinationRates[OriginRatesKey("NAT", "PSTN_71")]
+	if len(rprs) != 1 || csvr.ratingPlans["RP_MINMAX"].Ratings[rprs[0].Rating].Rates[0].Value.String() != "0.1" {
+		t.Errorf("Error loading the origin rates: %+v", rprs)
 	}
 }
 
 func TestLoadRatingProfiles(t *testing.T) {
-	if len(csvr.ratingProfiles) != 19 {
+	if len(csvr.ratingProfiles) != 22 {
 		t.Error("Failed to load rating profiles: ", len(csvr.ratingProfiles), csvr.ratingProfiles)
 	}
 	rp := csvr.ratingProfiles["*out:test:0:trp"]
@@ -763,6 +880,10 @@ func TestLoadRatingProfiles(t *testing.T) {
 	if !reflect.DeepEqual(rp, expected) {
 		t.Errorf("Error loading rating profile: %+v", rp.RatingPlanActivations[0])
 	}
+	rp = csvr.ratingProfiles["*out:cgrates.org:call:tokyo"]
+	if len(rp.RatingPlanActivations) != 1 || rp.RatingPlanActivations[0].Timezone != "Asia/Tokyo" {
+		t.Errorf("Error loading rating profile timezone: %+v", rp.RatingPlanActivations[0])
+	}
 }
 
 func TestLoadActions(t *testing.T) {
@@ -781,7 +902,7 @@ func TestLoadActions(t *testing.T) {
 			Weight:           10,
 			Balance: &Balance{
 				Uuid:   as1[0].Balance.Uuid,
-				Value:  10,
+				Value:  utils.NewDecimalFromFloat(10),
 				Weight: 10,
 			},
 		},
@@ -795,7 +916,7 @@ func TestLoadActions(t *testing.T) {
 			Weight:           10,
 			Balance: &Balance{
 				Uuid:           as1[1].Balance.Uuid,
-				Value:          100,
+				Value:          utils.NewDecimalFromFloat(100),
 				Weight:         10,
 				RatingSubject:  "test",
 				DestinationIds: "NAT",
@@ -816,7 +937,7 @@ func TestLoadActions(t *testing.T) {
 			Weight:           10,
 			Balance: &Balance{
 				Uuid:        as2[0].Balance.Uuid,
-				Value:       100,
+				Value:       utils.NewDecimalFromFloat(100),
 				Weight:      10,
 				SharedGroup: "SG1",
 			},
@@ -1043,11 +1164,11 @@ func TestLoadDerivedChargers(t *testing.T) {
 		&utils.DerivedCharger{RunId: "extra1", RunFilters: "^filteredHeader1/filterValue1/", ReqTypeField: "^prepaid", DirectionField: utils.META_DEFAULT,
 			TenantField: utils.META_DEFAULT, CategoryField: utils.META_DEFAULT, AccountField: "rif", SubjectField: "rif", DestinationField: utils.META_DEFAULT,
 			SetupTimeField: utils.META_DEFAULT, PddField: utils.META_DEFAULT, AnswerTimeField: utils.META_DEFAULT, UsageField: utils.META_DEFAULT,
-			SupplierField: utils.META_DEFAULT, DisconnectCauseField: utils.META_DEFAULT},
+			SupplierField: utils.META_DEFAULT, DisconnectCauseField: utils.META_DEFAULT, OriginField: utils.META_DEFAULT},
 		&utils.DerivedCharger{RunId: "extra2", ReqTypeField: utils.META_DEFAULT, DirectionField: utils.META_DEFAULT, TenantField: utils.META_DEFAULT,
 			CategoryField: utils.META_DEFAULT, AccountField: "ivo", SubjectField: "ivo", DestinationField: utils.META_DEFAULT,
 			SetupTimeField: utils.META_DEFAULT, PddField: utils.META_DEFAULT, AnswerTimeField: utils.META_DEFAULT, UsageField: utils.META_DEFAULT,
-			SupplierField: utils.META_DEFAULT, DisconnectCauseField: utils.META_DEFAULT},
+			SupplierField: utils.META_DEFAULT, DisconnectCauseField: utils.META_DEFAULT, OriginField: utils.META_DEFAULT},
 	}
 	keyCharger1 := utils.DerivedChargersKey("*out", "cgrates.org", "call", "dan", "dan")
 
@@ -1093,3 +1214,42 @@ func TestLoadCdrStats(t *testing.T) {
 		t.Errorf("Unexpected stats %+v", csvr.cdrStats[cdrStats1.Id])
 	}
 }
+
+func TestLoadExchangeRates(t *testing.T) {
+	if len(csvr.exchangeRates) != 2 {
+		t.Error("Failed to load exchange rates: ", csvr.exchangeRates)
+	}
+	expected := &ExchangeRate{FromCurrency: "EUR", ToCurrency: "USD", Rate: utils.NewDecimalFromFloat(1.12)}
+	if exr := csvr.exchangeRates["EUR:USD"]; !exr.Equal(expected) {
+		t.Errorf("Expecting: %+v, received: %+v", expected, exr)
+	}
+	if exr, err := ratingStorage.GetExchangeRate("GBP:EUR", false); err != nil || !exr.Rate.Equal(utils.NewDecimalFromFloat(1.4)) {
+		t.Errorf("Error getting cached exchange rate: %+v, %v", exr, err)
+	}
+}
+
+func TestLoadTaxes(t *testing.T) {
+	if len(csvr.taxes) != 1 {
+		t.Error("Failed to load taxes: ", csvr.taxes)
+	}
+	taxes := csvr.taxes["cgrates.org:taxes"]
+	if taxes == nil || len(taxes.Rules) != 3 {
+		t.Fatalf("Error loading taxes: %+v", taxes)
+	}
+	expected := &TaxRule{DestinationId: "GERMANY", TaxId: "REGIONAL", Rate: utils.NewDecimalFromFloat(1.5), Debit: false, Weight: 20}
+	if !reflect.DeepEqual(taxes.Rules[2], expected) {
+		t.Errorf("Expecting: %+v, received: %+v", expected, taxes.Rules[2])
+	}
+	if cached, err := ratingStorage.GetTaxes("cgrates.org:taxes", false); err != nil || len(cached.Rules) != 3 {
+		t.Errorf("Error getting cached taxes: %+v, %v", cached, err)
+	}
+}
+
+func TestLoadNumberPortability(t *testing.T) {
+	if len(csvr.portedNumbers) != 2 || csvr.portedNumbers["+49151123456"] != "+49160123456" {
+		t.Error("Failed to load ported numbers: ", csvr.portedNumbers)
+	}
+	if rtNr, err := ratingStorage.GetRoutingNumber("0724111222", false); err != nil || rtNr != "0256111222" {
+		t.Errorf("Error getting cached routing number: %s, %v", rtNr, err)
+	}
+}
@@ -130,11 +130,18 @@ func TestLoadFromCSV(t *testing.T) {
 		path.Join(*dataDir, "tariffplans", *tpCsvScenario, utils.ACTION_TRIGGERS_CSV),
 		path.Join(*dataDir, "tariffplans", *tpCsvScenario, utils.ACCOUNT_ACTIONS_CSV),
 		path.Join(*dataDir, "tariffplans", *tpCsvScenario, utils.DERIVED_CHARGERS_CSV),
-		path.Join(*dataDir, "tariffplans", *tpCsvScenario, utils.CDR_STATS_CSV)), "")
+		path.Join(*dataDir, "tariffplans", *tpCsvScenario, utils.CDR_STATS_CSV),
+		path.Join(*dataDir, "tariffplans", *tpCsvScenario, utils.EXCHANGE_RATES_CSV),
+		path.Join(*dataDir, "tariffplans", *tpCsvScenario, utils.TAXES_CSV),
+		path.Join(*dataDir, "tariffplans", *tpCsvScenario, utils.HOLIDAYS_CSV),
+		path.Join(*dataDir, "tariffplans", *tpCsvScenario, utils.NUMBER_PORTABILITY_CSV)), "")
 
 	if err = loader.LoadDestinations(); err != nil {
 		t.Error("Failed loading destinations: ", err.Error())
 	}
+	if err = loader.LoadHolidayCalendars(); err != nil {
+		t.Error("Failed loading holiday calendars: ", err.Error())
+	}
 	if err = loader.LoadTimings(); err != nil {
 		t.Error("Failed loading timings: ", err.Error())
 	}
@@ -57,6 +57,8 @@ func APItoModelRate(r *utils.TPRate) (result []TpRate) {
 			RateUnit:           rs.RateUnit,
 			RateIncrement:      rs.RateIncrement,
 			GroupIntervalStart: rs.GroupIntervalStart,
+			MinCost:            rs.MinCost,
+			MaxCost:            rs.MaxCost,
 		})
 	}
 	if len(r.RateSlots) == 0 {
@@ -79,6 +81,9 @@ func APItoModelDestinationRate(drs *utils.TPDestinationRate) (result []TpDestina
 			RoundingDecimals: dr.RoundingDecimals,
 			MaxCost:          dr.MaxCost,
 			MaxCostStrategy:  dr.MaxCostStrategy,
+			Currency:         dr.Currency,
+			UsageStart:       dr.UsageStart,
+			OriginsTag:       dr.OriginId,
 		})
 	}
 	if len(drs.DestinationRates) == 0 {
@@ -122,6 +127,7 @@ func APItoModelRatingProfile(rpf *utils.TPRatingProfile) (result []TpRatingProfi
 			RatingPlanTag:    ra.RatingPlanId,
 			FallbackSubjects: ra.FallbackSubjects,
 			CdrStatQueueIds:  ra.CdrStatQueueIds,
+			Timezone:         ra.Timezone,
 		})
 	}
 	if len(rpf.RatingPlanActivations) == 0 {
@@ -181,6 +187,7 @@ func APItoModelAction(as *utils.TPActions) (result []TpAction) {
 			BalanceWeight:   a.BalanceWeight,
 			ExtraParameters: a.ExtraParameters,
 			Weight:          a.Weight,
+			Currency:        a.Currency,
 		})
 	}
 	if len(as.Actions) == 0 {
@@ -276,6 +283,48 @@ func APItoModelSharedGroup(sgs *utils.TPSharedGroups) (result []TpSharedGroup) {
 	return
 }
 
+func APItoModelExchangeRate(exrs *utils.TPExchangeRates) (result []TpExchangeRate) {
+	for _, exr := range exrs.ExchangeRates {
+		result = append(result, TpExchangeRate{
+			Tpid:         exrs.TPid,
+			Tag:          exrs.ExchangeRatesId,
+			FromCurrency: exr.FromCurrency,
+			ToCurrency:   exr.ToCurrency,
+			Rate:         exr.Rate,
+		})
+	}
+	if len(exrs.ExchangeRates) == 0 {
+		result = append(result, TpExchangeRate{
+			Tpid: exrs.TPid,
+			Tag:  exrs.ExchangeRatesId,
+		})
+	}
+	return
+}
+
+func APItoModelTaxes(taxes *utils.TPTaxes) (result []TpTax) {
+	for _, tax := range taxes.Taxes {
+		result = append(result, TpTax{
+			Tpid:           taxes.TPid,
+			Tenant:         taxes.Tenant,
+			Category:       taxes.Category,
+			DestinationTag: tax.DestinationId,
+			TaxId:          tax.TaxId,
+			Rate:           tax.Rate,
+			Debit:          tax.Debit,
+			Weight:         tax.Weight,
+		})
+	}
+	if len(taxes.Taxes) == 0 {
+		result = append(result, TpTax{
+			Tpid:     taxes.TPid,
+			Tenant:   taxes.Tenant,
+			Category: taxes.Category,
+		})
+	}
+	return
+}
+
 func APItoModelDerivedCharger(dcs *utils.TPDerivedChargers) (result []TpDerivedCharger) {
 	for _, dc := range dcs.DerivedChargers {
 		result = append(result, TpDerivedCharger{
@@ -301,6 +350,7 @@ func APItoModelDerivedCharger(dcs *utils.TPDerivedChargers) (result []TpDerivedC
 			UsageField:           dc.UsageField,
 			SupplierField:        dc.SupplierField,
 			DisconnectCauseField: dc.DisconnectCauseField,
+			OriginField:          dc.OriginField,
 		})
 	}
 	if len(dcs.DerivedChargers) == 0 {
@@ -356,3 +406,29 @@ func APItoModelCdrStat(stats *utils.TPCdrStats) (result []TpCdrstat) {
 	}
 	return
 }
+
+func APItoModelHolidayCalendar(hc *utils.TPHolidayCalendar) (result []TpHoliday) {
+	for _, h := range hc.Holidays {
+		result = append(result, TpHoliday{
+			Tpid: hc.TPid,
+			Tag:  hc.CalendarId,
+			Date: h.Date,
+			Name: h.Name,
+		})
+	}
+	if len(hc.Holidays) == 0 {
+		result = append(result, TpHoliday{
+			Tpid: hc.TPid,
+			Tag:  hc.CalendarId,
+		})
+	}
+	return
+}
+
+func APItoModelPortedNumber(pn *utils.TPPortedNumber) TpNumberPortability {
+	return TpNumberPortability{
+		Tpid:          pn.TPid,
+		Number:        pn.Number,
+		RoutingNumber: pn.RoutingNumber,
+	}
+}
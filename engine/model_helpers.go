@@ -191,6 +191,7 @@ func (tps TpTimings) GetTimings() (map[string]*utils.TPTiming, error) {
 		rt.Years.Parse(tp.Years, utils.INFIELD_SEP)
 		rt.Months.Parse(tp.Months, utils.INFIELD_SEP)
 		rt.MonthDays.Parse(tp.MonthDays, utils.INFIELD_SEP)
+		rt.Holidays.Parse(tp.MonthDays, utils.INFIELD_SEP)
 		rt.WeekDays.Parse(tp.WeekDays, utils.INFIELD_SEP)
 		times := strings.Split(tp.Time, utils.INFIELD_SEP)
 		rt.StartTime = times[0]
@@ -233,6 +234,7 @@ func (tps TpRates) GetRates() (map[string]*utils.TPRate, error) {
 		if err != nil {
 			return nil, err
 		}
+		rs.MinCost, rs.MaxCost = tp.MinCost, tp.MaxCost
 		r := &utils.TPRate{
 			TPid:      tp.Tpid,
 			RateId:    tp.Tag,
@@ -266,6 +268,9 @@ func (tps TpDestinationRates) GetDestinationRates() (map[string]*utils.TPDestina
 					RoundingDecimals: tpDr.RoundingDecimals,
 					MaxCost:          tpDr.MaxCost,
 					MaxCostStrategy:  tpDr.MaxCostStrategy,
+					Currency:         tpDr.Currency,
+					UsageStart:       tpDr.UsageStart,
+					OriginId:         tpDr.OriginsTag,
 				},
 			},
 		}
@@ -308,24 +313,32 @@ func GetRateInterval(rpl *utils.TPRatingPlanBinding, dr *utils.DestinationRate)
 			Years:     rpl.Timing().Years,
 			Months:    rpl.Timing().Months,
 			MonthDays: rpl.Timing().MonthDays,
+			Holidays:  rpl.Timing().Holidays,
 			WeekDays:  rpl.Timing().WeekDays,
 			StartTime: rpl.Timing().StartTime,
 			tag:       rpl.Timing().TimingId,
 		},
 		Weight: rpl.Weight,
 		Rating: &RIRate{
-			ConnectFee:       dr.Rate.RateSlots[0].ConnectFee,
+			ConnectFee:       utils.NewDecimalFromFloat(dr.Rate.RateSlots[0].ConnectFee),
 			RoundingMethod:   dr.RoundingMethod,
 			RoundingDecimals: dr.RoundingDecimals,
-			MaxCost:          dr.MaxCost,
+			MinCost:          utils.NewDecimalFromFloat(dr.Rate.RateSlots[0].MinCost),
+			MaxCost:          utils.NewDecimalFromFloat(dr.MaxCost),
 			MaxCostStrategy:  dr.MaxCostStrategy,
+			Currency:         dr.Currency,
 			tag:              dr.Rate.RateId,
 		},
 	}
+	// the cap of the rate applies when the destination rate has none, the call continues free of charge
+	if dr.MaxCost == 0 && dr.Rate.RateSlots[0].MaxCost > 0 {
+		i.Rating.MaxCost = utils.NewDecimalFromFloat(dr.Rate.RateSlots[0].MaxCost)
+		i.Rating.MaxCostStrategy = utils.MAX_COST_FREE
+	}
 	for _, rl := range dr.Rate.RateSlots {
 		i.Rating.Rates = append(i.Rating.Rates, &Rate{
 			GroupIntervalStart: rl.GroupIntervalStartDuration(),
-			Value:              rl.Rate,
+			Value:              utils.NewDecimalFromFloat(rl.Rate),
 			RateIncrement:      rl.RateIncrementDuration(),
 			RateUnit:           rl.RateUnitDuration(),
 		})
@@ -333,6 +346,35 @@ func GetRateInterval(rpl *utils.TPRatingPlanBinding, dr *utils.DestinationRate)
 	return
 }
 
+// Returns the rate intervals of the binding for each destination (qualified by the origin if any),
+// the destination rates with usage start become the tiers of the one without
+func GetRateIntervals(rpl *utils.TPRatingPlanBinding, drs []*utils.DestinationRate) (map[string]*RateInterval, error) {
+	ris := make(map[string]*RateInterval)
+	tiers := make(map[string]RateTiers)
+	for _, dr := range drs {
+		usageStart, err := dr.UsageStartDuration()
+		if err != nil {
+			return nil, fmt.Errorf("invalid usage start %s for rate %s: %v", dr.UsageStart, dr.RateId, err)
+		}
+		ri := GetRateInterval(rpl, dr)
+		key := OriginRatesKey(dr.DestinationId, dr.OriginId)
+		if usageStart == 0 {
+			ris[key] = ri
+			continue
+		}
+		tiers[key] = append(tiers[key], &RateTier{UsageStart: usageStart, Rating: ri.Rating})
+	}
+	for dId, rts := range tiers {
+		ri, found := ris[dId]
+		if !found {
+			return nil, fmt.Errorf("no rate without usage start for the tiers of destination %s", dId)
+		}
+		rts.Sort()
+		ri.Tiers = rts
+	}
+	return ris, nil
+}
+
 type TpRatingProfiles []TpRatingProfile
 
 func (tps TpRatingProfiles) GetRatingProfiles() (map[string]*utils.TPRatingProfile, error) {
@@ -352,6 +394,7 @@ func (tps TpRatingProfiles) GetRatingProfiles() (map[string]*utils.TPRatingProfi
 			RatingPlanId:     tpRpf.RatingPlanTag,
 			FallbackSubjects: tpRpf.FallbackSubjects,
 			CdrStatQueueIds:  tpRpf.CdrStatQueueIds,
+			Timezone:         tpRpf.Timezone,
 		}
 		if existingRpf, exists := rpfs[rp.KeyId()]; !exists {
 			rp.RatingPlanActivations = []*utils.TPRatingActivation{ra}
@@ -378,6 +421,81 @@ func (tps TpSharedGroups) GetSharedGroups() (map[string][]*utils.TPSharedGroup,
 	return sgs, nil
 }
 
+type TpExchangeRates []TpExchangeRate
+
+func (tps TpExchangeRates) GetExchangeRates() (map[string][]*utils.TPExchangeRate, error) {
+	exrs := make(map[string][]*utils.TPExchangeRate)
+	for _, tpExr := range tps {
+		exrs[tpExr.Tag] = append(exrs[tpExr.Tag], &utils.TPExchangeRate{
+			FromCurrency: tpExr.FromCurrency,
+			ToCurrency:   tpExr.ToCurrency,
+			Rate:         tpExr.Rate,
+		})
+	}
+	return exrs, nil
+}
+
+type TpTaxes []TpTax
+
+func (tps TpTaxes) GetTaxes() (map[string]*utils.TPTaxes, error) {
+	taxes := make(map[string]*utils.TPTaxes)
+	for _, tpTax := range tps {
+		tpt := &utils.TPTaxes{TPid: tpTax.Tpid, Tenant: tpTax.Tenant, Category: tpTax.Category}
+		tag := tpt.GetTaxesId()
+		if _, hasIt := taxes[tag]; !hasIt {
+			taxes[tag] = tpt
+		}
+		if tpTax.TaxId == "" { // empty set, defined over API
+			continue
+		}
+		taxes[tag].Taxes = append(taxes[tag].Taxes, &utils.TPTax{
+			DestinationId: tpTax.DestinationTag,
+			TaxId:         tpTax.TaxId,
+			Rate:          tpTax.Rate,
+			Debit:         tpTax.Debit,
+			Weight:        tpTax.Weight,
+		})
+	}
+	return taxes, nil
+}
+
+type TpHolidays []TpHoliday
+
+func (tps TpHolidays) GetHolidayCalendars() (map[string]*utils.TPHolidayCalendar, error) {
+	calendars := make(map[string]*utils.TPHolidayCalendar)
+	for _, tpHoliday := range tps {
+		if _, hasIt := calendars[tpHoliday.Tag]; !hasIt {
+			calendars[tpHoliday.Tag] = &utils.TPHolidayCalendar{TPid: tpHoliday.Tpid, CalendarId: tpHoliday.Tag}
+		}
+		if tpHoliday.Date == "" { // empty calendar, defined over API
+			continue
+		}
+		calendars[tpHoliday.Tag].Holidays = append(calendars[tpHoliday.Tag].Holidays, &utils.TPHoliday{
+			Date: tpHoliday.Date,
+			Name: tpHoliday.Name,
+		})
+	}
+	return calendars, nil
+}
+
+type TpNumberPortabilitys []TpNumberPortability
+
+// Returns the routing number for each ported number
+func (tps TpNumberPortabilitys) GetPortedNumbers() (map[string]*utils.TPPortedNumber, error) {
+	portedNumbers := make(map[string]*utils.TPPortedNumber)
+	for _, tpPortedNumber := range tps {
+		if tpPortedNumber.Number == "" || tpPortedNumber.RoutingNumber == "" {
+			return nil, fmt.Errorf("invalid ported number: %+v", tpPortedNumber)
+		}
+		portedNumbers[tpPortedNumber.Number] = &utils.TPPortedNumber{
+			TPid:          tpPortedNumber.Tpid,
+			Number:        tpPortedNumber.Number,
+			RoutingNumber: tpPortedNumber.RoutingNumber,
+		}
+	}
+	return portedNumbers, nil
+}
+
 type TpActions []TpAction
 
 func (tps TpActions) GetActions() (map[string][]*utils.TPAction, error) {
@@ -398,6 +516,7 @@ func (tps TpActions) GetActions() (map[string][]*utils.TPAction, error) {
 			BalanceWeight:   tpAc.BalanceWeight,
 			ExtraParameters: tpAc.ExtraParameters,
 			Weight:          tpAc.Weight,
+			Currency:        tpAc.Currency,
 		}
 		as[tpAc.Tag] = append(as[tpAc.Tag], a)
 	}
@@ -491,6 +610,7 @@ func (tps TpDerivedChargers) GetDerivedChargers() (map[string]*utils.TPDerivedCh
 			UsageField:           ValueOrDefault(tpDcMdl.UsageField, utils.META_DEFAULT),
 			SupplierField:        ValueOrDefault(tpDcMdl.SupplierField, utils.META_DEFAULT),
 			DisconnectCauseField: ValueOrDefault(tpDcMdl.DisconnectCauseField, utils.META_DEFAULT),
+			OriginField:          ValueOrDefault(tpDcMdl.OriginField, utils.META_DEFAULT),
 		}
 		dcs[tag].DerivedChargers = append(dcs[tag].DerivedChargers, nDc)
 	}
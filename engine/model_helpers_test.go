@@ -68,7 +68,9 @@ func TestTPRateAsExportSlice(t *testing.T) {
 				Rate:               0.200,
 				RateUnit:           "60",
 				RateIncrement:      "60",
-				GroupIntervalStart: "0"},
+				GroupIntervalStart: "0",
+				MinCost:            0.5,
+				MaxCost:            1},
 			&utils.RateSlot{
 				ConnectFee:         0.0,
 				Rate:               0.1,
@@ -78,8 +80,8 @@ func TestTPRateAsExportSlice(t *testing.T) {
 		},
 	}
 	expectedSlc := [][]string{
-		[]string{"TEST_RATEID", "0.1", "0.2", "60", "60", "0"},
-		[]string{"TEST_RATEID", "0", "0.1", "1", "60", "60"},
+		[]string{"TEST_RATEID", "0.1", "0.2", "60", "60", "0", "0.5", "1"},
+		[]string{"TEST_RATEID", "0", "0.1", "1", "60", "60", "0", "0"},
 	}
 
 	ms := APItoModelRate(tpRate)
@@ -110,12 +112,14 @@ func TestTPDestinationRateAsExportSlice(t *testing.T) {
 				DestinationId:    "TEST_DEST2",
 				RateId:           "TEST_RATE2",
 				RoundingMethod:   "*up",
-				RoundingDecimals: 4},
+				RoundingDecimals: 4,
+				UsageStart:       "1000m",
+				OriginId:         "TEST_ORIGIN"},
 		},
 	}
 	expectedSlc := [][]string{
-		[]string{"TEST_DSTRATE", "TEST_DEST1", "TEST_RATE1", "*up", "4", "0", ""},
-		[]string{"TEST_DSTRATE", "TEST_DEST2", "TEST_RATE2", "*up", "4", "0", ""},
+		[]string{"TEST_DSTRATE", "TEST_DEST1", "TEST_RATE1", "*up", "4", "0", "", "", "", ""},
+		[]string{"TEST_DSTRATE", "TEST_DEST2", "TEST_RATE2", "*up", "4", "0", "", "", "1000m", "TEST_ORIGIN"},
 	}
 	ms := APItoModelDestinationRate(tpDstRate)
 	var slc [][]string
@@ -208,12 +212,13 @@ func TestTPRatingProfileAsExportSlice(t *testing.T) {
 			&utils.TPRatingActivation{
 				ActivationTime:   "2014-01-15T00:00:00Z",
 				RatingPlanId:     "TEST_RPLAN2",
-				FallbackSubjects: "subj1;subj2"},
+				FallbackSubjects: "subj1;subj2",
+				Timezone:         "Europe/Berlin"},
 		},
 	}
 	expectedSlc := [][]string{
-		[]string{utils.OUT, "cgrates.org", "call", "*any", "2014-01-14T00:00:00Z", "TEST_RPLAN1", "subj1;subj2", ""},
-		[]string{utils.OUT, "cgrates.org", "call", "*any", "2014-01-15T00:00:00Z", "TEST_RPLAN2", "subj1;subj2", ""},
+		[]string{utils.OUT, "cgrates.org", "call", "*any", "2014-01-14T00:00:00Z", "TEST_RPLAN1", "subj1;subj2", "", ""},
+		[]string{utils.OUT, "cgrates.org", "call", "*any", "2014-01-15T00:00:00Z", "TEST_RPLAN2", "subj1;subj2", "", "Europe/Berlin"},
 	}
 
 	ms := APItoModelRatingProfile(tpRpf)
@@ -265,8 +270,8 @@ func TestTPActionsAsExportSlice(t *testing.T) {
 		},
 	}
 	expectedSlc := [][]string{
-		[]string{"TEST_ACTIONS", "*topup_reset", "", "", "*monetary", utils.OUT, "call", "*any", "special1", "GROUP1", "*never", "", "5", "10", "10"},
-		[]string{"TEST_ACTIONS", "*http_post", "http://localhost/&param1=value1", "", "", "", "", "", "", "", "", "", "0", "0", "20"},
+		[]string{"TEST_ACTIONS", "*topup_reset", "", "", "*monetary", utils.OUT, "call", "*any", "special1", "GROUP1", "*never", "", "5", "10", "10", ""},
+		[]string{"TEST_ACTIONS", "*http_post", "http://localhost/&param1=value1", "", "", "", "", "", "", "", "", "", "0", "0", "20", ""},
 	}
 
 	ms := APItoModelAction(tpActs)
@@ -472,6 +477,7 @@ func TestTPDerivedChargersAsExportSlice(t *testing.T) {
 				UsageField:           utils.META_DEFAULT,
 				SupplierField:        utils.META_DEFAULT,
 				DisconnectCauseField: utils.META_DEFAULT,
+				OriginField:          utils.META_DEFAULT,
 			},
 			&utils.TPDerivedCharger{
 				RunId:                "derived_run2",
@@ -489,14 +495,15 @@ func TestTPDerivedChargersAsExportSlice(t *testing.T) {
 				UsageField:           utils.META_DEFAULT,
 				SupplierField:        utils.META_DEFAULT,
 				DisconnectCauseField: utils.META_DEFAULT,
+				OriginField:          utils.META_DEFAULT,
 			},
 		},
 	}
 	expectedSlc := [][]string{
 		[]string{"*out", "cgrates.org", "call", "1001", "1001",
-			"derived_run1", "", "^rated", utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, "^1002", utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT},
+			"derived_run1", "", "^rated", utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, "^1002", utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT},
 		[]string{"*out", "cgrates.org", "call", "1001", "1001",
-			"derived_run2", "", "^rated", utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, "^1002", utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT},
+			"derived_run2", "", "^rated", utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, "^1002", utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT, utils.META_DEFAULT},
 	}
 	ms := APItoModelDerivedCharger(dcs)
 	var slc [][]string
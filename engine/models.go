@@ -45,7 +45,7 @@ type TpDestination struct {
 	Id        int64
 	Tpid      string
 	Tag       string `index:"0" re:"\w+\s*,\s*"`
-	Prefix    string `index:"1" re:"\+?\d+.?\d*"`
+	Prefix    string `index:"1" re:"\+?\d+.?\d*|^~"` // prefix, number range (start-end) or ~ anchored regular expression
 	CreatedAt time.Time
 }
 
@@ -58,6 +58,8 @@ type TpRate struct {
 	RateUnit           string  `index:"3" re:"\d+\.*\d*(ns|us|µs|ms|s|m|h)*\s*"`
 	RateIncrement      string  `index:"4" re:"\d+\.*\d*(ns|us|µs|ms|s|m|h)*\s*"`
 	GroupIntervalStart string  `index:"5" re:"\d+\.*\d*(ns|us|µs|ms|s|m|h)*\s*"`
+	MinCost            float64 `index:"6" re:"\d+\.*\d*s*"`
+	MaxCost            float64 `index:"7" re:"\d+\.*\d*s*"`
 	CreatedAt          time.Time
 }
 
@@ -71,6 +73,9 @@ type TpDestinationRate struct {
 	RoundingDecimals int     `index:"4" re:"\d+"`
 	MaxCost          float64 `index:"5" re:"\d+\.*\d*s*"`
 	MaxCostStrategy  string  `index:"6" re:"\*free|\*disconnect"`
+	Currency         string  `index:"7" re:"\w*"`
+	UsageStart       string  `index:"8" re:"\d+\.?\d*[a-z]*"`
+	OriginsTag       string  `index:"9" re:"\w*"`
 	CreatedAt        time.Time
 }
 
@@ -96,6 +101,7 @@ type TpRatingProfile struct {
 	RatingPlanTag    string `index:"5" re:"\w+\s*"`
 	FallbackSubjects string `index:"6" re:"\w+\s*"`
 	CdrStatQueueIds  string `index:"7" re:"\w+\s*"`
+	Timezone         string `index:"8" re:"[\w/\+\-]*\s*"`
 	CreatedAt        time.Time
 }
 
@@ -168,6 +174,7 @@ type TpAction struct {
 	Units           float64 `index:"12" re:"\d+\s*"`
 	BalanceWeight   float64 `index:"13" re:"\d+\.?\d*\s*"`
 	Weight          float64 `index:"14" re:"\d+\.?\d*\s*"`
+	Currency        string  `index:"15" re:"\w*"`
 	CreatedAt       time.Time
 }
 
@@ -244,6 +251,64 @@ type TpSharedGroup struct {
 	CreatedAt     time.Time
 }
 
+type TpExchangeRate struct {
+	Id           int64
+	Tpid         string
+	Tag          string  `index:"0" re:"\w+\s*"`
+	FromCurrency string  `index:"1" re:"\w+\s*"`
+	ToCurrency   string  `index:"2" re:"\w+\s*"`
+	Rate         float64 `index:"3" re:"\d+\.?\d*\s*"`
+	CreatedAt    time.Time
+}
+
+type TpTax struct {
+	Id             int64
+	Tpid           string
+	Tenant         string  `index:"0" re:"\*any\s*|[0-9A-Za-z_\.]+\s*"`
+	Category       string  `index:"1" re:"\*any\s*|\w+\s*"`
+	DestinationTag string  `index:"2" re:"\*any\s*|\w+\s*"`
+	TaxId          string  `index:"3" re:"\w+\s*"`
+	Rate           float64 `index:"4" re:"\d+\.?\d*\s*"`
+	Debit          bool    `index:"5" re:""`
+	Weight         float64 `index:"6" re:"\d+\.?\d*\s*"`
+	CreatedAt      time.Time
+}
+
+func (tpt *TpTax) SetTaxesId(id string) error {
+	ids := strings.Split(id, utils.CONCATENATED_KEY_SEP)
+	if len(ids) != 2 {
+		return fmt.Errorf("Wrong TP Taxes Id: %s", id)
+	}
+	tpt.Tenant = ids[0]
+	tpt.Category = ids[1]
+	return nil
+}
+
+func (tpt *TpTax) GetTaxesId() string {
+	return utils.ConcatenatedKey(tpt.Tenant, tpt.Category)
+}
+
+type TpHoliday struct {
+	Id        int64
+	Tpid      string
+	Tag       string `index:"0" re:"\*?\w+\s*"`
+	Date      string `index:"1" re:"\d{4}-\d{1,2}-\d{1,2}|\d{1,2}-\d{1,2}|\*\w+[+-]?\d*"`
+	Name      string `index:"2" re:""`
+	CreatedAt time.Time
+}
+
+type TpNumberPortability struct {
+	Id            int64
+	Tpid          string
+	Number        string `index:"0" re:"\+?\d+"`
+	RoutingNumber string `index:"1" re:"\+?\w+"`
+	CreatedAt     time.Time
+}
+
+func (t TpNumberPortability) TableName() string {
+	return utils.TBL_TP_NUMBER_PORTABILITY
+}
+
 type TpDerivedCharger struct {
 	Id                   int64
 	Tpid                 string
@@ -268,6 +333,7 @@ type TpDerivedCharger struct {
 	UsageField           string `index:"17" re:"\*default\s*|[~^]*[0-9A-Za-z_/:().+]+\s*"`
 	SupplierField        string `index:"18" re:"\*default\s*|[~^]*[0-9A-Za-z_/:().+]+\s*"`
 	DisconnectCauseField string `index:"19" re:"\*default\s*|[~^]*[0-9A-Za-z_/:().+]+\s*"`
+	OriginField          string `index:"20" re:"\*default\s*|[~^]*[0-9A-Za-z_/:().+]+\s*"`
 	CreatedAt            time.Time
 }
 
@@ -339,6 +405,7 @@ type TblCdrsPrimary struct {
 	Usage           float64
 	Supplier        string
 	DisconnectCause string
+	Origin          string
 	CreatedAt       time.Time
 	DeletedAt       time.Time
 }
@@ -370,7 +437,7 @@ type TblCostDetail struct {
 	Account     string
 	Subject     string
 	Destination string
-	Cost        float64
+	Cost        utils.Decimal
 	Timespans   string
 	CostSource  string
 	CreatedAt   time.Time
@@ -399,7 +466,10 @@ type TblRatedCdr struct {
 	Usage           float64
 	Supplier        string
 	DisconnectCause string
-	Cost            float64
+	Origin          string
+	Cost            utils.Decimal
+	Taxes           string
+	RoutingNumber   string
 	ExtraInfo       string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
@@ -409,3 +479,32 @@ type TblRatedCdr struct {
 func (t TblRatedCdr) TableName() string {
 	return utils.TBL_RATED_CDRS
 }
+
+type TblAccountLedger struct {
+	Id          int64
+	Account     string
+	BalanceType string
+	BalanceUuid string
+	BalanceId   string
+	Delta       utils.Decimal
+	Value       utils.Decimal
+	Cause       string
+	CauseId     string
+	CreatedAt   time.Time
+}
+
+func (t TblAccountLedger) TableName() string {
+	return utils.TBL_ACCOUNT_LEDGER
+}
+
+type TblAccountSnapshot struct {
+	Id         int64
+	SnapshotId string
+	Account    string
+	Content    string
+	CreatedAt  time.Time
+}
+
+func (t TblAccountSnapshot) TableName() string {
+	return utils.TBL_ACCOUNT_SNAPSHOTS
+}
@@ -0,0 +1,62 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Translates the dialled number into the one used for destination matching,
+// eg: the routing number of the network a mobile number was ported to
+type NumberTranslator interface {
+	TranslateNumber(number string) (string, error)
+}
+
+// Looks up the ported numbers in the local MNP table loaded into the rating storage
+type LocalMNPTable struct{}
+
+func (LocalMNPTable) TranslateNumber(number string) (string, error) {
+	routingNumber, err := ratingStorage.GetRoutingNumber(number, false)
+	if err == utils.ErrNotFound {
+		return number, nil
+	}
+	return routingNumber, err
+}
+
+var numberTranslator NumberTranslator = LocalMNPTable{}
+
+// Replaces the number translation stage, nil disables it
+func SetNumberTranslator(nt NumberTranslator) {
+	numberTranslator = nt
+}
+
+// Rates the call on the translated number, keeping the dialled one aside.
+// Does nothing if the destination was already translated.
+func (cd *CallDescriptor) translateDestination() error {
+	if numberTranslator == nil || cd.DialledNumber != "" || cd.Destination == "" || cd.Destination == utils.ANY {
+		return nil
+	}
+	routingNumber, err := numberTranslator.TranslateNumber(cd.Destination)
+	if err != nil {
+		return err
+	}
+	if routingNumber != "" && routingNumber != cd.Destination {
+		cd.DialledNumber, cd.Destination = cd.Destination, routingNumber
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+type testNumberTranslator map[string]string
+
+func (tnt testNumberTranslator) TranslateNumber(number string) (string, error) {
+	if rtNr, found := tnt[number]; found {
+		return rtNr, nil
+	}
+	return number, nil
+}
+
+func TestNumberPortabilityTranslateDestination(t *testing.T) {
+	cd := &CallDescriptor{Destination: "0724111222"}
+	if err := cd.translateDestination(); err != nil || cd.Destination != "0256111222" || cd.DialledNumber != "0724111222" {
+		t.Errorf("Wrong translation: %+v, %v", cd, err)
+	}
+	// already translated, not looked up again
+	if err := cd.translateDestination(); err != nil || cd.Destination != "0256111222" || cd.DialledNumber != "0724111222" {
+		t.Errorf("Wrong second translation: %+v, %v", cd, err)
+	}
+	cd = &CallDescriptor{Destination: "0724111223"}
+	if err := cd.translateDestination(); err != nil || cd.Destination != "0724111223" || cd.DialledNumber != "" {
+		t.Errorf("Not ported number translated: %+v, %v", cd, err)
+	}
+}
+
+func TestNumberPortabilityCustomTranslator(t *testing.T) {
+	SetNumberTranslator(testNumberTranslator{"0724111222": "0257111222"})
+	defer SetNumberTranslator(LocalMNPTable{})
+	cd := &CallDescriptor{Destination: "0724111222"}
+	if err := cd.translateDestination(); err != nil || cd.Destination != "0257111222" {
+		t.Errorf("Wrong custom translation: %+v, %v", cd, err)
+	}
+	SetNumberTranslator(nil)
+	cd = &CallDescriptor{Destination: "0724111222"}
+	if err := cd.translateDestination(); err != nil || cd.Destination != "0724111222" || cd.DialledNumber != "" {
+		t.Errorf("Translated with the stage disabled: %+v, %v", cd, err)
+	}
+}
+
+func TestNumberPortabilityGetCost(t *testing.T) {
+	t1 := time.Date(2013, time.October, 8, 9, 23, 2, 0, time.UTC)
+	t2 := time.Date(2013, time.October, 8, 9, 24, 27, 0, time.UTC)
+	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "test", Subject: "trp", Destination: "0256111222", TimeStart: t1, TimeEnd: t2, DurationIndex: 85 * time.Second}
+	expected, err := cd.GetCost()
+	if err != nil {
+		t.Fatal("Error getting cost: ", err)
+	}
+	cd = &CallDescriptor{Direction: "*out", Category: "0", Tenant: "test", Subject: "trp", Destination: "0724111222", TimeStart: t1, TimeEnd: t2, DurationIndex: 85 * time.Second}
+	cc, err := cd.GetCost()
+	if err != nil {
+		t.Fatal("Error getting cost: ", err)
+	}
+	if !cc.Cost.Equal(expected.Cost) || cc.Destination != "0256111222" || cd.DialledNumber != "0724111222" ||
+		cc.Timespans[0].MatchedDestId != expected.Timespans[0].MatchedDestId {
+		t.Errorf("Ported number not rated on the routing number: %+v, expected: %+v", cc, expected)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Builds the price list of a rating plan with one row for every prefix of its destinations.
+// The rows hold the rate active at timeStart and the cost of each of the usages, the first row is the header.
+func GetPriceList(ratingPlanId string, timeStart time.Time, usages []time.Duration) ([][]string, error) {
+	rpl, err := ratingStorage.GetRatingPlan(ratingPlanId, false)
+	if err != nil || rpl == nil {
+		return nil, utils.ErrNotFound
+	}
+	header := []string{"Prefix", "DestinationId", "ConnectFee", "Rate", "RateUnit", "RateIncrement"}
+	for _, usage := range usages {
+		header = append(header, usage.String())
+	}
+	var rows [][]string
+	for dId := range rpl.DestinationRates {
+		if _, originId := SplitOriginRatesKey(dId); originId != "" {
+			continue // the list has the prices paid regardless of the origin
+		}
+		prefixes := []string{utils.ANY}
+		if dId != utils.ANY {
+			dest, err := ratingStorage.GetDestination(dId)
+			if err != nil {
+				Logger.Warning(fmt.Sprintf("Could not get destination %s for the price list of %s: %v", dId, ratingPlanId, err))
+				continue
+			}
+			prefixes = dest.Prefixes
+		}
+		ris := rpl.RateIntervalList(dId)
+		ris.Sort()
+		var ri *RateInterval
+		for _, interval := range ris {
+			if interval.Contains(timeStart, false) {
+				ri = interval
+				break
+			}
+		}
+		if ri == nil || ri.Rating == nil {
+			continue // no rate at that time
+		}
+		rate, rateIncrement, rateUnit := ri.GetRateParameters(0)
+		for _, prefix := range prefixes {
+			row := []string{prefix, dId, ri.Rating.ConnectFee.String(), rate.String(), rateUnit.String(), rateIncrement.String()}
+			for _, usage := range usages {
+				cd := &CallDescriptor{Direction: utils.OUT, Destination: prefix, TOR: utils.VOICE,
+					TimeStart: timeStart, TimeEnd: timeStart.Add(usage), DurationIndex: usage}
+				cd.RatingInfos = RatingInfos{&RatingInfo{RatingPlanId: rpl.Id, MatchedPrefix: prefix, MatchedDestId: dId,
+					ActivationTime: timeStart, RateIntervals: ris}}
+				cc := cd.rateTimeSpans()
+				if err := cd.finishCost(cc); err != nil {
+					return nil, err
+				}
+				row = append(row, cc.Cost.String())
+			}
+			rows = append(rows, row)
+		}
+	}
+	sort.Sort(priceListRows(rows))
+	return append([][]string{header}, rows...), nil
+}
+
+// sorts the price list rows on prefix and destination
+type priceListRows [][]string
+
+func (rows priceListRows) Len() int {
+	return len(rows)
+}
+
+func (rows priceListRows) Swap(i, j int) {
+	rows[i], rows[j] = rows[j], rows[i]
+}
+
+func (rows priceListRows) Less(i, j int) bool {
+	if rows[i][0] == rows[j][0] {
+		return rows[i][1] < rows[j][1]
+	}
+	return rows[i][0] < rows[j][0]
+}
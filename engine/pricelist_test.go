@@ -0,0 +1,44 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetPriceList(t *testing.T) {
+	priceList, err := GetPriceList("RP_MINMAX", time.Date(2015, 6, 30, 10, 0, 0, 0, time.UTC), []time.Duration{3 * time.Second, 7 * time.Second, time.Minute})
+	if err != nil {
+		t.Fatal("Error getting the price list: ", err)
+	}
+	expected := [][]string{
+		[]string{"Prefix", "DestinationId", "ConnectFee", "Rate", "RateUnit", "RateIncrement", "3s", "7s", "1m0s"},
+		[]string{"+49", "NAT", "0", "0.1", "1s", "1s", "0.5", "0.7", "1"},
+		[]string{"0256", "NAT", "0", "0.1", "1s", "1s", "0.5", "0.7", "1"},
+		[]string{"0257", "NAT", "0", "0.1", "1s", "1s", "0.5", "0.7", "1"},
+		[]string{"0723", "NAT", "0", "0.1", "1s", "1s", "0.5", "0.7", "1"},
+	}
+	if !reflect.DeepEqual(priceList, expected) {
+		t.Errorf("Expected %v was %v", expected, priceList)
+	}
+	if _, err := GetPriceList("RP_NONEXISTENT", time.Now(), nil); err == nil {
+		t.Error("Expecting error for missing rating plan")
+	}
+}
@@ -36,6 +36,52 @@ type RateInterval struct {
 	Timing *RITiming
 	Rating *RIRate
 	Weight float64
+	Tiers  RateTiers // replace the rating once the usage in the billing period reaches them
+}
+
+// Rating applied after a certain usage in the billing period
+type RateTier struct {
+	UsageStart time.Duration
+	Rating     *RIRate
+}
+
+type RateTiers []*RateTier
+
+func (rts RateTiers) Len() int {
+	return len(rts)
+}
+
+func (rts RateTiers) Swap(i, j int) {
+	rts[i], rts[j] = rts[j], rts[i]
+}
+
+func (rts RateTiers) Less(i, j int) bool {
+	return rts[i].UsageStart < rts[j].UsageStart
+}
+
+func (rts RateTiers) Sort() {
+	sort.Sort(rts)
+}
+
+// Returns the last tier reached by the usage, nil if it is still on the base rating
+func (rts RateTiers) getReached(usage time.Duration) (reached *RateTier) {
+	for _, rt := range rts {
+		if rt.UsageStart > usage {
+			break
+		}
+		reached = rt
+	}
+	return
+}
+
+// Returns the first tier not reached by the usage, nil if all of them were reached
+func (rts RateTiers) getNext(usage time.Duration) *RateTier {
+	for _, rt := range rts {
+		if rt.UsageStart > usage {
+			return rt
+		}
+	}
+	return nil
 }
 
 // Separate structure used for rating plan size optimization
@@ -43,6 +89,7 @@ type RITiming struct {
 	Years              utils.Years
 	Months             utils.Months
 	MonthDays          utils.MonthDays
+	Holidays           utils.HolidayCalendars // the days in these calendars are matched along the month days
 	WeekDays           utils.WeekDays
 	StartTime, EndTime string // ##:##:## format
 	cronString         string
@@ -131,8 +178,9 @@ func (rit *RITiming) IsActiveAt(t time.Time, endTime bool) bool {
 	if len(rit.Months) > 0 && !rit.Months.Contains(t.Month()) {
 		return false
 	}
-	// check for month days
-	if len(rit.MonthDays) > 0 && !rit.MonthDays.Contains(t.Day()) {
+	// check for month days and holidays
+	if (len(rit.MonthDays) > 0 || len(rit.Holidays) > 0) &&
+		!rit.MonthDays.Contains(t.Day()) && !isHoliday(rit.Holidays, t) {
 		return false
 	}
 	// check for weekdays
@@ -177,27 +225,56 @@ func (rit *RITiming) IsBlank() bool {
 	return len(rit.Years) == 0 &&
 		len(rit.Months) == 0 &&
 		len(rit.MonthDays) == 0 &&
+		len(rit.Holidays) == 0 &&
 		len(rit.WeekDays) == 0 &&
 		rit.StartTime == "00:00:00"
 }
 
+const MAX_START_TIME_SEARCH_DAYS = 4 * 366 // covers the one time holidays in the following years
+
+// Returns the first start time after now, searched day by day since the holidays cannot be expressed as cron
+func (rit *RITiming) getNextStartTime(now time.Time) time.Time {
+	var hour, min, sec int
+	if hms := strings.Split(rit.StartTime, ":"); len(hms) == 3 {
+		hour, _ = strconv.Atoi(hms[0])
+		min, _ = strconv.Atoi(hms[1])
+		sec, _ = strconv.Atoi(hms[2])
+	}
+	day := time.Date(now.Year(), now.Month(), now.Day(), hour, min, sec, 0, now.Location())
+	for i := 0; i < MAX_START_TIME_SEARCH_DAYS; i++ {
+		if day.After(now) && rit.IsActiveAt(day, false) {
+			return day
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
 func (rit *RITiming) Stringify() string {
 	return utils.Sha1(fmt.Sprintf("%v", rit))[:8]
 }
 
 // Separate structure used for rating plan size optimization
 type RIRate struct {
-	ConnectFee       float64
+	ConnectFee       utils.Decimal
 	RoundingMethod   string
 	RoundingDecimals int
-	MaxCost          float64
+	MinCost          utils.Decimal // minimum charged for a call
+	MaxCost          utils.Decimal
 	MaxCostStrategy  string
+	Currency         string     // empty currency is never converted
 	Rates            RateGroups // GroupRateInterval (start time): Rate
 	tag              string     // loading validation only
 }
 
 func (rir *RIRate) Stringify() string {
 	str := fmt.Sprintf("%v %v %v %v %v", rir.ConnectFee, rir.RoundingMethod, rir.RoundingDecimals, rir.MaxCost, rir.MaxCostStrategy)
+	if rir.Currency != "" { // keep the tags of rates without currency unchanged
+		str += " " + rir.Currency
+	}
+	if !rir.MinCost.IsZero() {
+		str += " " + rir.MinCost.String()
+	}
 	for _, r := range rir.Rates {
 		str += r.Stringify()
 	}
@@ -206,7 +283,7 @@ func (rir *RIRate) Stringify() string {
 
 type Rate struct {
 	GroupIntervalStart time.Duration
-	Value              float64
+	Value              utils.Decimal
 	RateIncrement      time.Duration
 	RateUnit           time.Duration
 }
@@ -217,7 +294,7 @@ func (r *Rate) Stringify() string {
 
 func (p *Rate) Equal(o *Rate) bool {
 	return p.GroupIntervalStart == o.GroupIntervalStart &&
-		p.Value == o.Value &&
+		p.Value.Equal(o.Value) &&
 		p.RateIncrement == o.RateIncrement &&
 		p.RateUnit == o.RateUnit
 }
@@ -326,19 +403,26 @@ func (i *RateInterval) Equal(o *RateInterval) bool {
 		i.Timing.EndTime == o.Timing.EndTime
 }
 
-func (i *RateInterval) GetCost(duration, startSecond time.Duration) float64 {
+// Returns the interval rated with the tier reached by the usage, the receiver if it has no such tier
+func (i *RateInterval) forUsage(usage time.Duration) *RateInterval {
+	rt := i.Tiers.getReached(usage)
+	if rt == nil || rt.Rating == i.Rating {
+		return i
+	}
+	return &RateInterval{Timing: i.Timing, Rating: rt.Rating, Weight: i.Weight, Tiers: i.Tiers}
+}
+
+func (i *RateInterval) GetCost(duration, startSecond time.Duration) utils.Decimal {
 	price, _, rateUnit := i.
 		GetRateParameters(startSecond)
-	price /= rateUnit.Seconds()
-	d := duration.Seconds()
-
-	return d * price
+	// price * duration / rateUnit with a single rounding
+	return price.MulDivInt(int64(duration), int64(rateUnit))
 }
 
 // Gets the price for a the provided start second
-func (i *RateInterval) GetRateParameters(startSecond time.Duration) (rate float64, rateIncrement, rateUnit time.Duration) {
+func (i *RateInterval) GetRateParameters(startSecond time.Duration) (rate utils.Decimal, rateIncrement, rateUnit time.Duration) {
 	if i.Rating == nil {
-		return -1, -1, -1
+		return utils.NewDecimalFromInt(-1), -1, -1
 	}
 	i.Rating.Rates.Sort()
 	for index, price := range i.Rating.Rates {
@@ -353,16 +437,30 @@ func (i *RateInterval) GetRateParameters(startSecond time.Duration) (rate float6
 			return price.Value, price.RateIncrement, price.RateUnit
 		}
 	}
-	return -1, -1, -1
+	return utils.NewDecimalFromInt(-1), -1, -1
 }
 
-func (ri *RateInterval) GetMaxCost() (float64, string) {
+func (ri *RateInterval) GetMaxCost() (utils.Decimal, string) {
 	if ri.Rating == nil {
-		return 0.0, ""
+		return utils.Decimal{}, ""
 	}
 	return ri.Rating.MaxCost, ri.Rating.MaxCostStrategy
 }
 
+func (ri *RateInterval) GetMinCost() utils.Decimal {
+	if ri == nil || ri.Rating == nil {
+		return utils.Decimal{}
+	}
+	return ri.Rating.MinCost
+}
+
+func (ri *RateInterval) GetCurrency() string {
+	if ri == nil || ri.Rating == nil {
+		return ""
+	}
+	return ri.Rating.Currency
+}
+
 // Structure to store intervals according to weight
 type RateIntervalList []*RateInterval
 
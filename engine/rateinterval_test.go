@@ -230,17 +230,17 @@ func TestRitStrigyfy(t *testing.T) {
 
 func TestRirStrigyfy(t *testing.T) {
 	rir1 := &RIRate{
-		ConnectFee: 0.1,
+		ConnectFee: utils.NewDecimalFromFloat(0.1),
 		Rates: RateGroups{
 			&Rate{
 				GroupIntervalStart: time.Hour,
-				Value:              0.17,
+				Value:              utils.NewDecimalFromFloat(0.17),
 				RateIncrement:      time.Second,
 				RateUnit:           time.Minute,
 			},
 			&Rate{
 				GroupIntervalStart: 0,
-				Value:              0.7,
+				Value:              utils.NewDecimalFromFloat(0.7),
 				RateIncrement:      time.Second,
 				RateUnit:           time.Minute,
 			},
@@ -249,17 +249,17 @@ func TestRirStrigyfy(t *testing.T) {
 		RoundingDecimals: 4,
 	}
 	rir2 := &RIRate{
-		ConnectFee: 0.1,
+		ConnectFee: utils.NewDecimalFromFloat(0.1),
 		Rates: RateGroups{
 			&Rate{
 				GroupIntervalStart: time.Hour,
-				Value:              0.17,
+				Value:              utils.NewDecimalFromFloat(0.17),
 				RateIncrement:      time.Second,
 				RateUnit:           time.Minute,
 			},
 			&Rate{
 				GroupIntervalStart: 0,
-				Value:              0.7,
+				Value:              utils.NewDecimalFromFloat(0.7),
 				RateIncrement:      time.Second,
 				RateUnit:           time.Minute,
 			},
@@ -275,12 +275,12 @@ func TestRirStrigyfy(t *testing.T) {
 func TestRateStrigyfy(t *testing.T) {
 	r1 := &Rate{
 		GroupIntervalStart: time.Hour,
-		Value:              0.17,
+		Value:              utils.NewDecimalFromFloat(0.17),
 		RateUnit:           time.Minute,
 	}
 	r2 := &Rate{
 		GroupIntervalStart: time.Hour,
-		Value:              0.17,
+		Value:              utils.NewDecimalFromFloat(0.17),
 		RateUnit:           time.Minute,
 	}
 	if r1.Stringify() != r2.Stringify() {
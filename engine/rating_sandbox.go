@@ -0,0 +1,202 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Rating data of a tariff plan loaded out of StorDB in memory, used to rate without touching the live data.
+// Holiday calendars and taxes are still taken out of the live data.
+type RatingSandbox struct {
+	TPid           string
+	ratingPlans    map[string]*RatingPlan
+	ratingProfiles map[string]*RatingProfile
+	destIndex      *DestinationIndex
+}
+
+// Loads the rating part of the tariff plan, the rating plans and destinations referenced
+// but not defined by it are copied out of the live data
+func NewRatingSandbox(lr LoadReader, tpid string) (*RatingSandbox, error) {
+	tpr := NewTpReader(ratingStorage, accountingStorage, lr, tpid)
+	for _, load := range []func() error{tpr.LoadDestinations, tpr.LoadTimings, tpr.LoadRates,
+		tpr.LoadDestinationRates, tpr.LoadRatingPlans, tpr.LoadRatingProfiles} {
+		if err := load(); err != nil {
+			return nil, err
+		}
+	}
+	if len(tpr.ratingProfiles) == 0 {
+		return nil, fmt.Errorf("no rating profiles in tariff plan %s", tpid)
+	}
+	sb := &RatingSandbox{TPid: tpid, ratingPlans: tpr.ratingPlans, ratingProfiles: tpr.ratingProfiles, destIndex: NewDestinationIndex()}
+	for _, rpf := range sb.ratingProfiles {
+		for _, rpa := range rpf.RatingPlanActivations {
+			if _, found := sb.ratingPlans[rpa.RatingPlanId]; found {
+				continue
+			}
+			rpl, err := ratingStorage.GetRatingPlan(rpa.RatingPlanId, false)
+			if err != nil {
+				return nil, fmt.Errorf("could not load rating plan %s: %v", rpa.RatingPlanId, err)
+			}
+			sb.ratingPlans[rpl.Id] = rpl
+		}
+	}
+	var dests []*Destination
+	for _, dest := range tpr.destinations {
+		dests = append(dests, dest)
+	}
+	for _, rpl := range sb.ratingPlans {
+		for key := range rpl.DestinationRates {
+			dId, originId := SplitOriginRatesKey(key)
+			for _, dId := range []string{dId, originId} {
+				if _, found := tpr.destinations[dId]; found || dId == utils.ANY || dId == "" {
+					continue
+				}
+				dest, err := ratingStorage.GetDestination(dId)
+				if err != nil {
+					return nil, fmt.Errorf("could not load destination %s: %v", dId, err)
+				}
+				tpr.destinations[dId] = dest
+				dests = append(dests, dest)
+			}
+		}
+	}
+	sb.destIndex.Reset(dests)
+	return sb, nil
+}
+
+func (sb *RatingSandbox) GetRatingProfile(key string) (*RatingProfile, error) {
+	if rpf, found := sb.ratingProfiles[key]; found {
+		return rpf, nil
+	}
+	return nil, utils.ErrNotFound
+}
+
+func (sb *RatingSandbox) GetRatingPlan(id string) (*RatingPlan, error) {
+	if rpl, found := sb.ratingPlans[id]; found {
+		return rpl, nil
+	}
+	return nil, utils.ErrNotFound
+}
+
+// Rates the call descriptor on the sandbox data, no balances are touched
+func (sb *RatingSandbox) GetCost(cd *CallDescriptor) (*CallCost, error) {
+	cd.sandbox = sb
+	return cd.GetCost()
+}
+
+// Old and new costs summed up for one account and destination
+type RatingSimulationTotal struct {
+	Tenant        string
+	Account       string
+	DestinationId string // matched by the new rating
+	Cdrs          int
+	OldCost       utils.Decimal
+	NewCost       utils.Decimal
+}
+
+func (rst *RatingSimulationTotal) GetDiff() utils.Decimal {
+	return rst.NewCost.Sub(rst.OldCost)
+}
+
+// Revenue impact of a tariff plan on a set of rated CDRs
+type RatingSimulation struct {
+	TPid    string
+	Totals  []*RatingSimulationTotal // sorted on tenant, account and destination
+	OldCost utils.Decimal
+	NewCost utils.Decimal
+	Errors  map[string]string // CDRs which could not be compared, keyed on CgrId and MediationRunId
+	Diff    [][]string        // one line per CDR, header first
+}
+
+var ratingSimulationDiffHeader = []string{"CgrId", "MediationRunId", "Tenant", "Account", "Destination", "DestinationId",
+	"AnswerTime", "Usage", "OldCost", "NewCost", "Diff"}
+
+// Rates again the CDRs on the sandbox and compares the costs with the ones already stored.
+// The CDRs never rated before are reported in the errors.
+func (sb *RatingSandbox) SimulateRating(cdrs []*StoredCdr) *RatingSimulation {
+	rs := &RatingSimulation{TPid: sb.TPid, Errors: make(map[string]string), Diff: [][]string{ratingSimulationDiffHeader}}
+	totals := make(map[string]*RatingSimulationTotal)
+	for _, cdr := range cdrs {
+		cdrKey := utils.ConcatenatedKey(cdr.CgrId, cdr.MediationRunId)
+		if cdr.Cost.Sign() < 0 {
+			rs.Errors[cdrKey] = "not rated"
+			continue
+		}
+		cc, err := sb.GetCost(&CallDescriptor{
+			TOR:           cdr.TOR,
+			Direction:     cdr.Direction,
+			Tenant:        cdr.Tenant,
+			Category:      cdr.Category,
+			Subject:       cdr.Subject,
+			Account:       cdr.Account,
+			Destination:   cdr.Destination,
+			TimeStart:     cdr.AnswerTime,
+			TimeEnd:       cdr.AnswerTime.Add(cdr.Usage),
+			DurationIndex: cdr.Usage,
+		})
+		if err != nil {
+			rs.Errors[cdrKey] = err.Error()
+			continue
+		}
+		destId := ""
+		if len(cc.Timespans) > 0 {
+			destId = cc.Timespans[0].MatchedDestId
+		}
+		totalKey := utils.ConcatenatedKey(cdr.Tenant, cdr.Account, destId)
+		total, found := totals[totalKey]
+		if !found {
+			total = &RatingSimulationTotal{Tenant: cdr.Tenant, Account: cdr.Account, DestinationId: destId}
+			totals[totalKey] = total
+			rs.Totals = append(rs.Totals, total)
+		}
+		total.Cdrs++
+		total.OldCost = total.OldCost.Add(cdr.Cost)
+		total.NewCost = total.NewCost.Add(cc.Cost)
+		rs.OldCost = rs.OldCost.Add(cdr.Cost)
+		rs.NewCost = rs.NewCost.Add(cc.Cost)
+		rs.Diff = append(rs.Diff, []string{cdr.CgrId, cdr.MediationRunId, cdr.Tenant, cdr.Account, cdr.Destination, destId,
+			cdr.AnswerTime.Format(time.RFC3339), cdr.Usage.String(), cdr.Cost.String(), cc.Cost.String(), cc.Cost.Sub(cdr.Cost).String()})
+	}
+	sort.Sort(ratingSimulationTotals(rs.Totals))
+	return rs
+}
+
+type ratingSimulationTotals []*RatingSimulationTotal
+
+func (rsts ratingSimulationTotals) Len() int {
+	return len(rsts)
+}
+
+func (rsts ratingSimulationTotals) Swap(i, j int) {
+	rsts[i], rsts[j] = rsts[j], rsts[i]
+}
+
+func (rsts ratingSimulationTotals) Less(i, j int) bool {
+	if rsts[i].Tenant != rsts[j].Tenant {
+		return rsts[i].Tenant < rsts[j].Tenant
+	}
+	if rsts[i].Account != rsts[j].Account {
+		return rsts[i].Account < rsts[j].Account
+	}
+	return rsts[i].DestinationId < rsts[j].DestinationId
+}
@@ -0,0 +1,115 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func testRatingSandbox(t *testing.T) *RatingSandbox {
+	dests := `
+SB_NAT,0256
+SB_INTL,0999
+`
+	rts := `
+SB_R1,0,2,1s,1s,0s,,
+SB_R2,0,1,1s,1s,0s,,
+`
+	drs := `
+SB_DR,SB_NAT,SB_R1,*middle,4,0,,,,
+SB_DR,SB_INTL,SB_R2,*middle,4,0,,,,
+`
+	rps := `
+SB_RP,SB_DR,*any,10
+`
+	rpfs := `
+*out,cgrates.org,call,sandbox,2013-01-06T00:00:00Z,SB_RP,,,
+`
+	sb, err := NewRatingSandbox(NewStringCSVStorage(',', dests, "", rts, drs, rps, rpfs,
+		"", "", "", "", "", "", "", "", "", "", "", ""), "TP_SB")
+	if err != nil {
+		t.Fatal("Error loading the sandbox: ", err)
+	}
+	return sb
+}
+
+func TestRatingSandboxIsolated(t *testing.T) {
+	sb := testRatingSandbox(t)
+	if len(destIndex.Match("0999")) != 0 {
+		t.Error("Sandbox destinations in the live index")
+	}
+	if _, err := ratingStorage.GetRatingPlan("SB_RP", false); err == nil {
+		t.Error("Sandbox rating plan in the live data")
+	}
+	t1 := time.Date(2015, time.October, 8, 9, 23, 2, 0, time.UTC)
+	cd := &CallDescriptor{Direction: OUTBOUND, Category: "call", Tenant: "cgrates.org", Subject: "sandbox", Destination: "09991",
+		TimeStart: t1, TimeEnd: t1.Add(10 * time.Second), DurationIndex: 10 * time.Second}
+	if cc, err := sb.GetCost(cd); err != nil || cc.Cost.String() != "10" {
+		t.Errorf("Wrong sandbox cost: %+v, %v", cc, err)
+	}
+	cd = &CallDescriptor{Direction: OUTBOUND, Category: "call", Tenant: "cgrates.org", Subject: "sandbox", Destination: "09991",
+		TimeStart: t1, TimeEnd: t1.Add(10 * time.Second), DurationIndex: 10 * time.Second}
+	if _, err := cd.GetCost(); err == nil {
+		t.Error("Sandbox rating profile used on the live data")
+	}
+}
+
+func TestRatingSandboxSimulateRating(t *testing.T) {
+	sb := testRatingSandbox(t)
+	t1 := time.Date(2015, time.October, 8, 9, 23, 2, 0, time.UTC)
+	newCdr := func(cgrId, account, destination string, usage time.Duration, cost float64) *StoredCdr {
+		return &StoredCdr{CgrId: cgrId, MediationRunId: utils.META_DEFAULT, TOR: utils.VOICE, Direction: OUTBOUND, Tenant: "cgrates.org",
+			Category: "call", Account: account, Subject: "sandbox", Destination: destination, AnswerTime: t1, Usage: usage,
+			Cost: utils.NewDecimalFromFloat(cost)}
+	}
+	cdrs := []*StoredCdr{
+		newCdr("1", "1002", "02561", 10*time.Second, 15),
+		newCdr("2", "1001", "02562", 10*time.Second, 15),
+		newCdr("3", "1001", "02563", 5*time.Second, 10),
+		newCdr("4", "1001", "09991", 10*time.Second, 12),
+		newCdr("5", "1001", "02564", 10*time.Second, -1),
+		newCdr("6", "1001", "0111", 10*time.Second, 3),
+	}
+	rs := sb.SimulateRating(cdrs)
+	if rs.OldCost.String() != "52" || rs.NewCost.String() != "60" {
+		t.Errorf("Wrong simulation totals: %v, %v", rs.OldCost, rs.NewCost)
+	}
+	if len(rs.Totals) != 3 {
+		t.Fatalf("Wrong simulation totals: %+v", rs.Totals)
+	}
+	if tot := rs.Totals[0]; tot.Account != "1001" || tot.DestinationId != "SB_INTL" || tot.Cdrs != 1 || tot.GetDiff().String() != "-2" {
+		t.Errorf("Wrong total: %+v", tot)
+	}
+	if tot := rs.Totals[1]; tot.Account != "1001" || tot.DestinationId != "SB_NAT" || tot.Cdrs != 2 ||
+		tot.OldCost.String() != "25" || tot.NewCost.String() != "30" {
+		t.Errorf("Wrong total: %+v", tot)
+	}
+	if tot := rs.Totals[2]; tot.Account != "1002" || tot.DestinationId != "SB_NAT" || tot.GetDiff().String() != "5" {
+		t.Errorf("Wrong total: %+v", tot)
+	}
+	if len(rs.Errors) != 2 || rs.Errors[utils.ConcatenatedKey("5", utils.META_DEFAULT)] != "not rated" ||
+		rs.Errors[utils.ConcatenatedKey("6", utils.META_DEFAULT)] == "" {
+		t.Errorf("Wrong simulation errors: %+v", rs.Errors)
+	}
+	if len(rs.Diff) != 5 || rs.Diff[1][5] != "SB_NAT" || rs.Diff[1][10] != "5" {
+		t.Errorf("Wrong simulation diff: %+v", rs.Diff)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"fmt"
+)
+
+// Rating decisions traced in the *debug mode
+const (
+	TRACE_RATING_PROFILE = "*rating_profile"
+	TRACE_FALLBACK       = "*fallback"
+	TRACE_RATING_PLAN    = "*rating_plan"
+	TRACE_DESTINATION    = "*destination"
+	TRACE_RATE_INTERVAL  = "*rate_interval"
+	TRACE_RATE_TIER      = "*rate_tier"
+)
+
+// One decision taken while rating a call
+type TraceEntry struct {
+	Step    string // one of the TRACE_* decisions
+	Key     string // what was looked up
+	Found   bool
+	Details string
+}
+
+type RatingTrace []*TraceEntry
+
+// Returns the entries of one step
+func (rt RatingTrace) GetSteps(step string) (entries RatingTrace) {
+	for _, entry := range rt {
+		if entry.Step == step {
+			entries = append(entries, entry)
+		}
+	}
+	return
+}
+
+// Records the decision if the call descriptor is in *debug mode
+func (cd *CallDescriptor) addTrace(step, key string, found bool, format string, args ...interface{}) {
+	if !cd.Debug {
+		return
+	}
+	cd.trace = append(cd.trace, &TraceEntry{Step: step, Key: key, Found: found, Details: fmt.Sprintf(format, args...)})
+}
+
+// Records the rate interval, slot and tier selected for each timespan
+func (cd *CallDescriptor) traceTimeSpans(timespans TimeSpans) {
+	if !cd.Debug {
+		return
+	}
+	for _, ts := range timespans {
+		if ts.RateInterval == nil {
+			cd.addTrace(TRACE_RATE_INTERVAL, ts.RatingPlanId, false, "%v - %v: no rate interval", ts.TimeStart, ts.TimeEnd)
+			continue
+		}
+		details := fmt.Sprintf("%v - %v: prefix %s of destination %s", ts.TimeStart, ts.TimeEnd, ts.MatchedPrefix, ts.MatchedDestId)
+		if t := ts.RateInterval.Timing; t != nil {
+			details += fmt.Sprintf(", timing start time %s, week days %v, month days %v, months %v, years %v, holidays %v",
+				t.StartTime, t.WeekDays, t.MonthDays, t.Months, t.Years, t.Holidays)
+		}
+		details += fmt.Sprintf(", weight %v", ts.RateInterval.Weight)
+		if rate, rateIncrement, rateUnit := ts.RateInterval.GetRateParameters(ts.GetGroupStart()); rateUnit > 0 {
+			details += fmt.Sprintf(", rate slot from %v: %v per %v in increments of %v", ts.GetGroupStart(), rate, rateUnit, rateIncrement)
+		}
+		cd.addTrace(TRACE_RATE_INTERVAL, ts.RatingPlanId, true, "%s", details)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRatingTraceFallback(t *testing.T) {
+	cd := &CallDescriptor{
+		TimeStart:   time.Date(2013, 10, 21, 18, 34, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2013, 10, 21, 18, 35, 0, 0, time.UTC),
+		Category:    "0",
+		Direction:   OUTBOUND,
+		Tenant:      "vdf",
+		Subject:     "fall",
+		Destination: "0723045",
+		Debug:       true,
+	}
+	cc, err := cd.GetCost()
+	if err != nil {
+		t.Fatal("Error getting cost: ", err)
+	}
+	if rpfs := cc.Trace.GetSteps(TRACE_RATING_PROFILE); len(rpfs) < 2 ||
+		rpfs[0].Key != "*out:vdf:0:fall" || !rpfs[0].Found ||
+		rpfs[1].Key != "*out:vdf:0:rif" || !rpfs[1].Found {
+		t.Errorf("Wrong rating profiles trace: %+v", rpfs)
+	}
+	if fbs := cc.Trace.GetSteps(TRACE_FALLBACK); len(fbs) != 1 || fbs[0].Key != "*out:vdf:0:rif" {
+		t.Errorf("Wrong fallback trace: %+v", fbs)
+	}
+	if dsts := cc.Trace.GetSteps(TRACE_DESTINATION); len(dsts) < 2 || dsts[0].Found || !dsts[len(dsts)-1].Found {
+		t.Errorf("Wrong destinations trace: %+v", dsts)
+	}
+	if ris := cc.Trace.GetSteps(TRACE_RATE_INTERVAL); len(ris) != len(cc.Timespans) || !ris[0].Found {
+		t.Errorf("Wrong rate intervals trace: %+v", ris)
+	}
+}
+
+func TestRatingTraceOff(t *testing.T) {
+	cd := &CallDescriptor{
+		TimeStart:   time.Date(2013, 10, 21, 18, 34, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2013, 10, 21, 18, 35, 0, 0, time.UTC),
+		Category:    "0",
+		Direction:   OUTBOUND,
+		Tenant:      "vdf",
+		Subject:     "fall",
+		Destination: "0723045",
+	}
+	if cc, err := cd.GetCost(); err != nil || cc.Trace != nil {
+		t.Errorf("Not expecting trace: %+v, %v", cc, err)
+	}
+}
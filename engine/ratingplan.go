@@ -21,10 +21,15 @@ package engine
 import (
 	"encoding/json"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/cgrates/cgrates/history"
+	"github.com/cgrates/cgrates/utils"
 )
 
+const ORIGIN_RATES_SEP = "@"
+
 /*
 The struture that is saved to storage.
 */
@@ -39,14 +44,57 @@ type RPRate struct {
 	Timing string
 	Rating string
 	Weight float64
+	Tiers  []*RPRateTier
+}
+
+type RPRateTier struct {
+	UsageStart time.Duration
+	Rating     string
 }
 
 func (rpr *RPRate) Equal(orpr *RPRate) bool {
+	if len(rpr.Tiers) != len(orpr.Tiers) {
+		return false
+	}
+	for i, tier := range rpr.Tiers {
+		if tier.UsageStart != orpr.Tiers[i].UsageStart || tier.Rating != orpr.Tiers[i].Rating {
+			return false
+		}
+	}
 	return rpr.Timing == orpr.Timing && rpr.Rating == orpr.Rating && rpr.Weight == orpr.Weight
 }
 
 type RPRateList []*RPRate
 
+// The destination rates restricted to the calls from an origin are kept
+// on the destination id qualified by the origin one, eg: GERMANY@ROAMING_EU
+func OriginRatesKey(dId, originId string) string {
+	if originId == "" {
+		return dId
+	}
+	return dId + ORIGIN_RATES_SEP + originId
+}
+
+func SplitOriginRatesKey(key string) (dId, originId string) {
+	if idx := strings.Index(key, ORIGIN_RATES_SEP); idx != -1 {
+		return key[:idx], key[idx+1:]
+	}
+	return key, ""
+}
+
+// Returns the key of the rates for the destination, the ones of the longest matching origin come first
+func (rp *RatingPlan) getRatesKey(dId string, originMatches []*utils.PrefixMatch) (string, string, bool) {
+	for _, match := range originMatches {
+		for _, originId := range match.Ids {
+			if key := OriginRatesKey(dId, originId); rp.DestinationRates[key] != nil {
+				return key, originId, true
+			}
+		}
+	}
+	_, found := rp.DestinationRates[dId]
+	return dId, "", found
+}
+
 func (rp *RatingPlan) RateIntervalList(dId string) RateIntervalList {
 	ril := make(RateIntervalList, len(rp.DestinationRates[dId]))
 	for i, rpr := range rp.DestinationRates[dId] {
@@ -55,6 +103,9 @@ func (rp *RatingPlan) RateIntervalList(dId string) RateIntervalList {
 			Rating: rp.Ratings[rpr.Rating],
 			Weight: rpr.Weight,
 		}
+		for _, tier := range rpr.Tiers {
+			ril[i].Tiers = append(ril[i].Tiers, &RateTier{UsageStart: tier.UsageStart, Rating: rp.Ratings[tier.Rating]})
+		}
 	}
 	return ril
 }
@@ -87,6 +138,11 @@ func (rp *RatingPlan) AddRateInterval(dId string, ris ...*RateInterval) {
 			rp.Ratings[ratingTag] = ri.Rating
 			rpr.Rating = ratingTag
 		}
+		for _, tier := range ri.Tiers {
+			ratingTag := tier.Rating.Stringify()
+			rp.Ratings[ratingTag] = tier.Rating
+			rpr.Tiers = append(rpr.Tiers, &RPRateTier{UsageStart: tier.UsageStart, Rating: ratingTag})
+		}
 		found := false
 		for _, erpr := range rp.DestinationRates[dId] {
 			if erpr.Equal(rpr) {
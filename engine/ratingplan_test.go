@@ -182,13 +182,13 @@ func TestApAddIntervalIfNotPresent(t *testing.T) {
 
 func TestApAddRateIntervalGroups(t *testing.T) {
 	i1 := &RateInterval{
-		Rating: &RIRate{Rates: RateGroups{&Rate{0, 1, 1 * time.Second, 1 * time.Second}}},
+		Rating: &RIRate{Rates: RateGroups{&Rate{0, utils.NewDecimalFromFloat(1), 1 * time.Second, 1 * time.Second}}},
 	}
 	i2 := &RateInterval{
-		Rating: &RIRate{Rates: RateGroups{&Rate{30 * time.Second, 2, 1 * time.Second, 1 * time.Second}}},
+		Rating: &RIRate{Rates: RateGroups{&Rate{30 * time.Second, utils.NewDecimalFromFloat(2), 1 * time.Second, 1 * time.Second}}},
 	}
 	i3 := &RateInterval{
-		Rating: &RIRate{Rates: RateGroups{&Rate{30 * time.Second, 2, 1 * time.Second, 1 * time.Second}}},
+		Rating: &RIRate{Rates: RateGroups{&Rate{30 * time.Second, utils.NewDecimalFromFloat(2), 1 * time.Second, 1 * time.Second}}},
 	}
 	ap := &RatingPlan{}
 	ap.AddRateInterval("NAT", i1)
@@ -24,7 +24,6 @@ import (
 	"sort"
 	"time"
 
-	"github.com/cgrates/cgrates/cache2go"
 	"github.com/cgrates/cgrates/history"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -39,6 +38,7 @@ type RatingPlanActivation struct {
 	RatingPlanId    string
 	FallbackKeys    []string
 	CdrStatQueueIds []string
+	Timezone        string // location of the timings, empty for the one of the call
 }
 
 func (rpa *RatingPlanActivation) Equal(orpa *RatingPlanActivation) bool {
@@ -80,13 +80,28 @@ func (rpas RatingPlanActivations) GetActiveForCall(cd *CallDescriptor) RatingPla
 }
 
 type RatingInfo struct {
-	MatchedSubject string
-	RatingPlanId   string
-	MatchedPrefix  string
-	MatchedDestId  string
-	ActivationTime time.Time
-	RateIntervals  RateIntervalList
-	FallbackKeys   []string
+	MatchedSubject  string
+	RatingPlanId    string
+	MatchedPrefix   string
+	MatchedDestId   string
+	MatchedOriginId string // set when the rates of the destination are restricted to the origin of the call
+	ActivationTime  time.Time
+	RateIntervals   RateIntervalList
+	FallbackKeys    []string
+	Timezone        string
+}
+
+// Returns the location of the rating plan timings, nil if they follow the one of the call
+func (ri *RatingInfo) getLocation() *time.Location {
+	if ri.Timezone == "" {
+		return nil
+	}
+	loc, err := utils.GetLocation(ri.Timezone)
+	if err != nil {
+		Logger.Err(fmt.Sprintf("Error loading timezone %s: %v", ri.Timezone, err))
+		return nil
+	}
+	return loc
 }
 
 type RatingInfos []*RatingInfo
@@ -114,36 +129,37 @@ func (ris RatingInfos) String() string {
 
 func (rp *RatingProfile) GetRatingPlansForPrefix(cd *CallDescriptor) (err error) {
 	var ris RatingInfos
+	originMatches := cd.matchOrigin()
 	for index, rpa := range rp.RatingPlanActivations.GetActiveForCall(cd) {
-		rpl, err := ratingStorage.GetRatingPlan(rpa.RatingPlanId, false)
+		rpl, err := cd.getRatingPlan(rpa.RatingPlanId)
 		if err != nil || rpl == nil {
 			Logger.Err(fmt.Sprintf("Error checking destination: %v", err))
+			cd.addTrace(TRACE_RATING_PLAN, rpa.RatingPlanId, false, "%v", err)
 			continue
 		}
+		cd.addTrace(TRACE_RATING_PLAN, rpa.RatingPlanId, true, "activated at %v in %s", rpa.ActivationTime, rp.Id)
 		prefix := ""
 		destinationId := ""
+		originId := ""
 		var rps RateIntervalList
 		//log.Printf("RPA: %+v", rpa)
 		if cd.Destination == utils.ANY || cd.Destination == "" {
 			cd.Destination = utils.ANY
-			if _, ok := rpl.DestinationRates[utils.ANY]; ok {
-				rps = rpl.RateIntervalList(utils.ANY)
+			if key, oId, ok := rpl.getRatesKey(utils.ANY, originMatches); ok {
+				rps = rpl.RateIntervalList(key)
 				prefix = utils.ANY
 				destinationId = utils.ANY
+				originId = oId
 			}
 		} else {
-			for _, p := range utils.SplitPrefix(cd.Destination, MIN_PREFIX_MATCH) {
-				if x, err := cache2go.GetCached(DESTINATION_PREFIX + p); err == nil {
-
-					destIds := x.(map[interface{}]struct{})
-					for idId := range destIds {
-						dId := idId.(string)
-						if _, ok := rpl.DestinationRates[dId]; ok {
-							rps = rpl.RateIntervalList(dId)
-							prefix = p
-							destinationId = dId
-							break
-						}
+			for _, match := range cd.matchDestination() {
+				for _, dId := range match.Ids {
+					if key, oId, ok := rpl.getRatesKey(dId, originMatches); ok {
+						rps = rpl.RateIntervalList(key)
+						prefix = match.Prefix
+						destinationId = dId
+						originId = oId
+						break
 					}
 				}
 				if rps != nil {
@@ -151,13 +167,19 @@ func (rp *RatingProfile) GetRatingPlansForPrefix(cd *CallDescriptor) (err error)
 				}
 			}
 			if rps == nil { // fallback on *any destination
-				if _, ok := rpl.DestinationRates[utils.ANY]; ok {
-					rps = rpl.RateIntervalList(utils.ANY)
+				if key, oId, ok := rpl.getRatesKey(utils.ANY, originMatches); ok {
+					rps = rpl.RateIntervalList(key)
 					prefix = utils.ANY
 					destinationId = utils.ANY
+					originId = oId
 				}
 			}
 		}
+		if len(prefix) > 0 {
+			cd.addTrace(TRACE_DESTINATION, cd.Destination, true, "prefix %s of destination %s in %s, origin %q", prefix, destinationId, rpl.Id, originId)
+		} else {
+			cd.addTrace(TRACE_DESTINATION, cd.Destination, false, "no destination in %s, fallback keys %v", rpl.Id, rpa.FallbackKeys)
+		}
 		// check if it's the first ri and add a blank one for the initial part not covered
 		if index == 0 && cd.TimeStart.Before(rpa.ActivationTime) {
 			ris = append(ris, &RatingInfo{
@@ -170,13 +192,15 @@ func (rp *RatingProfile) GetRatingPlansForPrefix(cd *CallDescriptor) (err error)
 		}
 		if len(prefix) > 0 {
 			ris = append(ris, &RatingInfo{
-				MatchedSubject: rp.Id,
-				RatingPlanId:   rpl.Id,
-				MatchedPrefix:  prefix,
-				MatchedDestId:  destinationId,
-				ActivationTime: rpa.ActivationTime,
-				RateIntervals:  rps,
-				FallbackKeys:   rpa.FallbackKeys})
+				MatchedSubject:  rp.Id,
+				RatingPlanId:    rpl.Id,
+				MatchedPrefix:   prefix,
+				MatchedDestId:   destinationId,
+				MatchedOriginId: originId,
+				ActivationTime:  rpa.ActivationTime,
+				RateIntervals:   rps,
+				FallbackKeys:    rpa.FallbackKeys,
+				Timezone:        rpa.Timezone})
 		} else {
 			// add for fallback information
 			ris = append(ris, &RatingInfo{
@@ -186,6 +210,7 @@ func (rp *RatingProfile) GetRatingPlansForPrefix(cd *CallDescriptor) (err error)
 				ActivationTime: rpa.ActivationTime,
 				RateIntervals:  nil,
 				FallbackKeys:   rpa.FallbackKeys,
+				Timezone:       rpa.Timezone,
 			})
 		}
 	}
@@ -0,0 +1,157 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+const MAX_RESELLER_LEVELS = 10 // protects against the loops in the hierarchy
+
+// Node in the reseller hierarchy
+type AccountTree struct {
+	Id       string
+	Children []*AccountTree `json:",omitempty"`
+}
+
+// Builds the tree under the root account out of the parent ids of the accounts
+func NewAccountTree(rootId string, accounts []*Account) *AccountTree {
+	children := make(map[string][]string)
+	for _, acc := range accounts {
+		if acc.ParentId != "" {
+			children[acc.ParentId] = append(children[acc.ParentId], acc.Id)
+		}
+	}
+	var build func(id string, level int) *AccountTree
+	build = func(id string, level int) *AccountTree {
+		node := &AccountTree{Id: id}
+		if level >= MAX_RESELLER_LEVELS {
+			return node
+		}
+		for _, childId := range children[id] {
+			node.Children = append(node.Children, build(childId, level+1))
+		}
+		return node
+	}
+	return build(rootId, 0)
+}
+
+// Sets the reseller of the account, refusing the missing resellers and the loops in the hierarchy
+func (acc *Account) SetParentId(parentId string) error {
+	for id := parentId; id != ""; {
+		if id == acc.Id {
+			return errors.New("LOOP_IN_RESELLER_HIERARCHY")
+		}
+		parent, err := accountingStorage.GetAccount(id)
+		if err != nil {
+			return err
+		}
+		id = parent.ParentId
+	}
+	acc.ParentId = parentId
+	return nil
+}
+
+// Returns the ids of the reseller accounts above this one, the closest first
+func (acc *Account) getResellerIds() (ids []string) {
+	visited := map[string]bool{acc.Id: true}
+	for parentId := acc.ParentId; parentId != "" && !visited[parentId] && len(ids) < MAX_RESELLER_LEVELS; {
+		parent, err := accountingStorage.GetAccount(parentId)
+		if err != nil {
+			Logger.Warning(fmt.Sprintf("<Rater> Could not get reseller account <%s>: %v", parentId, err))
+			break
+		}
+		ids = append(ids, parentId)
+		visited[parentId] = true
+		parentId = parent.ParentId
+	}
+	return
+}
+
+// The usage of the child account rated for the reseller, on the reseller own subject
+func (cd *CallDescriptor) resellerCallDescriptor(reseller *Account) *CallDescriptor {
+	rcd := cd.Clone()
+	rcd.MinCostCredit = utils.Decimal{}
+	rcd.forceDebit = true
+	if ids := strings.SplitN(reseller.Id, utils.CONCATENATED_KEY_SEP, 3); len(ids) == 3 {
+		rcd.Tenant, rcd.Account = ids[1], ids[2]
+	}
+	rcd.Subject = reseller.ResellerSubject
+	if rcd.Subject == "" {
+		rcd.Subject = rcd.Account
+	}
+	return rcd
+}
+
+// Debits the usage on each reseller level, the resellers go negative if needed since the usage already happened
+func (cd *CallDescriptor) debitResellers(cc *CallCost, resellerIds []string) {
+	for _, resellerId := range resellerIds {
+		reseller, err := accountingStorage.GetAccount(resellerId)
+		if err != nil {
+			Logger.Err(fmt.Sprintf("<Rater> Could not get reseller account <%s>: %v", resellerId, err))
+			return
+		}
+		rcc, err := cd.resellerCallDescriptor(reseller).debit(reseller, false, true)
+		if err != nil {
+			Logger.Err(fmt.Sprintf("<Rater> Error debiting reseller account <%s>: %s", resellerId, err.Error()))
+			return
+		}
+		if reseller.isOverCreditLimit(cd.Direction) {
+			Logger.Warning(fmt.Sprintf("<Rater> Reseller account <%s> went over its credit limit", resellerId))
+		}
+		cc.ResellerCosts = append(cc.ResellerCosts, rcc)
+	}
+}
+
+// Locks the shared group members together with the resellers, without duplicates
+func (acc *Account) getDebitLockIds(memberIds []string) (lockIds []string, resellerIds []string) {
+	resellerIds = acc.getResellerIds()
+	lockIds = append(lockIds, memberIds...)
+	for _, resellerId := range resellerIds {
+		if !utils.IsSliceMember(lockIds, resellerId) {
+			lockIds = append(lockIds, resellerId)
+		}
+	}
+	return
+}
+
+// One rated CDR per reseller level, out of the costs debited on the resellers
+func (storedCdr *StoredCdr) resellerCdrs() (cdrs []*StoredCdr) {
+	if storedCdr.CostDetails == nil {
+		return
+	}
+	for i, rcc := range storedCdr.CostDetails.ResellerCosts {
+		rcdr := *storedCdr
+		rcdr.MediationRunId = utils.ConcatenatedKey(storedCdr.MediationRunId, utils.META_RESELLER, strconv.Itoa(i+1))
+		rcdr.ExtraFields = make(map[string]string, len(storedCdr.ExtraFields))
+		for key, value := range storedCdr.ExtraFields {
+			rcdr.ExtraFields[key] = value
+		}
+		rcdr.Tenant, rcdr.Account, rcdr.Subject = rcc.Tenant, rcc.Account, rcc.Subject
+		rcdr.Cost = rcc.Cost
+		rcdr.Taxes = rcc.Taxes
+		rcdr.CostDetails = rcc
+		cdrs = append(cdrs, &rcdr)
+	}
+	return
+}
@@ -0,0 +1,107 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestResellersGetIds(t *testing.T) {
+	accountingStorage.SetAccount(&Account{Id: "*out:cgrates.org:loop1", ParentId: "*out:cgrates.org:loop2"})
+	accountingStorage.SetAccount(&Account{Id: "*out:cgrates.org:loop2", ParentId: "*out:cgrates.org:loop1"})
+	acc := &Account{Id: "*out:cgrates.org:loop0", ParentId: "*out:cgrates.org:loop1"}
+	if ids := acc.getResellerIds(); len(ids) != 2 || ids[0] != "*out:cgrates.org:loop1" || ids[1] != "*out:cgrates.org:loop2" {
+		t.Error("Wrong reseller ids: ", ids)
+	}
+	acc.ParentId = "*out:cgrates.org:missing"
+	if ids := acc.getResellerIds(); len(ids) != 0 {
+		t.Error("Not expecting missing resellers: ", ids)
+	}
+	acc.ParentId = "*out:cgrates.org:loop1"
+	if lockIds, _ := acc.getDebitLockIds([]string{"*out:cgrates.org:loop2"}); len(lockIds) != 2 {
+		t.Error("Wrong lock ids: ", lockIds)
+	}
+}
+
+func TestResellersDebit(t *testing.T) {
+	accountingStorage.SetAccount(&Account{Id: "*out:vdf:res_top", ResellerSubject: "rif"})
+	accountingStorage.SetAccount(&Account{Id: "*out:vdf:res_mid", ParentId: "*out:vdf:res_top", ResellerSubject: "rif"})
+	accountingStorage.SetAccount(&Account{Id: "*out:vdf:res_child", ParentId: "*out:vdf:res_mid", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(100)}},
+	}})
+	cd := &CallDescriptor{
+		TimeStart:   time.Date(2013, 10, 21, 18, 34, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2013, 10, 21, 18, 35, 0, 0, time.UTC),
+		Direction:   OUTBOUND,
+		Category:    "0",
+		Tenant:      "vdf",
+		Subject:     "minu",
+		Account:     "res_child",
+		Destination: "0723",
+	}
+	cc, err := cd.Debit()
+	if err != nil {
+		t.Fatal("Error debiting: ", err)
+	}
+	if len(cc.ResellerCosts) != 2 || cc.ResellerCosts[0].Account != "res_mid" || cc.ResellerCosts[0].Subject != "rif" ||
+		cc.ResellerCosts[1].Account != "res_top" || cc.ResellerCosts[0].Cost.Sign() <= 0 {
+		t.Fatalf("Wrong reseller costs: %+v, %+v", cc.ResellerCosts[0], cc.ResellerCosts[1])
+	}
+	for i, accId := range []string{"*out:vdf:res_mid", "*out:vdf:res_top"} {
+		acc, err := accountingStorage.GetAccount(accId)
+		if err != nil || !acc.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().Equal(cc.ResellerCosts[i].Cost.Neg()) {
+			t.Errorf("Wrong reseller balance for %s: %+v, %v", accId, acc, err)
+		}
+	}
+	cdr := &StoredCdr{CgrId: "res1", MediationRunId: utils.META_DEFAULT, Tenant: "vdf", Account: "res_child", Subject: "minu",
+		Cost: cc.Cost, CostDetails: cc, ExtraFields: map[string]string{"field": "value"}}
+	cdrs := cdr.resellerCdrs()
+	if len(cdrs) != 2 || cdrs[0].MediationRunId != "*default:*reseller:1" || cdrs[0].Account != "res_mid" ||
+		!cdrs[1].Cost.Equal(cc.ResellerCosts[1].Cost) || cdrs[1].ExtraFields["field"] != "value" {
+		t.Errorf("Wrong reseller CDRs: %+v", cdrs)
+	}
+}
+
+func TestResellersAccountTree(t *testing.T) {
+	tree := NewAccountTree("*out:vdf:res_top", []*Account{
+		&Account{Id: "*out:vdf:res_top"},
+		&Account{Id: "*out:vdf:res_mid", ParentId: "*out:vdf:res_top"},
+		&Account{Id: "*out:vdf:res_child", ParentId: "*out:vdf:res_mid"},
+		&Account{Id: "*out:vdf:other"},
+	})
+	if len(tree.Children) != 1 || tree.Children[0].Id != "*out:vdf:res_mid" ||
+		len(tree.Children[0].Children) != 1 || tree.Children[0].Children[0].Id != "*out:vdf:res_child" {
+		t.Errorf("Wrong account tree: %+v", tree)
+	}
+}
+
+func TestResellersSetParentId(t *testing.T) {
+	acc := &Account{Id: "*out:vdf:res_top"}
+	if err := acc.SetParentId("*out:vdf:res_child"); err == nil {
+		t.Error("Expecting error on loop in the hierarchy")
+	}
+	if err := acc.SetParentId("*out:vdf:res_missing"); err != utils.ErrNotFound {
+		t.Error("Expecting not found, got: ", err)
+	}
+	if err := acc.SetParentId(""); err != nil || acc.ParentId != "" {
+		t.Errorf("Error removing the parent: %v, %+v", err, acc)
+	}
+}
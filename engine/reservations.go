@@ -0,0 +1,234 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+var ErrInsufficientCredit = errors.New("INSUFFICIENT_CREDIT")
+
+// Time limited hold on the monetary balances of an account, used for charging outside sessions
+type Reservation struct {
+	Id         string
+	Direction  string
+	Amount     utils.Decimal
+	ExpiryTime time.Time
+}
+
+func (r *Reservation) IsExpired() bool {
+	return !r.ExpiryTime.IsZero() && r.ExpiryTime.Before(time.Now())
+}
+
+type Reservations []*Reservation
+
+func (rs Reservations) Get(id string) *Reservation {
+	for _, r := range rs {
+		if r.Id == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// Sum of the amounts still held on the direction
+func (rs Reservations) GetTotal(direction string) (total utils.Decimal) {
+	for _, r := range rs {
+		if r.Direction == direction && !r.IsExpired() {
+			total = total.Add(r.Amount)
+		}
+	}
+	return
+}
+
+// Parameters of the reservation requests
+type ReservationRequest struct {
+	Direction     string
+	Tenant        string
+	Account       string
+	ReservationId string
+	Amount        utils.Decimal // the amount to hold or to capture
+	TTL           time.Duration // the hold expires after it, 0 for no expiry
+}
+
+func (rr *ReservationRequest) GetAccountKey() string {
+	return utils.ConcatenatedKey(rr.Direction, rr.Tenant, rr.Account)
+}
+
+// Places the hold, refused if the account cannot cover it within its credit limit
+func (acc *Account) reserve(r *Reservation) error {
+	acc.CleanExpiredReservations()
+	if acc.Reservations.Get(r.Id) != nil {
+		return utils.ErrExists
+	}
+	if credit, unlimited := acc.getReservableCredit(r.Direction); !unlimited && credit.Cmp(r.Amount) < 0 {
+		return ErrInsufficientCredit
+	}
+	acc.Reservations = append(acc.Reservations, r)
+	return nil
+}
+
+// Removes the hold and returns it, nil if not found or expired
+func (acc *Account) release(id string) *Reservation {
+	acc.CleanExpiredReservations()
+	for i, r := range acc.Reservations {
+		if r.Id == id {
+			acc.Reservations = append(acc.Reservations[:i], acc.Reservations[i+1:]...)
+			return r
+		}
+	}
+	return nil
+}
+
+// Releases the hold and debits the actual amount from the monetary balances, which can differ from the held one.
+// The amount is limited to the hold plus the credit still available within the credit limit.
+func (acc *Account) capture(id string, amount utils.Decimal) error {
+	r := acc.release(id)
+	if r == nil {
+		return utils.ErrNotFound
+	}
+	// the released hold is back in the available credit
+	if credit, unlimited := acc.getReservableCredit(r.Direction); !unlimited && credit.Cmp(amount) < 0 {
+		acc.Reservations = append(acc.Reservations, r)
+		return ErrInsufficientCredit
+	}
+	acc.debitMoney(r.Direction, amount)
+	acc.countUnits(&Action{BalanceType: utils.MONETARY, Direction: r.Direction, Balance: &Balance{Value: amount}})
+	return nil
+}
+
+// The monetary balances the holds are taken from, selected like for rating a call with no destination and category:
+// the balances restricted to destinations, categories or shared groups are left out, as the ones in other currencies
+// than the default balance
+func (acc *Account) getReservationBalances(direction string) BalanceChain {
+	var currency string
+	for _, b := range acc.BalanceMap[utils.MONETARY+direction] {
+		if b.IsDefault() {
+			currency = b.Currency
+			break
+		}
+	}
+	var bc BalanceChain
+	for _, b := range acc.getBalancesForPrefix("", "", direction, acc.BalanceMap[utils.MONETARY+direction], "") {
+		if b.IsActive() && b.SharedGroup == "" && b.Currency == currency {
+			bc = append(bc, b)
+		}
+	}
+	return bc
+}
+
+// Like the available credit but counting only the balances the holds are taken from
+func (acc *Account) getReservableCredit(direction string) (credit utils.Decimal, unlimited bool) {
+	limit, unlimited := acc.getCreditLimitAmount(direction)
+	credit = acc.getReservationBalances(direction).GetTotalValue().Add(limit).Sub(acc.Reservations.GetTotal(direction))
+	return
+}
+
+// Debits the amount from the reservation balances in their usage order, the rest goes negative on the default balance
+func (acc *Account) debitMoney(direction string, amount utils.Decimal) {
+	if amount.IsZero() {
+		return
+	}
+	defaultBalance := acc.GetDefaultMoneyBalance(direction) // make sure we have where to go negative
+	left := amount
+	for _, b := range acc.getReservationBalances(direction) {
+		if b.Value.Sign() <= 0 {
+			continue
+		}
+		debit := utils.MinDecimal(b.Value, left)
+		b.SubstractAmount(debit)
+		if left = left.Sub(debit); left.Sign() <= 0 {
+			return
+		}
+	}
+	defaultBalance.SubstractAmount(left)
+}
+
+// Takes the held amounts out of the balances, used on the cloned accounts so the dry runs see only the free credit
+func (acc *Account) applyReservations(direction string) {
+	acc.debitMoney(direction, acc.Reservations.GetTotal(direction))
+}
+
+func (acc *Account) CleanExpiredReservations() {
+	for i := 0; i < len(acc.Reservations); i++ {
+		if acc.Reservations[i].IsExpired() {
+			acc.Reservations = append(acc.Reservations[:i], acc.Reservations[i+1:]...)
+			i--
+		}
+	}
+}
+
+// Loads the account, applies the change and saves it back, all under the account lock
+func updateReservations(accKey string, handler func(*Account) error) error {
+	_, err := AccLock.Guard(func() (interface{}, error) {
+		acc, err := accountingStorage.GetAccount(accKey)
+		if err != nil {
+			return nil, err
+		}
+		if acc.Disabled {
+			return nil, fmt.Errorf("User %s is disabled", acc.Id)
+		}
+		if err := handler(acc); err != nil {
+			return nil, err
+		}
+		return nil, accountingStorage.SetAccount(acc)
+	}, accKey)
+	return err
+}
+
+// Holds the amount on the account until captured, released or expired
+func ReserveBalance(rr *ReservationRequest) (*Reservation, error) {
+	if rr.Amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid reservation amount: %v", rr.Amount)
+	}
+	r := &Reservation{Id: rr.ReservationId, Direction: rr.Direction, Amount: rr.Amount}
+	if rr.TTL > 0 {
+		r.ExpiryTime = time.Now().Add(rr.TTL)
+	}
+	if err := updateReservations(rr.GetAccountKey(), func(acc *Account) error {
+		return acc.reserve(r)
+	}); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Debits the actual amount and drops the hold
+func CaptureReservation(rr *ReservationRequest) error {
+	if rr.Amount.Sign() < 0 {
+		return fmt.Errorf("invalid capture amount: %v", rr.Amount)
+	}
+	return updateReservations(rr.GetAccountKey(), func(acc *Account) error {
+		acc.SetLedgerCause(LEDGER_RESERVATION, rr.ReservationId)
+		return acc.capture(rr.ReservationId, rr.Amount)
+	})
+}
+
+// Drops the hold without debiting anything
+func ReleaseReservation(rr *ReservationRequest) error {
+	return updateReservations(rr.GetAccountKey(), func(acc *Account) error {
+		if acc.release(rr.ReservationId) == nil {
+			return utils.ErrNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,176 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestReservationsReserve(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:res", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)}},
+	}}
+	if err := acc.reserve(&Reservation{Id: "r1", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(6)}); err != nil {
+		t.Fatal("Error reserving: ", err)
+	}
+	if err := acc.reserve(&Reservation{Id: "r1", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(1)}); err != utils.ErrExists {
+		t.Error("Expecting exists error, got: ", err)
+	}
+	if err := acc.reserve(&Reservation{Id: "r2", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(5)}); err != ErrInsufficientCredit {
+		t.Error("Expecting insufficient credit, got: ", err)
+	}
+	acc.Reservations = append(acc.Reservations, &Reservation{Id: "old", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(3), ExpiryTime: time.Now().Add(-time.Minute)})
+	if err := acc.reserve(&Reservation{Id: "r2", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(4)}); err != nil {
+		t.Error("Error reserving: ", err)
+	}
+	if len(acc.Reservations) != 2 || acc.Reservations.Get("old") != nil {
+		t.Errorf("Expired reservation not cleaned: %+v", acc.Reservations)
+	}
+	if credit, _ := acc.getAvailableCredit(OUTBOUND); !credit.IsZero() {
+		t.Error("Wrong available credit: ", credit)
+	}
+}
+
+func TestReservationsCaptureRelease(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:res", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10), Weight: 10}},
+	}}
+	acc.Reservations = Reservations{
+		&Reservation{Id: "r1", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(6)},
+		&Reservation{Id: "r2", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(2)},
+	}
+	if err := acc.capture("r1", utils.NewDecimalFromFloat(7)); err != nil {
+		t.Fatal("Error capturing: ", err)
+	}
+	if total := acc.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue(); total.String() != "3" {
+		t.Error("Wrong balance after capture: ", total)
+	}
+	if err := acc.capture("r1", utils.NewDecimalFromFloat(1)); err != utils.ErrNotFound {
+		t.Error("Expecting not found on second capture, got: ", err)
+	}
+	if r := acc.release("r2"); r == nil || len(acc.Reservations) != 0 {
+		t.Errorf("Wrong release: %+v, %+v", r, acc.Reservations)
+	}
+}
+
+func TestReservationsOverCapture(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:res", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)}},
+	}, Reservations: Reservations{
+		&Reservation{Id: "r1", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(6)},
+		&Reservation{Id: "r2", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(2)},
+	}}
+	// the hold of 6 plus the free credit of 2
+	if err := acc.capture("r1", utils.NewDecimalFromFloat(8.5)); err != ErrInsufficientCredit {
+		t.Error("Expecting insufficient credit, got: ", err)
+	}
+	if acc.Reservations.Get("r1") == nil || acc.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().String() != "10" {
+		t.Errorf("Rejected capture changed the account: %+v, %+v", acc.Reservations, acc.BalanceMap)
+	}
+	acc.SetCreditLimit(OUTBOUND, &CreditLimit{Amount: utils.NewDecimalFromFloat(5)})
+	if err := acc.capture("r1", utils.NewDecimalFromFloat(13)); err != nil {
+		t.Fatal("Error capturing within the credit limit: ", err)
+	}
+	if total := acc.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue(); total.String() != "-3" {
+		t.Error("Wrong balance after capture: ", total)
+	}
+	acc.SetCreditLimit(OUTBOUND, &CreditLimit{Unlimited: true})
+	if err := acc.capture("r2", utils.NewDecimalFromFloat(100)); err != nil {
+		t.Error("Error capturing on unlimited credit: ", err)
+	}
+}
+
+func TestReservationsPartialCapture(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:res", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)}},
+	}, Reservations: Reservations{&Reservation{Id: "r1", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(6)}}}
+	if err := acc.capture("r1", utils.NewDecimalFromFloat(2.5)); err != nil {
+		t.Fatal("Error capturing: ", err)
+	}
+	if credit, _ := acc.getAvailableCredit(OUTBOUND); credit.String() != "7.5" || len(acc.Reservations) != 0 {
+		t.Errorf("Wrong credit after partial capture: %v, %+v", credit, acc.Reservations)
+	}
+}
+
+func TestReservationsCaptureBalances(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:res", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{
+			&Balance{Uuid: "nat", Value: utils.NewDecimalFromFloat(50), DestinationIds: "NAT", Weight: 20},
+			&Balance{Uuid: "ron", Value: utils.NewDecimalFromFloat(50), Currency: "RON", Weight: 10},
+			&Balance{Uuid: "bonus", Value: utils.NewDecimalFromFloat(3), Weight: 5},
+			&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(2)},
+			&Balance{Uuid: "expired", Value: utils.NewDecimalFromFloat(10), ExpirationDate: time.Now().Add(-time.Hour)},
+		}}, Reservations: Reservations{&Reservation{Id: "r1", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(5)}}}
+	if err := acc.reserve(&Reservation{Id: "r2", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(1)}); err != ErrInsufficientCredit {
+		t.Error("Expecting insufficient credit outside the restricted balances, got: ", err)
+	}
+	acc.SetCreditLimit(OUTBOUND, &CreditLimit{Amount: utils.NewDecimalFromFloat(10)})
+	if err := acc.capture("r1", utils.NewDecimalFromFloat(8)); err != nil {
+		t.Fatal("Error capturing: ", err)
+	}
+	chain := acc.BalanceMap[utils.MONETARY+OUTBOUND]
+	if chain.GetBalance("nat").Value.String() != "50" || chain.GetBalance("ron").Value.String() != "50" ||
+		chain.GetBalance("bonus").Value.String() != "0" || chain.GetBalance("money").Value.String() != "-3" ||
+		chain.GetBalance("expired").Value.String() != "10" {
+		t.Errorf("Wrong balances after capture: %+v", chain)
+	}
+}
+
+func TestReservationsCreditForPrefix(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:res", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)}},
+	}, Reservations: Reservations{&Reservation{Id: "r1", Direction: OUTBOUND, Amount: utils.NewDecimalFromFloat(7)}}}
+	cd := &CallDescriptor{Direction: OUTBOUND, Category: "0", Destination: "0723", TOR: utils.VOICE}
+	if _, credit, _ := acc.getCreditForPrefix(cd); credit.String() != "3" {
+		t.Error("Wrong credit with the holds: ", credit)
+	}
+	clone := acc.Clone()
+	clone.applyReservations(OUTBOUND)
+	if clone.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().String() != "3" ||
+		acc.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().String() != "10" {
+		t.Error("Wrong holds applied on the clone: ", clone.BalanceMap[utils.MONETARY+OUTBOUND])
+	}
+}
+
+func TestReservationsStorage(t *testing.T) {
+	accountingStorage.SetAccount(&Account{Id: "*out:cgrates.org:reserved", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)}},
+	}})
+	rr := &ReservationRequest{Direction: OUTBOUND, Tenant: "cgrates.org", Account: "reserved", ReservationId: "order1",
+		Amount: utils.NewDecimalFromFloat(8), TTL: time.Hour}
+	if r, err := ReserveBalance(rr); err != nil || r.ExpiryTime.IsZero() {
+		t.Fatalf("Error reserving: %+v, %v", r, err)
+	}
+	if acc, err := accountingStorage.GetAccount(rr.GetAccountKey()); err != nil || acc.Reservations.Get("order1") == nil {
+		t.Fatalf("Reservation not stored: %+v, %v", acc, err)
+	}
+	rr.Amount = utils.NewDecimalFromFloat(7.5)
+	if err := CaptureReservation(rr); err != nil {
+		t.Error("Error capturing: ", err)
+	}
+	if err := ReleaseReservation(rr); err != utils.ErrNotFound {
+		t.Error("Expecting not found after capture, got: ", err)
+	}
+	if acc, err := accountingStorage.GetAccount(rr.GetAccountKey()); err != nil || len(acc.Reservations) != 0 ||
+		acc.BalanceMap[utils.MONETARY+OUTBOUND].GetTotalValue().String() != "2.5" {
+		t.Errorf("Wrong account after capture: %+v, %v", acc, err)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/balancer2go"
@@ -67,6 +68,53 @@ func (rs *Responder) GetCost(arg *CallDescriptor, reply *CallCost) (err error) {
 	return
 }
 
+// One result of the batch cost request, Error is set if the cost could not be calculated
+type CostResult struct {
+	CallCost *CallCost
+	Error    string
+}
+
+/*
+RPC method calculating the costs of many call descriptors at once, they are rated concurrently.
+The rating only reads the accounts so it does not wait for their locks.
+*/
+func (rs *Responder) GetCosts(args []*CallDescriptor, reply *[]*CostResult) error {
+	for idx, cd := range args {
+		if cd == nil {
+			return utils.NewErrMandatoryIeMissing(fmt.Sprintf("CallDescriptor[%d]", idx))
+		}
+	}
+	results := make([]*CostResult, len(args))
+	workers := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for idx, cd := range args {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(idx int, cd *CallDescriptor) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			var cc *CallCost
+			var err error
+			if rs.Bal != nil {
+				cc = new(CallCost)
+				err = rs.GetCost(cd, cc)
+			} else {
+				cc, err = cd.GetCost()
+			}
+			if err != nil {
+				results[idx] = &CostResult{Error: err.Error()}
+			} else {
+				results[idx] = &CostResult{CallCost: cc}
+			}
+		}(idx, cd)
+	}
+	wg.Wait()
+	*reply = results
+	return nil
+}
+
 func (rs *Responder) Debit(arg *CallDescriptor, reply *CallCost) (err error) {
 	if rs.Bal != nil {
 		r, e := rs.getCallCost(arg, "Responder.Debit")
@@ -103,7 +151,7 @@ func (rs *Responder) RefundIncrements(arg *CallDescriptor, reply *float64) (err
 	} else {
 		r, e := AccLock.Guard(func() (interface{}, error) {
 			return arg.RefundIncrements()
-		}, arg.GetAccountKey())
+		}, arg.getRefundLockIds()...)
 		*reply, err = r.(float64), e
 	}
 	return
@@ -119,6 +167,43 @@ func (rs *Responder) GetMaxSessionTime(arg *CallDescriptor, reply *float64) (err
 	return
 }
 
+// Places a time limited hold on the monetary balances of the account
+func (rs *Responder) ReserveBalance(arg *ReservationRequest, reply *Reservation) error {
+	if rs.Bal != nil {
+		return errors.New("unsupported method on the balancer")
+	}
+	r, err := ReserveBalance(arg)
+	if err != nil {
+		return err
+	}
+	*reply = *r
+	return nil
+}
+
+// Debits the actual amount of a reservation and drops its hold
+func (rs *Responder) CaptureReservation(arg *ReservationRequest, reply *string) error {
+	if rs.Bal != nil {
+		return errors.New("unsupported method on the balancer")
+	}
+	if err := CaptureReservation(arg); err != nil {
+		return err
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// Drops the hold of a reservation without debiting
+func (rs *Responder) ReleaseReservation(arg *ReservationRequest, reply *string) error {
+	if rs.Bal != nil {
+		return errors.New("unsupported method on the balancer")
+	}
+	if err := ReleaseReservation(arg); err != nil {
+		return err
+	}
+	*reply = utils.OK
+	return nil
+}
+
 // Returns MaxSessionTime for an event received in SessionManager, considering DerivedCharging for it
 func (rs *Responder) GetDerivedMaxSessionTime(ev *StoredCdr, reply *float64) error {
 	if rs.Bal != nil {
@@ -158,6 +243,7 @@ func (rs *Responder) GetDerivedMaxSessionTime(ev *StoredCdr, reply *float64) err
 			Subject:     ev.GetSubject(dc.SubjectField),
 			Account:     ev.GetAccount(dc.AccountField),
 			Destination: ev.GetDestination(dc.DestinationField),
+			Origin:      ev.GetOrigin(dc.OriginField),
 			TimeStart:   startTime,
 			TimeEnd:     startTime.Add(config.CgrConfig().MaxCallDuration),
 		}
@@ -206,6 +292,8 @@ func (rs *Responder) GetSessionRuns(ev *StoredCdr, sRuns *[]*SessionRun) error {
 			Subject:     ev.GetSubject(dc.SubjectField),
 			Account:     ev.GetAccount(dc.AccountField),
 			Destination: ev.GetDestination(dc.DestinationField),
+			Origin:      ev.GetOrigin(dc.OriginField),
+			CgrId:       ev.CgrId,
 			TimeStart:   startTime}
 		sesRuns = append(sesRuns, &SessionRun{DerivedCharger: dc, CallDescriptor: cd})
 	}
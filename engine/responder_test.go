@@ -44,7 +44,7 @@ func TestResponderGetDerivedChargers(t *testing.T) {
 	if err := ratingStorage.SetDerivedChargers(utils.DerivedChargersKey(utils.OUT, utils.ANY, utils.ANY, utils.ANY, utils.ANY), cfgedDC); err != nil {
 		t.Error(err)
 	}
-	if err := ratingStorage.CacheRating([]string{}, []string{}, []string{}, []string{}, []string{}, nil); err != nil {
+	if err := ratingStorage.CacheRating([]string{}, []string{}, []string{}, []string{}, []string{}, nil, []string{}, []string{}, []string{}, []string{}); err != nil {
 		t.Error(err)
 	}
 	var dcs utils.DerivedChargers
@@ -55,13 +55,42 @@ func TestResponderGetDerivedChargers(t *testing.T) {
 	}
 }
 
+func TestResponderGetCosts(t *testing.T) {
+	t1 := time.Date(2015, 6, 30, 10, 0, 0, 0, time.UTC)
+	cds := []*CallDescriptor{
+		&CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "minmax", Destination: "0723045326",
+			TimeStart: t1, TimeEnd: t1.Add(3 * time.Second)},
+		&CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "nocategory", Subject: "minmax", Destination: "0723045326",
+			TimeStart: t1, TimeEnd: t1.Add(3 * time.Second)},
+		&CallDescriptor{Direction: OUTBOUND, Tenant: "cgrates.org", Category: "call", Subject: "minmax", Destination: "0723045326",
+			TimeStart: t1, TimeEnd: t1.Add(7 * time.Second)},
+	}
+	var results []*CostResult
+	rs := &Responder{}
+	if err := rs.GetCosts(cds, &results); err != nil || len(results) != 3 {
+		t.Fatalf("Error getting costs: %+v, %v", results, err)
+	}
+	if results[0].Error != "" || results[0].CallCost.Cost.String() != "0.5" {
+		t.Errorf("Wrong first result: %+v", results[0])
+	}
+	if results[1].Error == "" || results[1].CallCost != nil {
+		t.Errorf("Expecting error on the second result: %+v", results[1])
+	}
+	if results[2].Error != "" || results[2].CallCost.Cost.String() != "0.7" {
+		t.Errorf("Wrong third result: %+v", results[2])
+	}
+	if err := rs.GetCosts([]*CallDescriptor{cds[0], nil}, &results); err == nil {
+		t.Error("Expecting error on nil call descriptor")
+	}
+}
+
 func TestGetDerivedMaxSessionTime(t *testing.T) {
 	testTenant := "vdf"
 	cdr := &StoredCdr{CgrId: utils.Sha1("dsafdsaf", time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC).String()), OrderId: 123, TOR: utils.VOICE, AccId: "dsafdsaf",
 		CdrHost: "192.168.1.1", CdrSource: "test", ReqType: utils.META_RATED, Direction: "*out", Tenant: testTenant, Category: "call", Account: "dan", Subject: "dan",
 		Destination: "1002", SetupTime: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC), AnswerTime: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC),
 		MediationRunId: utils.DEFAULT_RUNID, Usage: time.Duration(10) * time.Second, ExtraFields: map[string]string{"field_extr1": "val_extr1", "fieldextr2": "valextr2"},
-		Cost: 1.01, RatedAccount: "dan", RatedSubject: "dan"}
+		Cost: utils.NewDecimalFromFloat(1.01), RatedAccount: "dan", RatedSubject: "dan"}
 	var maxSessionTime float64
 	if err := rsponder.GetDerivedMaxSessionTime(cdr, &maxSessionTime); err != nil {
 		t.Error(err)
@@ -72,8 +101,8 @@ func TestGetDerivedMaxSessionTime(t *testing.T) {
 	if err := ratingStorage.SetDestination(deTMobile); err != nil {
 		t.Error(err)
 	}
-	b10 := &Balance{Value: 10, Weight: 10, DestinationIds: "DE_TMOBILE"}
-	b20 := &Balance{Value: 20, Weight: 10, DestinationIds: "DE_TMOBILE"}
+	b10 := &Balance{Value: utils.NewDecimalFromFloat(10), Weight: 10, DestinationIds: "DE_TMOBILE"}
+	b20 := &Balance{Value: utils.NewDecimalFromFloat(20), Weight: 10, DestinationIds: "DE_TMOBILE"}
 	rifsAccount := &Account{Id: utils.ConcatenatedKey(utils.OUT, testTenant, "rif"), BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b10}}}
 	dansAccount := &Account{Id: utils.ConcatenatedKey(utils.OUT, testTenant, "dan"), BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{b20}}}
 	if err := accountingStorage.SetAccount(rifsAccount); err != nil {
@@ -94,18 +123,18 @@ func TestGetDerivedMaxSessionTime(t *testing.T) {
 	if err := ratingStorage.SetDerivedChargers(keyCharger1, charger1); err != nil {
 		t.Error("Error on setting DerivedChargers", err.Error())
 	}
-	ratingStorage.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingStorage.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	accountingStorage.CacheAccounting(nil, nil, nil)
 	if rifStoredAcnt, err := accountingStorage.GetAccount(utils.ConcatenatedKey(utils.OUT, testTenant, "rif")); err != nil {
 		t.Error(err)
 		//} else if rifStoredAcnt.BalanceMap[utils.VOICE+OUTBOUND].Equal(rifsAccount.BalanceMap[utils.VOICE+OUTBOUND]) {
 		//	t.Errorf("Expected: %+v, received: %+v", rifsAccount.BalanceMap[utils.VOICE+OUTBOUND][0], rifStoredAcnt.BalanceMap[utils.VOICE+OUTBOUND][0])
-	} else if rifStoredAcnt.BalanceMap[utils.VOICE+OUTBOUND][0].Value != rifsAccount.BalanceMap[utils.VOICE+OUTBOUND][0].Value {
+	} else if !rifStoredAcnt.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Equal(rifsAccount.BalanceMap[utils.VOICE+OUTBOUND][0].Value) {
 		t.Error("BalanceValue: ", rifStoredAcnt.BalanceMap[utils.VOICE+OUTBOUND][0].Value)
 	}
 	if danStoredAcnt, err := accountingStorage.GetAccount(utils.ConcatenatedKey(utils.OUT, testTenant, "dan")); err != nil {
 		t.Error(err)
-	} else if danStoredAcnt.BalanceMap[utils.VOICE+OUTBOUND][0].Value != dansAccount.BalanceMap[utils.VOICE+OUTBOUND][0].Value {
+	} else if !danStoredAcnt.BalanceMap[utils.VOICE+OUTBOUND][0].Value.Equal(dansAccount.BalanceMap[utils.VOICE+OUTBOUND][0].Value) {
 		t.Error("BalanceValue: ", danStoredAcnt.BalanceMap[utils.VOICE+OUTBOUND][0].Value)
 	}
 	var dcs utils.DerivedChargers
@@ -129,12 +158,12 @@ func TestGetSessionRuns(t *testing.T) {
 		CdrHost: "192.168.1.1", CdrSource: "test", ReqType: utils.META_PREPAID, Direction: "*out", Tenant: testTenant, Category: "call", Account: "dan2", Subject: "dan2",
 		Destination: "1002", SetupTime: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC), Pdd: 3 * time.Second, AnswerTime: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC), Supplier: "suppl1",
 		MediationRunId: utils.DEFAULT_RUNID, Usage: time.Duration(10) * time.Second, ExtraFields: map[string]string{"field_extr1": "val_extr1", "fieldextr2": "valextr2"},
-		Cost: 1.01, RatedAccount: "dan", RatedSubject: "dan"}
+		Cost: utils.NewDecimalFromFloat(1.01), RatedAccount: "dan", RatedSubject: "dan"}
 	keyCharger1 := utils.ConcatenatedKey("*out", testTenant, "call", "dan2", "dan2")
 	dfDC := &utils.DerivedCharger{RunId: utils.DEFAULT_RUNID, ReqTypeField: utils.META_DEFAULT, DirectionField: utils.META_DEFAULT, TenantField: utils.META_DEFAULT,
 		CategoryField: utils.META_DEFAULT, AccountField: utils.META_DEFAULT, SubjectField: utils.META_DEFAULT, DestinationField: utils.META_DEFAULT,
 		SetupTimeField: utils.META_DEFAULT, PddField: utils.META_DEFAULT, AnswerTimeField: utils.META_DEFAULT, UsageField: utils.META_DEFAULT, SupplierField: utils.META_DEFAULT,
-		DisconnectCauseField: utils.META_DEFAULT}
+		DisconnectCauseField: utils.META_DEFAULT, OriginField: utils.META_DEFAULT}
 	extra1DC := &utils.DerivedCharger{RunId: "extra1", ReqTypeField: "^" + utils.META_PREPAID, DirectionField: utils.META_DEFAULT, TenantField: utils.META_DEFAULT,
 		CategoryField: "^0", AccountField: "^minitsboy", SubjectField: "^rif", DestinationField: "^0256",
 		SetupTimeField: utils.META_DEFAULT, PddField: utils.META_DEFAULT, AnswerTimeField: utils.META_DEFAULT, UsageField: utils.META_DEFAULT, SupplierField: utils.META_DEFAULT}
@@ -149,15 +178,15 @@ func TestGetSessionRuns(t *testing.T) {
 	if err := ratingStorage.SetDerivedChargers(keyCharger1, charger1); err != nil {
 		t.Error("Error on setting DerivedChargers", err.Error())
 	}
-	ratingStorage.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingStorage.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	sesRuns := make([]*SessionRun, 0)
 	eSRuns := []*SessionRun{
 		&SessionRun{DerivedCharger: extra1DC,
-			CallDescriptor: &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Account: "minitsboy", Destination: "0256", TimeStart: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC)}},
+			CallDescriptor: &CallDescriptor{Direction: "*out", Category: "0", Tenant: "vdf", Subject: "rif", Account: "minitsboy", Destination: "0256", CgrId: cdr.CgrId, TimeStart: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC)}},
 		&SessionRun{DerivedCharger: extra2DC,
-			CallDescriptor: &CallDescriptor{Direction: "*out", Category: "call", Tenant: "vdf", Subject: "ivo", Account: "ivo", Destination: "1002", TimeStart: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC)}},
+			CallDescriptor: &CallDescriptor{Direction: "*out", Category: "call", Tenant: "vdf", Subject: "ivo", Account: "ivo", Destination: "1002", CgrId: cdr.CgrId, TimeStart: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC)}},
 		&SessionRun{DerivedCharger: dfDC,
-			CallDescriptor: &CallDescriptor{Direction: "*out", Category: "call", Tenant: "vdf", Subject: "dan2", Account: "dan2", Destination: "1002", TimeStart: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC)}}}
+			CallDescriptor: &CallDescriptor{Direction: "*out", Category: "call", Tenant: "vdf", Subject: "dan2", Account: "dan2", Destination: "1002", CgrId: cdr.CgrId, TimeStart: time.Date(2013, 11, 7, 8, 42, 26, 0, time.UTC)}}}
 	if err := rsponder.GetSessionRuns(cdr, &sesRuns); err != nil {
 		t.Error(err)
 	} else if !reflect.DeepEqual(eSRuns, sesRuns) {
@@ -184,11 +213,11 @@ func TestGetLCR(t *testing.T) {
 		},
 		Ratings: map[string]*RIRate{
 			"b457f86d": &RIRate{
-				ConnectFee: 0,
+				ConnectFee: utils.NewDecimalFromFloat(0),
 				Rates: []*Rate{
 					&Rate{
 						GroupIntervalStart: 0,
-						Value:              0.01,
+						Value:              utils.NewDecimalFromFloat(0.01),
 						RateIncrement:      time.Second,
 						RateUnit:           time.Second,
 					},
@@ -220,11 +249,11 @@ func TestGetLCR(t *testing.T) {
 		},
 		Ratings: map[string]*RIRate{
 			"b457f86d": &RIRate{
-				ConnectFee: 0,
+				ConnectFee: utils.NewDecimalFromFloat(0),
 				Rates: []*Rate{
 					&Rate{
 						GroupIntervalStart: 0,
-						Value:              0.02,
+						Value:              utils.NewDecimalFromFloat(0.02),
 						RateIncrement:      time.Second,
 						RateUnit:           time.Second,
 					},
@@ -256,11 +285,11 @@ func TestGetLCR(t *testing.T) {
 		},
 		Ratings: map[string]*RIRate{
 			"b457f86d": &RIRate{
-				ConnectFee: 0,
+				ConnectFee: utils.NewDecimalFromFloat(0),
 				Rates: []*Rate{
 					&Rate{
 						GroupIntervalStart: 0,
-						Value:              0.03,
+						Value:              utils.NewDecimalFromFloat(0.03),
 						RateIncrement:      time.Second,
 						RateUnit:           time.Second,
 					},
@@ -365,7 +394,7 @@ func TestGetLCR(t *testing.T) {
 		[]string{RATING_PROFILE_PREFIX + danRpfl.Id, RATING_PROFILE_PREFIX + rifRpfl.Id},
 		[]string{},
 		[]string{LCR_PREFIX + lcrStatic.GetId(), LCR_PREFIX + lcrLowestCost.GetId()},
-		[]string{}); err != nil {
+		[]string{}, []string{}, []string{}, []string{}, []string{}); err != nil {
 		t.Error(err)
 	}
 	cdStatic := &CallDescriptor{
@@ -381,9 +410,9 @@ func TestGetLCR(t *testing.T) {
 	eStLcr := &LCRCost{
 		Entry: &LCREntry{DestinationId: utils.ANY, RPCategory: "call", Strategy: LCR_STRATEGY_STATIC, StrategyParams: "ivo12;dan12;rif12", Weight: 10.0},
 		SupplierCosts: []*LCRSupplierCost{
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: 1.8, Duration: 60 * time.Second},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: 0.6, Duration: 60 * time.Second},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: 1.2, Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: utils.NewDecimalFromFloat(1.8), Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: utils.NewDecimalFromFloat(0.6), Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: utils.NewDecimalFromFloat(1.2), Duration: 60 * time.Second},
 		},
 	}
 	var lcr LCRCost
@@ -408,9 +437,9 @@ func TestGetLCR(t *testing.T) {
 	eLcLcr := &LCRCost{
 		Entry: &LCREntry{DestinationId: utils.ANY, RPCategory: "call", Strategy: LCR_STRATEGY_LOWEST, Weight: 10.0},
 		SupplierCosts: []*LCRSupplierCost{
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: 0.6, Duration: 60 * time.Second},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: 1.2, Duration: 60 * time.Second},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: 1.8, Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: utils.NewDecimalFromFloat(0.6), Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: utils.NewDecimalFromFloat(1.2), Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: utils.NewDecimalFromFloat(1.8), Duration: 60 * time.Second},
 		},
 	}
 	var lcrLc LCRCost
@@ -422,10 +451,10 @@ func TestGetLCR(t *testing.T) {
 	} else if !reflect.DeepEqual(eLcLcr.SupplierCosts, lcrLc.SupplierCosts) {
 		t.Errorf("Expecting: %+v, received: %+v", eLcLcr.SupplierCosts[1], lcrLc.SupplierCosts[1])
 	}
-	bRif12 := &Balance{Value: 40, Weight: 10, DestinationIds: dstDe.Id}
-	bIvo12 := &Balance{Value: 60, Weight: 10, DestinationIds: dstDe.Id}
-	rif12sAccount := &Account{Id: utils.ConcatenatedKey(utils.OUT, "tenant12", "rif12"), BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{bRif12}}, AllowNegative: true}
-	ivo12sAccount := &Account{Id: utils.ConcatenatedKey(utils.OUT, "tenant12", "ivo12"), BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{bIvo12}}, AllowNegative: true}
+	bRif12 := &Balance{Value: utils.NewDecimalFromFloat(40), Weight: 10, DestinationIds: dstDe.Id}
+	bIvo12 := &Balance{Value: utils.NewDecimalFromFloat(60), Weight: 10, DestinationIds: dstDe.Id}
+	rif12sAccount := &Account{Id: utils.ConcatenatedKey(utils.OUT, "tenant12", "rif12"), BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{bRif12}}, CreditLimits: map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}}}
+	ivo12sAccount := &Account{Id: utils.ConcatenatedKey(utils.OUT, "tenant12", "ivo12"), BalanceMap: map[string]BalanceChain{utils.VOICE + OUTBOUND: BalanceChain{bIvo12}}, CreditLimits: map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}}}
 	for _, acnt := range []*Account{rif12sAccount, ivo12sAccount} {
 		if err := accountingStorage.SetAccount(acnt); err != nil {
 			t.Error(err)
@@ -434,9 +463,9 @@ func TestGetLCR(t *testing.T) {
 	eLcLcr = &LCRCost{
 		Entry: &LCREntry{DestinationId: utils.ANY, RPCategory: "call", Strategy: LCR_STRATEGY_LOWEST, Weight: 10.0},
 		SupplierCosts: []*LCRSupplierCost{
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: 0, Duration: 60 * time.Second},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: 0.4, Duration: 60 * time.Second},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: 0.6, Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: utils.NewDecimalFromFloat(0), Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: utils.NewDecimalFromFloat(0.4), Duration: 60 * time.Second},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: utils.NewDecimalFromFloat(0.6), Duration: 60 * time.Second},
 		},
 	}
 	if err := rsponder.GetLCR(cdLowestCost, &lcrLc); err != nil {
@@ -462,9 +491,9 @@ func TestGetLCR(t *testing.T) {
 	eQTLcr := &LCRCost{
 		Entry: &LCREntry{DestinationId: utils.ANY, RPCategory: "call", Strategy: LCR_STRATEGY_QOS_THRESHOLD, StrategyParams: "35;;;;4m;;;;;;;", Weight: 10.0},
 		SupplierCosts: []*LCRSupplierCost{
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: 0, Duration: 60 * time.Second, QOS: map[string]float64{TCD: -1, ACC: -1, TCC: -1, ASR: -1, ACD: -1, PDD: -1}, qosSortParams: []string{"35", "4m"}},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: 0.4, Duration: 60 * time.Second, QOS: map[string]float64{TCD: -1, ACC: -1, TCC: -1, ASR: -1, ACD: -1, PDD: -1}, qosSortParams: []string{"35", "4m"}},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: 0.6, Duration: 60 * time.Second, QOS: map[string]float64{TCD: -1, ACC: -1, TCC: -1, ASR: -1, ACD: -1, PDD: -1}, qosSortParams: []string{"35", "4m"}},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: utils.NewDecimalFromFloat(0), Duration: 60 * time.Second, QOS: map[string]float64{TCD: -1, ACC: -1, TCC: -1, ASR: -1, ACD: -1, PDD: -1}, qosSortParams: []string{"35", "4m"}},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: utils.NewDecimalFromFloat(0.4), Duration: 60 * time.Second, QOS: map[string]float64{TCD: -1, ACC: -1, TCC: -1, ASR: -1, ACD: -1, PDD: -1}, qosSortParams: []string{"35", "4m"}},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: utils.NewDecimalFromFloat(0.6), Duration: 60 * time.Second, QOS: map[string]float64{TCD: -1, ACC: -1, TCC: -1, ASR: -1, ACD: -1, PDD: -1}, qosSortParams: []string{"35", "4m"}},
 		},
 	}
 	var lcrQT LCRCost
@@ -476,15 +505,15 @@ func TestGetLCR(t *testing.T) {
 	} else if !reflect.DeepEqual(eQTLcr.SupplierCosts, lcrQT.SupplierCosts) {
 		t.Errorf("Expecting: %+v, received: %+v", eQTLcr.SupplierCosts[0], lcrQT.SupplierCosts[0])
 	}
-	cdr := &StoredCdr{Supplier: "rif12", AnswerTime: time.Now(), Usage: 3 * time.Minute, Cost: 1}
+	cdr := &StoredCdr{Supplier: "rif12", AnswerTime: time.Now(), Usage: 3 * time.Minute, Cost: utils.NewDecimalFromFloat(1)}
 	rsponder.Stats.AppendCDR(cdr, nil)
-	cdr = &StoredCdr{Supplier: "dan12", AnswerTime: time.Now(), Usage: 5 * time.Minute, Cost: 2}
+	cdr = &StoredCdr{Supplier: "dan12", AnswerTime: time.Now(), Usage: 5 * time.Minute, Cost: utils.NewDecimalFromFloat(2)}
 	rsponder.Stats.AppendCDR(cdr, nil)
 	eQTLcr = &LCRCost{
 		Entry: &LCREntry{DestinationId: utils.ANY, RPCategory: "call", Strategy: LCR_STRATEGY_QOS_THRESHOLD, StrategyParams: "35;;;;4m;;;;;;;", Weight: 10.0},
 		SupplierCosts: []*LCRSupplierCost{
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: 0, Duration: 60 * time.Second, QOS: map[string]float64{PDD: -1, TCD: -1, ACC: -1, TCC: -1, ASR: -1, ACD: -1}, qosSortParams: []string{"35", "4m"}},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: 0.6, Duration: 60 * time.Second, QOS: map[string]float64{PDD: -1, ACD: 300, TCD: 300, ASR: 100, ACC: 2, TCC: 2}, qosSortParams: []string{"35", "4m"}},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: utils.NewDecimalFromFloat(0), Duration: 60 * time.Second, QOS: map[string]float64{PDD: -1, TCD: -1, ACC: -1, TCC: -1, ASR: -1, ACD: -1}, qosSortParams: []string{"35", "4m"}},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: utils.NewDecimalFromFloat(0.6), Duration: 60 * time.Second, QOS: map[string]float64{PDD: -1, ACD: 300, TCD: 300, ASR: 100, ACC: 2, TCC: 2}, qosSortParams: []string{"35", "4m"}},
 		},
 	}
 	if err := rsponder.GetLCR(cdQosThreshold, &lcrQT); err != nil {
@@ -510,9 +539,9 @@ func TestGetLCR(t *testing.T) {
 	eQosLcr := &LCRCost{
 		Entry: &LCREntry{DestinationId: utils.ANY, RPCategory: "call", Strategy: LCR_STRATEGY_QOS, Weight: 10.0},
 		SupplierCosts: []*LCRSupplierCost{
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: 0, Duration: 60 * time.Second, QOS: map[string]float64{ACD: -1, PDD: -1, TCD: -1, ASR: -1, ACC: -1, TCC: -1}, qosSortParams: []string{ASR, PDD, ACD, TCD, ACC, TCC}},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: 0.6, Duration: 60 * time.Second, QOS: map[string]float64{ACD: 300, PDD: -1, TCD: 300, ASR: 100, ACC: 2, TCC: 2}, qosSortParams: []string{ASR, PDD, ACD, TCD, ACC, TCC}},
-			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: 0.4, Duration: 60 * time.Second, QOS: map[string]float64{ACD: 180, PDD: -1, TCD: 180, ASR: 100, ACC: 1, TCC: 1}, qosSortParams: []string{ASR, PDD, ACD, TCD, ACC, TCC}},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:ivo12", Cost: utils.NewDecimalFromFloat(0), Duration: 60 * time.Second, QOS: map[string]float64{ACD: -1, PDD: -1, TCD: -1, ASR: -1, ACC: -1, TCC: -1}, qosSortParams: []string{ASR, PDD, ACD, TCD, ACC, TCC}},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:dan12", Cost: utils.NewDecimalFromFloat(0.6), Duration: 60 * time.Second, QOS: map[string]float64{ACD: 300, PDD: -1, TCD: 300, ASR: 100, ACC: 2, TCC: 2}, qosSortParams: []string{ASR, PDD, ACD, TCD, ACC, TCC}},
+			&LCRSupplierCost{Supplier: "*out:tenant12:call:rif12", Cost: utils.NewDecimalFromFloat(0.4), Duration: 60 * time.Second, QOS: map[string]float64{ACD: 180, PDD: -1, TCD: 180, ASR: 100, ACC: 1, TCC: 1}, qosSortParams: []string{ASR, PDD, ACD, TCD, ACC, TCC}},
 		},
 	}
 	var lcrQ LCRCost
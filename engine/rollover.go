@@ -0,0 +1,128 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Carries the unused units of a balance into a new one when the balance expires or is reset
+type Rollover struct {
+	Percent  float64       // part of the unused value carried over, 0 carries all of it
+	MaxValue utils.Decimal // cap on the carried over value, 0 for no cap
+	Expiry   string        // expiry of the new balance relative to the dropped one: +720h, *daily, *monthly, *yearly or *unlimited
+	Weight   float64       // weight of the new balance
+}
+
+// Returns the expiry of the rolled over balance, counted from the start time
+func (r *Rollover) getExpirationDate(start time.Time) (time.Time, error) {
+	expiry := strings.TrimSpace(r.Expiry)
+	switch {
+	case expiry == "" || expiry == UNLIMITED:
+		return time.Time{}, nil
+	case strings.HasPrefix(expiry, "+"):
+		d, err := time.ParseDuration(expiry[1:])
+		if err != nil {
+			return time.Time{}, err
+		}
+		return start.Add(d), nil
+	case expiry == "*daily":
+		return start.AddDate(0, 0, 1), nil
+	case expiry == "*monthly":
+		return start.AddDate(0, 1, 0), nil
+	case expiry == "*yearly":
+		return start.AddDate(1, 0, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("unsupported rollover expiry: %s", expiry)
+}
+
+// Returns the part of the value carried over
+func (r *Rollover) getValue(value utils.Decimal) utils.Decimal {
+	if r.Percent > 0 {
+		value = value.Mul(utils.NewDecimalFromFloat(r.Percent)).DivInt(100).Round(globalRoundingDecimals, utils.ROUNDING_MIDDLE)
+	}
+	if r.MaxValue.Sign() > 0 && value.Cmp(r.MaxValue) > 0 {
+		value = r.MaxValue
+	}
+	return value
+}
+
+// Creates the balance receiving the unused units of the dropped one, nil if there is nothing to carry.
+// The new balance has no rollover so the units are carried only once, and the balance actions skip it
+// so the next reset neither drops the carried units nor tops them up.
+func (b *Balance) rollover(start time.Time) *Balance {
+	if b.Rollover == nil || b.Value.Sign() <= 0 {
+		return nil
+	}
+	value := b.Rollover.getValue(b.Value)
+	if value.Sign() <= 0 {
+		return nil
+	}
+	expDate, err := b.Rollover.getExpirationDate(start)
+	if err != nil {
+		Logger.Err(fmt.Sprintf("<Rater> Could not roll over balance %s: %v", b.Uuid, err))
+		return nil
+	}
+	if !expDate.IsZero() && expDate.Before(time.Now()) {
+		return nil // expired as well
+	}
+	return &Balance{
+		Uuid:           utils.GenUUID(),
+		Value:          value,
+		ExpirationDate: expDate,
+		Weight:         b.Rollover.Weight,
+		DestinationIds: b.DestinationIds,
+		RatingSubject:  b.RatingSubject,
+		Category:       b.Category,
+		SharedGroup:    b.SharedGroup,
+		Currency:       b.Currency,
+		TimingIDs:      b.TimingIDs,
+		Timings:        b.Timings,
+		RolledOver:     true,
+		dirty:          true,
+	}
+}
+
+// Sets the rollover from the extra parameters on the matching balances, without parameters the rollover is removed
+func rolloverAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs Actions) (err error) {
+	if ub == nil {
+		return errors.New("nil user balance")
+	}
+	var rollover *Rollover
+	if a.ExtraParameters != "" {
+		rollover = &Rollover{}
+		if err = json.Unmarshal([]byte(a.ExtraParameters), rollover); err != nil {
+			return
+		}
+		if _, err = rollover.getExpirationDate(time.Now()); err != nil {
+			return
+		}
+	}
+	for _, b := range ub.BalanceMap[a.BalanceType+a.Direction] {
+		if !b.IsExpired() && !b.RolledOver && b.MatchFilter(a.Balance) {
+			b.Rollover = rollover
+		}
+	}
+	return
+}
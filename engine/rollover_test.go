@@ -0,0 +1,110 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestRolloverGetValue(t *testing.T) {
+	r := &Rollover{Percent: 50, MaxValue: utils.NewDecimalFromFloat(100)}
+	if v := r.getValue(utils.NewDecimalFromFloat(150)); v.String() != "75" {
+		t.Error("Wrong rollover value: ", v)
+	}
+	if v := r.getValue(utils.NewDecimalFromFloat(300)); v.String() != "100" {
+		t.Error("Wrong capped rollover value: ", v)
+	}
+	start := time.Date(2015, time.January, 31, 0, 0, 0, 0, time.UTC)
+	if expDate, err := (&Rollover{Expiry: "+24h"}).getExpirationDate(start); err != nil || !expDate.Equal(start.Add(24*time.Hour)) {
+		t.Errorf("Wrong rollover expiry: %v, %v", expDate, err)
+	}
+	if expDate, err := (&Rollover{Expiry: "*unlimited"}).getExpirationDate(start); err != nil || !expDate.IsZero() {
+		t.Errorf("Wrong unlimited rollover expiry: %v, %v", expDate, err)
+	}
+	if _, err := (&Rollover{Expiry: "*weekly"}).getExpirationDate(start); err == nil {
+		t.Error("Expecting error on unsupported expiry")
+	}
+}
+
+func TestRolloverCleanExpiredBalances(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+	acc := &Account{Id: "*out:cgrates.org:bundle", BalanceMap: map[string]BalanceChain{
+		utils.VOICE + OUTBOUND: BalanceChain{
+			&Balance{Uuid: "plain", Value: utils.NewDecimalFromFloat(60), ExpirationDate: expired},
+			&Balance{Uuid: "bundle", Value: utils.NewDecimalFromFloat(600), ExpirationDate: expired, DestinationIds: "NAT",
+				Rollover: &Rollover{MaxValue: utils.NewDecimalFromFloat(300), Expiry: "*monthly", Weight: 5}},
+			&Balance{Uuid: "old", Value: utils.NewDecimalFromFloat(60), ExpirationDate: expired.AddDate(0, -2, 0),
+				Rollover: &Rollover{Expiry: "*monthly"}},
+			&Balance{Uuid: "active", Value: utils.NewDecimalFromFloat(10)},
+		}}}
+	acc.CleanExpiredBalances()
+	chain := acc.BalanceMap[utils.VOICE+OUTBOUND]
+	if len(chain) != 2 || chain[0].Uuid != "active" {
+		t.Fatalf("Wrong balances after clean: %+v", chain)
+	}
+	if rb := chain[1]; rb.Value.String() != "300" || rb.DestinationIds != "NAT" || rb.Weight != 5 ||
+		rb.Rollover != nil || !rb.ExpirationDate.Equal(expired.AddDate(0, 1, 0)) {
+		t.Errorf("Wrong rolled over balance: %+v", rb)
+	}
+}
+
+func TestRolloverTopupReset(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:bundle", BalanceMap: map[string]BalanceChain{
+		utils.VOICE + OUTBOUND: BalanceChain{&Balance{Uuid: "bundle", Id: "MONTHLY", Value: utils.NewDecimalFromFloat(40)}},
+	}}
+	a := &Action{ActionType: ROLLOVER, BalanceType: utils.VOICE, Direction: OUTBOUND,
+		ExtraParameters: `{"Percent":50,"Expiry":"+720h","Weight":10}`, Balance: &Balance{Id: "MONTHLY"}}
+	if err := rolloverAction(acc, nil, a, nil); err != nil || acc.BalanceMap[utils.VOICE+OUTBOUND][0].Rollover == nil {
+		t.Fatalf("Rollover not set: %v, %+v", err, acc.BalanceMap[utils.VOICE+OUTBOUND][0])
+	}
+	topupResetAction(acc, nil, &Action{ActionType: TOPUP_RESET, BalanceType: utils.VOICE, Direction: OUTBOUND,
+		Balance: &Balance{Id: "MONTHLY", Value: utils.NewDecimalFromFloat(100)}}, nil)
+	chain := acc.BalanceMap[utils.VOICE+OUTBOUND]
+	if len(chain) != 2 || chain[0].Value.String() != "100" || chain[0].Rollover == nil ||
+		chain[1].Value.String() != "20" || chain[1].Weight != 10 || chain[1].ExpirationDate.IsZero() {
+		t.Errorf("Wrong balances after reset: %+v, %+v", chain[0], chain[1])
+	}
+	a.ExtraParameters = ""
+	rolloverAction(acc, nil, a, nil)
+	if acc.BalanceMap[utils.VOICE+OUTBOUND][0].Rollover != nil {
+		t.Error("Rollover not removed")
+	}
+}
+
+func TestRolloverTopupResetTwice(t *testing.T) {
+	acc := &Account{Id: "*out:cgrates.org:bundle_twice", BalanceMap: map[string]BalanceChain{
+		utils.VOICE + OUTBOUND: BalanceChain{&Balance{Uuid: "bundle", Value: utils.NewDecimalFromFloat(40), DestinationIds: "NAT",
+			Rollover: &Rollover{Expiry: "+720h"}}},
+	}}
+	reset := &Action{ActionType: TOPUP_RESET, BalanceType: utils.VOICE, Direction: OUTBOUND,
+		Balance: &Balance{Value: utils.NewDecimalFromFloat(100), DestinationIds: "NAT"}}
+	topupResetAction(acc, nil, reset, nil)
+	acc.BalanceMap[utils.VOICE+OUTBOUND][0].Value = utils.NewDecimalFromFloat(30) // used 70 of the second bundle
+	topupResetAction(acc, nil, reset, nil)
+	chain := acc.BalanceMap[utils.VOICE+OUTBOUND]
+	if len(chain) != 3 || chain[0].Value.String() != "100" ||
+		!chain[1].RolledOver || chain[1].Value.String() != "40" || !chain[2].RolledOver || chain[2].Value.String() != "30" {
+		t.Errorf("Wrong balances after two resets: %+v", chain)
+	}
+	if total := chain.GetTotalValue(); total.String() != "170" {
+		t.Error("Wrong total after two resets: ", total)
+	}
+}
@@ -19,8 +19,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"fmt"
 	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -35,6 +37,7 @@ const (
 	STRATEGY_LOWEST       = "*lowest"
 	STRATEGY_HIGHEST      = "*highest"
 	STRATEGY_RANDOM       = "*random"
+	STRATEGY_PROPORTIONAL = "*proportional" // monetary debits split across members by ratio
 )
 
 type SharedGroup struct {
@@ -47,24 +50,70 @@ type SharedGroup struct {
 type SharingParameters struct {
 	Strategy      string
 	RatingSubject string
+	Ratio         float64       // share of each *proportional debit paid by the member, relative to the others (default 1)
+	MaxSpent      utils.Decimal // maximum amount the member pays on *proportional debits in a period, zero for no cap
+	Period        string        // window of the MaxSpent, see the counter windows (default *monthly)
 }
 
-func (sg *SharedGroup) SortBalancesByStrategy(myBalance *Balance, bc BalanceChain) BalanceChain {
-	sharingParameters := sg.AccountParameters[utils.ANY]
-	if sp, hasParamsForAccount := sg.AccountParameters[myBalance.account.Id]; hasParamsForAccount {
-		sharingParameters = sp
+// Creates the sharing parameters out of the TP strategy, the *proportional
+// one accepts the ratio, the spending cap and its period: *proportional[:ratio[:max_spent[:period]]]
+func NewSharingParameters(strategy, ratingSubject string) (*SharingParameters, error) {
+	sp := &SharingParameters{Strategy: strategy, RatingSubject: ratingSubject}
+	if !strings.HasPrefix(strategy, STRATEGY_PROPORTIONAL+utils.CONCATENATED_KEY_SEP) {
+		return sp, nil
+	}
+	params := strings.SplitN(strategy, utils.CONCATENATED_KEY_SEP, 4) // the period can have its own parameter
+	sp.Strategy = params[0]
+	var err error
+	if sp.Ratio, err = strconv.ParseFloat(params[1], 64); err != nil || sp.Ratio < 0 {
+		return nil, fmt.Errorf("invalid sharing ratio: %s", strategy)
 	}
+	if len(params) > 2 {
+		if sp.MaxSpent, err = utils.NewDecimalFromString(params[2]); err != nil || sp.MaxSpent.Sign() < 0 {
+			return nil, fmt.Errorf("invalid sharing max spent: %s", strategy)
+		}
+	}
+	if len(params) > 3 {
+		if _, err = parseCounterWindow(params[3]); err != nil {
+			return nil, fmt.Errorf("invalid sharing period: %s", strategy)
+		}
+		sp.Period = params[3]
+	}
+	return sp, nil
+}
 
-	strategy := STRATEGY_MINE_RANDOM
-	if sharingParameters != nil && sharingParameters.Strategy != "" {
-		strategy = sharingParameters.Strategy
+func (sp *SharingParameters) getPeriod() string {
+	if sp.Period == "" {
+		return WINDOW_MONTHLY
 	}
+	return sp.Period
+}
+
+func (sg *SharedGroup) getSharingParameters(accountId string) *SharingParameters {
+	if sp, hasParamsForAccount := sg.AccountParameters[accountId]; hasParamsForAccount {
+		return sp
+	}
+	return sg.AccountParameters[utils.ANY]
+}
+
+func (sg *SharedGroup) getStrategy(accountId string) string {
+	if sp := sg.getSharingParameters(accountId); sp != nil && sp.Strategy != "" {
+		return sp.Strategy
+	}
+	return STRATEGY_MINE_RANDOM
+}
+
+func (sg *SharedGroup) SortBalancesByStrategy(myBalance *Balance, bc BalanceChain) BalanceChain {
+	strategy := sg.getStrategy(myBalance.account.Id)
 	switch strategy {
 	case STRATEGY_LOWEST, STRATEGY_MINE_LOWEST:
 		sort.Sort(LowestBalanceChainSorter(bc))
 	case STRATEGY_HIGHEST, STRATEGY_MINE_HIGHEST:
 		sort.Sort(HighestBalanceChainSorter(bc))
-	case STRATEGY_RANDOM, STRATEGY_MINE_RANDOM:
+	case STRATEGY_RANDOM, STRATEGY_MINE_RANDOM, STRATEGY_PROPORTIONAL:
+		if strategy == STRATEGY_PROPORTIONAL { // unit balances are not split, the initiating member goes first
+			strategy = STRATEGY_MINE_RANDOM
+		}
 		rbc := RandomBalanceChainSorter(bc)
 		(&rbc).Sort()
 		bc = BalanceChain(rbc)
@@ -103,7 +152,7 @@ func (sg *SharedGroup) GetBalances(destination, category, balanceType string, ub
 			}
 		}
 		//sg.members = append(sg.members, nUb)
-		sb := nUb.getBalancesForPrefix(destination, category, nUb.BalanceMap[balanceType], sg.Id)
+		sb := nUb.getBalancesForPrefix(destination, category, "", nUb.BalanceMap[balanceType], sg.Id)
 		bc = append(bc, sb...)
 	}
 	/*	} else {
@@ -126,7 +175,7 @@ func (lbcs LowestBalanceChainSorter) Swap(i, j int) {
 }
 
 func (lbcs LowestBalanceChainSorter) Less(i, j int) bool {
-	return lbcs[i].Value < lbcs[j].Value
+	return lbcs[i].Value.Cmp(lbcs[j].Value) < 0
 }
 
 type HighestBalanceChainSorter []*Balance
@@ -140,7 +189,7 @@ func (hbcs HighestBalanceChainSorter) Swap(i, j int) {
 }
 
 func (hbcs HighestBalanceChainSorter) Less(i, j int) bool {
-	return hbcs[i].Value > hbcs[j].Value
+	return hbcs[i].Value.Cmp(hbcs[j].Value) > 0
 }
 
 type RandomBalanceChainSorter []*Balance
@@ -0,0 +1,212 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// The members of a *proportional shared group, debited together through the
+// balance of the account initiating the debit
+type proportionalShare struct {
+	sg      *SharedGroup
+	members []*shareMember
+	debited bool
+	now     time.Time // the spending caps windows are checked against it
+}
+
+type shareMember struct {
+	accountId string
+	balances  BalanceChain
+	ratio     float64
+	limit     *SpendingLimit // the spending cap kept on the member account, nil for no cap
+}
+
+// For the *proportional strategy the monetary balances of the group are
+// replaced by myBalance which debits all the members on each increment
+func (sg *SharedGroup) getProportionalBalances(myBalance *Balance, bc BalanceChain) BalanceChain {
+	if sg.getStrategy(myBalance.account.Id) != STRATEGY_PROPORTIONAL {
+		myBalance.proportional = nil
+		return bc
+	}
+	ps := &proportionalShare{sg: sg, now: time.Now()}
+	membersIndex := make(map[string]*shareMember)
+	for _, b := range bc {
+		if b.account == nil {
+			continue
+		}
+		m, found := membersIndex[b.account.Id]
+		if !found {
+			m = &shareMember{accountId: b.account.Id, ratio: 1}
+			if sp := sg.getSharingParameters(b.account.Id); sp != nil {
+				if sp.Ratio > 0 {
+					m.ratio = sp.Ratio
+				}
+				m.limit = b.account.getSharedLimit(sg.Id, sp, ps.now)
+			}
+			membersIndex[b.account.Id] = m
+			ps.members = append(ps.members, m)
+		}
+		m.balances = append(m.balances, b)
+	}
+	for _, m := range ps.members {
+		m.balances.Sort()
+	}
+	myBalance.proportional = ps
+	return BalanceChain{myBalance}
+}
+
+// Returns the spending cap of the account on the shared group, created on first use, nil if the group has no cap
+func (acc *Account) getSharedLimit(sgId string, sp *SharingParameters, now time.Time) *SpendingLimit {
+	if sp.MaxSpent.Sign() <= 0 {
+		return nil
+	}
+	sl, found := acc.SharedLimits[sgId]
+	if !found {
+		if acc.SharedLimits == nil {
+			acc.SharedLimits = make(map[string]*SpendingLimit)
+		}
+		sl = &SpendingLimit{Id: sgId, BalanceType: utils.MONETARY}
+		acc.SharedLimits[sgId] = sl
+	}
+	if sl.Period != sp.getPeriod() { // new or changed by a tariff plan reload
+		sl.Period, sl.Spent, sl.WindowStart = sp.getPeriod(), utils.Decimal{}, time.Time{}
+		sl.WindowStart = sl.getWindowStart(now)
+	}
+	sl.Value = sp.MaxSpent
+	return sl
+}
+
+// the amount the member can still pay, spending cap included
+func (m *shareMember) getAvailable(now time.Time) (available utils.Decimal) {
+	for _, b := range m.balances {
+		if !b.IsExpired() && b.IsActive() && b.Value.Sign() > 0 {
+			available = available.Add(b.Value)
+		}
+	}
+	if m.limit != nil {
+		if left := m.limit.getLeft(now); left.Cmp(available) < 0 {
+			available = left
+		}
+	}
+	if available.Sign() < 0 {
+		available = utils.Decimal{}
+	}
+	return
+}
+
+func (ps *proportionalShare) getTotalRatio() (total float64) {
+	for _, m := range ps.members {
+		total += m.ratio
+	}
+	return
+}
+
+// Splits the amount by the members ratios, the last member gets the rounding leftover
+func (ps *proportionalShare) split(amount utils.Decimal) []utils.Decimal {
+	total := utils.NewDecimalFromFloat(ps.getTotalRatio())
+	shares := make([]utils.Decimal, len(ps.members))
+	left := amount
+	for i, m := range ps.members {
+		if i == len(ps.members)-1 {
+			shares[i] = left
+			break
+		}
+		shares[i] = amount.Mul(utils.NewDecimalFromFloat(m.ratio)).Div(total).Round(globalRoundingDecimals, utils.ROUNDING_MIDDLE)
+		left = left.Sub(shares[i])
+	}
+	return shares
+}
+
+// The maximum amount the group can pay before one of the members runs out
+func (ps *proportionalShare) getCredit() (credit utils.Decimal) {
+	total := utils.NewDecimalFromFloat(ps.getTotalRatio())
+	for i, m := range ps.members {
+		memberCredit := m.getAvailable(ps.now).Mul(total).Div(utils.NewDecimalFromFloat(m.ratio))
+		if i == 0 || memberCredit.Cmp(credit) < 0 {
+			credit = memberCredit
+		}
+	}
+	return
+}
+
+// Debits the amount from all the members, nothing is debited if one of them
+// cannot pay its share
+func (ps *proportionalShare) debit(amount utils.Decimal) (debits []*BalanceShare, ok bool) {
+	shares := ps.split(amount)
+	for i, m := range ps.members {
+		if m.getAvailable(ps.now).Cmp(shares[i]) < 0 {
+			return nil, false
+		}
+	}
+	for i, m := range ps.members {
+		left := shares[i]
+		for _, b := range m.balances {
+			if left.Sign() <= 0 {
+				break
+			}
+			if b.IsExpired() || !b.IsActive() || b.Value.Sign() <= 0 {
+				continue
+			}
+			value := left
+			if b.Value.Cmp(value) < 0 {
+				value = b.Value
+			}
+			b.SubstractAmount(value)
+			left = left.Sub(value)
+			debits = append(debits, &BalanceShare{AccountId: m.accountId, BalanceUuid: b.Uuid, Value: value})
+		}
+		if m.limit != nil {
+			m.limit.addSpent(shares[i], ps.now)
+		}
+	}
+	ps.debited = true
+	return debits, true
+}
+
+// Saves the members accounts, their spending caps included
+func (ps *proportionalShare) saveDebits(acc *Account) {
+	if !ps.debited {
+		return
+	}
+	for _, m := range ps.members {
+		for _, b := range m.balances {
+			if b.account != nil && b.account != acc && b.dirty {
+				b.account.SetLedgerCause(acc.ledgerCause, acc.ledgerCauseId)
+				accountingStorage.SetAccount(b.account)
+				break // one save per account
+			}
+		}
+	}
+	ps.debited = false
+}
+
+// Gives back a *proportional share to the member balance and spending, with the taxes debited on it
+func (ub *Account) refundShare(share *BalanceShare, direction string, taxRate utils.Decimal) {
+	balance := ub.BalanceMap[utils.MONETARY+direction].GetBalance(share.BalanceUuid)
+	if balance == nil {
+		return
+	}
+	value := share.Value.Add(getTax(share.Value, taxRate))
+	balance.Value = balance.Value.Add(value)
+	if sl, found := ub.SharedLimits[balance.SharedGroup]; found {
+		sl.addSpent(value.Neg(), time.Now())
+	}
+}
@@ -21,6 +21,10 @@ package engine
 import (
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/cache2go"
+	"github.com/cgrates/cgrates/utils"
 )
 
 func TestSharedSetGet(t *testing.T) {
@@ -49,51 +53,51 @@ func TestSharedSetGet(t *testing.T) {
 
 func TestSharedPopBalanceByStrategyLow(t *testing.T) {
 	bc := BalanceChain{
-		&Balance{Value: 2.0},
-		&Balance{Uuid: "uuuu", Value: 1.0, account: &Account{Id: "test"}},
-		&Balance{Value: 3.0},
+		&Balance{Value: utils.NewDecimalFromFloat(2.0)},
+		&Balance{Uuid: "uuuu", Value: utils.NewDecimalFromFloat(1.0), account: &Account{Id: "test"}},
+		&Balance{Value: utils.NewDecimalFromFloat(3.0)},
 	}
 	sg := &SharedGroup{AccountParameters: map[string]*SharingParameters{
 		"test": &SharingParameters{Strategy: STRATEGY_LOWEST}},
 	}
 	sbc := sg.SortBalancesByStrategy(bc[1], bc)
 	if len(sbc) != 3 ||
-		sbc[0].Value != 1.0 ||
-		sbc[1].Value != 2.0 {
+		sbc[0].Value.Float64() != 1.0 ||
+		sbc[1].Value.Float64() != 2.0 {
 		t.Error("Error sorting balance chain: ", sbc[0].Value)
 	}
 }
 
 func TestSharedPopBalanceByStrategyHigh(t *testing.T) {
 	bc := BalanceChain{
-		&Balance{Uuid: "uuuu", Value: 2.0, account: &Account{Id: "test"}},
-		&Balance{Value: 1.0},
-		&Balance{Value: 3.0},
+		&Balance{Uuid: "uuuu", Value: utils.NewDecimalFromFloat(2.0), account: &Account{Id: "test"}},
+		&Balance{Value: utils.NewDecimalFromFloat(1.0)},
+		&Balance{Value: utils.NewDecimalFromFloat(3.0)},
 	}
 	sg := &SharedGroup{AccountParameters: map[string]*SharingParameters{
 		"test": &SharingParameters{Strategy: STRATEGY_HIGHEST}},
 	}
 	sbc := sg.SortBalancesByStrategy(bc[0], bc)
 	if len(sbc) != 3 ||
-		sbc[0].Value != 3.0 ||
-		sbc[1].Value != 2.0 {
+		sbc[0].Value.Float64() != 3.0 ||
+		sbc[1].Value.Float64() != 2.0 {
 		t.Error("Error sorting balance chain: ", sbc)
 	}
 }
 
 func TestSharedPopBalanceByStrategyMineHigh(t *testing.T) {
 	bc := BalanceChain{
-		&Balance{Uuid: "uuuu", Value: 2.0, account: &Account{Id: "test"}},
-		&Balance{Value: 1.0},
-		&Balance{Value: 3.0},
+		&Balance{Uuid: "uuuu", Value: utils.NewDecimalFromFloat(2.0), account: &Account{Id: "test"}},
+		&Balance{Value: utils.NewDecimalFromFloat(1.0)},
+		&Balance{Value: utils.NewDecimalFromFloat(3.0)},
 	}
 	sg := &SharedGroup{AccountParameters: map[string]*SharingParameters{
 		"test": &SharingParameters{Strategy: STRATEGY_MINE_HIGHEST}},
 	}
 	sbc := sg.SortBalancesByStrategy(bc[0], bc)
 	if len(sbc) != 3 ||
-		sbc[0].Value != 2.0 ||
-		sbc[1].Value != 3.0 {
+		sbc[0].Value.Float64() != 2.0 ||
+		sbc[1].Value.Float64() != 3.0 {
 		t.Error("Error sorting balance chain: ", sbc)
 	}
 }
@@ -120,3 +124,190 @@ func TestSharedPopBalanceByStrategyMineHigh(t *testing.T) {
 		t.Error("Something is wrong with balance randomizer")
 	}
 }*/
+
+func TestSharedNewSharingParameters(t *testing.T) {
+	if sp, err := NewSharingParameters("*proportional:80:50.5", "rif"); err != nil ||
+		sp.Strategy != STRATEGY_PROPORTIONAL || sp.Ratio != 80 || sp.MaxSpent.String() != "50.5" || sp.RatingSubject != "rif" {
+		t.Errorf("Wrong sharing parameters: %+v, %v", sp, err)
+	}
+	if sp, err := NewSharingParameters("*proportional:80:50.5:*monthly:15", ""); err != nil ||
+		sp.MaxSpent.String() != "50.5" || sp.Period != "*monthly:15" {
+		t.Errorf("Wrong sharing parameters: %+v, %v", sp, err)
+	}
+	if sp, err := NewSharingParameters(STRATEGY_HIGHEST, ""); err != nil || sp.Strategy != STRATEGY_HIGHEST || sp.Ratio != 0 {
+		t.Errorf("Wrong sharing parameters: %+v, %v", sp, err)
+	}
+	for _, strategy := range []string{"*proportional:x", "*proportional:-1", "*proportional:1:x", "*proportional:1:2:3"} {
+		if _, err := NewSharingParameters(strategy, ""); err == nil {
+			t.Error("Expecting error for strategy: ", strategy)
+		}
+	}
+}
+
+func proportionalTestDescriptor() *CallDescriptor {
+	cc := &CallCost{
+		Tenant:      "vdf",
+		Category:    "0",
+		Direction:   OUTBOUND,
+		Destination: "0723045326",
+		Timespans: []*TimeSpan{
+			&TimeSpan{
+				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
+				TimeEnd:       time.Date(2013, 9, 24, 10, 49, 0, 0, time.UTC),
+				DurationIndex: 60 * time.Second,
+				RateInterval:  &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(2), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+			},
+		},
+		deductConnectFee: true,
+	}
+	return &CallDescriptor{
+		Tenant:        cc.Tenant,
+		Category:      cc.Category,
+		TimeStart:     cc.Timespans[0].TimeStart,
+		TimeEnd:       cc.Timespans[0].TimeEnd,
+		Direction:     cc.Direction,
+		Destination:   cc.Destination,
+		TOR:           cc.TOR,
+		DurationIndex: cc.GetDuration(),
+		testCallcost:  cc,
+	}
+}
+
+func TestSharedProportionalDebit(t *testing.T) {
+	employee := &Account{Id: "employee", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "employee_money", Value: utils.NewDecimalFromFloat(100), SharedGroup: "SG_PROP"}},
+	}}
+	company := &Account{Id: "company", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "company_money", Value: utils.NewDecimalFromFloat(500), SharedGroup: "SG_PROP"}},
+	}}
+	sg := &SharedGroup{Id: "SG_PROP", MemberIds: []string{employee.Id, company.Id}, AccountParameters: map[string]*SharingParameters{
+		"*any":     &SharingParameters{Strategy: STRATEGY_PROPORTIONAL, Ratio: 80},
+		"employee": &SharingParameters{Strategy: STRATEGY_PROPORTIONAL, Ratio: 20, MaxSpent: utils.NewDecimalFromFloat(1000)},
+	}}
+	accountingStorage.SetAccount(company)
+	accountingStorage.SetSharedGroup(sg)
+	cache2go.Cache(SHARED_GROUP_PREFIX+"SG_PROP", sg)
+	defer cache2go.RemKey(SHARED_GROUP_PREFIX + "SG_PROP")
+	cd := proportionalTestDescriptor()
+	cc, err := employee.debitCreditBalance(cd, false, false, true)
+	if err != nil {
+		t.Fatal("Error debiting balance: ", err)
+	}
+	if employee.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "76" {
+		t.Errorf("Wrong employee share: %+v", employee.BalanceMap[utils.MONETARY+OUTBOUND][0])
+	}
+	company, _ = accountingStorage.GetAccount("company")
+	if company.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "404" {
+		t.Errorf("Wrong company share: %+v", company.BalanceMap[utils.MONETARY+OUTBOUND][0])
+	}
+	if employee.SharedLimits["SG_PROP"].Spent.String() != "24" || len(company.SharedLimits) != 0 {
+		t.Errorf("Wrong spent amounts: %+v, %+v", employee.SharedLimits["SG_PROP"], company.SharedLimits)
+	}
+	inc := cc.Timespans[0].Increments[0]
+	if inc.BalanceInfo.AccountId != "employee" || len(inc.BalanceInfo.Shares) != 2 ||
+		inc.BalanceInfo.Shares[0].AccountId != "employee" || inc.BalanceInfo.Shares[0].Value.String() != "4" ||
+		inc.BalanceInfo.Shares[1].AccountId != "company" || inc.BalanceInfo.Shares[1].Value.String() != "16" {
+		t.Errorf("Wrong increment attribution: %+v", inc.BalanceInfo)
+	}
+	// refund the first increment
+	accountingStorage.SetAccount(employee)
+	cd.Increments = Increments{inc}
+	if _, err := cd.RefundIncrements(); err != nil {
+		t.Fatal("Error refunding increments: ", err)
+	}
+	company, _ = accountingStorage.GetAccount("company")
+	employee, _ = accountingStorage.GetAccount("employee")
+	if employee.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "80" ||
+		company.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "420" {
+		t.Errorf("Wrong refund: %+v, %+v", employee.BalanceMap[utils.MONETARY+OUTBOUND][0], company.BalanceMap[utils.MONETARY+OUTBOUND][0])
+	}
+	if employee.SharedLimits["SG_PROP"].Spent.String() != "20" {
+		t.Errorf("Wrong spent amount after refund: %+v", employee.SharedLimits["SG_PROP"])
+	}
+}
+
+func TestSharedProportionalMaxSpent(t *testing.T) {
+	employee := &Account{Id: "employee_capped", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{
+			&Balance{Uuid: "employee_money", Value: utils.NewDecimalFromFloat(100), SharedGroup: "SG_PROP_CAP", Weight: 20},
+			&Balance{Uuid: "employee_own", Value: utils.NewDecimalFromFloat(100)},
+		},
+	}}
+	company := &Account{Id: "company_capped", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "company_money", Value: utils.NewDecimalFromFloat(500), SharedGroup: "SG_PROP_CAP"}},
+	}}
+	sg := &SharedGroup{Id: "SG_PROP_CAP", MemberIds: []string{employee.Id, company.Id}, AccountParameters: map[string]*SharingParameters{
+		"*any":            &SharingParameters{Strategy: STRATEGY_PROPORTIONAL, Ratio: 80},
+		"employee_capped": &SharingParameters{Strategy: STRATEGY_PROPORTIONAL, Ratio: 20, MaxSpent: utils.NewDecimalFromFloat(10)},
+	}}
+	accountingStorage.SetAccount(company)
+	accountingStorage.SetSharedGroup(sg)
+	cache2go.Cache(SHARED_GROUP_PREFIX+"SG_PROP_CAP", sg)
+	defer cache2go.RemKey(SHARED_GROUP_PREFIX + "SG_PROP_CAP")
+	if _, credit, _ := employee.getCreditForPrefix(proportionalTestDescriptor()); credit.String() != "150" {
+		t.Error("Wrong group credit: ", credit)
+	}
+	if _, err := employee.debitCreditBalance(proportionalTestDescriptor(), false, false, true); err != nil {
+		t.Fatal("Error debiting balance: ", err)
+	}
+	// the group stops paying at the employee cap, the rest goes to the own balance
+	if sl := employee.SharedLimits["SG_PROP_CAP"]; sl == nil || sl.Spent.String() != "8" || sl.Period != WINDOW_MONTHLY {
+		t.Errorf("Wrong spent amount: %+v", sl)
+	}
+	if employee.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "92" ||
+		employee.BalanceMap[utils.MONETARY+OUTBOUND][1].Value.String() != "20" {
+		t.Errorf("Wrong employee balances: %+v, %+v", employee.BalanceMap[utils.MONETARY+OUTBOUND][0], employee.BalanceMap[utils.MONETARY+OUTBOUND][1])
+	}
+}
+
+func TestSharedProportionalConnectFee(t *testing.T) {
+	employee := &Account{Id: "employee_fee", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "employee_money", Value: utils.NewDecimalFromFloat(100), SharedGroup: "SG_PROP_FEE"}},
+	}}
+	company := &Account{Id: "company_fee", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "company_money", Value: utils.NewDecimalFromFloat(500), SharedGroup: "SG_PROP_FEE"}},
+	}}
+	sg := &SharedGroup{Id: "SG_PROP_FEE", MemberIds: []string{employee.Id, company.Id}, AccountParameters: map[string]*SharingParameters{
+		"*any":         &SharingParameters{Strategy: STRATEGY_PROPORTIONAL, Ratio: 80},
+		"employee_fee": &SharingParameters{Strategy: STRATEGY_PROPORTIONAL, Ratio: 20, MaxSpent: utils.NewDecimalFromFloat(1000)},
+	}}
+	accountingStorage.SetAccount(company)
+	accountingStorage.SetSharedGroup(sg)
+	cache2go.Cache(SHARED_GROUP_PREFIX+"SG_PROP_FEE", sg)
+	defer cache2go.RemKey(SHARED_GROUP_PREFIX + "SG_PROP_FEE")
+	cc := proportionalTestDescriptor().testCallcost
+	cc.Timespans[0].RateInterval.Rating.ConnectFee = utils.NewDecimalFromFloat(10)
+	balances := employee.getAlldBalancesForPrefix(cc.Destination, cc.Category, OUTBOUND, utils.MONETARY+OUTBOUND)
+	employee.DebitConnectionFee(cc, balances, false)
+	balances.SaveDirtyBalances(employee)
+	company, _ = accountingStorage.GetAccount("company_fee")
+	if employee.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "98" ||
+		company.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "492" {
+		t.Errorf("Wrong connect fee split: %+v, %+v", employee.BalanceMap[utils.MONETARY+OUTBOUND][0], company.BalanceMap[utils.MONETARY+OUTBOUND][0])
+	}
+	if employee.SharedLimits["SG_PROP_FEE"].Spent.String() != "2" {
+		t.Errorf("Wrong spent amount: %+v", employee.SharedLimits["SG_PROP_FEE"])
+	}
+	if len(cc.Shares) != 2 || cc.Shares[0].Value.String() != "2" || cc.Shares[1].AccountId != "company_fee" {
+		t.Errorf("Wrong connect fee shares: %+v", cc.Shares)
+	}
+}
+
+func TestSharedLimitWindow(t *testing.T) {
+	now := time.Date(2015, time.March, 11, 14, 30, 0, 0, time.UTC)
+	acc := &Account{Id: "employee_window"}
+	sp := &SharingParameters{Strategy: STRATEGY_PROPORTIONAL, MaxSpent: utils.NewDecimalFromFloat(10), Period: "*daily"}
+	sl := acc.getSharedLimit("SG_WINDOW", sp, now)
+	sl.addSpent(utils.NewDecimalFromFloat(10), now)
+	// a tariff plan reload replaces the shared group, the spending stays on the account
+	sp = &SharingParameters{Strategy: STRATEGY_PROPORTIONAL, MaxSpent: utils.NewDecimalFromFloat(12), Period: "*daily"}
+	if sl = acc.getSharedLimit("SG_WINDOW", sp, now); sl.getLeft(now).String() != "2" {
+		t.Errorf("Wrong spending after reload: %+v", sl)
+	}
+	if left := sl.getLeft(now.AddDate(0, 0, 1)); left.String() != "12" {
+		t.Error("Cap not reset in the next window: ", left)
+	}
+	if acc.getSharedLimit("SG_WINDOW", &SharingParameters{Strategy: STRATEGY_PROPORTIONAL}, now) != nil {
+		t.Error("Expecting no limit without cap")
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Maximum amount or usage an account can spend on some destinations in a period
+type SpendingLimit struct {
+	Id             string
+	Direction      string
+	BalanceType    string        // *monetary limits the cost, the unit types limit the usage
+	DestinationIds string        // destinations the limit applies to, empty or *any for all
+	Value          utils.Decimal // maximum spent in a window
+	Period         string        // *daily, *weekly, *monthly[:day] or *rolling:<days> (see counter windows)
+	Spent          utils.Decimal // spent in the current window
+	WindowStart    time.Time
+}
+
+// Parameters of the *set_spending_limit action
+type spendingLimitParameters struct {
+	Period string // defaults to *monthly
+}
+
+func (sl *SpendingLimit) matchDestination(destination string) bool {
+	if sl.DestinationIds == "" || sl.DestinationIds == utils.ANY {
+		return true
+	}
+	limitDestIds := strings.Split(sl.DestinationIds, utils.INFIELD_SEP)
+	for _, match := range destIndex.Match(destination) {
+		for _, dId := range match.Ids {
+			for _, limitDestId := range limitDestIds {
+				if dId == limitDestId {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (sl *SpendingLimit) getWindowStart(t time.Time) time.Time {
+	cw, err := parseCounterWindow(sl.Period)
+	if err != nil {
+		return sl.WindowStart
+	}
+	return cw.getStart(t, sl.WindowStart)
+}
+
+// Returns what can still be spent in the window containing t
+func (sl *SpendingLimit) getLeft(t time.Time) utils.Decimal {
+	if sl.getWindowStart(t).After(sl.WindowStart) {
+		return sl.Value // a new window
+	}
+	return sl.Value.Sub(sl.Spent)
+}
+
+func (sl *SpendingLimit) addSpent(value utils.Decimal, t time.Time) {
+	if start := sl.getWindowStart(t); start.After(sl.WindowStart) {
+		sl.Spent, sl.WindowStart = utils.Decimal{}, start
+	}
+	if sl.Spent = sl.Spent.Add(value); sl.Spent.Sign() < 0 { // refunds of a past window
+		sl.Spent = utils.Decimal{}
+	}
+}
+
+// Returns the spending limits matching the call
+func (ub *Account) getSpendingLimits(direction, tor, destination string) (limits []*SpendingLimit) {
+	for _, sl := range ub.SpendingLimits {
+		if sl.Direction == direction && (sl.BalanceType == utils.MONETARY || sl.BalanceType == tor) &&
+			sl.matchDestination(destination) {
+			limits = append(limits, sl)
+		}
+	}
+	return
+}
+
+// Returns the maximum duration of the call allowed by the spending limits,
+// -1 if no limit applies
+func (ub *Account) getSpendingLimitDuration(origCD *CallDescriptor) (time.Duration, error) {
+	tor := origCD.TOR
+	if tor == "" {
+		tor = utils.VOICE
+	}
+	limits := ub.getSpendingLimits(origCD.Direction, tor, origCD.Destination)
+	if len(limits) == 0 {
+		return -1, nil
+	}
+	cd := origCD.Clone()
+	cc, err := cd.getCost()
+	if err != nil {
+		return 0, err
+	}
+	left := make([]utils.Decimal, len(limits))
+	for i, sl := range limits {
+		left[i] = sl.getLeft(cd.TimeStart)
+		if sl.BalanceType == utils.MONETARY && cd.LoopIndex == 0 && len(cc.Timespans) > 0 {
+			left[i] = left[i].Sub(cc.GetConnectFee())
+		}
+		if left[i].Sign() < 0 {
+			return 0, nil
+		}
+	}
+	initialDuration := cd.TimeEnd.Sub(cd.TimeStart)
+	var totalDuration time.Duration
+	for _, ts := range cc.Timespans {
+		if ts.Increments == nil {
+			ts.createIncrementsSlice()
+		}
+		for _, incr := range ts.Increments {
+			for i, sl := range limits {
+				spent := incr.Cost
+				if sl.BalanceType != utils.MONETARY {
+					spent = utils.NewDecimalFromFloat(incr.Duration.Seconds())
+				}
+				if left[i] = left[i].Sub(spent); left[i].Sign() < 0 {
+					return utils.MinDuration(initialDuration, totalDuration), nil
+				}
+			}
+			totalDuration += incr.Duration
+		}
+	}
+	return initialDuration, nil
+}
+
+// Adds the cost and usage of a debited call to the matching spending limits
+func (ub *Account) countSpending(cd *CallDescriptor, cost utils.Decimal, usage time.Duration, t time.Time) {
+	tor := cd.TOR
+	if tor == "" {
+		tor = utils.VOICE
+	}
+	for _, sl := range ub.getSpendingLimits(cd.Direction, tor, cd.Destination) {
+		if sl.BalanceType == utils.MONETARY {
+			sl.addSpent(cost, t)
+		} else {
+			sl.addSpent(utils.NewDecimalFromFloat(usage.Seconds()), t)
+		}
+	}
+}
+
+// Sets the spending limit with the balance id, replacing the existing one
+func setSpendingLimitAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs Actions) (err error) {
+	if ub == nil {
+		return errors.New("nil user balance")
+	}
+	if a.Balance == nil || a.Balance.Id == "" {
+		return errors.New("missing spending limit id")
+	}
+	var params spendingLimitParameters
+	if a.ExtraParameters != "" {
+		if err = json.Unmarshal([]byte(a.ExtraParameters), &params); err != nil {
+			return
+		}
+	}
+	if params.Period == "" {
+		params.Period = WINDOW_MONTHLY
+	}
+	cw, err := parseCounterWindow(params.Period)
+	if err != nil {
+		return err
+	}
+	sl := &SpendingLimit{
+		Id:             a.Balance.Id,
+		Direction:      actionDirection(a),
+		BalanceType:    a.BalanceType,
+		DestinationIds: a.Balance.DestinationIds,
+		Value:          a.Balance.Value,
+		Period:         params.Period,
+		WindowStart:    cw.getStart(time.Now(), time.Time{}),
+	}
+	for i, existing := range ub.SpendingLimits {
+		if existing.Id == sl.Id {
+			if existing.Period == sl.Period { // keep counting in the same window
+				sl.Spent, sl.WindowStart = existing.Spent, existing.WindowStart
+			}
+			ub.SpendingLimits[i] = sl
+			return
+		}
+	}
+	ub.SpendingLimits = append(ub.SpendingLimits, sl)
+	return
+}
+
+// Removes the spending limit with the balance id, all of them if no id is given
+func removeSpendingLimitAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs Actions) (err error) {
+	if ub == nil {
+		return errors.New("nil user balance")
+	}
+	if a.Balance == nil || a.Balance.Id == "" {
+		ub.SpendingLimits = nil
+		return
+	}
+	for i, sl := range ub.SpendingLimits {
+		if sl.Id == a.Balance.Id {
+			ub.SpendingLimits = append(ub.SpendingLimits[:i], ub.SpendingLimits[i+1:]...)
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,125 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSpendingLimitActions(t *testing.T) {
+	acc := &Account{Id: "*out:vdf:spender"}
+	a := &Action{ActionType: SET_SPENDING, BalanceType: utils.MONETARY, Direction: OUTBOUND, ExtraParameters: `{"Period":"*daily"}`,
+		Balance: &Balance{Id: "premium", DestinationIds: "NAT", Value: utils.NewDecimalFromFloat(10)}}
+	if err := setSpendingLimitAction(acc, nil, a, nil); err != nil {
+		t.Fatal("Error setting spending limit: ", err)
+	}
+	acc.SpendingLimits[0].Spent = utils.NewDecimalFromFloat(3)
+	a.Balance.Value = utils.NewDecimalFromFloat(20)
+	if err := setSpendingLimitAction(acc, nil, a, nil); err != nil {
+		t.Fatal("Error setting spending limit: ", err)
+	}
+	if len(acc.SpendingLimits) != 1 || acc.SpendingLimits[0].Value.String() != "20" || acc.SpendingLimits[0].Spent.String() != "3" ||
+		acc.SpendingLimits[0].WindowStart.IsZero() {
+		t.Errorf("Wrong spending limits: %+v", acc.SpendingLimits)
+	}
+	a.ExtraParameters = `{"Period":"*yearly"}`
+	if err := setSpendingLimitAction(acc, nil, a, nil); err == nil {
+		t.Error("Expecting error on unsupported period")
+	}
+	if err := removeSpendingLimitAction(acc, nil, &Action{Balance: &Balance{Id: "premium"}}, nil); err != nil || len(acc.SpendingLimits) != 0 {
+		t.Errorf("Wrong spending limits after remove: %+v, %v", acc.SpendingLimits, err)
+	}
+}
+
+func TestSpendingLimitActionDefaults(t *testing.T) {
+	acc := &Account{Id: "*out:vdf:spender"}
+	a := &Action{ActionType: SET_SPENDING, BalanceType: utils.MONETARY,
+		Balance: &Balance{Id: "premium", DestinationIds: "NAT", Value: utils.NewDecimalFromFloat(10)}}
+	if err := setSpendingLimitAction(acc, nil, a, nil); err != nil {
+		t.Fatal("Error setting spending limit without parameters: ", err)
+	}
+	if sl := acc.SpendingLimits[0]; sl.Direction != OUTBOUND || sl.Period != WINDOW_MONTHLY || sl.WindowStart.IsZero() {
+		t.Errorf("Wrong default spending limit: %+v", sl)
+	}
+	if limits := acc.getSpendingLimits(OUTBOUND, utils.VOICE, "0723"); len(limits) != 1 {
+		t.Errorf("Spending limit without direction not applied: %+v", limits)
+	}
+}
+
+func TestSpendingLimitWindow(t *testing.T) {
+	now := time.Date(2015, time.March, 11, 14, 30, 0, 0, time.UTC)
+	sl := &SpendingLimit{Value: utils.NewDecimalFromFloat(10), Period: "*daily", WindowStart: time.Date(2015, time.March, 11, 0, 0, 0, 0, time.UTC)}
+	sl.addSpent(utils.NewDecimalFromFloat(4), now)
+	if left := sl.getLeft(now); left.String() != "6" {
+		t.Error("Wrong left in the window: ", left)
+	}
+	if left := sl.getLeft(now.AddDate(0, 0, 1)); left.String() != "10" {
+		t.Error("Wrong left in the next window: ", left)
+	}
+	sl.addSpent(utils.NewDecimalFromFloat(1), now.AddDate(0, 0, 1))
+	if sl.Spent.String() != "1" || !sl.WindowStart.Equal(time.Date(2015, time.March, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Wrong new window: %+v", sl)
+	}
+}
+
+func TestSpendingLimitMaxSession(t *testing.T) {
+	accountingStorage.SetAccount(&Account{Id: "*out:vdf:spender", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(100)}},
+	}, SpendingLimits: []*SpendingLimit{
+		&SpendingLimit{Id: "nat_voice", Direction: OUTBOUND, BalanceType: utils.VOICE, DestinationIds: "NAT",
+			Value: utils.NewDecimalFromFloat(90), Period: "*daily", WindowStart: time.Now()},
+		&SpendingLimit{Id: "other", Direction: OUTBOUND, BalanceType: utils.MONETARY, DestinationIds: "GERMANY",
+			Value: utils.NewDecimalFromFloat(0), Period: "*daily", WindowStart: time.Now()},
+	}})
+	cd := &CallDescriptor{
+		TimeStart:   time.Date(2013, 10, 21, 18, 34, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2013, 10, 21, 18, 36, 0, 0, time.UTC),
+		Direction:   OUTBOUND,
+		Category:    "0",
+		Tenant:      "vdf",
+		Subject:     "minu",
+		Account:     "spender",
+		Destination: "0723",
+	}
+	if duration, err := cd.Clone().GetMaxSessionDuration(); err != nil || duration != 90*time.Second {
+		t.Errorf("Wrong max session duration: %v, %v", duration, err)
+	}
+	debitCD := cd.Clone()
+	debitCD.TimeEnd = debitCD.TimeStart.Add(60 * time.Second)
+	if _, err := debitCD.Debit(); err != nil {
+		t.Fatal("Error debiting: ", err)
+	}
+	if duration, err := cd.Clone().GetMaxSessionDuration(); err != nil || duration != 30*time.Second {
+		t.Errorf("Wrong max session duration after debit: %v, %v", duration, err)
+	}
+	acc, _ := accountingStorage.GetAccount("*out:vdf:spender")
+	if acc.SpendingLimits[0].Spent.String() != "60" || acc.SpendingLimits[1].Spent.Sign() != 0 {
+		t.Errorf("Wrong spent: %+v, %+v", acc.SpendingLimits[0], acc.SpendingLimits[1])
+	}
+	cc, err := cd.Clone().MaxDebit()
+	if err != nil || cc.GetDuration() != 30*time.Second {
+		t.Errorf("Wrong max debit: %+v, %v", cc, err)
+	}
+	// the limit is reached, nothing left to debit
+	if cc, _ := cd.Clone().MaxDebit(); cc.GetDuration() != 0 {
+		t.Errorf("Debited over the spending limit: %+v", cc)
+	}
+}
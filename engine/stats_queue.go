@@ -128,7 +128,7 @@ func (sq *StatsQueue) simplifyCdr(cdr *StoredCdr) *QCdr {
 		AnswerTime: cdr.AnswerTime,
 		Pdd:        cdr.Pdd,
 		Usage:      cdr.Usage,
-		Cost:       cdr.Cost,
+		Cost:       cdr.Cost.Float64(),
 	}
 }
 
@@ -37,12 +37,12 @@ func TestStatsValue(t *testing.T) {
 	cdr := &StoredCdr{
 		AnswerTime: time.Date(2014, 7, 14, 14, 25, 0, 0, time.UTC),
 		Usage:      10 * time.Second,
-		Cost:       1,
+		Cost:       utils.NewDecimalFromFloat(1),
 	}
 	sq.AppendCDR(cdr)
-	cdr.Cost = 2
+	cdr.Cost = utils.NewDecimalFromFloat(2)
 	sq.AppendCDR(cdr)
-	cdr.Cost = 3
+	cdr.Cost = utils.NewDecimalFromFloat(3)
 	sq.AppendCDR(cdr)
 	s := sq.GetStats()
 	if s[ASR] != 100 ||
@@ -70,14 +70,14 @@ func TestStatsSimplifyCDR(t *testing.T) {
 		SetupTime:      time.Date(2014, 7, 3, 13, 43, 0, 0, time.UTC),
 		Usage:          10 * time.Second,
 		MediationRunId: "mri",
-		Cost:           10,
+		Cost:           utils.NewDecimalFromFloat(10),
 	}
 	sq := &StatsQueue{}
 	qcdr := sq.simplifyCdr(cdr)
 	if cdr.SetupTime != qcdr.SetupTime ||
 		cdr.AnswerTime != qcdr.AnswerTime ||
 		cdr.Usage != qcdr.Usage ||
-		cdr.Cost != qcdr.Cost {
+		cdr.Cost.Float64() != qcdr.Cost {
 		t.Errorf("Failed to simplify cdr: %+v", qcdr)
 	}
 }
@@ -102,7 +102,7 @@ func TestAcceptCdr(t *testing.T) {
 		Supplier:        "supplier1",
 		DisconnectCause: "normal",
 		MediationRunId:  "mri",
-		Cost:            10,
+		Cost:            utils.NewDecimalFromFloat(10),
 	}
 	sq.conf = &CdrStats{}
 	if sq.conf.AcceptCdr(cdr) != true {
@@ -223,7 +223,7 @@ func TestStatsAppendCdr(t *testing.T) {
 		AnswerTime:      time.Now(),
 		SetupTime:       time.Now(),
 		Usage:           10 * time.Second,
-		Cost:            10,
+		Cost:            utils.NewDecimalFromFloat(10),
 		Supplier:        "suppl1",
 		DisconnectCause: "NORMAL_CLEARNING",
 	}
@@ -245,7 +245,7 @@ func TestStatsGetValues(t *testing.T) {
 		AnswerTime: time.Now(),
 		SetupTime:  time.Now(),
 		Usage:      10 * time.Second,
-		Cost:       10,
+		Cost:       utils.NewDecimalFromFloat(10),
 	}
 	cdrStats.AppendCDR(cdr, nil)
 	cdr = &StoredCdr{
@@ -254,7 +254,7 @@ func TestStatsGetValues(t *testing.T) {
 		AnswerTime: time.Now(),
 		SetupTime:  time.Now(),
 		Usage:      2 * time.Second,
-		Cost:       4,
+		Cost:       utils.NewDecimalFromFloat(4),
 	}
 	cdrStats.AppendCDR(cdr, nil)
 	valMap := make(map[string]float64)
@@ -274,7 +274,7 @@ func TestStatsReloadQueues(t *testing.T) {
 		AnswerTime: time.Now(),
 		SetupTime:  time.Now(),
 		Usage:      10 * time.Second,
-		Cost:       10,
+		Cost:       utils.NewDecimalFromFloat(10),
 	}
 	cdrStats.AppendCDR(cdr, nil)
 	if err := cdrStats.ReloadQueues(nil, nil); err != nil {
@@ -309,7 +309,7 @@ func TestStatsReloadQueuesWithDefault(t *testing.T) {
 		AnswerTime: time.Now(),
 		SetupTime:  time.Now(),
 		Usage:      10 * time.Second,
-		Cost:       10,
+		Cost:       utils.NewDecimalFromFloat(10),
 	}
 	cdrStats.AppendCDR(cdr, nil)
 
@@ -342,7 +342,7 @@ func TestStatsReloadQueuesWithIds(t *testing.T) {
 		AnswerTime: time.Now(),
 		SetupTime:  time.Now(),
 		Usage:      10 * time.Second,
-		Cost:       10,
+		Cost:       utils.NewDecimalFromFloat(10),
 	}
 	cdrStats.AppendCDR(cdr, nil)
 	if err := cdrStats.ReloadQueues([]string{"CDRST1"}, nil); err != nil {
@@ -374,7 +374,7 @@ func TestStatsResetQueues(t *testing.T) {
 		AnswerTime: time.Now(),
 		SetupTime:  time.Now(),
 		Usage:      10 * time.Second,
-		Cost:       10,
+		Cost:       utils.NewDecimalFromFloat(10),
 	}
 	cdrStats.AppendCDR(cdr, nil)
 	if err := cdrStats.ResetQueues(nil, nil); err != nil {
@@ -406,7 +406,7 @@ func TestStatsResetQueuesWithIds(t *testing.T) {
 		AnswerTime: time.Now(),
 		SetupTime:  time.Now(),
 		Usage:      10 * time.Second,
-		Cost:       10,
+		Cost:       utils.NewDecimalFromFloat(10),
 	}
 	cdrStats.AppendCDR(cdr, nil)
 	if err := cdrStats.ResetQueues([]string{"CDRST1"}, nil); err != nil {
@@ -15,26 +15,26 @@ type CSVStorage struct {
 	readerFunc func(string, rune, int) (*csv.Reader, *os.File, error)
 	// file names
 	destinationsFn, ratesFn, destinationratesFn, timingsFn, destinationratetimingsFn, ratingprofilesFn,
-	sharedgroupsFn, lcrFn, actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn, derivedChargersFn, cdrStatsFn string
+	sharedgroupsFn, lcrFn, actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn, derivedChargersFn, cdrStatsFn, exchangeRatesFn, taxesFn, holidaysFn, numberPortabilityFn string
 }
 
 func NewFileCSVStorage(sep rune,
 	destinationsFn, timingsFn, ratesFn, destinationratesFn, destinationratetimingsFn, ratingprofilesFn, sharedgroupsFn, lcrFn,
-	actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn, derivedChargersFn, cdrStatsFn string) *CSVStorage {
+	actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn, derivedChargersFn, cdrStatsFn, exchangeRatesFn, taxesFn, holidaysFn, numberPortabilityFn string) *CSVStorage {
 	c := new(CSVStorage)
 	c.sep = sep
 	c.readerFunc = openFileCSVStorage
 	c.destinationsFn, c.timingsFn, c.ratesFn, c.destinationratesFn, c.destinationratetimingsFn, c.ratingprofilesFn,
-		c.sharedgroupsFn, c.lcrFn, c.actionsFn, c.actiontimingsFn, c.actiontriggersFn, c.accountactionsFn, c.derivedChargersFn, c.cdrStatsFn = destinationsFn, timingsFn,
-		ratesFn, destinationratesFn, destinationratetimingsFn, ratingprofilesFn, sharedgroupsFn, lcrFn, actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn, derivedChargersFn, cdrStatsFn
+		c.sharedgroupsFn, c.lcrFn, c.actionsFn, c.actiontimingsFn, c.actiontriggersFn, c.accountactionsFn, c.derivedChargersFn, c.cdrStatsFn, c.exchangeRatesFn, c.taxesFn, c.holidaysFn, c.numberPortabilityFn = destinationsFn, timingsFn,
+		ratesFn, destinationratesFn, destinationratetimingsFn, ratingprofilesFn, sharedgroupsFn, lcrFn, actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn, derivedChargersFn, cdrStatsFn, exchangeRatesFn, taxesFn, holidaysFn, numberPortabilityFn
 	return c
 }
 
 func NewStringCSVStorage(sep rune,
 	destinationsFn, timingsFn, ratesFn, destinationratesFn, destinationratetimingsFn, ratingprofilesFn, sharedgroupsFn, lcrFn,
-	actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn, derivedChargersFn, cdrStatsFn string) *CSVStorage {
+	actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn, derivedChargersFn, cdrStatsFn, exchangeRatesFn, taxesFn, holidaysFn, numberPortabilityFn string) *CSVStorage {
 	c := NewFileCSVStorage(sep, destinationsFn, timingsFn, ratesFn, destinationratesFn, destinationratetimingsFn,
-		ratingprofilesFn, sharedgroupsFn, lcrFn, actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn, derivedChargersFn, cdrStatsFn)
+		ratingprofilesFn, sharedgroupsFn, lcrFn, actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn, derivedChargersFn, cdrStatsFn, exchangeRatesFn, taxesFn, holidaysFn, numberPortabilityFn)
 	c.readerFunc = openStringCSVStorage
 	return c
 }
@@ -261,6 +261,126 @@ func (csvs *CSVStorage) GetTpSharedGroups(tpid, tag string) ([]TpSharedGroup, er
 	return tpSharedGroups, nil
 }
 
+func (csvs *CSVStorage) GetTpExchangeRates(tpid, tag string) ([]TpExchangeRate, error) {
+	csvReader, fp, err := csvs.readerFunc(csvs.exchangeRatesFn, csvs.sep, getColumnCount(TpExchangeRate{}))
+	if err != nil {
+		log.Print("Could not load exchange rates file: ", err)
+		// allow writing of the other values
+		return nil, nil
+	}
+	if fp != nil {
+		defer fp.Close()
+	}
+	var tpExchangeRates []TpExchangeRate
+	for record, err := csvReader.Read(); err != io.EOF; record, err = csvReader.Read() {
+		if err != nil {
+			log.Print("bad line in exchange rates csv: ", err)
+			return nil, err
+		}
+		if tpExr, err := csvLoad(TpExchangeRate{}, record); err != nil {
+			log.Print("error loading exchange rate: ", err)
+			return nil, err
+		} else {
+			exr := tpExr.(TpExchangeRate)
+			exr.Tpid = tpid
+			tpExchangeRates = append(tpExchangeRates, exr)
+		}
+	}
+	return tpExchangeRates, nil
+}
+
+func (csvs *CSVStorage) GetTpTaxes(filter *TpTax) ([]TpTax, error) {
+	csvReader, fp, err := csvs.readerFunc(csvs.taxesFn, csvs.sep, getColumnCount(TpTax{}))
+	if err != nil {
+		log.Print("Could not load taxes file: ", err)
+		// allow writing of the other values
+		return nil, nil
+	}
+	if fp != nil {
+		defer fp.Close()
+	}
+	var tpTaxes []TpTax
+	for record, err := csvReader.Read(); err != io.EOF; record, err = csvReader.Read() {
+		if err != nil {
+			log.Print("bad line in taxes csv: ", err)
+			return nil, err
+		}
+		if tpTax, err := csvLoad(TpTax{}, record); err != nil {
+			log.Print("error loading tax: ", err)
+			return nil, err
+		} else {
+			tax := tpTax.(TpTax)
+			if filter != nil {
+				tax.Tpid = filter.Tpid
+			}
+			tpTaxes = append(tpTaxes, tax)
+		}
+	}
+	return tpTaxes, nil
+}
+
+func (csvs *CSVStorage) GetTpHolidays(tpid, tag string) ([]TpHoliday, error) {
+	csvReader, fp, err := csvs.readerFunc(csvs.holidaysFn, csvs.sep, getColumnCount(TpHoliday{}))
+	if err != nil {
+		log.Print("Could not load holidays file: ", err)
+		// allow writing of the other values
+		return nil, nil
+	}
+	if fp != nil {
+		defer fp.Close()
+	}
+	var tpHolidays []TpHoliday
+	for record, err := csvReader.Read(); err != io.EOF; record, err = csvReader.Read() {
+		if err != nil {
+			log.Print("bad line in holidays csv: ", err)
+			return nil, err
+		}
+		if tpHoliday, err := csvLoad(TpHoliday{}, record); err != nil {
+			log.Print("error loading holiday: ", err)
+			return nil, err
+		} else {
+			h := tpHoliday.(TpHoliday)
+			if tag != "" && h.Tag != tag {
+				continue
+			}
+			h.Tpid = tpid
+			tpHolidays = append(tpHolidays, h)
+		}
+	}
+	return tpHolidays, nil
+}
+
+func (csvs *CSVStorage) GetTpNumberPortability(tpid, number string) ([]TpNumberPortability, error) {
+	csvReader, fp, err := csvs.readerFunc(csvs.numberPortabilityFn, csvs.sep, getColumnCount(TpNumberPortability{}))
+	if err != nil {
+		log.Print("Could not load number portability file: ", err)
+		// allow writing of the other values
+		return nil, nil
+	}
+	if fp != nil {
+		defer fp.Close()
+	}
+	var tpPortedNumbers []TpNumberPortability
+	for record, err := csvReader.Read(); err != io.EOF; record, err = csvReader.Read() {
+		if err != nil {
+			log.Print("bad line in number portability csv: ", err)
+			return nil, err
+		}
+		if tpPortedNumber, err := csvLoad(TpNumberPortability{}, record); err != nil {
+			log.Print("error loading ported number: ", err)
+			return nil, err
+		} else {
+			pn := tpPortedNumber.(TpNumberPortability)
+			if number != "" && pn.Number != number {
+				continue
+			}
+			pn.Tpid = tpid
+			tpPortedNumbers = append(tpPortedNumbers, pn)
+		}
+	}
+	return tpPortedNumbers, nil
+}
+
 func (csvs *CSVStorage) GetTpLCRs(tpid, tag string) ([]TpLcrRule, error) {
 	csvReader, fp, err := csvs.readerFunc(csvs.lcrFn, csvs.sep, getColumnCount(TpLcrRule{}))
 	if err != nil {
@@ -41,6 +41,11 @@ const (
 	DESTINATION_PREFIX        = "dst_"
 	LCR_PREFIX                = "lcr_"
 	DERIVEDCHARGERS_PREFIX    = "dcs_"
+	EXCHANGE_RATE_PREFIX      = "exr_"
+	TAXES_PREFIX              = "tax_"
+	HOLIDAY_CALENDAR_PREFIX   = "hcl_"
+	NUMBER_PORTABILITY_PREFIX = "mnp_"
+	EXPIRING_TRIGGERS_PREFIX  = "ext_" // index of the accounts with *balance_expiring triggers
 	CDR_STATS_PREFIX          = "cst_"
 	TEMP_DESTINATION_PREFIX   = "tmp_"
 	LOG_CALL_COST_PREFIX      = "cco_"
@@ -70,7 +75,7 @@ type Storage interface {
 // Interface for storage providers.
 type RatingStorage interface {
 	Storage
-	CacheRating([]string, []string, []string, []string, []string, []string) error
+	CacheRating([]string, []string, []string, []string, []string, []string, []string, []string, []string, []string) error
 	HasData(string, string) (bool, error)
 	GetRatingPlan(string, bool) (*RatingPlan, error)
 	SetRatingPlan(*RatingPlan) error
@@ -89,6 +94,14 @@ type RatingStorage interface {
 	GetAllCdrStats() ([]*CdrStats, error)
 	GetDerivedChargers(string, bool) (utils.DerivedChargers, error)
 	SetDerivedChargers(string, utils.DerivedChargers) error
+	GetExchangeRate(string, bool) (*ExchangeRate, error)
+	SetExchangeRate(*ExchangeRate) error
+	GetTaxes(string, bool) (*Taxes, error)
+	SetTaxes(*Taxes) error
+	GetHolidayCalendar(string, bool) (*HolidayCalendar, error)
+	SetHolidayCalendar(*HolidayCalendar) error
+	GetRoutingNumber(string, bool) (string, error)
+	SetRoutingNumber(string, string) error
 }
 
 type AccountingStorage interface {
@@ -108,6 +121,9 @@ type AccountingStorage interface {
 	GetActionPlans(string) (ActionPlans, error)
 	SetActionPlans(string, ActionPlans) error
 	GetAllActionPlans() (map[string]ActionPlans, error)
+	MigrateDecimalValues() (int, error)
+	MigrateCreditLimits() (int, error)
+	ReindexExpiringTriggers() (int, error)
 }
 
 type CdrStorage interface {
@@ -118,6 +134,10 @@ type CdrStorage interface {
 	GetCallCostLog(cgrid, source, runid string) (*CallCost, error)
 	GetStoredCdrs(*utils.CdrsFilter) ([]*StoredCdr, int64, error)
 	RemStoredCdrs([]string) error
+	SetLedgerEntries([]*LedgerEntry) error
+	GetLedgerEntries(*utils.LedgerFilter) ([]*LedgerEntry, error)
+	SetAccountSnapshot(string, []*Account) error
+	GetAccountSnapshot(string, []string) ([]*Account, error)
 }
 
 type LogStorage interface {
@@ -144,6 +164,10 @@ type LoadReader interface {
 	GetTpRatingPlans(string, string, *utils.Paginator) ([]TpRatingPlan, error)
 	GetTpRatingProfiles(*TpRatingProfile) ([]TpRatingProfile, error)
 	GetTpSharedGroups(string, string) ([]TpSharedGroup, error)
+	GetTpExchangeRates(string, string) ([]TpExchangeRate, error)
+	GetTpTaxes(*TpTax) ([]TpTax, error)
+	GetTpHolidays(string, string) ([]TpHoliday, error)
+	GetTpNumberPortability(string, string) ([]TpNumberPortability, error)
 	GetTpCdrStats(string, string) ([]TpCdrstat, error)
 	GetTpDerivedChargers(*TpDerivedCharger) ([]TpDerivedCharger, error)
 	GetTpLCRs(string, string) ([]TpLcrRule, error)
@@ -162,6 +186,10 @@ type LoadWriter interface {
 	SetTpRatingPlans([]TpRatingPlan) error
 	SetTpRatingProfiles([]TpRatingProfile) error
 	SetTpSharedGroups([]TpSharedGroup) error
+	SetTpExchangeRates([]TpExchangeRate) error
+	SetTpTaxes([]TpTax) error
+	SetTpHolidays([]TpHoliday) error
+	SetTpNumberPortability([]TpNumberPortability) error
 	SetTpCdrStats([]TpCdrstat) error
 	SetTpDerivedChargers([]TpDerivedCharger) error
 	SetTpLCRs([]TpLcrRule) error
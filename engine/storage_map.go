@@ -62,13 +62,8 @@ func (ms *MapStorage) GetKeysForPrefix(prefix string) ([]string, error) {
 	return keysForPrefix, nil
 }
 
-func (ms *MapStorage) CacheRating(dKeys, rpKeys, rpfKeys, alsKeys, lcrKeys, dcsKeys []string) error {
+func (ms *MapStorage) CacheRating(dKeys, rpKeys, rpfKeys, alsKeys, lcrKeys, dcsKeys, exrKeys, taxKeys, hclKeys, mnpKeys []string) error {
 	cache2go.BeginTransaction()
-	if dKeys == nil || (float64(cache2go.CountEntries(DESTINATION_PREFIX))*DESTINATIONS_LOAD_THRESHOLD < float64(len(dKeys))) {
-		cache2go.RemPrefixKey(DESTINATION_PREFIX)
-	} else {
-		CleanStalePrefixes(dKeys)
-	}
 	if rpKeys == nil {
 		cache2go.RemPrefixKey(RATING_PLAN_PREFIX)
 	}
@@ -84,12 +79,27 @@ func (ms *MapStorage) CacheRating(dKeys, rpKeys, rpfKeys, alsKeys, lcrKeys, dcsK
 	if dcsKeys == nil {
 		cache2go.RemPrefixKey(DERIVEDCHARGERS_PREFIX)
 	}
+	if exrKeys == nil {
+		cache2go.RemPrefixKey(EXCHANGE_RATE_PREFIX)
+	}
+	if taxKeys == nil {
+		cache2go.RemPrefixKey(TAXES_PREFIX)
+	}
+	if hclKeys == nil {
+		cache2go.RemPrefixKey(HOLIDAY_CALENDAR_PREFIX)
+	}
+	if mnpKeys == nil {
+		cache2go.RemPrefixKey(NUMBER_PORTABILITY_PREFIX)
+	}
+	var dests []*Destination
 	for k, _ := range ms.dict {
 		if strings.HasPrefix(k, DESTINATION_PREFIX) {
-			if _, err := ms.GetDestination(k[len(DESTINATION_PREFIX):]); err != nil {
+			dest, err := ms.GetDestination(k[len(DESTINATION_PREFIX):])
+			if err != nil {
 				cache2go.RollbackTransaction()
 				return err
 			}
+			dests = append(dests, dest)
 		}
 		if strings.HasPrefix(k, RATING_PLAN_PREFIX) {
 			cache2go.RemKey(k)
@@ -126,8 +136,37 @@ func (ms *MapStorage) CacheRating(dKeys, rpKeys, rpfKeys, alsKeys, lcrKeys, dcsK
 				return err
 			}
 		}
+		if strings.HasPrefix(k, EXCHANGE_RATE_PREFIX) {
+			cache2go.RemKey(k)
+			if _, err := ms.GetExchangeRate(k[len(EXCHANGE_RATE_PREFIX):], true); err != nil {
+				cache2go.RollbackTransaction()
+				return err
+			}
+		}
+		if strings.HasPrefix(k, TAXES_PREFIX) {
+			cache2go.RemKey(k)
+			if _, err := ms.GetTaxes(k[len(TAXES_PREFIX):], true); err != nil {
+				cache2go.RollbackTransaction()
+				return err
+			}
+		}
+		if strings.HasPrefix(k, HOLIDAY_CALENDAR_PREFIX) {
+			cache2go.RemKey(k)
+			if _, err := ms.GetHolidayCalendar(k[len(HOLIDAY_CALENDAR_PREFIX):], true); err != nil {
+				cache2go.RollbackTransaction()
+				return err
+			}
+		}
+		if strings.HasPrefix(k, NUMBER_PORTABILITY_PREFIX) {
+			cache2go.RemKey(k)
+			if _, err := ms.GetRoutingNumber(k[len(NUMBER_PORTABILITY_PREFIX):], true); err != nil {
+				cache2go.RollbackTransaction()
+				return err
+			}
+		}
 	}
 	cache2go.CommitTransaction()
+	destIndex.Reset(dests) // all destinations are loaded every time
 	return nil
 }
 
@@ -178,6 +217,9 @@ func (ms *MapStorage) HasData(categ, subject string) (bool, error) {
 	case RATING_PLAN_PREFIX:
 		_, exists := ms.dict[RATING_PLAN_PREFIX+subject]
 		return exists, nil
+	case HOLIDAY_CALENDAR_PREFIX:
+		_, exists := ms.dict[HOLIDAY_CALENDAR_PREFIX+subject]
+		return exists, nil
 	}
 	return false, errors.New("Unsupported category")
 }
@@ -410,10 +452,6 @@ func (ms *MapStorage) GetDestination(key string) (dest *Destination, err error)
 		r.Close()
 		dest = new(Destination)
 		err = ms.ms.Unmarshal(out, dest)
-		// create optimized structure
-		for _, p := range dest.Prefixes {
-			cache2go.CachePush(DESTINATION_PREFIX+p, dest.Id)
-		}
 	} else {
 		return nil, utils.ErrNotFound
 	}
@@ -427,6 +465,7 @@ func (ms *MapStorage) SetDestination(dest *Destination) (err error) {
 	w.Write(result)
 	w.Close()
 	ms.dict[DESTINATION_PREFIX+dest.Id] = b.Bytes()
+	destIndex.SetDestination(dest)
 	response := 0
 	if historyScribe != nil {
 		go historyScribe.Record(dest.GetHistoryRecord(), &response)
@@ -488,7 +527,10 @@ func (ms *MapStorage) SetSharedGroup(sg *SharedGroup) (err error) {
 func (ms *MapStorage) GetAccount(key string) (ub *Account, err error) {
 	if values, ok := ms.dict[ACCOUNT_PREFIX+key]; ok {
 		ub = &Account{Id: key}
-		err = ms.ms.Unmarshal(values, ub)
+		if err = ms.ms.Unmarshal(values, ub); err == nil {
+			ub.snapshotLedger()
+			ub.snapshotExpiringIndex()
+		}
 	} else {
 		return nil, utils.ErrNotFound
 	}
@@ -499,17 +541,34 @@ func (ms *MapStorage) SetAccount(ub *Account) (err error) {
 	// never override existing account with an empty one
 	// UPDATE: if all balances expired and were clean it makes
 	// sense to write empty balance map
+	orig := ub // the ledger movements are collected on the saved account
 	if len(ub.BalanceMap) == 0 {
 		if ac, err := ms.GetAccount(ub.Id); err == nil && !ac.allBalancesExpired() {
 			ac.ActionTriggers = ub.ActionTriggers
 			ac.UnitCounters = ub.UnitCounters
-			ac.AllowNegative = ub.AllowNegative
+			ac.CreditLimits = ub.CreditLimits
+			ac.ParentId = ub.ParentId
+			ac.ResellerSubject = ub.ResellerSubject
+			ac.SpendingLimits = ub.SpendingLimits
+			ac.SharedLimits = ub.SharedLimits
 			ac.Disabled = ub.Disabled
 			ub = ac
 		}
 	}
 	result, err := ms.ms.Marshal(ub)
+	if err != nil {
+		return err
+	}
 	ms.dict[ACCOUNT_PREFIX+ub.Id] = result
+	orig.recordLedger() // only the stored movements go in the ledger
+	if indexed, changed := orig.expiringIndexChanged(); changed {
+		if indexed {
+			ms.dict[EXPIRING_TRIGGERS_PREFIX+ub.Id] = []byte{}
+		} else {
+			delete(ms.dict, EXPIRING_TRIGGERS_PREFIX+ub.Id)
+		}
+		orig.snapshotExpiringIndex()
+	}
 	return
 }
 
@@ -547,6 +606,60 @@ func (ms *MapStorage) GetAllActionPlans() (ats map[string]ActionPlans, err error
 	return
 }
 
+// Rewrites the accounts and actions saved with float money values
+func (ms *MapStorage) MigrateDecimalValues() (migrated int, err error) {
+	return ms.migrateValues([]string{ACCOUNT_PREFIX, ACTION_PREFIX}, migrateDecimalValues)
+}
+
+// Rewrites the accounts saved with the AllowNegative flag
+func (ms *MapStorage) MigrateCreditLimits() (migrated int, err error) {
+	return ms.migrateValues([]string{ACCOUNT_PREFIX}, migrateCreditLimits)
+}
+
+// Rebuilds the index of the accounts with expiring triggers, returns the number of indexed accounts
+func (ms *MapStorage) ReindexExpiringTriggers() (indexed int, err error) {
+	for key := range ms.dict {
+		if strings.HasPrefix(key, EXPIRING_TRIGGERS_PREFIX) {
+			delete(ms.dict, key)
+		}
+	}
+	for key := range ms.dict {
+		if !strings.HasPrefix(key, ACCOUNT_PREFIX) {
+			continue
+		}
+		ub, err := ms.GetAccount(key[len(ACCOUNT_PREFIX):])
+		if err != nil {
+			return indexed, fmt.Errorf("reindexing %s: %s", key, err.Error())
+		}
+		if ub.hasExpiringTriggers() {
+			ms.dict[EXPIRING_TRIGGERS_PREFIX+ub.Id] = []byte{}
+			indexed++
+		}
+	}
+	return
+}
+
+func (ms *MapStorage) migrateValues(prefixes []string, migrate func(Marshaler, string, []byte) ([]byte, bool, error)) (migrated int, err error) {
+	for key, values := range ms.dict {
+		found := false
+		for _, prefix := range prefixes {
+			found = found || strings.HasPrefix(key, prefix)
+		}
+		if !found {
+			continue
+		}
+		result, changed, err := migrate(ms.ms, key, values)
+		if err != nil {
+			return migrated, fmt.Errorf("migrating %s: %s", key, err.Error())
+		}
+		if changed {
+			ms.dict[key] = result
+			migrated++
+		}
+	}
+	return
+}
+
 func (ms *MapStorage) GetDerivedChargers(key string, skipCache bool) (dcs utils.DerivedChargers, err error) {
 	key = DERIVEDCHARGERS_PREFIX + key
 	if !skipCache {
@@ -571,6 +684,101 @@ func (ms *MapStorage) SetDerivedChargers(key string, dcs utils.DerivedChargers)
 	return err
 }
 
+func (ms *MapStorage) GetExchangeRate(key string, skipCache bool) (exr *ExchangeRate, err error) {
+	key = EXCHANGE_RATE_PREFIX + key
+	if !skipCache {
+		if x, err := cache2go.GetCached(key); err == nil {
+			return x.(*ExchangeRate), nil
+		} else {
+			return nil, err
+		}
+	}
+	if values, ok := ms.dict[key]; ok {
+		err = ms.ms.Unmarshal(values, &exr)
+		cache2go.Cache(key, exr)
+	} else {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+func (ms *MapStorage) SetExchangeRate(exr *ExchangeRate) error {
+	result, err := ms.ms.Marshal(exr)
+	ms.dict[EXCHANGE_RATE_PREFIX+exr.GetId()] = result
+	return err
+}
+
+func (ms *MapStorage) GetTaxes(key string, skipCache bool) (taxes *Taxes, err error) {
+	key = TAXES_PREFIX + key
+	if !skipCache {
+		if x, err := cache2go.GetCached(key); err == nil {
+			return x.(*Taxes), nil
+		} else {
+			return nil, err
+		}
+	}
+	if values, ok := ms.dict[key]; ok {
+		err = ms.ms.Unmarshal(values, &taxes)
+		cache2go.Cache(key, taxes)
+	} else {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+func (ms *MapStorage) SetTaxes(taxes *Taxes) error {
+	result, err := ms.ms.Marshal(taxes)
+	ms.dict[TAXES_PREFIX+taxes.GetId()] = result
+	return err
+}
+
+func (ms *MapStorage) GetHolidayCalendar(key string, skipCache bool) (hc *HolidayCalendar, err error) {
+	key = HOLIDAY_CALENDAR_PREFIX + key
+	if !skipCache {
+		if x, err := cache2go.GetCached(key); err == nil {
+			return x.(*HolidayCalendar), nil
+		} else {
+			return nil, err
+		}
+	}
+	if values, ok := ms.dict[key]; ok {
+		err = ms.ms.Unmarshal(values, &hc)
+		cache2go.Cache(key, hc)
+	} else {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+func (ms *MapStorage) SetHolidayCalendar(hc *HolidayCalendar) error {
+	result, err := ms.ms.Marshal(hc)
+	ms.dict[HOLIDAY_CALENDAR_PREFIX+hc.Id] = result
+	return err
+}
+
+func (ms *MapStorage) GetRoutingNumber(number string, skipCache bool) (routingNumber string, err error) {
+	key := NUMBER_PORTABILITY_PREFIX + number
+	if !skipCache {
+		if x, err := cache2go.GetCached(key); err == nil {
+			return x.(string), nil
+		} else {
+			return "", err
+		}
+	}
+	if values, ok := ms.dict[key]; ok {
+		routingNumber = string(values)
+		cache2go.Cache(key, routingNumber)
+	} else {
+		return "", utils.ErrNotFound
+	}
+	return
+}
+
+func (ms *MapStorage) SetRoutingNumber(number, routingNumber string) error {
+	ms.dict[NUMBER_PORTABILITY_PREFIX+number] = []byte(routingNumber)
+	return nil
+}
+
 func (ms *MapStorage) SetCdrStats(cs *CdrStats) error {
 	result, err := ms.ms.Marshal(cs)
 	ms.dict[CDR_STATS_PREFIX+cs.Id] = result
@@ -123,7 +123,10 @@ func (self *PostgresStorage) SetRatedCdr(cdr *StoredCdr) (err error) {
 		Pdd:             cdr.Pdd.Seconds(),
 		Supplier:        cdr.Supplier,
 		DisconnectCause: cdr.DisconnectCause,
+		Origin:          cdr.Origin,
 		Cost:            cdr.Cost,
+		Taxes:           cdr.TaxesJson(),
+		RoutingNumber:   cdr.RoutingNumber,
 		ExtraInfo:       cdr.ExtraInfo,
 		CreatedAt:       time.Now(),
 	})
@@ -132,8 +135,8 @@ func (self *PostgresStorage) SetRatedCdr(cdr *StoredCdr) (err error) {
 		tx = self.db.Begin()
 		updated := tx.Model(TblRatedCdr{}).Where(&TblRatedCdr{Cgrid: cdr.CgrId, Runid: cdr.MediationRunId}).Updates(&TblRatedCdr{Reqtype: cdr.ReqType,
 			Direction: cdr.Direction, Tenant: cdr.Tenant, Category: cdr.Category, Account: cdr.Account, Subject: cdr.Subject, Destination: cdr.Destination,
-			SetupTime: cdr.SetupTime, AnswerTime: cdr.AnswerTime, Usage: cdr.Usage.Seconds(), Pdd: cdr.Pdd.Seconds(), Supplier: cdr.Supplier, DisconnectCause: cdr.DisconnectCause,
-			Cost: cdr.Cost, ExtraInfo: cdr.ExtraInfo,
+			SetupTime: cdr.SetupTime, AnswerTime: cdr.AnswerTime, Usage: cdr.Usage.Seconds(), Pdd: cdr.Pdd.Seconds(), Supplier: cdr.Supplier, DisconnectCause: cdr.DisconnectCause, Origin: cdr.Origin,
+			Cost: cdr.Cost, Taxes: cdr.TaxesJson(), RoutingNumber: cdr.RoutingNumber, ExtraInfo: cdr.ExtraInfo,
 			UpdatedAt: time.Now()})
 		if updated.Error != nil {
 			tx.Rollback()
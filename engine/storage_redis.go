@@ -66,29 +66,32 @@ func (rs *RedisStorage) GetKeysForPrefix(prefix string) ([]string, error) {
 	return rs.db.Keys(prefix + "*")
 }
 
-func (rs *RedisStorage) CacheRating(dKeys, rpKeys, rpfKeys, alsKeys, lcrKeys, dcsKeys []string) (err error) {
+func (rs *RedisStorage) CacheRating(dKeys, rpKeys, rpfKeys, alsKeys, lcrKeys, dcsKeys, exrKeys, taxKeys, hclKeys, mnpKeys []string) (err error) {
 	cache2go.BeginTransaction()
-	if dKeys == nil || (float64(cache2go.CountEntries(DESTINATION_PREFIX))*DESTINATIONS_LOAD_THRESHOLD < float64(len(dKeys))) {
+	allDests := false
+	if dKeys == nil || (float64(destIndex.Len())*DESTINATIONS_LOAD_THRESHOLD < float64(len(dKeys))) {
 		// if need to load more than a half of exiting keys load them all
 		Logger.Info("Caching all destinations")
 		if dKeys, err = rs.db.Keys(DESTINATION_PREFIX + "*"); err != nil {
 			cache2go.RollbackTransaction()
 			return err
 		}
-		cache2go.RemPrefixKey(DESTINATION_PREFIX)
+		allDests = true
 	} else if len(dKeys) != 0 {
 		Logger.Info(fmt.Sprintf("Caching destinations: %v", dKeys))
-		CleanStalePrefixes(dKeys)
 	}
+	dests := make([]*Destination, 0, len(dKeys))
 	for _, key := range dKeys {
 		if len(key) <= len(DESTINATION_PREFIX) {
 			Logger.Warning(fmt.Sprintf("Got malformed destination id: %s", key))
 			continue
 		}
-		if _, err = rs.GetDestination(key[len(DESTINATION_PREFIX):]); err != nil {
+		var dest *Destination
+		if dest, err = rs.GetDestination(key[len(DESTINATION_PREFIX):]); err != nil {
 			cache2go.RollbackTransaction()
 			return err
 		}
+		dests = append(dests, dest)
 	}
 	if len(dKeys) != 0 {
 		Logger.Info("Finished destinations caching.")
@@ -194,7 +197,95 @@ func (rs *RedisStorage) CacheRating(dKeys, rpKeys, rpfKeys, alsKeys, lcrKeys, dc
 	if len(dcsKeys) != 0 {
 		Logger.Info("Finished derived chargers caching.")
 	}
+	if exrKeys == nil {
+		Logger.Info("Caching all exchange rates")
+		if exrKeys, err = rs.db.Keys(EXCHANGE_RATE_PREFIX + "*"); err != nil {
+			cache2go.RollbackTransaction()
+			return err
+		}
+		cache2go.RemPrefixKey(EXCHANGE_RATE_PREFIX)
+	} else if len(exrKeys) != 0 {
+		Logger.Info(fmt.Sprintf("Caching exchange rates: %v", exrKeys))
+	}
+	for _, key := range exrKeys {
+		cache2go.RemKey(key)
+		if _, err = rs.GetExchangeRate(key[len(EXCHANGE_RATE_PREFIX):], true); err != nil {
+			cache2go.RollbackTransaction()
+			return err
+		}
+	}
+	if len(exrKeys) != 0 {
+		Logger.Info("Finished exchange rates caching.")
+	}
+	if taxKeys == nil {
+		Logger.Info("Caching all taxes")
+		if taxKeys, err = rs.db.Keys(TAXES_PREFIX + "*"); err != nil {
+			cache2go.RollbackTransaction()
+			return err
+		}
+		cache2go.RemPrefixKey(TAXES_PREFIX)
+	} else if len(taxKeys) != 0 {
+		Logger.Info(fmt.Sprintf("Caching taxes: %v", taxKeys))
+	}
+	for _, key := range taxKeys {
+		cache2go.RemKey(key)
+		if _, err = rs.GetTaxes(key[len(TAXES_PREFIX):], true); err != nil {
+			cache2go.RollbackTransaction()
+			return err
+		}
+	}
+	if len(taxKeys) != 0 {
+		Logger.Info("Finished taxes caching.")
+	}
+	if hclKeys == nil {
+		Logger.Info("Caching all holiday calendars")
+		if hclKeys, err = rs.db.Keys(HOLIDAY_CALENDAR_PREFIX + "*"); err != nil {
+			cache2go.RollbackTransaction()
+			return err
+		}
+		cache2go.RemPrefixKey(HOLIDAY_CALENDAR_PREFIX)
+	} else if len(hclKeys) != 0 {
+		Logger.Info(fmt.Sprintf("Caching holiday calendars: %v", hclKeys))
+	}
+	for _, key := range hclKeys {
+		cache2go.RemKey(key)
+		if _, err = rs.GetHolidayCalendar(key[len(HOLIDAY_CALENDAR_PREFIX):], true); err != nil {
+			cache2go.RollbackTransaction()
+			return err
+		}
+	}
+	if len(hclKeys) != 0 {
+		Logger.Info("Finished holiday calendars caching.")
+	}
+	if mnpKeys == nil {
+		Logger.Info("Caching all ported numbers")
+		if mnpKeys, err = rs.db.Keys(NUMBER_PORTABILITY_PREFIX + "*"); err != nil {
+			cache2go.RollbackTransaction()
+			return err
+		}
+		cache2go.RemPrefixKey(NUMBER_PORTABILITY_PREFIX)
+	} else if len(mnpKeys) != 0 {
+		Logger.Info(fmt.Sprintf("Caching ported numbers: %v", mnpKeys))
+	}
+	for _, key := range mnpKeys {
+		cache2go.RemKey(key)
+		if _, err = rs.GetRoutingNumber(key[len(NUMBER_PORTABILITY_PREFIX):], true); err != nil {
+			cache2go.RollbackTransaction()
+			return err
+		}
+	}
+	if len(mnpKeys) != 0 {
+		Logger.Info("Finished ported numbers caching.")
+	}
 	cache2go.CommitTransaction()
+	// destinations index follows the committed cache
+	if allDests {
+		destIndex.Reset(dests)
+	} else {
+		for _, dest := range dests {
+			destIndex.SetDestination(dest)
+		}
+	}
 	return nil
 }
 
@@ -271,7 +362,7 @@ func (rs *RedisStorage) CacheAccounting(actKeys, shgKeys, alsKeys []string) (err
 // Used to check if specific subject is stored using prefix key attached to entity
 func (rs *RedisStorage) HasData(category, subject string) (bool, error) {
 	switch category {
-	case DESTINATION_PREFIX, RATING_PLAN_PREFIX, RATING_PROFILE_PREFIX, ACTION_PREFIX, ACTION_TIMING_PREFIX, ACCOUNT_PREFIX:
+	case DESTINATION_PREFIX, RATING_PLAN_PREFIX, RATING_PROFILE_PREFIX, ACTION_PREFIX, ACTION_TIMING_PREFIX, ACCOUNT_PREFIX, HOLIDAY_CALENDAR_PREFIX:
 		return rs.db.Exists(category + subject)
 	}
 	return false, errors.New("Unsupported category in HasData")
@@ -537,10 +628,6 @@ func (rs *RedisStorage) GetDestination(key string) (dest *Destination, err error
 		r.Close()
 		dest = new(Destination)
 		err = rs.ms.Unmarshal(out, dest)
-		// create optimized structure
-		for _, p := range dest.Prefixes {
-			cache2go.CachePush(DESTINATION_PREFIX+p, dest.Id)
-		}
 	} else {
 		return nil, errors.New("not found")
 	}
@@ -557,6 +644,9 @@ func (rs *RedisStorage) SetDestination(dest *Destination) (err error) {
 	w.Write(result)
 	w.Close()
 	err = rs.db.Set(DESTINATION_PREFIX+dest.Id, b.Bytes())
+	if err == nil {
+		destIndex.SetDestination(dest)
+	}
 	if err == nil && historyScribe != nil {
 		response := 0
 		go historyScribe.Record(dest.GetHistoryRecord(), &response)
@@ -617,7 +707,10 @@ func (rs *RedisStorage) GetAccount(key string) (ub *Account, err error) {
 	var values []byte
 	if values, err = rs.db.Get(ACCOUNT_PREFIX + key); err == nil {
 		ub = &Account{Id: key}
-		err = rs.ms.Unmarshal(values, ub)
+		if err = rs.ms.Unmarshal(values, ub); err == nil {
+			ub.snapshotLedger()
+			ub.snapshotExpiringIndex()
+		}
 	}
 
 	return
@@ -627,17 +720,38 @@ func (rs *RedisStorage) SetAccount(ub *Account) (err error) {
 	// never override existing account with an empty one
 	// UPDATE: if all balances expired and were cleaned it makes
 	// sense to write empty balance map
+	orig := ub // the ledger movements are collected on the saved account
 	if len(ub.BalanceMap) == 0 {
 		if ac, err := rs.GetAccount(ub.Id); err == nil && !ac.allBalancesExpired() {
 			ac.ActionTriggers = ub.ActionTriggers
 			ac.UnitCounters = ub.UnitCounters
-			ac.AllowNegative = ub.AllowNegative
+			ac.CreditLimits = ub.CreditLimits
+			ac.ParentId = ub.ParentId
+			ac.ResellerSubject = ub.ResellerSubject
+			ac.SpendingLimits = ub.SpendingLimits
+			ac.SharedLimits = ub.SharedLimits
 			ac.Disabled = ub.Disabled
 			ub = ac
 		}
 	}
 	result, err := rs.ms.Marshal(ub)
-	err = rs.db.Set(ACCOUNT_PREFIX+ub.Id, result)
+	if err != nil {
+		return err
+	}
+	if err = rs.db.Set(ACCOUNT_PREFIX+ub.Id, result); err != nil {
+		return
+	}
+	orig.recordLedger() // only the stored movements go in the ledger
+	if indexed, changed := orig.expiringIndexChanged(); changed {
+		if indexed {
+			err = rs.db.Set(EXPIRING_TRIGGERS_PREFIX+ub.Id, []byte{})
+		} else {
+			_, err = rs.db.Del(EXPIRING_TRIGGERS_PREFIX + ub.Id)
+		}
+		if err == nil {
+			orig.snapshotExpiringIndex()
+		}
+	}
 	return
 }
 
@@ -679,6 +793,74 @@ func (rs *RedisStorage) GetAllActionPlans() (ats map[string]ActionPlans, err err
 	return
 }
 
+// Rewrites the accounts and actions saved with float money values
+func (rs *RedisStorage) MigrateDecimalValues() (migrated int, err error) {
+	return rs.migrateValues([]string{ACCOUNT_PREFIX, ACTION_PREFIX}, migrateDecimalValues)
+}
+
+// Rewrites the accounts saved with the AllowNegative flag
+func (rs *RedisStorage) MigrateCreditLimits() (migrated int, err error) {
+	return rs.migrateValues([]string{ACCOUNT_PREFIX}, migrateCreditLimits)
+}
+
+// Rebuilds the index of the accounts with expiring triggers, returns the number of indexed accounts
+func (rs *RedisStorage) ReindexExpiringTriggers() (indexed int, err error) {
+	indexKeys, err := rs.db.Keys(EXPIRING_TRIGGERS_PREFIX + "*")
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range indexKeys {
+		if _, err = rs.db.Del(key); err != nil {
+			return 0, err
+		}
+	}
+	keys, err := rs.db.Keys(ACCOUNT_PREFIX + "*")
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range keys {
+		ub, err := rs.GetAccount(key[len(ACCOUNT_PREFIX):])
+		if err != nil {
+			return indexed, fmt.Errorf("reindexing %s: %s", key, err.Error())
+		}
+		if !ub.hasExpiringTriggers() {
+			continue
+		}
+		if err = rs.db.Set(EXPIRING_TRIGGERS_PREFIX+ub.Id, []byte{}); err != nil {
+			return indexed, err
+		}
+		indexed++
+	}
+	return
+}
+
+func (rs *RedisStorage) migrateValues(prefixes []string, migrate func(Marshaler, string, []byte) ([]byte, bool, error)) (migrated int, err error) {
+	for _, prefix := range prefixes {
+		keys, err := rs.db.Keys(prefix + "*")
+		if err != nil {
+			return migrated, err
+		}
+		for _, key := range keys {
+			values, err := rs.db.Get(key)
+			if err != nil {
+				return migrated, err
+			}
+			result, changed, err := migrate(rs.ms, key, values)
+			if err != nil {
+				return migrated, fmt.Errorf("migrating %s: %s", key, err.Error())
+			}
+			if !changed {
+				continue
+			}
+			if err = rs.db.Set(key, result); err != nil {
+				return migrated, err
+			}
+			migrated++
+		}
+	}
+	return
+}
+
 func (rs *RedisStorage) GetDerivedChargers(key string, skipCache bool) (dcs utils.DerivedChargers, err error) {
 	key = DERIVEDCHARGERS_PREFIX + key
 	if !skipCache {
@@ -707,6 +889,97 @@ func (rs *RedisStorage) SetDerivedChargers(key string, dcs utils.DerivedChargers
 	return err
 }
 
+func (rs *RedisStorage) GetExchangeRate(key string, skipCache bool) (exr *ExchangeRate, err error) {
+	key = EXCHANGE_RATE_PREFIX + key
+	if !skipCache {
+		if x, err := cache2go.GetCached(key); err == nil {
+			return x.(*ExchangeRate), nil
+		} else {
+			return nil, err
+		}
+	}
+	var values []byte
+	if values, err = rs.db.Get(key); err == nil {
+		err = rs.ms.Unmarshal(values, &exr)
+		cache2go.Cache(key, exr)
+	}
+	return
+}
+
+func (rs *RedisStorage) SetExchangeRate(exr *ExchangeRate) (err error) {
+	result, err := rs.ms.Marshal(exr)
+	err = rs.db.Set(EXCHANGE_RATE_PREFIX+exr.GetId(), result)
+	return
+}
+
+func (rs *RedisStorage) GetTaxes(key string, skipCache bool) (taxes *Taxes, err error) {
+	key = TAXES_PREFIX + key
+	if !skipCache {
+		if x, err := cache2go.GetCached(key); err == nil {
+			return x.(*Taxes), nil
+		} else {
+			return nil, err
+		}
+	}
+	var values []byte
+	if values, err = rs.db.Get(key); err == nil {
+		err = rs.ms.Unmarshal(values, &taxes)
+		cache2go.Cache(key, taxes)
+	}
+	return
+}
+
+func (rs *RedisStorage) SetTaxes(taxes *Taxes) (err error) {
+	result, err := rs.ms.Marshal(taxes)
+	err = rs.db.Set(TAXES_PREFIX+taxes.GetId(), result)
+	return
+}
+
+func (rs *RedisStorage) GetHolidayCalendar(key string, skipCache bool) (hc *HolidayCalendar, err error) {
+	key = HOLIDAY_CALENDAR_PREFIX + key
+	if !skipCache {
+		if x, err := cache2go.GetCached(key); err == nil {
+			return x.(*HolidayCalendar), nil
+		} else {
+			return nil, err
+		}
+	}
+	var values []byte
+	if values, err = rs.db.Get(key); err == nil {
+		err = rs.ms.Unmarshal(values, &hc)
+		cache2go.Cache(key, hc)
+	}
+	return
+}
+
+func (rs *RedisStorage) SetHolidayCalendar(hc *HolidayCalendar) (err error) {
+	result, err := rs.ms.Marshal(hc)
+	err = rs.db.Set(HOLIDAY_CALENDAR_PREFIX+hc.Id, result)
+	return
+}
+
+func (rs *RedisStorage) GetRoutingNumber(number string, skipCache bool) (routingNumber string, err error) {
+	key := NUMBER_PORTABILITY_PREFIX + number
+	if !skipCache {
+		if x, err := cache2go.GetCached(key); err == nil {
+			return x.(string), nil
+		} else {
+			return "", err
+		}
+	}
+	var values []byte
+	if values, err = rs.db.Get(key); err == nil {
+		routingNumber = string(values)
+		cache2go.Cache(key, routingNumber)
+	}
+	return
+}
+
+func (rs *RedisStorage) SetRoutingNumber(number, routingNumber string) (err error) {
+	err = rs.db.Set(NUMBER_PORTABILITY_PREFIX+number, []byte(routingNumber))
+	return
+}
+
 func (rs *RedisStorage) SetCdrStats(cs *CdrStats) error {
 	marshaled, err := rs.ms.Marshal(cs)
 	err = rs.db.Set(CDR_STATS_PREFIX+cs.Id, marshaled)
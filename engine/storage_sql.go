@@ -163,7 +163,8 @@ func (self *SQLStorage) RemTpData(table, tpid string, args ...string) error {
 	tx := self.db.Begin()
 	if len(table) == 0 { // Remove tpid out of all tables
 		for _, tblName := range []string{utils.TBL_TP_TIMINGS, utils.TBL_TP_DESTINATIONS, utils.TBL_TP_RATES, utils.TBL_TP_DESTINATION_RATES, utils.TBL_TP_RATING_PLANS, utils.TBL_TP_RATE_PROFILES,
-			utils.TBL_TP_SHARED_GROUPS, utils.TBL_TP_CDR_STATS, utils.TBL_TP_LCRS, utils.TBL_TP_ACTIONS, utils.TBL_TP_ACTION_PLANS, utils.TBL_TP_ACTION_TRIGGERS, utils.TBL_TP_ACCOUNT_ACTIONS, utils.TBL_TP_DERIVED_CHARGERS} {
+			utils.TBL_TP_SHARED_GROUPS, utils.TBL_TP_CDR_STATS, utils.TBL_TP_LCRS, utils.TBL_TP_ACTIONS, utils.TBL_TP_ACTION_PLANS, utils.TBL_TP_ACTION_TRIGGERS, utils.TBL_TP_ACCOUNT_ACTIONS, utils.TBL_TP_DERIVED_CHARGERS,
+			utils.TBL_TP_EXCHANGE_RATES, utils.TBL_TP_TAXES, utils.TBL_TP_HOLIDAYS, utils.TBL_TP_NUMBER_PORTABILITY} {
 			if err := tx.Table(tblName).Where("tpid = ?", tpid).Delete(nil).Error; err != nil {
 				tx.Rollback()
 				return err
@@ -183,6 +184,10 @@ func (self *SQLStorage) RemTpData(table, tpid string, args ...string) error {
 		tx = tx.Where("loadid = ?", args[0]).Where("direction = ?", args[1]).Where("tenant = ?", args[2]).Where("account = ?", args[3])
 	case utils.TBL_TP_DERIVED_CHARGERS:
 		tx = tx.Where("loadid = ?", args[0]).Where("direction = ?", args[1]).Where("tenant = ?", args[2]).Where("category = ?", args[3]).Where("account = ?", args[4]).Where("subject = ?", args[5])
+	case utils.TBL_TP_TAXES:
+		tx = tx.Where("tenant = ?", args[0]).Where("category = ?", args[1])
+	case utils.TBL_TP_NUMBER_PORTABILITY:
+		tx = tx.Where("number = ?", args[0])
 	}
 	if err := tx.Delete(nil).Error; err != nil {
 		tx.Rollback()
@@ -370,6 +375,104 @@ func (self *SQLStorage) SetTpSharedGroups(sgs []TpSharedGroup) error {
 	return nil
 }
 
+func (self *SQLStorage) SetTpExchangeRates(exrs []TpExchangeRate) error {
+	if len(exrs) == 0 {
+		return nil //Nothing to set
+	}
+	m := make(map[string]bool)
+
+	tx := self.db.Begin()
+	for _, exr := range exrs {
+		if found, _ := m[exr.Tag]; !found {
+			m[exr.Tag] = true
+			if err := tx.Where(&TpExchangeRate{Tpid: exr.Tpid, Tag: exr.Tag}).Delete(TpExchangeRate{}).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		saved := tx.Save(&exr)
+		if saved.Error != nil {
+			tx.Rollback()
+			return saved.Error
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+func (self *SQLStorage) SetTpTaxes(taxes []TpTax) error {
+	if len(taxes) == 0 {
+		return nil //Nothing to set
+	}
+	m := make(map[string]bool)
+
+	tx := self.db.Begin()
+	for _, tax := range taxes {
+		if found, _ := m[tax.GetTaxesId()]; !found {
+			m[tax.GetTaxesId()] = true
+			if err := tx.Where(&TpTax{Tpid: tax.Tpid, Tenant: tax.Tenant, Category: tax.Category}).Delete(TpTax{}).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		if tax.TaxId == "" { // empty set, only clears the previous definitions
+			continue
+		}
+		if err := tx.Save(&tax).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+func (self *SQLStorage) SetTpHolidays(holidays []TpHoliday) error {
+	if len(holidays) == 0 {
+		return nil //Nothing to set
+	}
+	m := make(map[string]bool)
+
+	tx := self.db.Begin()
+	for _, holiday := range holidays {
+		if found, _ := m[holiday.Tag]; !found {
+			m[holiday.Tag] = true
+			if err := tx.Where(&TpHoliday{Tpid: holiday.Tpid, Tag: holiday.Tag}).Delete(TpHoliday{}).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		if holiday.Date == "" { // empty calendar, only clears the previous definitions
+			continue
+		}
+		if err := tx.Save(&holiday).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+func (self *SQLStorage) SetTpNumberPortability(portedNumbers []TpNumberPortability) error {
+	if len(portedNumbers) == 0 {
+		return nil //Nothing to set
+	}
+	tx := self.db.Begin()
+	for _, portedNumber := range portedNumbers {
+		if err := tx.Where(&TpNumberPortability{Tpid: portedNumber.Tpid, Number: portedNumber.Number}).Delete(TpNumberPortability{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Save(&portedNumber).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
 func (self *SQLStorage) SetTpCdrStats(css []TpCdrstat) error {
 	if len(css) == 0 {
 		return nil //Nothing to set
@@ -620,6 +723,7 @@ func (self *SQLStorage) SetCdr(cdr *StoredCdr) error {
 		Pdd:             cdr.Pdd.Seconds(),
 		Supplier:        cdr.Supplier,
 		DisconnectCause: cdr.DisconnectCause,
+		Origin:          cdr.Origin,
 		CreatedAt:       time.Now()})
 	if saved.Error != nil {
 		tx.Rollback()
@@ -643,16 +747,16 @@ func (self *SQLStorage) GetStoredCdrs(qryFltr *utils.CdrsFilter) ([]*StoredCdr,
 	// Select string
 	var selectStr string
 	if qryFltr.FilterOnRated { // We use different tables to query account data in case of derived
-		selectStr = fmt.Sprintf("%s.cgrid,%s.id,%s.tor,%s.accid,%s.cdrhost,%s.cdrsource,%s.reqtype,%s.direction,%s.tenant,%s.category,%s.account,%s.subject,%s.destination,%s.setup_time,%s.answer_time,%s.usage,%s.pdd,%s.supplier,%s.disconnect_cause,%s.extra_fields,%s.runid,%s.cost,%s.tor,%s.direction,%s.tenant,%s.category,%s.account,%s.subject,%s.destination,%s.cost,%s.timespans",
+		selectStr = fmt.Sprintf("%s.cgrid,%s.id,%s.tor,%s.accid,%s.cdrhost,%s.cdrsource,%s.reqtype,%s.direction,%s.tenant,%s.category,%s.account,%s.subject,%s.destination,%s.setup_time,%s.answer_time,%s.usage,%s.pdd,%s.supplier,%s.disconnect_cause,%s.origin,%s.extra_fields,%s.runid,%s.cost,%s.taxes,%s.routing_number,%s.tor,%s.direction,%s.tenant,%s.category,%s.account,%s.subject,%s.destination,%s.cost,%s.timespans",
 			utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS,
-			utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS,
-			utils.TBL_CDRS_EXTRA, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS,
+			utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS,
+			utils.TBL_CDRS_EXTRA, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS,
 			utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS)
 	} else {
-		selectStr = fmt.Sprintf("%s.cgrid,%s.id,%s.tor,%s.accid,%s.cdrhost,%s.cdrsource,%s.reqtype,%s.direction,%s.tenant,%s.category,%s.account,%s.subject,%s.destination,%s.setup_time,%s.answer_time,%s.usage,%s.pdd,%s.supplier,%s.disconnect_cause,%s.extra_fields,%s.runid,%s.cost,%s.tor,%s.direction,%s.tenant,%s.category,%s.account,%s.subject,%s.destination,%s.cost,%s.timespans",
+		selectStr = fmt.Sprintf("%s.cgrid,%s.id,%s.tor,%s.accid,%s.cdrhost,%s.cdrsource,%s.reqtype,%s.direction,%s.tenant,%s.category,%s.account,%s.subject,%s.destination,%s.setup_time,%s.answer_time,%s.usage,%s.pdd,%s.supplier,%s.disconnect_cause,%s.origin,%s.extra_fields,%s.runid,%s.cost,%s.taxes,%s.routing_number,%s.tor,%s.direction,%s.tenant,%s.category,%s.account,%s.subject,%s.destination,%s.cost,%s.timespans",
 			utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY,
-			utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY,
-			utils.TBL_CDRS_EXTRA, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS,
+			utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY, utils.TBL_CDRS_PRIMARY,
+			utils.TBL_CDRS_EXTRA, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_RATED_CDRS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS,
 			utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS, utils.TBL_COST_DETAILS)
 
 	}
@@ -1014,16 +1118,18 @@ func (self *SQLStorage) GetStoredCdrs(qryFltr *utils.CdrsFilter) ([]*StoredCdr,
 	}
 	for rows.Next() {
 		var cgrid, tor, accid, cdrhost, cdrsrc, reqtype, direction, tenant, category, account, subject, destination, runid, ccTor,
-			ccDirection, ccTenant, ccCategory, ccAccount, ccSubject, ccDestination, ccSupplier, ccDisconnectCause sql.NullString
-		var extraFields, ccTimespansBytes []byte
+			ccDirection, ccTenant, ccCategory, ccAccount, ccSubject, ccDestination, ccSupplier, ccDisconnectCause, origin, routingNumber sql.NullString
+		var extraFields, taxesBytes, ccTimespansBytes []byte
 		var setupTime, answerTime mysql.NullTime
 		var orderid int64
-		var usage, pdd, cost, ccCost sql.NullFloat64
+		var usage, pdd sql.NullFloat64
+		var cost, ccCost sql.NullString // decimal columns, parsed without passing through float
 		var extraFieldsMp map[string]string
 		var ccTimespans TimeSpans
+		var taxes TaxLines
 		if err := rows.Scan(&cgrid, &orderid, &tor, &accid, &cdrhost, &cdrsrc, &reqtype, &direction, &tenant, &category, &account, &subject, &destination,
-			&setupTime, &answerTime, &usage, &pdd, &ccSupplier, &ccDisconnectCause,
-			&extraFields, &runid, &cost, &ccTor, &ccDirection, &ccTenant, &ccCategory, &ccAccount, &ccSubject, &ccDestination, &ccCost, &ccTimespansBytes); err != nil {
+			&setupTime, &answerTime, &usage, &pdd, &ccSupplier, &ccDisconnectCause, &origin,
+			&extraFields, &runid, &cost, &taxesBytes, &routingNumber, &ccTor, &ccDirection, &ccTenant, &ccCategory, &ccAccount, &ccSubject, &ccDestination, &ccCost, &ccTimespansBytes); err != nil {
 			return nil, 0, err
 		}
 		if len(extraFields) != 0 {
@@ -1036,21 +1142,28 @@ func (self *SQLStorage) GetStoredCdrs(qryFltr *utils.CdrsFilter) ([]*StoredCdr,
 				return nil, 0, fmt.Errorf("JSON unmarshal callcost error for cgrid: %s, runid: %v, error: %s", cgrid.String, runid.String, err.Error())
 			}
 		}
+		if len(taxesBytes) != 0 {
+			if err := json.Unmarshal(taxesBytes, &taxes); err != nil {
+				return nil, 0, fmt.Errorf("JSON unmarshal taxes error for cgrid: %s, runid: %v, error: %s", cgrid.String, runid.String, err.Error())
+			}
+		}
 		usageDur, _ := time.ParseDuration(strconv.FormatFloat(usage.Float64, 'f', -1, 64) + "s")
 		pddDur, _ := time.ParseDuration(strconv.FormatFloat(pdd.Float64, 'f', -1, 64) + "s")
+		cdrCost, _ := utils.NewDecimalFromString(cost.String)
 		storCdr := &StoredCdr{
 			CgrId: cgrid.String, OrderId: orderid, TOR: tor.String, AccId: accid.String, CdrHost: cdrhost.String, CdrSource: cdrsrc.String, ReqType: reqtype.String,
 			Direction: direction.String, Tenant: tenant.String,
 			Category: category.String, Account: account.String, Subject: subject.String, Destination: destination.String,
-			SetupTime: setupTime.Time, AnswerTime: answerTime.Time, Usage: usageDur, Pdd: pddDur, Supplier: ccSupplier.String, DisconnectCause: ccDisconnectCause.String,
-			ExtraFields: extraFieldsMp, MediationRunId: runid.String, RatedAccount: ccAccount.String, RatedSubject: ccSubject.String, Cost: cost.Float64,
+			SetupTime: setupTime.Time, AnswerTime: answerTime.Time, Usage: usageDur, Pdd: pddDur, Supplier: ccSupplier.String, DisconnectCause: ccDisconnectCause.String, Origin: origin.String,
+			ExtraFields: extraFieldsMp, MediationRunId: runid.String, RatedAccount: ccAccount.String, RatedSubject: ccSubject.String, Cost: cdrCost, Taxes: taxes, RoutingNumber: routingNumber.String,
 		}
 		if ccTimespans != nil {
+			ccCostDec, _ := utils.NewDecimalFromString(ccCost.String)
 			storCdr.CostDetails = &CallCost{Direction: ccDirection.String, Category: ccCategory.String, Tenant: ccTenant.String, Subject: ccSubject.String, Account: ccAccount.String, Destination: ccDestination.String, TOR: ccTor.String,
-				Cost: ccCost.Float64, Timespans: ccTimespans}
+				Cost: ccCostDec, Timespans: ccTimespans}
 		}
 		if !cost.Valid { //There was no cost provided, will fakely insert 0 if we do not handle it and reflect on re-rating
-			storCdr.Cost = -1
+			storCdr.Cost = utils.NewDecimalFromInt(-1)
 		}
 		cdrs = append(cdrs, storCdr)
 	}
@@ -1081,6 +1194,124 @@ func (self *SQLStorage) RemStoredCdrs(cgrIds []string) error {
 	return nil
 }
 
+func (self *SQLStorage) SetLedgerEntries(entries []*LedgerEntry) error {
+	tx := self.db.Begin()
+	for _, entry := range entries {
+		if err := tx.Save(&TblAccountLedger{
+			Account:     entry.AccountId,
+			BalanceType: entry.BalanceType,
+			BalanceUuid: entry.BalanceUuid,
+			BalanceId:   entry.BalanceId,
+			Delta:       entry.Delta,
+			Value:       entry.Value,
+			Cause:       entry.Cause,
+			CauseId:     entry.CauseId,
+			CreatedAt:   entry.Time}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// Returns the ledger entries in the order they were recorded
+func (self *SQLStorage) GetLedgerEntries(qryFltr *utils.LedgerFilter) ([]*LedgerEntry, error) {
+	q := self.db.Table(utils.TBL_ACCOUNT_LEDGER)
+	if len(qryFltr.AccountIds) != 0 {
+		q = q.Where("account in (?)", qryFltr.AccountIds)
+	}
+	if len(qryFltr.BalanceUuids) != 0 {
+		q = q.Where("balance_uuid in (?)", qryFltr.BalanceUuids)
+	}
+	if len(qryFltr.Causes) != 0 {
+		q = q.Where("cause in (?)", qryFltr.Causes)
+	}
+	if len(qryFltr.CauseIds) != 0 {
+		q = q.Where("cause_id in (?)", qryFltr.CauseIds)
+	}
+	if qryFltr.TimeStart != nil {
+		q = q.Where("created_at >= ?", qryFltr.TimeStart)
+	}
+	if qryFltr.TimeEnd != nil {
+		q = q.Where("created_at < ?", qryFltr.TimeEnd)
+	}
+	if qryFltr.Paginator.Limit != nil {
+		q = q.Limit(*qryFltr.Paginator.Limit)
+	}
+	if qryFltr.Paginator.Offset != nil {
+		q = q.Offset(*qryFltr.Paginator.Offset)
+	}
+	var tblEntries []TblAccountLedger
+	if err := q.Order("id").Find(&tblEntries).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]*LedgerEntry, len(tblEntries))
+	for idx, tblEntry := range tblEntries {
+		entries[idx] = &LedgerEntry{
+			Id:          tblEntry.Id,
+			Time:        tblEntry.CreatedAt,
+			AccountId:   tblEntry.Account,
+			BalanceType: tblEntry.BalanceType,
+			BalanceUuid: tblEntry.BalanceUuid,
+			BalanceId:   tblEntry.BalanceId,
+			Delta:       tblEntry.Delta,
+			Value:       tblEntry.Value,
+			Cause:       tblEntry.Cause,
+			CauseId:     tblEntry.CauseId,
+		}
+	}
+	return entries, nil
+}
+
+// Stores the accounts under the snapshot id, replacing the ones already there
+func (self *SQLStorage) SetAccountSnapshot(snapshotId string, accounts []*Account) error {
+	tx := self.db.Begin()
+	now := time.Now()
+	for _, acc := range accounts {
+		content, err := json.Marshal(acc)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Where(&TblAccountSnapshot{SnapshotId: snapshotId, Account: acc.Id}).Delete(TblAccountSnapshot{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Save(&TblAccountSnapshot{
+			SnapshotId: snapshotId,
+			Account:    acc.Id,
+			Content:    string(content),
+			CreatedAt:  now}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// Returns the accounts stored under the snapshot id, all of them if no account ids are given
+func (self *SQLStorage) GetAccountSnapshot(snapshotId string, accountIds []string) ([]*Account, error) {
+	q := self.db.Table(utils.TBL_ACCOUNT_SNAPSHOTS).Where("snapshot_id = ?", snapshotId)
+	if len(accountIds) != 0 {
+		q = q.Where("account in (?)", accountIds)
+	}
+	var tblSnapshots []TblAccountSnapshot
+	if err := q.Order("account").Find(&tblSnapshots).Error; err != nil {
+		return nil, err
+	}
+	if len(tblSnapshots) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	accounts := make([]*Account, len(tblSnapshots))
+	for idx, tblSnapshot := range tblSnapshots {
+		accounts[idx] = &Account{}
+		if err := json.Unmarshal([]byte(tblSnapshot.Content), accounts[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return accounts, nil
+}
+
 func (self *SQLStorage) GetTpDestinations(tpid, tag string) ([]TpDestination, error) {
 	var tpDests []TpDestination
 	q := self.db.Where("tpid = ?", tpid)
@@ -1198,6 +1429,57 @@ func (self *SQLStorage) GetTpSharedGroups(tpid, tag string) ([]TpSharedGroup, er
 
 }
 
+func (self *SQLStorage) GetTpExchangeRates(tpid, tag string) ([]TpExchangeRate, error) {
+	var tpExchangeRates []TpExchangeRate
+	q := self.db.Where("tpid = ?", tpid)
+	if len(tag) != 0 {
+		q = q.Where("tag = ?", tag)
+	}
+	if err := q.Find(&tpExchangeRates).Error; err != nil {
+		return nil, err
+	}
+	return tpExchangeRates, nil
+}
+
+func (self *SQLStorage) GetTpTaxes(filter *TpTax) ([]TpTax, error) {
+	var tpTaxes []TpTax
+	q := self.db.Where("tpid = ?", filter.Tpid)
+	if len(filter.Tenant) != 0 {
+		q = q.Where("tenant = ?", filter.Tenant)
+	}
+	if len(filter.Category) != 0 {
+		q = q.Where("category = ?", filter.Category)
+	}
+	if err := q.Find(&tpTaxes).Error; err != nil {
+		return nil, err
+	}
+	return tpTaxes, nil
+}
+
+func (self *SQLStorage) GetTpHolidays(tpid, tag string) ([]TpHoliday, error) {
+	var tpHolidays []TpHoliday
+	q := self.db.Where("tpid = ?", tpid).Order("id")
+	if len(tag) != 0 {
+		q = q.Where("tag = ?", tag)
+	}
+	if err := q.Find(&tpHolidays).Error; err != nil {
+		return nil, err
+	}
+	return tpHolidays, nil
+}
+
+func (self *SQLStorage) GetTpNumberPortability(tpid, number string) ([]TpNumberPortability, error) {
+	var tpPortedNumbers []TpNumberPortability
+	q := self.db.Where("tpid = ?", tpid).Order("id")
+	if len(number) != 0 {
+		q = q.Where("number = ?", number)
+	}
+	if err := q.Find(&tpPortedNumbers).Error; err != nil {
+		return nil, err
+	}
+	return tpPortedNumbers, nil
+}
+
 func (self *SQLStorage) GetTpLCRs(tpid, tag string) ([]TpLcrRule, error) {
 	var tpLcrRule []TpLcrRule
 	q := self.db.Where("tpid = ?", tpid)
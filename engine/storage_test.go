@@ -76,6 +76,106 @@ func TestMsgpackTime(t *testing.T) {
 	}
 }
 
+func TestMsgpackDecimal(t *testing.T) {
+	d1 := utils.NewDecimalFromFloat(0.1).Add(utils.NewDecimalFromFloat(0.2))
+	m := NewCodecMsgpackMarshaler()
+	buf, err := m.Marshal(&Balance{Value: d1})
+	if err != nil {
+		t.Error("error marshaling structure: ", err)
+	}
+	b := new(Balance)
+	if err = m.Unmarshal(buf, b); err != nil || !b.Value.Equal(d1) {
+		t.Errorf("error unmarshalling structure: %v %v", b.Value, err)
+	}
+}
+
+func TestMigrateDecimalValues(t *testing.T) {
+	type legacyBalance struct {
+		Uuid  string
+		Value float64
+	}
+	legacyAcnt := struct {
+		Id           string
+		BalanceMap   map[string][]*legacyBalance
+		UnitCounters []struct{ Balances []*legacyBalance }
+	}{
+		Id:           "cgrates.org:migrated",
+		BalanceMap:   map[string][]*legacyBalance{utils.MONETARY + OUTBOUND: []*legacyBalance{&legacyBalance{Uuid: "uuid1", Value: 10.25}}},
+		UnitCounters: []struct{ Balances []*legacyBalance }{{Balances: []*legacyBalance{&legacyBalance{Value: 1.5}}}},
+	}
+	m := NewCodecMsgpackMarshaler()
+	buf, err := m.Marshal(&legacyAcnt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, changed, err := migrateDecimalValues(m, ACCOUNT_PREFIX+legacyAcnt.Id, buf)
+	if err != nil || !changed {
+		t.Fatal("Account not migrated: ", changed, err)
+	}
+	acnt := new(Account)
+	if err = m.Unmarshal(result, acnt); err != nil {
+		t.Fatal(err)
+	}
+	if !acnt.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.Equal(utils.NewDecimalFromFloat(10.25)) ||
+		!acnt.UnitCounters[0].Balances[0].Value.Equal(utils.NewDecimalFromFloat(1.5)) {
+		t.Errorf("Wrong migrated values: %+v", acnt)
+	}
+	if _, changed, err = migrateDecimalValues(m, ACCOUNT_PREFIX+legacyAcnt.Id, result); err != nil || changed {
+		t.Error("Account migrated twice: ", changed, err)
+	}
+	legacyActs := []struct{ Balance *legacyBalance }{{Balance: &legacyBalance{Value: -0.3}}, {}}
+	if buf, err = m.Marshal(&legacyActs); err != nil {
+		t.Fatal(err)
+	}
+	if result, changed, err = migrateDecimalValues(m, ACTION_PREFIX+"MIGRATED", buf); err != nil || !changed {
+		t.Fatal("Actions not migrated: ", changed, err)
+	}
+	var acts Actions
+	if err = m.Unmarshal(result, &acts); err != nil {
+		t.Fatal(err)
+	}
+	if len(acts) != 2 || !acts[0].Balance.Value.Equal(utils.NewDecimalFromFloat(-0.3)) || acts[1].Balance != nil {
+		t.Errorf("Wrong migrated actions: %+v", acts)
+	}
+}
+
+func TestMigrateCreditLimits(t *testing.T) {
+	legacyAcnt := struct {
+		Id            string
+		AllowNegative bool
+	}{Id: "*in:cgrates.org:postpaid", AllowNegative: true}
+	m := NewCodecMsgpackMarshaler()
+	buf, err := m.Marshal(&legacyAcnt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, changed, err := migrateCreditLimits(m, ACCOUNT_PREFIX+legacyAcnt.Id, buf)
+	if err != nil || !changed {
+		t.Fatal("Account not migrated: ", changed, err)
+	}
+	acnt := new(Account)
+	if err = m.Unmarshal(result, acnt); err != nil {
+		t.Fatal(err)
+	}
+	if !acnt.allowsNegative(INBOUND) || !acnt.allowsNegative(OUTBOUND) {
+		t.Errorf("Wrong migrated credit limits: %+v", acnt.CreditLimits)
+	}
+	if _, changed, err = migrateCreditLimits(m, ACCOUNT_PREFIX+legacyAcnt.Id, result); err != nil || changed {
+		t.Error("Account migrated twice: ", changed, err)
+	}
+	legacyAcnt.AllowNegative = false
+	if buf, err = m.Marshal(&legacyAcnt); err != nil {
+		t.Fatal(err)
+	}
+	if result, changed, err = migrateCreditLimits(m, ACCOUNT_PREFIX+legacyAcnt.Id, buf); err != nil || !changed {
+		t.Fatal("Legacy flag not removed: ", changed, err)
+	}
+	acnt = new(Account)
+	if err = m.Unmarshal(result, acnt); err != nil || len(acnt.CreditLimits) != 0 {
+		t.Errorf("Unexpected credit limits: %+v, %v", acnt.CreditLimits, err)
+	}
+}
+
 func TestStorageDestinationContainsPrefixShort(t *testing.T) {
 	dest, err := ratingStorage.GetDestination("NAT")
 	precision := dest.containsPrefix("0723")
@@ -105,7 +205,7 @@ func TestCacheRefresh(t *testing.T) {
 	ratingStorage.GetDestination("T11")
 	ratingStorage.SetDestination(&Destination{"T11", []string{"1"}})
 	t.Log("Test cache refresh")
-	ratingStorage.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingStorage.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	d, err := ratingStorage.GetDestination("T11")
 	p := d.containsPrefix("1")
 	if err != nil || p == 0 {
@@ -206,7 +306,7 @@ func GetUB() *Account {
 	uc := &UnitsCounter{
 		Direction:   OUTBOUND,
 		BalanceType: utils.SMS,
-		Balances:    BalanceChain{&Balance{Value: 1}, &Balance{Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}},
+		Balances:    BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}, &Balance{Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}},
 	}
 	at := &ActionTrigger{
 		Id:                    "some_uuid",
@@ -221,8 +321,8 @@ func GetUB() *Account {
 	zeroTime = zeroTime.UTC() // for deep equal to find location
 	ub := &Account{
 		Id:             "rif",
-		AllowNegative:  true,
-		BalanceMap:     map[string]BalanceChain{utils.SMS + OUTBOUND: BalanceChain{&Balance{Value: 14, ExpirationDate: zeroTime}}, utils.DATA + OUTBOUND: BalanceChain{&Balance{Value: 1024, ExpirationDate: zeroTime}}, utils.VOICE: BalanceChain{&Balance{Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
+		CreditLimits:   map[string]*CreditLimit{OUTBOUND: &CreditLimit{Unlimited: true}},
+		BalanceMap:     map[string]BalanceChain{utils.SMS + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(14), ExpirationDate: zeroTime}}, utils.DATA + OUTBOUND: BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1024), ExpirationDate: zeroTime}}, utils.VOICE: BalanceChain{&Balance{Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}}},
 		UnitCounters:   []*UnitsCounter{uc, uc},
 		ActionTriggers: ActionTriggerPriotityList{at, at, at},
 	}
@@ -21,6 +21,7 @@ package engine
 import (
 	"errors"
 	"strconv"
+	"strings"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -140,3 +141,100 @@ func ConfigureCdrStorage(db_type, host, port, name, user, pass string, maxConn,
 	}
 	return d.(CdrStorage), nil
 }
+
+// Converts the money values of accounts and actions stored as floats (before utils.Decimal) into decimal strings.
+// Works on the generic decoded form so it does not depend on the previous structures.
+// Returns the new encoding and false if there was nothing to migrate.
+func migrateDecimalValues(ms Marshaler, key string, values []byte) ([]byte, bool, error) {
+	switch ms.(type) {
+	case *JSONMarshaler, *JSONBufMarshaler: // json numbers decode directly into utils.Decimal
+		return nil, false, nil
+	}
+	var data interface{}
+	if err := ms.Unmarshal(values, &data); err != nil {
+		return nil, false, err
+	}
+	changed := false
+	switch {
+	case strings.HasPrefix(key, ACCOUNT_PREFIX):
+		acnt, _ := data.(map[string]interface{})
+		if balanceMap, ok := acnt["BalanceMap"].(map[string]interface{}); ok {
+			for _, chain := range balanceMap {
+				changed = migrateDecimalField(chain, "Value") || changed
+			}
+		}
+		if counters, ok := acnt["UnitCounters"].([]interface{}); ok {
+			for _, uc := range counters {
+				if ucMap, ok := uc.(map[string]interface{}); ok {
+					changed = migrateDecimalField(ucMap["Balances"], "Value") || changed
+				}
+			}
+		}
+	case strings.HasPrefix(key, ACTION_PREFIX):
+		if actions, ok := data.([]interface{}); ok {
+			for _, a := range actions {
+				if aMap, ok := a.(map[string]interface{}); ok {
+					changed = migrateDecimalField([]interface{}{aMap["Balance"]}, "Value") || changed
+				}
+			}
+		}
+	}
+	if !changed {
+		return nil, false, nil
+	}
+	result, err := ms.Marshal(data)
+	return result, true, err
+}
+
+// Converts the AllowNegative flag of the accounts saved before the credit limits into an unlimited credit limit
+// on every direction, as the flag applied to all of them. Returns the new encoding and false if there was nothing to migrate.
+func migrateCreditLimits(ms Marshaler, key string, values []byte) ([]byte, bool, error) {
+	if !strings.HasPrefix(key, ACCOUNT_PREFIX) {
+		return nil, false, nil
+	}
+	var data interface{}
+	if err := ms.Unmarshal(values, &data); err != nil {
+		return nil, false, err
+	}
+	acnt, _ := data.(map[string]interface{})
+	allowNegative, found := acnt["AllowNegative"]
+	if !found {
+		return nil, false, nil
+	}
+	delete(acnt, "AllowNegative")
+	if allow, _ := allowNegative.(bool); allow && acnt["CreditLimits"] == nil {
+		acnt["CreditLimits"] = map[string]interface{}{
+			OUTBOUND: map[string]interface{}{"Unlimited": true},
+			INBOUND:  map[string]interface{}{"Unlimited": true},
+		}
+	}
+	result, err := ms.Marshal(data)
+	return result, true, err
+}
+
+// replaces the numeric field of every map in the list with its decimal string
+func migrateDecimalField(list interface{}, field string) (changed bool) {
+	items, _ := list.([]interface{})
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var value float64
+		switch v := itemMap[field].(type) {
+		case float64:
+			value = v
+		case float32:
+			value = float64(v)
+		case int64:
+			value = float64(v)
+		case uint64:
+			value = float64(v)
+		default:
+			continue
+		}
+		itemMap[field] = utils.NewDecimalFromFloat(value).String()
+		changed = true
+	}
+	return
+}
@@ -33,7 +33,7 @@ func NewStoredCdrFromExternalCdr(extCdr *ExternalCdr) (*StoredCdr, error) {
 	var err error
 	storedCdr := &StoredCdr{CgrId: extCdr.CgrId, OrderId: extCdr.OrderId, TOR: extCdr.TOR, AccId: extCdr.AccId, CdrHost: extCdr.CdrHost, CdrSource: extCdr.CdrSource,
 		ReqType: extCdr.ReqType, Direction: extCdr.Direction, Tenant: extCdr.Tenant, Category: extCdr.Category, Account: extCdr.Account, Subject: extCdr.Subject,
-		Destination: extCdr.Destination, Supplier: extCdr.Supplier, DisconnectCause: extCdr.DisconnectCause, ExtraFields: extCdr.ExtraFields,
+		Destination: extCdr.Destination, RoutingNumber: extCdr.RoutingNumber, Origin: extCdr.Origin, Supplier: extCdr.Supplier, DisconnectCause: extCdr.DisconnectCause, ExtraFields: extCdr.ExtraFields,
 		MediationRunId: extCdr.MediationRunId, RatedAccount: extCdr.RatedAccount, RatedSubject: extCdr.RatedSubject, Cost: extCdr.Cost, Rated: extCdr.Rated}
 	if storedCdr.SetupTime, err = utils.ParseTimeDetectLayout(extCdr.SetupTime); err != nil {
 		return nil, err
@@ -52,6 +52,11 @@ func NewStoredCdrFromExternalCdr(extCdr *ExternalCdr) (*StoredCdr, error) {
 			return nil, err
 		}
 	}
+	if len(extCdr.Taxes) != 0 {
+		if err = json.Unmarshal([]byte(extCdr.Taxes), &storedCdr.Taxes); err != nil {
+			return nil, err
+		}
+	}
 	return storedCdr, nil
 }
 
@@ -70,6 +75,8 @@ type StoredCdr struct {
 	Account         string            // account id (accounting subsystem) the record should be attached to
 	Subject         string            // rating subject (rating subsystem) this record should be attached to
 	Destination     string            // destination to be charged
+	RoutingNumber   string            // number the destination was rated on when ported to another network
+	Origin          string            // A-number or location of the caller, used in the origin based rating
 	SetupTime       time.Time         // set-up time of the event. Supported formats: datetime RFC3339 compatible, SQL datetime (eg: MySQL), unix timestamp.
 	Pdd             time.Duration     // PDD value
 	AnswerTime      time.Time         // answer time of the event. Supported formats: datetime RFC3339 compatible, SQL datetime (eg: MySQL), unix timestamp.
@@ -80,9 +87,10 @@ type StoredCdr struct {
 	MediationRunId  string
 	RatedAccount    string // Populated out of rating data
 	RatedSubject    string
-	Cost            float64
+	Cost            utils.Decimal
 	ExtraInfo       string    // Container for extra information related to this CDR, eg: populated with error reason in case of error on calculation
 	CostDetails     *CallCost // Attach the cost details to CDR when possible
+	Taxes           TaxLines  // Taxes computed on top of the cost
 	Rated           bool      // Mark the CDR as rated so we do not process it during mediation
 }
 
@@ -94,6 +102,14 @@ func (storedCdr *StoredCdr) CostDetailsJson() string {
 	return string(mrshled)
 }
 
+func (storedCdr *StoredCdr) TaxesJson() string {
+	if len(storedCdr.Taxes) == 0 {
+		return ""
+	}
+	mrshled, _ := json.Marshal(storedCdr.Taxes)
+	return string(mrshled)
+}
+
 // Used to multiply usage on export
 func (storedCdr *StoredCdr) UsageMultiply(multiplyFactor float64, roundDecimals int) {
 	storedCdr.Usage = time.Duration(int(utils.Round(float64(storedCdr.Usage.Nanoseconds())*multiplyFactor, roundDecimals, utils.ROUNDING_MIDDLE))) // Rounding down could introduce a slight loss here but only at nanoseconds level
@@ -101,16 +117,25 @@ func (storedCdr *StoredCdr) UsageMultiply(multiplyFactor float64, roundDecimals
 
 // Used to multiply cost on export
 func (storedCdr *StoredCdr) CostMultiply(multiplyFactor float64, roundDecimals int) {
-	storedCdr.Cost = utils.Round(storedCdr.Cost*multiplyFactor, roundDecimals, utils.ROUNDING_MIDDLE)
+	storedCdr.Cost = storedCdr.Cost.Mul(utils.NewDecimalFromFloat(multiplyFactor)).Round(roundDecimals, utils.ROUNDING_MIDDLE)
 }
 
 // Format cost as string on export
 func (storedCdr *StoredCdr) FormatCost(shiftDecimals, roundDecimals int) string {
 	cost := storedCdr.Cost
 	if shiftDecimals != 0 {
-		cost = cost * math.Pow10(shiftDecimals)
+		cost = cost.Mul(utils.NewDecimalFromFloat(math.Pow10(shiftDecimals)))
 	}
-	return strconv.FormatFloat(cost, 'f', roundDecimals, 64)
+	return cost.StringFixed(roundDecimals)
+}
+
+// Format the sum of the taxes as string on export
+func (storedCdr *StoredCdr) FormatTaxCost(shiftDecimals, roundDecimals int) string {
+	taxCost := storedCdr.Taxes.GetTotal()
+	if shiftDecimals != 0 {
+		taxCost = taxCost.Mul(utils.NewDecimalFromFloat(math.Pow10(shiftDecimals)))
+	}
+	return taxCost.StringFixed(roundDecimals)
 }
 
 // Formats usage on export
@@ -172,9 +197,17 @@ func (storedCdr *StoredCdr) FieldAsString(rsrFld *utils.RSRField) string {
 	case utils.RATED_SUBJECT:
 		return rsrFld.ParseValue(storedCdr.RatedSubject)
 	case utils.COST:
-		return rsrFld.ParseValue(strconv.FormatFloat(storedCdr.Cost, 'f', -1, 64)) // Recommended to use FormatCost
+		return rsrFld.ParseValue(storedCdr.Cost.String()) // Recommended to use FormatCost
 	case utils.COST_DETAILS:
 		return rsrFld.ParseValue(storedCdr.CostDetailsJson())
+	case utils.TAX_COST:
+		return rsrFld.ParseValue(storedCdr.Taxes.GetTotal().String()) // Recommended to use FormatTaxCost
+	case utils.TAXES:
+		return rsrFld.ParseValue(storedCdr.TaxesJson())
+	case utils.ROUTING_NUMBER:
+		return rsrFld.ParseValue(storedCdr.RoutingNumber)
+	case utils.ORIGIN:
+		return rsrFld.ParseValue(storedCdr.Origin)
 	default:
 		return rsrFld.ParseValue(storedCdr.ExtraFields[rsrFld.Id])
 	}
@@ -227,6 +260,7 @@ func (storedCdr *StoredCdr) AsHttpForm() url.Values {
 	v.Set(utils.USAGE, storedCdr.FormatUsage(utils.SECONDS))
 	v.Set(utils.SUPPLIER, storedCdr.Supplier)
 	v.Set(utils.DISCONNECT_CAUSE, storedCdr.DisconnectCause)
+	v.Set(utils.ORIGIN, storedCdr.Origin)
 	if storedCdr.CostDetails != nil {
 		v.Set(utils.COST_DETAILS, storedCdr.CostDetailsJson())
 	}
@@ -235,7 +269,7 @@ func (storedCdr *StoredCdr) AsHttpForm() url.Values {
 
 // Used in mediation, primaryMandatory marks whether missing field out of request represents error or can be ignored
 func (storedCdr *StoredCdr) ForkCdr(runId string, reqTypeFld, directionFld, tenantFld, categFld, accountFld, subjectFld, destFld, setupTimeFld, pddFld,
-	answerTimeFld, durationFld, supplierFld, disconnectCauseFld *utils.RSRField,
+	answerTimeFld, durationFld, supplierFld, disconnectCauseFld, originFld *utils.RSRField,
 	extraFlds []*utils.RSRField, primaryMandatory bool) (*StoredCdr, error) {
 	if reqTypeFld == nil {
 		reqTypeFld, _ = utils.NewRSRField(utils.META_DEFAULT)
@@ -315,12 +349,18 @@ func (storedCdr *StoredCdr) ForkCdr(runId string, reqTypeFld, directionFld, tena
 	if disconnectCauseFld.Id == utils.META_DEFAULT {
 		disconnectCauseFld.Id = utils.DISCONNECT_CAUSE
 	}
+	if originFld == nil {
+		originFld, _ = utils.NewRSRField(utils.META_DEFAULT)
+	}
+	if originFld.Id == utils.META_DEFAULT {
+		originFld.Id = utils.ORIGIN
+	}
 	var err error
 	frkStorCdr := new(StoredCdr)
 	frkStorCdr.CgrId = storedCdr.CgrId
 	frkStorCdr.TOR = storedCdr.TOR
 	frkStorCdr.MediationRunId = runId
-	frkStorCdr.Cost = -1.0 // Default for non-rated CDR
+	frkStorCdr.Cost = utils.NewDecimalFromInt(-1) // Default for non-rated CDR
 	frkStorCdr.AccId = storedCdr.AccId
 	frkStorCdr.CdrHost = storedCdr.CdrHost
 	frkStorCdr.CdrSource = storedCdr.CdrSource
@@ -378,6 +418,7 @@ func (storedCdr *StoredCdr) ForkCdr(runId string, reqTypeFld, directionFld, tena
 	}
 	frkStorCdr.Supplier = storedCdr.FieldAsString(supplierFld)
 	frkStorCdr.DisconnectCause = storedCdr.FieldAsString(disconnectCauseFld)
+	frkStorCdr.Origin = storedCdr.FieldAsString(originFld)
 	frkStorCdr.ExtraFields = make(map[string]string, len(extraFlds))
 	for _, fld := range extraFlds {
 		frkStorCdr.ExtraFields[fld.Id] = storedCdr.FieldAsString(fld)
@@ -411,6 +452,9 @@ func (storedCdr *StoredCdr) AsExternalCdr() *ExternalCdr {
 		RatedSubject:    storedCdr.RatedSubject,
 		Cost:            storedCdr.Cost,
 		CostDetails:     storedCdr.CostDetailsJson(),
+		Taxes:           storedCdr.TaxesJson(),
+		RoutingNumber:   storedCdr.RoutingNumber,
+		Origin:          storedCdr.Origin,
 	}
 }
 
@@ -568,6 +612,12 @@ func (storedCdr *StoredCdr) GetDisconnectCause(fieldName string) string {
 	}
 	return storedCdr.FieldAsString(&utils.RSRField{Id: fieldName})
 }
+func (storedCdr *StoredCdr) GetOrigin(fieldName string) string {
+	if utils.IsSliceMember([]string{utils.ORIGIN, utils.META_DEFAULT}, fieldName) {
+		return storedCdr.Origin
+	}
+	return storedCdr.FieldAsString(&utils.RSRField{Id: fieldName})
+}
 func (storedCdr *StoredCdr) GetOriginatorIP(fieldName string) string {
 	if utils.IsSliceMember([]string{utils.CDRHOST, utils.META_DEFAULT}, fieldName) {
 		return storedCdr.CdrHost
@@ -616,8 +666,11 @@ type ExternalCdr struct {
 	MediationRunId  string
 	RatedAccount    string
 	RatedSubject    string
-	Cost            float64
+	Cost            utils.Decimal
 	CostDetails     string
+	Taxes           string
+	RoutingNumber   string
+	Origin          string
 	Rated           bool // Mark the CDR as rated so we do not process it during mediation
 }
 
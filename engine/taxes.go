@@ -0,0 +1,172 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Tax rules applied on top of the costs for one tenant and category
+type Taxes struct {
+	Tenant   string
+	Category string
+	Rules    []*TaxRule
+}
+
+type TaxRule struct {
+	DestinationId string
+	TaxId         string        // eg: VAT, rules with the same TaxId exclude each other
+	Rate          utils.Decimal // percent out of the cost
+	Debit         bool          // the tax is debited from the account together with the cost
+	Weight        float64
+}
+
+// One tax computed for a cost
+type TaxLine struct {
+	TaxId  string
+	Rate   utils.Decimal
+	Amount utils.Decimal
+	Debit  bool
+}
+
+type TaxLines []*TaxLine
+
+func (tls TaxLines) GetTotal() (total utils.Decimal) {
+	for _, tl := range tls {
+		total = total.Add(tl.Amount)
+	}
+	return
+}
+
+// Sum of the taxes which should be debited from the account
+func (tls TaxLines) GetDebitTotal() (total utils.Decimal) {
+	for _, tl := range tls {
+		if tl.Debit {
+			total = total.Add(tl.Amount)
+		}
+	}
+	return
+}
+
+// Sums up the amounts of the same taxes, the ones not present are added
+func (tls TaxLines) Merge(other TaxLines) TaxLines {
+	for _, otl := range other {
+		merged := false
+		for _, tl := range tls {
+			if tl.TaxId == otl.TaxId && tl.Rate.Equal(otl.Rate) {
+				tl.Amount = tl.Amount.Add(otl.Amount)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			tls = append(tls, &TaxLine{TaxId: otl.TaxId, Rate: otl.Rate, Amount: otl.Amount, Debit: otl.Debit})
+		}
+	}
+	return tls
+}
+
+func (t *Taxes) GetId() string {
+	return utils.ConcatenatedKey(t.Tenant, t.Category)
+}
+
+// Returns the rules applying to the destination, one per TaxId.
+// The rule on the longest matching prefix wins, weight breaks the ties and *any is the fallback.
+func (t *Taxes) GetRulesForPrefix(destination string) (rules []*TaxRule) {
+	precisions := make(map[string]int)
+	selected := make(map[string]*TaxRule)
+	var taxIds []string // keep the order of the definitions
+	consider := func(rule *TaxRule, precision int) {
+		current, found := selected[rule.TaxId]
+		if !found {
+			taxIds = append(taxIds, rule.TaxId)
+		}
+		if !found || precision > precisions[rule.TaxId] ||
+			(precision == precisions[rule.TaxId] && rule.Weight > current.Weight) {
+			selected[rule.TaxId] = rule
+			precisions[rule.TaxId] = precision
+		}
+	}
+	for _, rule := range t.Rules {
+		if rule.DestinationId == utils.ANY || rule.DestinationId == "" {
+			consider(rule, 0)
+		}
+	}
+	if destination != "" {
+		for _, match := range destIndex.Match(destination) {
+			for _, dId := range match.Ids {
+				for _, rule := range t.Rules {
+					if rule.DestinationId == dId {
+						consider(rule, match.Precision)
+					}
+				}
+			}
+		}
+	}
+	for _, taxId := range taxIds {
+		rules = append(rules, selected[taxId])
+	}
+	return
+}
+
+// Computes the tax lines for the cost sent to the destination
+func (t *Taxes) GetTaxLines(cost utils.Decimal, destination string, roundingDecimals int, roundingMethod string) (tls TaxLines) {
+	for _, rule := range t.GetRulesForPrefix(destination) {
+		tls = append(tls, &TaxLine{
+			TaxId:  rule.TaxId,
+			Rate:   rule.Rate,
+			Amount: cost.Mul(rule.Rate).DivInt(100).Round(roundingDecimals, roundingMethod),
+			Debit:  rule.Debit,
+		})
+	}
+	return
+}
+
+// Sum of the rates of the taxes debited with the costs sent to the destination
+func getDebitTaxRate(tenant, category, destination string) (rate utils.Decimal) {
+	taxes, err := getTaxes(tenant, category)
+	if err != nil {
+		return
+	}
+	for _, rule := range taxes.GetRulesForPrefix(destination) {
+		if rule.Debit {
+			rate = rate.Add(rule.Rate)
+		}
+	}
+	return
+}
+
+// The tax debited on top of the amount, the rate is a percent
+func getTax(amount, rate utils.Decimal) utils.Decimal {
+	return amount.Mul(rate).DivInt(100)
+}
+
+// Searches the taxes defined for tenant and category, falling back on *any
+func getTaxes(tenant, category string) (*Taxes, error) {
+	keyVariants := []string{
+		utils.ConcatenatedKey(tenant, category),
+		utils.ConcatenatedKey(tenant, utils.ANY),
+		utils.ConcatenatedKey(utils.ANY, utils.ANY),
+	}
+	for _, key := range keyVariants {
+		if taxes, err := ratingStorage.GetTaxes(key, false); err == nil {
+			return taxes, nil
+		}
+	}
+	return nil, utils.ErrNotFound
+}
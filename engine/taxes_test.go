@@ -0,0 +1,149 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/cache2go"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestTaxesGetRulesForPrefix(t *testing.T) {
+	destIndex.SetDestination(&Destination{Id: "TAX_LOCAL", Prefixes: []string{"7777"}})
+	destIndex.SetDestination(&Destination{Id: "TAX_LOCAL_ONE", Prefixes: []string{"77771"}})
+	defer destIndex.RemoveDestination("TAX_LOCAL")
+	defer destIndex.RemoveDestination("TAX_LOCAL_ONE")
+	taxes := &Taxes{Tenant: "cgrates.org", Category: "call", Rules: []*TaxRule{
+		&TaxRule{DestinationId: utils.ANY, TaxId: "VAT", Rate: utils.NewDecimalFromFloat(19), Weight: 10},
+		&TaxRule{DestinationId: "TAX_LOCAL", TaxId: "VAT", Rate: utils.NewDecimalFromFloat(24), Weight: 10},
+		&TaxRule{DestinationId: "TAX_LOCAL_ONE", TaxId: "VAT", Rate: utils.NewDecimalFromFloat(9), Weight: 0},
+		&TaxRule{DestinationId: "TAX_LOCAL", TaxId: "REGIONAL", Rate: utils.NewDecimalFromFloat(1.5), Weight: 10},
+		&TaxRule{DestinationId: "TAX_LOCAL", TaxId: "REGIONAL", Rate: utils.NewDecimalFromFloat(2), Weight: 20},
+	}}
+	rules := taxes.GetRulesForPrefix("777712")
+	if len(rules) != 2 || rules[0] != taxes.Rules[2] || rules[1] != taxes.Rules[4] {
+		t.Errorf("Wrong rules for 777712: %+v", rules)
+	}
+	rules = taxes.GetRulesForPrefix("77772")
+	if len(rules) != 2 || rules[0] != taxes.Rules[1] || rules[1] != taxes.Rules[4] {
+		t.Errorf("Wrong rules for 77772: %+v", rules)
+	}
+	rules = taxes.GetRulesForPrefix("")
+	if len(rules) != 1 || rules[0] != taxes.Rules[0] {
+		t.Errorf("Wrong rules for empty destination: %+v", rules)
+	}
+}
+
+func TestTaxesGetTaxLines(t *testing.T) {
+	taxes := &Taxes{Tenant: "cgrates.org", Category: "call", Rules: []*TaxRule{
+		&TaxRule{DestinationId: "NAT", TaxId: "VAT", Rate: utils.NewDecimalFromFloat(24), Debit: true},
+		&TaxRule{DestinationId: utils.ANY, TaxId: "REGIONAL", Rate: utils.NewDecimalFromFloat(1.5)},
+	}}
+	tls := taxes.GetTaxLines(utils.NewDecimalFromFloat(10.33), "0256", 2, utils.ROUNDING_MIDDLE)
+	if len(tls) != 2 ||
+		tls[0].Amount.String() != "0.15" || tls[0].Debit ||
+		tls[1].Amount.String() != "2.48" || !tls[1].Debit {
+		t.Errorf("Wrong tax lines: %+v, %+v", tls[0], tls[1])
+	}
+	if tls.GetTotal().String() != "2.63" || tls.GetDebitTotal().String() != "2.48" {
+		t.Errorf("Wrong tax totals: %v, %v", tls.GetTotal(), tls.GetDebitTotal())
+	}
+}
+
+func TestTaxLinesMerge(t *testing.T) {
+	tls := TaxLines{&TaxLine{TaxId: "VAT", Rate: utils.NewDecimalFromFloat(24), Amount: utils.NewDecimalFromFloat(1), Debit: true}}
+	other := TaxLines{
+		&TaxLine{TaxId: "VAT", Rate: utils.NewDecimalFromFloat(24), Amount: utils.NewDecimalFromFloat(0.5), Debit: true},
+		&TaxLine{TaxId: "REGIONAL", Rate: utils.NewDecimalFromFloat(1.5), Amount: utils.NewDecimalFromFloat(0.1)},
+	}
+	tls = tls.Merge(other)
+	if len(tls) != 2 || tls[0].Amount.String() != "1.5" || tls[1].Amount.String() != "0.1" {
+		t.Errorf("Wrong merged tax lines: %+v, %+v", tls[0], tls[1])
+	}
+}
+
+func TestTaxesGetCost(t *testing.T) {
+	taxes := &Taxes{Tenant: "test", Category: "0", Rules: []*TaxRule{
+		&TaxRule{DestinationId: "NAT", TaxId: "VAT", Rate: utils.NewDecimalFromFloat(20), Debit: true},
+	}}
+	ratingStorage.SetTaxes(taxes)
+	ratingStorage.GetTaxes(taxes.GetId(), true) // cache it
+	defer cache2go.RemKey(TAXES_PREFIX + taxes.GetId())
+	t1 := time.Date(2013, time.October, 8, 9, 23, 2, 0, time.UTC)
+	t2 := time.Date(2013, time.October, 8, 9, 24, 27, 0, time.UTC)
+	cd := &CallDescriptor{Direction: "*out", Category: "0", Tenant: "test", Subject: "trp", Destination: "0256", TimeStart: t1, TimeEnd: t2, LoopIndex: 0, DurationIndex: 85 * time.Second}
+	result, err := cd.GetCost()
+	if err != nil {
+		t.Fatal("Error getting cost: ", err)
+	}
+	if result.Cost.Float64() != 85 || len(result.Taxes) != 1 || result.Taxes.GetTotal().Float64() != 17 {
+		t.Errorf("Wrong taxes for cost %v: %+v", result.Cost, result.Taxes)
+	}
+}
+
+func TestTaxesDebit(t *testing.T) {
+	cc := &CallCost{Direction: OUTBOUND, Destination: "0723", Taxes: TaxLines{
+		&TaxLine{TaxId: "VAT", Rate: utils.NewDecimalFromFloat(24), Amount: utils.NewDecimalFromFloat(2.4), Debit: true},
+		&TaxLine{TaxId: "REGIONAL", Rate: utils.NewDecimalFromFloat(1.5), Amount: utils.NewDecimalFromFloat(0.15)},
+	}}
+	acc := &Account{Id: "other", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(10)}},
+	}}
+	acc.debitTaxes(cc, false)
+	if acc.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "7.6" {
+		t.Errorf("Wrong balance after taxes: %+v", acc.BalanceMap[utils.MONETARY+OUTBOUND][0])
+	}
+	acc.BalanceMap[utils.MONETARY+OUTBOUND][0].Value = utils.NewDecimalFromFloat(1)
+	acc.debitTaxes(cc, false)
+	if acc.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "-1.4" {
+		t.Errorf("Wrong negative balance after taxes: %+v", acc.BalanceMap[utils.MONETARY+OUTBOUND][0])
+	}
+}
+
+func TestTaxesRefund(t *testing.T) {
+	taxes := &Taxes{Tenant: "test_refund", Category: "0", Rules: []*TaxRule{
+		&TaxRule{DestinationId: utils.ANY, TaxId: "VAT", Rate: utils.NewDecimalFromFloat(20), Debit: true},
+		&TaxRule{DestinationId: utils.ANY, TaxId: "REPORTED", Rate: utils.NewDecimalFromFloat(5)},
+	}}
+	ratingStorage.SetTaxes(taxes)
+	ratingStorage.GetTaxes(taxes.GetId(), true) // cache it
+	defer cache2go.RemKey(TAXES_PREFIX + taxes.GetId())
+	accountingStorage.SetAccount(&Account{Id: "*out:test_refund:taxed", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{&Balance{Uuid: "money", Value: utils.NewDecimalFromFloat(100)}},
+	}})
+	cd := &CallDescriptor{Direction: OUTBOUND, Tenant: "test_refund", Category: "0", Account: "taxed", Destination: "0256", Increments: Increments{
+		&Increment{Duration: time.Second, Cost: utils.NewDecimalFromFloat(1), BalanceInfo: &BalanceInfo{MoneyBalanceUuid: "money", AccountId: "*out:test_refund:taxed"}},
+		&Increment{Duration: time.Second, Cost: utils.NewDecimalFromFloat(1), BalanceInfo: &BalanceInfo{MoneyBalanceUuid: "money", AccountId: "*out:test_refund:taxed"}},
+	}}
+	if _, err := cd.RefundIncrements(); err != nil {
+		t.Fatal("Error refunding increments: ", err)
+	}
+	if acc, err := accountingStorage.GetAccount("*out:test_refund:taxed"); err != nil || acc.BalanceMap[utils.MONETARY+OUTBOUND][0].Value.String() != "102.4" {
+		t.Errorf("Wrong balance after refund with taxes: %+v, %v", acc, err)
+	}
+	cc := &CallCost{Cost: utils.NewDecimalFromFloat(10), Taxes: TaxLines{
+		&TaxLine{TaxId: "VAT", Rate: utils.NewDecimalFromFloat(20), Amount: utils.NewDecimalFromFloat(2), Debit: true},
+		&TaxLine{TaxId: "REPORTED", Rate: utils.NewDecimalFromFloat(5), Amount: utils.NewDecimalFromFloat(0.5)},
+	}}
+	cc.RefundTaxes(utils.NewDecimalFromFloat(2))
+	if cc.Taxes[0].Amount.String() != "1.6" || cc.Taxes[1].Amount.String() != "0.4" {
+		t.Errorf("Wrong tax lines after refund: %+v, %+v", cc.Taxes[0], cc.Taxes[1])
+	}
+}
@@ -32,7 +32,7 @@ A unit in which a call will be split that has a specific price related interval
 */
 type TimeSpan struct {
 	TimeStart, TimeEnd                                         time.Time
-	Cost                                                       float64
+	Cost                                                       utils.Decimal
 	ratingInfo                                                 *RatingInfo
 	RateInterval                                               *RateInterval
 	DurationIndex                                              time.Duration // the call duration so far till TimeEnd
@@ -42,7 +42,7 @@ type TimeSpan struct {
 
 type Increment struct {
 	Duration            time.Duration
-	Cost                float64
+	Cost                utils.Decimal
 	BalanceInfo         *BalanceInfo // need more than one for units with cost
 	BalanceRateInterval *RateInterval
 	UnitInfo            *UnitInfo
@@ -67,13 +67,38 @@ func (mi *UnitInfo) Equal(other *UnitInfo) bool {
 type BalanceInfo struct {
 	UnitBalanceUuid  string
 	MoneyBalanceUuid string
-	AccountId        string // used when debited from shared balance
+	AccountId        string          // used when debited from shared balance
+	ExchangeRate     *ExchangeRate   // applied when the money balance currency differs from the rate one
+	Shares           []*BalanceShare // per member debits on *proportional shared groups
+}
+
+// Amount debited from a member balance of a *proportional shared group
+type BalanceShare struct {
+	AccountId   string
+	BalanceUuid string
+	Value       utils.Decimal
 }
 
 func (bi *BalanceInfo) Equal(other *BalanceInfo) bool {
 	return bi.UnitBalanceUuid == other.UnitBalanceUuid &&
 		bi.MoneyBalanceUuid == other.MoneyBalanceUuid &&
-		bi.AccountId == other.AccountId
+		bi.AccountId == other.AccountId &&
+		bi.ExchangeRate.Equal(other.ExchangeRate) &&
+		bi.sharesEqual(other)
+}
+
+func (bi *BalanceInfo) sharesEqual(other *BalanceInfo) bool {
+	if len(bi.Shares) != len(other.Shares) {
+		return false
+	}
+	for i, s := range bi.Shares {
+		if s.AccountId != other.Shares[i].AccountId ||
+			s.BalanceUuid != other.Shares[i].BalanceUuid ||
+			!s.Value.Equal(other.Shares[i].Value) {
+			return false
+		}
+	}
+	return true
 }
 
 type TimeSpans []*TimeSpan
@@ -202,7 +227,7 @@ func (incr *Increment) Clone() *Increment {
 
 func (incr *Increment) Equal(other *Increment) bool {
 	return incr.Duration == other.Duration &&
-		incr.Cost == other.Cost &&
+		incr.Cost.Equal(other.Cost) &&
 		((incr.BalanceInfo == nil && other.BalanceInfo == nil) || incr.BalanceInfo.Equal(other.BalanceInfo)) &&
 		((incr.BalanceRateInterval == nil && other.BalanceRateInterval == nil) || reflect.DeepEqual(incr.BalanceRateInterval, other.BalanceRateInterval)) &&
 		((incr.UnitInfo == nil && other.UnitInfo == nil) || incr.UnitInfo.Equal(other.UnitInfo))
@@ -217,12 +242,25 @@ func (incr *Increment) GetCompressFactor() int {
 
 type Increments []*Increment
 
-func (incs Increments) GetTotalCost() float64 {
-	cost := 0.0
+func (incs Increments) GetTotalCost() (cost utils.Decimal) {
 	for _, increment := range incs {
-		cost += (float64(increment.GetCompressFactor()) * increment.Cost)
+		cost = cost.Add(increment.Cost.MulInt(int64(increment.GetCompressFactor())))
 	}
-	return cost
+	return
+}
+
+// Returns copies of the decompressed increments with the total cost limited to the amount,
+// the ones over the limit are kept with zero cost
+func (incs Increments) LimitCost(amount utils.Decimal) (limited Increments) {
+	for _, incr := range incs {
+		lIncr := incr.Clone()
+		if lIncr.Cost.Cmp(amount) > 0 {
+			lIncr.Cost = amount
+		}
+		amount = amount.Sub(lIncr.Cost)
+		limited = append(limited, lIncr)
+	}
+	return
 }
 
 func (incs Increments) Length() (length int) {
@@ -256,7 +294,7 @@ func (ts *TimeSpan) SetRateInterval(i *RateInterval) {
 	}
 	iPrice, _, _ := i.GetRateParameters(ts.GetGroupStart())
 	tsPrice, _, _ := ts.RateInterval.GetRateParameters(ts.GetGroupStart())
-	if ts.RateInterval.Weight == i.Weight && iPrice <= tsPrice {
+	if ts.RateInterval.Weight == i.Weight && iPrice.Cmp(tsPrice) <= 0 {
 		ts.RateInterval = i
 	}
 	//log.Printf("END TS: %+v", ts.RateInterval.Timing)
@@ -265,24 +303,21 @@ func (ts *TimeSpan) SetRateInterval(i *RateInterval) {
 // Returns the cost of the timespan according to the relevant cost interval.
 // It also sets the Cost field of this timespan (used for refund on session
 // manager debit loop where the cost cannot be recalculated)
-func (ts *TimeSpan) getCost() float64 {
+func (ts *TimeSpan) getCost() utils.Decimal {
 	if ts.Increments.Length() == 0 {
 		if ts.RateInterval == nil {
-			return 0
+			return utils.Decimal{}
 		}
 		cost := ts.RateInterval.GetCost(ts.GetDuration(), ts.GetGroupStart())
-		ts.Cost = utils.Round(cost, ts.RateInterval.Rating.RoundingDecimals, ts.RateInterval.Rating.RoundingMethod)
+		ts.Cost = cost.Round(ts.RateInterval.Rating.RoundingDecimals, ts.RateInterval.Rating.RoundingMethod)
 		return ts.Cost
 	} else {
-		cost := 0.0
 		// some increments may have 0 cost because of the max  cost strategy
-		for _, inc := range ts.Increments {
-			cost += inc.Cost
-		}
+		cost := ts.Increments.GetTotalCost()
 		if ts.RateInterval != nil && ts.RateInterval.Rating != nil {
-			return utils.Round(cost, ts.RateInterval.Rating.RoundingDecimals, ts.RateInterval.Rating.RoundingMethod)
+			return cost.Round(ts.RateInterval.Rating.RoundingDecimals, ts.RateInterval.Rating.RoundingMethod)
 		} else {
-			return utils.Round(cost, globalRoundingDecimals, utils.ROUNDING_MIDDLE)
+			return cost.Round(globalRoundingDecimals, utils.ROUNDING_MIDDLE)
 		}
 	}
 }
@@ -306,8 +341,11 @@ func (ts *TimeSpan) createIncrementsSlice() {
 	// because ts cost is rounded
 	//incrementCost := rate / rateUnit.Seconds() * rateIncrement.Seconds()
 	nbIncrements := int(ts.GetDuration() / rateIncrement)
-	incrementCost := ts.getCost() / float64(nbIncrements)
-	incrementCost = utils.Round(incrementCost, globalRoundingDecimals, utils.ROUNDING_MIDDLE) // just get rid of the extra decimals
+	if nbIncrements == 0 {
+		return
+	}
+	incrementCost := ts.getCost().DivInt(int64(nbIncrements))
+	incrementCost = incrementCost.Round(globalRoundingDecimals, utils.ROUNDING_MIDDLE) // just get rid of the extra decimals
 	for s := 0; s < nbIncrements; s++ {
 		inc := &Increment{
 			Duration:    rateIncrement,
@@ -317,7 +355,7 @@ func (ts *TimeSpan) createIncrementsSlice() {
 		ts.Increments = append(ts.Increments, inc)
 	}
 	// put the rounded cost back in timespan
-	ts.Cost = incrementCost * float64(nbIncrements)
+	ts.Cost = incrementCost.MulInt(int64(nbIncrements))
 }
 
 // returns whether the timespan has all increments marked as paid and if not
@@ -429,6 +467,34 @@ func (ts *TimeSpan) SplitByRateInterval(i *RateInterval, data bool) (nts *TimeSp
 	return
 }
 
+/*
+Splits the timespan where the usage in the billing period reaches the next rate tier,
+the received usage being the one before the timespan start. The rating of the reached tier
+is attached to the timespan and a new timespan is returned for the part after the split.
+*/
+func (ts *TimeSpan) SplitByRateTier(usage time.Duration) (nts *TimeSpan) {
+	if ts.RateInterval == nil || len(ts.RateInterval.Tiers) == 0 {
+		return
+	}
+	ts.RateInterval.Tiers.Sort()
+	ts.RateInterval = ts.RateInterval.forUsage(usage)
+	next := ts.RateInterval.Tiers.getNext(usage)
+	if next == nil || usage+ts.GetDuration() <= next.UsageStart {
+		return
+	}
+	splitTime := ts.TimeStart.Add(next.UsageStart - usage)
+	nts = &TimeSpan{
+		TimeStart:    splitTime,
+		TimeEnd:      ts.TimeEnd,
+		RateInterval: ts.RateInterval,
+	}
+	nts.copyRatingInfo(ts)
+	ts.TimeEnd = splitTime
+	nts.DurationIndex = ts.DurationIndex
+	ts.SetNewDurationIndex(nts)
+	return
+}
+
 // Split the timespan at the given increment start
 func (ts *TimeSpan) SplitByIncrement(index int) *TimeSpan {
 	if index <= 0 || index >= len(ts.Increments) {
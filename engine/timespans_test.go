@@ -214,16 +214,16 @@ func TestTimespanGetCost(t *testing.T) {
 	t1 := time.Date(2012, time.February, 5, 17, 45, 0, 0, time.UTC)
 	t2 := time.Date(2012, time.February, 5, 17, 55, 0, 0, time.UTC)
 	ts1 := TimeSpan{TimeStart: t1, TimeEnd: t2}
-	if ts1.getCost() != 0 {
+	if ts1.getCost().Float64() != 0 {
 		t.Error("No interval and still kicking")
 	}
-	ts1.SetRateInterval(&RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{0, 1.0, 1 * time.Second, 1 * time.Second}}}})
-	if ts1.getCost() != 600 {
+	ts1.SetRateInterval(&RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{0, utils.NewDecimalFromFloat(1.0), 1 * time.Second, 1 * time.Second}}}})
+	if ts1.getCost().Float64() != 600 {
 		t.Error("Expected 10 got ", ts1.Cost)
 	}
 	ts1.RateInterval = nil
-	ts1.SetRateInterval(&RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{0, 1.0, 1 * time.Second, 60 * time.Second}}}})
-	if ts1.getCost() != 10 {
+	ts1.SetRateInterval(&RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{0, utils.NewDecimalFromFloat(1.0), 1 * time.Second, 60 * time.Second}}}})
+	if ts1.getCost().Float64() != 10 {
 		t.Error("Expected 6000 got ", ts1.Cost)
 	}
 }
@@ -232,17 +232,17 @@ func TestTimespanGetCostIntervals(t *testing.T) {
 	ts := &TimeSpan{}
 	ts.Increments = make(Increments, 11)
 	for i := 0; i < 11; i++ {
-		ts.Increments[i] = &Increment{Cost: 0.02}
+		ts.Increments[i] = &Increment{Cost: utils.NewDecimalFromFloat(0.02)}
 	}
-	if ts.getCost() != 0.22 {
+	if ts.getCost().Float64() != 0.22 {
 		t.Error("Error caclulating timespan cost: ", ts.getCost())
 	}
 }
 
 func TestSetRateInterval(t *testing.T) {
-	i1 := &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{0, 1.0, 1 * time.Second, 1 * time.Second}}}}
+	i1 := &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{0, utils.NewDecimalFromFloat(1.0), 1 * time.Second, 1 * time.Second}}}}
 	ts1 := TimeSpan{RateInterval: i1}
-	i2 := &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{0, 2.0, 1 * time.Second, 1 * time.Second}}}}
+	i2 := &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{0, utils.NewDecimalFromFloat(2.0), 1 * time.Second, 1 * time.Second}}}}
 	ts1.SetRateInterval(i2)
 	if ts1.RateInterval != i1 {
 		t.Error("Smaller price interval should win")
@@ -260,7 +260,7 @@ func TestTimespanSplitGroupedRates(t *testing.T) {
 			EndTime: "17:59:00",
 		},
 		Rating: &RIRate{
-			Rates: RateGroups{&Rate{0, 2, 1 * time.Second, 1 * time.Second}, &Rate{900 * time.Second, 1, 1 * time.Second, 1 * time.Second}},
+			Rates: RateGroups{&Rate{0, utils.NewDecimalFromFloat(2), 1 * time.Second, 1 * time.Second}, &Rate{900 * time.Second, utils.NewDecimalFromFloat(1), 1 * time.Second, 1 * time.Second}},
 		},
 	}
 	t1 := time.Date(2012, time.February, 3, 17, 30, 0, 0, time.UTC)
@@ -280,7 +280,7 @@ func TestTimespanSplitGroupedRates(t *testing.T) {
 	}
 	c1 := ts.RateInterval.GetCost(ts.GetDuration(), ts.GetGroupStart())
 	c2 := nts.RateInterval.GetCost(nts.GetDuration(), nts.GetGroupStart())
-	if c1 != 1800 || c2 != 900 {
+	if c1.Float64() != 1800 || c2.Float64() != 900 {
 		t.Error("Wrong costs: ", c1, c2)
 	}
 
@@ -301,12 +301,12 @@ func TestTimespanSplitGroupedRatesIncrements(t *testing.T) {
 			Rates: RateGroups{
 				&Rate{
 					GroupIntervalStart: 0,
-					Value:              2,
+					Value:              utils.NewDecimalFromFloat(2),
 					RateIncrement:      time.Second,
 					RateUnit:           time.Second},
 				&Rate{
 					GroupIntervalStart: 30 * time.Second,
-					Value:              1,
+					Value:              utils.NewDecimalFromFloat(1),
 					RateIncrement:      time.Minute,
 					RateUnit:           time.Second,
 				}}},
@@ -336,7 +336,7 @@ func TestTimespanSplitGroupedRatesIncrements(t *testing.T) {
 	}
 	c1 := ts.RateInterval.GetCost(ts.GetDuration(), ts.GetGroupStart())
 	c2 := nts.RateInterval.GetCost(nts.GetDuration(), nts.GetGroupStart())
-	if c1 != 60 || c2 != 60 {
+	if c1.Float64() != 60 || c2.Float64() != 60 {
 		t.Error("Wrong costs: ", c1, c2)
 	}
 
@@ -354,7 +354,7 @@ func TestTimespanSplitRightHourMarginBeforeGroup(t *testing.T) {
 			EndTime: "17:00:30",
 		},
 		Rating: &RIRate{
-			Rates: RateGroups{&Rate{0, 2, 1 * time.Second, 1 * time.Second}, &Rate{60 * time.Second, 1, 60 * time.Second, 1 * time.Second}},
+			Rates: RateGroups{&Rate{0, utils.NewDecimalFromFloat(2), 1 * time.Second, 1 * time.Second}, &Rate{60 * time.Second, utils.NewDecimalFromFloat(1), 60 * time.Second, 1 * time.Second}},
 		},
 	}
 	t1 := time.Date(2012, time.February, 3, 17, 00, 0, 0, time.UTC)
@@ -391,7 +391,7 @@ func TestTimespanSplitGroupSecondSplit(t *testing.T) {
 			EndTime: "17:03:30",
 		},
 		Rating: &RIRate{
-			Rates: RateGroups{&Rate{0, 2, 1 * time.Second, 1 * time.Second}, &Rate{60 * time.Second, 1, 1 * time.Second, 1 * time.Second}}},
+			Rates: RateGroups{&Rate{0, utils.NewDecimalFromFloat(2), 1 * time.Second, 1 * time.Second}, &Rate{60 * time.Second, utils.NewDecimalFromFloat(1), 1 * time.Second, 1 * time.Second}}},
 	}
 	t1 := time.Date(2012, time.February, 3, 17, 00, 0, 0, time.UTC)
 	t2 := time.Date(2012, time.February, 3, 17, 04, 0, 0, time.UTC)
@@ -468,7 +468,7 @@ func TestTimespanSplitMultipleGroup(t *testing.T) {
 			EndTime: "17:05:00",
 		},
 		Rating: &RIRate{
-			Rates: RateGroups{&Rate{0, 2, 1 * time.Second, 1 * time.Second}, &Rate{60 * time.Second, 1, 1 * time.Second, 1 * time.Second}, &Rate{180 * time.Second, 1, 1 * time.Second, 1 * time.Second}}},
+			Rates: RateGroups{&Rate{0, utils.NewDecimalFromFloat(2), 1 * time.Second, 1 * time.Second}, &Rate{60 * time.Second, utils.NewDecimalFromFloat(1), 1 * time.Second, 1 * time.Second}, &Rate{180 * time.Second, utils.NewDecimalFromFloat(1), 1 * time.Second, 1 * time.Second}}},
 	}
 	t1 := time.Date(2012, time.February, 3, 17, 00, 0, 0, time.UTC)
 	t2 := time.Date(2012, time.February, 3, 17, 04, 0, 0, time.UTC)
@@ -696,14 +696,14 @@ func TestTimespanCreateSecondsSlice(t *testing.T) {
 		TimeStart: time.Date(2013, 9, 10, 14, 30, 0, 0, time.UTC),
 		TimeEnd:   time.Date(2013, 9, 10, 14, 30, 30, 0, time.UTC),
 		RateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{
-			&Rate{Value: 2.0},
+			&Rate{Value: utils.NewDecimalFromFloat(2.0)},
 		}}},
 	}
 	ts.createIncrementsSlice()
 	if len(ts.Increments) != 30 {
 		t.Error("Error creating second slice: ", ts.Increments)
 	}
-	if ts.Increments[0].Cost != 2.0 {
+	if ts.Increments[0].Cost.Float64() != 2.0 {
 		t.Error("Wrong second slice: ", ts.Increments[0])
 	}
 }
@@ -718,7 +718,7 @@ func TestTimespanCreateIncrements(t *testing.T) {
 				RoundingDecimals: 2,
 				Rates: RateGroups{
 					&Rate{
-						Value:         2.0,
+						Value:         utils.NewDecimalFromFloat(2.0),
 						RateIncrement: 10 * time.Second,
 					},
 				},
@@ -729,7 +729,7 @@ func TestTimespanCreateIncrements(t *testing.T) {
 	if len(ts.Increments) != 3 {
 		t.Error("Error creating increment slice: ", len(ts.Increments))
 	}
-	if len(ts.Increments) < 3 || ts.Increments[2].Cost != 20.0666666667 {
+	if len(ts.Increments) < 3 || ts.Increments[2].Cost.Float64() != 20.0666666667 {
 		t.Error("Wrong second slice: ", ts.Increments[2].Cost)
 	}
 }
@@ -746,7 +746,7 @@ func TestTimespanSplitByIncrement(t *testing.T) {
 				RoundingDecimals: 2,
 				Rates: RateGroups{
 					&Rate{
-						Value:         2.0,
+						Value:         utils.NewDecimalFromFloat(2.0),
 						RateIncrement: 10 * time.Second,
 					},
 				},
@@ -780,7 +780,7 @@ func TestTimespanSplitByIncrementStart(t *testing.T) {
 				RoundingDecimals: 2,
 				Rates: RateGroups{
 					&Rate{
-						Value:         2.0,
+						Value:         utils.NewDecimalFromFloat(2.0),
 						RateIncrement: 10 * time.Second,
 					},
 				},
@@ -814,7 +814,7 @@ func TestTimespanSplitByIncrementEnd(t *testing.T) {
 				RoundingDecimals: 2,
 				Rates: RateGroups{
 					&Rate{
-						Value:         2.0,
+						Value:         utils.NewDecimalFromFloat(2.0),
 						RateIncrement: 10 * time.Second,
 					},
 				},
@@ -849,7 +849,7 @@ func TestTimespanSplitByDuration(t *testing.T) {
 				RoundingDecimals: 2,
 				Rates: RateGroups{
 					&Rate{
-						Value:         2.0,
+						Value:         utils.NewDecimalFromFloat(2.0),
 						RateIncrement: 10 * time.Second,
 					},
 				},
@@ -1498,37 +1498,37 @@ func TestTSCompressDecompress(t *testing.T) {
 			Increments: Increments{
 				&Increment{
 					Duration:            time.Minute,
-					Cost:                10.4,
-					BalanceInfo:         &BalanceInfo{"1", "2", "3"},
-					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+					Cost:                utils.NewDecimalFromFloat(10.4),
+					BalanceInfo:         &BalanceInfo{"1", "2", "3", nil, nil},
+					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 					UnitInfo:            &UnitInfo{"1", 2.3, utils.VOICE},
 				},
 				&Increment{
 					Duration:            time.Minute,
-					Cost:                10.4,
-					BalanceInfo:         &BalanceInfo{"1", "2", "3"},
-					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+					Cost:                utils.NewDecimalFromFloat(10.4),
+					BalanceInfo:         &BalanceInfo{"1", "2", "3", nil, nil},
+					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 					UnitInfo:            &UnitInfo{"1", 2.3, utils.VOICE},
 				},
 				&Increment{
 					Duration:            time.Minute,
-					Cost:                10.4,
-					BalanceInfo:         &BalanceInfo{"1", "2", "3"},
-					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+					Cost:                utils.NewDecimalFromFloat(10.4),
+					BalanceInfo:         &BalanceInfo{"1", "2", "3", nil, nil},
+					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 					UnitInfo:            &UnitInfo{"1", 2.3, utils.VOICE},
 				},
 				&Increment{
 					Duration:            time.Minute,
-					Cost:                10.4,
-					BalanceInfo:         &BalanceInfo{"1", "2", "3"},
-					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 1111 * time.Second, RateUnit: time.Second}}}},
+					Cost:                utils.NewDecimalFromFloat(10.4),
+					BalanceInfo:         &BalanceInfo{"1", "2", "3", nil, nil},
+					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 1111 * time.Second, RateUnit: time.Second}}}},
 					UnitInfo:            &UnitInfo{"1", 2.3, utils.VOICE},
 				},
 				&Increment{
 					Duration:            time.Minute,
-					Cost:                10.4,
-					BalanceInfo:         &BalanceInfo{"1", "2", "3"},
-					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+					Cost:                utils.NewDecimalFromFloat(10.4),
+					BalanceInfo:         &BalanceInfo{"1", "2", "3", nil, nil},
+					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 					UnitInfo:            &UnitInfo{"1", 2.3, utils.VOICE},
 				},
 			},
@@ -1550,37 +1550,37 @@ func TestTSMultipleCompressDecompress(t *testing.T) {
 			Increments: Increments{
 				&Increment{
 					Duration:            time.Minute,
-					Cost:                10.4,
-					BalanceInfo:         &BalanceInfo{"1", "2", "3"},
-					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+					Cost:                utils.NewDecimalFromFloat(10.4),
+					BalanceInfo:         &BalanceInfo{"1", "2", "3", nil, nil},
+					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 					UnitInfo:            &UnitInfo{"1", 2.3, utils.VOICE},
 				},
 				&Increment{
 					Duration:            time.Minute,
-					Cost:                10.4,
-					BalanceInfo:         &BalanceInfo{"1", "2", "3"},
-					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+					Cost:                utils.NewDecimalFromFloat(10.4),
+					BalanceInfo:         &BalanceInfo{"1", "2", "3", nil, nil},
+					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 					UnitInfo:            &UnitInfo{"1", 2.3, utils.VOICE},
 				},
 				&Increment{
 					Duration:            time.Minute,
-					Cost:                10.4,
-					BalanceInfo:         &BalanceInfo{"1", "2", "3"},
-					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+					Cost:                utils.NewDecimalFromFloat(10.4),
+					BalanceInfo:         &BalanceInfo{"1", "2", "3", nil, nil},
+					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 					UnitInfo:            &UnitInfo{"1", 2.3, utils.VOICE},
 				},
 				&Increment{
 					Duration:            time.Minute,
-					Cost:                10.4,
-					BalanceInfo:         &BalanceInfo{"1", "2", "3"},
-					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 1111 * time.Second, RateUnit: time.Second}}}},
+					Cost:                utils.NewDecimalFromFloat(10.4),
+					BalanceInfo:         &BalanceInfo{"1", "2", "3", nil, nil},
+					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 1111 * time.Second, RateUnit: time.Second}}}},
 					UnitInfo:            &UnitInfo{"1", 2.3, utils.VOICE},
 				},
 				&Increment{
 					Duration:            time.Minute,
-					Cost:                10.4,
-					BalanceInfo:         &BalanceInfo{"1", "2", "3"},
-					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: 100, RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
+					Cost:                utils.NewDecimalFromFloat(10.4),
+					BalanceInfo:         &BalanceInfo{"1", "2", "3", nil, nil},
+					BalanceRateInterval: &RateInterval{Rating: &RIRate{Rates: RateGroups{&Rate{GroupIntervalStart: 0, Value: utils.NewDecimalFromFloat(100), RateIncrement: 10 * time.Second, RateUnit: time.Second}}}},
 					UnitInfo:            &UnitInfo{"1", 2.3, utils.VOICE},
 				},
 			},
@@ -1681,3 +1681,35 @@ func TestTSBetterIntervalAgainAfter(t *testing.T) {
 		t.Error("Wrong better rate interval!")
 	}
 }
+
+func TestTSSplitByRateTier(t *testing.T) {
+	base := &RIRate{Rates: RateGroups{&Rate{Value: utils.NewDecimalFromFloat(1), RateIncrement: time.Second, RateUnit: time.Second}}}
+	second := &RIRate{Rates: RateGroups{&Rate{Value: utils.NewDecimalFromFloat(0.5), RateIncrement: time.Second, RateUnit: time.Second}}}
+	third := &RIRate{Rates: RateGroups{&Rate{Value: utils.NewDecimalFromFloat(0.1), RateIncrement: time.Second, RateUnit: time.Second}}}
+	ri := &RateInterval{Timing: &RITiming{}, Rating: base, Tiers: RateTiers{
+		&RateTier{UsageStart: 20 * time.Minute, Rating: third},
+		&RateTier{UsageStart: 10 * time.Minute, Rating: second},
+	}}
+	ts := &TimeSpan{
+		TimeStart:     time.Date(2015, 6, 1, 10, 0, 0, 0, time.UTC),
+		TimeEnd:       time.Date(2015, 6, 1, 10, 2, 0, 0, time.UTC),
+		DurationIndex: 2 * time.Minute,
+		RateInterval:  ri,
+	}
+	nts := ts.SplitByRateTier(9 * time.Minute)
+	if nts == nil || ts.RateInterval.Rating != base || nts.RateInterval.Rating != base ||
+		ts.GetDuration() != time.Minute || nts.GetDuration() != time.Minute || ts.DurationIndex != time.Minute {
+		t.Fatalf("Error splitting on the second tier: %+v, %+v", ts, nts)
+	}
+	if nnts := nts.SplitByRateTier(10 * time.Minute); nnts != nil || nts.RateInterval.Rating != second {
+		t.Errorf("Error applying the second tier: %+v, %+v", nts.RateInterval, nnts)
+	}
+	ts = &TimeSpan{
+		TimeStart:    time.Date(2015, 6, 1, 10, 0, 0, 0, time.UTC),
+		TimeEnd:      time.Date(2015, 6, 1, 10, 2, 0, 0, time.UTC),
+		RateInterval: ri,
+	}
+	if nts := ts.SplitByRateTier(25 * time.Minute); nts != nil || ts.RateInterval.Rating != third || ri.Rating != base {
+		t.Errorf("Error applying the last tier: %+v, %+v", ts.RateInterval, nts)
+	}
+}
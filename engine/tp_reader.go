@@ -33,6 +33,10 @@ type TpReader struct {
 	lcrs              map[string]*LCR
 	derivedChargers   map[string]utils.DerivedChargers
 	cdrStats          map[string]*CdrStats
+	exchangeRates     map[string]*ExchangeRate
+	taxes             map[string]*Taxes
+	holidayCalendars  map[string]*HolidayCalendar
+	portedNumbers     map[string]string
 }
 
 func NewTpReader(rs RatingStorage, as AccountingStorage, lr LoadReader, tpid string) *TpReader {
@@ -57,6 +61,10 @@ func NewTpReader(rs RatingStorage, as AccountingStorage, lr LoadReader, tpid str
 		accountActions:    make(map[string]*Account),
 		cdrStats:          make(map[string]*CdrStats),
 		derivedChargers:   make(map[string]utils.DerivedChargers),
+		exchangeRates:     make(map[string]*ExchangeRate),
+		taxes:             make(map[string]*Taxes),
+		holidayCalendars:  make(map[string]*HolidayCalendar),
+		portedNumbers:     make(map[string]string),
 	}
 	//add *any and *asap timing tag (in case of no timings file)
 	tpr.timings[utils.ANY] = &utils.TPTiming{
@@ -107,6 +115,22 @@ func (tpr *TpReader) LoadTimings() (err error) {
 	}
 
 	tpr.timings, err = TpTimings(tps).GetTimings()
+	if err != nil {
+		return err
+	}
+	for _, tm := range tpr.timings {
+		for _, hclId := range tm.Holidays {
+			_, found := tpr.holidayCalendars[hclId]
+			if !found {
+				if found, err = tpr.ratingStorage.HasData(HOLIDAY_CALENDAR_PREFIX, hclId); err != nil {
+					return fmt.Errorf("[Timings] error querying ratingDb %s", err.Error())
+				}
+			}
+			if !found {
+				return fmt.Errorf("[Timings] could not find holiday calendar with tag %s", hclId)
+			}
+		}
+	}
 	// add *any timing tag
 	tpr.timings[utils.ANY] = &utils.TPTiming{
 		TimingId:  utils.ANY,
@@ -153,6 +177,9 @@ func (tpr *TpReader) LoadDestinationRates() (err error) {
 			if !exists {
 				return fmt.Errorf("could not find rate for tag %v", dr.RateId)
 			}
+			if _, err := dr.UsageStartDuration(); err != nil {
+				return fmt.Errorf("cannot parse usage start %v for destination rate %v", dr.UsageStart, drs.DestinationRateId)
+			}
 			dr.Rate = rate
 			destinationExists := dr.DestinationId == utils.ANY
 			if !destinationExists {
@@ -166,6 +193,18 @@ func (tpr *TpReader) LoadDestinationRates() (err error) {
 			if !destinationExists {
 				return fmt.Errorf("could not get destination for tag %v", dr.DestinationId)
 			}
+			if dr.OriginId == "" {
+				continue
+			}
+			_, originExists := tpr.destinations[dr.OriginId]
+			if !originExists && tpr.ratingStorage != nil {
+				if originExists, err = tpr.ratingStorage.HasData(DESTINATION_PREFIX, dr.OriginId); err != nil {
+					return err
+				}
+			}
+			if !originExists {
+				return fmt.Errorf("could not get origin destination for tag %v", dr.OriginId)
+			}
 		}
 	}
 	return nil
@@ -217,31 +256,39 @@ func (tpr *TpReader) LoadRatingPlansFiltered(tag string) (bool, error) {
 				}
 
 				drate.Rate = rt[drate.RateId]
-				ratingPlan.AddRateInterval(drate.DestinationId, GetRateInterval(rp, drate))
-				if drate.DestinationId == utils.ANY {
-					continue // no need of loading the destinations in this case
-				}
-				tpDests, err := tpr.lr.GetTpDestinations(tpr.tpid, drate.DestinationId)
-				dms, err := TpDestinations(tpDests).GetDestinations()
-				if err != nil {
-					return false, err
-				}
-				destsExist := len(dms) != 0
-				if !destsExist && tpr.ratingStorage != nil {
-					if dbExists, err := tpr.ratingStorage.HasData(DESTINATION_PREFIX, drate.DestinationId); err != nil {
+				for _, dId := range []string{drate.DestinationId, drate.OriginId} { // the origin is a destination as well
+					if dId == utils.ANY || dId == "" {
+						continue // no need of loading the destinations in this case
+					}
+					tpDests, err := tpr.lr.GetTpDestinations(tpr.tpid, dId)
+					dms, err := TpDestinations(tpDests).GetDestinations()
+					if err != nil {
 						return false, err
-					} else if dbExists {
-						destsExist = true
 					}
-					continue
-				}
-				if !destsExist {
-					return false, fmt.Errorf("could not get destination for tag %v", drate.DestinationId)
-				}
-				for _, destination := range dms {
-					tpr.ratingStorage.SetDestination(destination)
+					destsExist := len(dms) != 0
+					if !destsExist && tpr.ratingStorage != nil {
+						if dbExists, err := tpr.ratingStorage.HasData(DESTINATION_PREFIX, dId); err != nil {
+							return false, err
+						} else if dbExists {
+							destsExist = true
+						}
+						continue
+					}
+					if !destsExist {
+						return false, fmt.Errorf("could not get destination for tag %v", dId)
+					}
+					for _, destination := range dms {
+						tpr.ratingStorage.SetDestination(destination)
+					}
 				}
 			}
+			ris, err := GetRateIntervals(rp, drm[rp.DestinationRatesId].DestinationRates)
+			if err != nil {
+				return false, err
+			}
+			for dId, ri := range ris {
+				ratingPlan.AddRateInterval(dId, ri)
+			}
 		}
 		if err := tpr.ratingStorage.SetRatingPlan(ratingPlan); err != nil {
 			return false, err
@@ -277,8 +324,12 @@ func (tpr *TpReader) LoadRatingPlans() (err error) {
 				plan = &RatingPlan{Id: tag}
 				tpr.ratingPlans[plan.Id] = plan
 			}
-			for _, dr := range drs.DestinationRates {
-				plan.AddRateInterval(dr.DestinationId, GetRateInterval(rplBnd, dr))
+			ris, err := GetRateIntervals(rplBnd, drs.DestinationRates)
+			if err != nil {
+				return err
+			}
+			for dId, ri := range ris {
+				plan.AddRateInterval(dId, ri)
 			}
 		}
 	}
@@ -303,6 +354,11 @@ func (tpr *TpReader) LoadRatingProfilesFiltered(qriedRpf *TpRatingProfile) error
 			if err != nil {
 				return fmt.Errorf("cannot parse activation time from %v", tpRa.ActivationTime)
 			}
+			if tpRa.Timezone != "" {
+				if _, err := utils.GetLocation(tpRa.Timezone); err != nil {
+					return fmt.Errorf("unknown timezone %v for rating profile %v", tpRa.Timezone, tpRpf.KeyId())
+				}
+			}
 			_, exists := tpr.ratingPlans[tpRa.RatingPlanId]
 			if !exists && tpr.ratingStorage != nil {
 				if exists, err = tpr.ratingStorage.HasData(RATING_PLAN_PREFIX, tpRa.RatingPlanId); err != nil {
@@ -318,6 +374,7 @@ func (tpr *TpReader) LoadRatingProfilesFiltered(qriedRpf *TpRatingProfile) error
 					RatingPlanId:    tpRa.RatingPlanId,
 					FallbackKeys:    utils.FallbackSubjKeys(tpRpf.Direction, tpRpf.Tenant, tpRpf.Category, tpRa.FallbackSubjects),
 					CdrStatQueueIds: strings.Split(tpRa.CdrStatQueueIds, utils.INFIELD_SEP),
+					Timezone:        tpRa.Timezone,
 				})
 		}
 		if err := tpr.ratingStorage.SetRatingProfile(resultRatingProfile); err != nil {
@@ -353,6 +410,11 @@ func (tpr *TpReader) LoadRatingProfiles() (err error) {
 			if err != nil {
 				return fmt.Errorf("cannot parse activation time from %v", tpRa.ActivationTime)
 			}
+			if tpRa.Timezone != "" {
+				if _, err := utils.GetLocation(tpRa.Timezone); err != nil {
+					return fmt.Errorf("unknown timezone %v for rating profile %v", tpRa.Timezone, tpRpf.KeyId())
+				}
+			}
 			_, exists := tpr.ratingPlans[tpRa.RatingPlanId]
 			if !exists && tpr.ratingStorage != nil { // Only query if there is a connection, eg on dry run there is none
 				if exists, err = tpr.ratingStorage.HasData(RATING_PLAN_PREFIX, tpRa.RatingPlanId); err != nil {
@@ -368,6 +430,7 @@ func (tpr *TpReader) LoadRatingProfiles() (err error) {
 					RatingPlanId:    tpRa.RatingPlanId,
 					FallbackKeys:    utils.FallbackSubjKeys(tpRpf.Direction, tpRpf.Tenant, tpRpf.Category, tpRa.FallbackSubjects),
 					CdrStatQueueIds: strings.Split(tpRa.CdrStatQueueIds, utils.INFIELD_SEP),
+					Timezone:        tpRa.Timezone,
 				})
 		}
 		tpr.ratingProfiles[tpRpf.KeyId()] = rpf
@@ -393,9 +456,8 @@ func (tpr *TpReader) LoadSharedGroupsFiltered(tag string, save bool) (err error)
 			}
 		}
 		for _, tpSg := range tpSgs {
-			sg.AccountParameters[tpSg.Account] = &SharingParameters{
-				Strategy:      tpSg.Strategy,
-				RatingSubject: tpSg.RatingSubject,
+			if sg.AccountParameters[tpSg.Account], err = NewSharingParameters(tpSg.Strategy, tpSg.RatingSubject); err != nil {
+				return err
 			}
 		}
 		tpr.sharedGroups[tag] = sg
@@ -414,6 +476,146 @@ func (tpr *TpReader) LoadSharedGroups() error {
 	return tpr.LoadSharedGroupsFiltered(tpr.tpid, false)
 }
 
+func (tpr *TpReader) LoadExchangeRatesFiltered(tag string, save bool) (err error) {
+	tps, err := tpr.lr.GetTpExchangeRates(tpr.tpid, tag)
+	if err != nil {
+		return err
+	}
+	storExrs, err := TpExchangeRates(tps).GetExchangeRates()
+	if err != nil {
+		return err
+	}
+	for _, tpExrs := range storExrs {
+		for _, tpExr := range tpExrs {
+			exr := &ExchangeRate{
+				FromCurrency: tpExr.FromCurrency,
+				ToCurrency:   tpExr.ToCurrency,
+				Rate:         utils.NewDecimalFromFloat(tpExr.Rate),
+			}
+			tpr.exchangeRates[exr.GetId()] = exr
+		}
+	}
+	if save {
+		for _, exr := range tpr.exchangeRates {
+			if err := tpr.ratingStorage.SetExchangeRate(exr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (tpr *TpReader) LoadExchangeRates() error {
+	return tpr.LoadExchangeRatesFiltered("", false)
+}
+
+func (tpr *TpReader) LoadTaxesFiltered(filter *TpTax, save bool) (err error) {
+	tps, err := tpr.lr.GetTpTaxes(filter)
+	if err != nil {
+		return err
+	}
+	storTaxes, err := TpTaxes(tps).GetTaxes()
+	if err != nil {
+		return err
+	}
+	for _, tpTaxes := range storTaxes {
+		taxes := &Taxes{Tenant: tpTaxes.Tenant, Category: tpTaxes.Category}
+		for _, tpTax := range tpTaxes.Taxes {
+			if tpTax.DestinationId != "" && tpTax.DestinationId != utils.ANY {
+				_, found := tpr.destinations[tpTax.DestinationId]
+				if !found {
+					if found, err = tpr.ratingStorage.HasData(DESTINATION_PREFIX, tpTax.DestinationId); err != nil {
+						return fmt.Errorf("[Taxes] error querying ratingDb %s", err.Error())
+					}
+				}
+				if !found {
+					return fmt.Errorf("[Taxes] could not find destination with tag %s", tpTax.DestinationId)
+				}
+			}
+			taxes.Rules = append(taxes.Rules, &TaxRule{
+				DestinationId: tpTax.DestinationId,
+				TaxId:         tpTax.TaxId,
+				Rate:          utils.NewDecimalFromFloat(tpTax.Rate),
+				Debit:         tpTax.Debit,
+				Weight:        tpTax.Weight,
+			})
+		}
+		tpr.taxes[taxes.GetId()] = taxes
+	}
+	if save {
+		for _, taxes := range tpr.taxes {
+			if err := tpr.ratingStorage.SetTaxes(taxes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (tpr *TpReader) LoadTaxes() error {
+	return tpr.LoadTaxesFiltered(&TpTax{Tpid: tpr.tpid}, false)
+}
+
+func (tpr *TpReader) LoadHolidayCalendarsFiltered(tag string, save bool) (err error) {
+	tps, err := tpr.lr.GetTpHolidays(tpr.tpid, tag)
+	if err != nil {
+		return err
+	}
+	storCalendars, err := TpHolidays(tps).GetHolidayCalendars()
+	if err != nil {
+		return err
+	}
+	for _, tpCalendar := range storCalendars {
+		hc := &HolidayCalendar{Id: tpCalendar.CalendarId}
+		for _, tpHoliday := range tpCalendar.Holidays {
+			holiday, err := NewHoliday(tpHoliday.Date, tpHoliday.Name)
+			if err != nil {
+				return fmt.Errorf("[HolidayCalendars] %s: %s", hc.Id, err.Error())
+			}
+			hc.Holidays = append(hc.Holidays, holiday)
+		}
+		tpr.holidayCalendars[hc.Id] = hc
+	}
+	if save {
+		for _, hc := range tpr.holidayCalendars {
+			if err := tpr.ratingStorage.SetHolidayCalendar(hc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (tpr *TpReader) LoadHolidayCalendars() error {
+	return tpr.LoadHolidayCalendarsFiltered("", false)
+}
+
+func (tpr *TpReader) LoadNumberPortabilityFiltered(number string, save bool) (err error) {
+	tps, err := tpr.lr.GetTpNumberPortability(tpr.tpid, number)
+	if err != nil {
+		return err
+	}
+	storPortedNumbers, err := TpNumberPortabilitys(tps).GetPortedNumbers()
+	if err != nil {
+		return err
+	}
+	for _, tpPortedNumber := range storPortedNumbers {
+		tpr.portedNumbers[tpPortedNumber.Number] = tpPortedNumber.RoutingNumber
+	}
+	if save {
+		for number, routingNumber := range tpr.portedNumbers {
+			if err := tpr.ratingStorage.SetRoutingNumber(number, routingNumber); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (tpr *TpReader) LoadNumberPortability() error {
+	return tpr.LoadNumberPortabilityFiltered("", false)
+}
+
 func (tpr *TpReader) LoadLCRs() (err error) {
 	tps, err := tpr.lr.GetTpLCRs(tpr.tpid, "")
 	if err != nil {
@@ -516,13 +718,14 @@ func (tpr *TpReader) LoadActions() (err error) {
 				Balance: &Balance{
 					Uuid:           utils.GenUUID(),
 					Id:             tpact.BalanceId,
-					Value:          tpact.Units,
+					Value:          utils.NewDecimalFromFloat(tpact.Units),
 					Weight:         tpact.BalanceWeight,
 					TimingIDs:      tpact.TimingTags,
 					RatingSubject:  tpact.RatingSubject,
 					Category:       tpact.Category,
 					DestinationIds: tpact.DestinationIds,
 					SharedGroup:    tpact.SharedGroup,
+					Currency:       tpact.Currency,
 				},
 			}
 			// load action timings from tags
@@ -534,6 +737,7 @@ func (tpr *TpReader) LoadActions() (err error) {
 							Years:     timing.Years,
 							Months:    timing.Months,
 							MonthDays: timing.MonthDays,
+							Holidays:  timing.Holidays,
 							WeekDays:  timing.WeekDays,
 							StartTime: timing.StartTime,
 							EndTime:   timing.EndTime,
@@ -584,6 +788,7 @@ func (tpr *TpReader) LoadActionPlans() (err error) {
 						Years:     t.Years,
 						Months:    t.Months,
 						MonthDays: t.MonthDays,
+						Holidays:  t.Holidays,
 						WeekDays:  t.WeekDays,
 						StartTime: t.StartTime,
 					},
@@ -709,6 +914,7 @@ func (tpr *TpReader) LoadAccountActionsFiltered(qriedAA *TpAccountAction) error
 						Timing: &RITiming{
 							Months:    t.Months,
 							MonthDays: t.MonthDays,
+							Holidays:  t.Holidays,
 							WeekDays:  t.WeekDays,
 							StartTime: t.StartTime,
 						},
@@ -804,10 +1010,11 @@ func (tpr *TpReader) LoadAccountActionsFiltered(qriedAA *TpAccountAction) error
 						ExpirationString: tpact.ExpiryTime,
 						Balance: &Balance{
 							Uuid:           utils.GenUUID(),
-							Value:          tpact.Units,
+							Value:          utils.NewDecimalFromFloat(tpact.Units),
 							Weight:         tpact.BalanceWeight,
 							RatingSubject:  tpact.RatingSubject,
 							DestinationIds: tpact.DestinationIds,
+							Currency:       tpact.Currency,
 						},
 					}
 				}
@@ -901,7 +1108,7 @@ func (tpr *TpReader) LoadDerivedChargersFiltered(filter *TpDerivedCharger, save
 		for _, tpDc := range tpDcs.DerivedChargers {
 			dc, err := utils.NewDerivedCharger(tpDc.RunId, tpDc.RunFilters, tpDc.ReqTypeField, tpDc.DirectionField, tpDc.TenantField, tpDc.CategoryField,
 				tpDc.AccountField, tpDc.SubjectField, tpDc.DestinationField, tpDc.SetupTimeField, tpDc.PddField, tpDc.AnswerTimeField, tpDc.UsageField, tpDc.SupplierField,
-				tpDc.DisconnectCauseField)
+				tpDc.DisconnectCauseField, tpDc.OriginField)
 			if err != nil {
 				return err
 			}
@@ -967,6 +1174,12 @@ func (tpr *TpReader) LoadAll() error {
 	if err = tpr.LoadDestinations(); err != nil {
 		return err
 	}
+	if err = tpr.LoadHolidayCalendars(); err != nil {
+		return err
+	}
+	if err = tpr.LoadNumberPortability(); err != nil {
+		return err
+	}
 	if err = tpr.LoadTimings(); err != nil {
 		return err
 	}
@@ -1006,11 +1219,23 @@ func (tpr *TpReader) LoadAll() error {
 	if err = tpr.LoadCdrStats(); err != nil {
 		return err
 	}
+	if err = tpr.LoadExchangeRates(); err != nil {
+		return err
+	}
+	if err = tpr.LoadTaxes(); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (tpr *TpReader) IsValid() bool {
 	valid := true
+	for dstTag, dst := range tpr.destinations {
+		if crazyPrefix := dst.getFirstInvalidPrefix(); crazyPrefix != "" {
+			log.Printf("The destination %s has the invalid prefix %q", dstTag, crazyPrefix)
+			valid = false
+		}
+	}
 	for rplTag, rpl := range tpr.ratingPlans {
 		if !rpl.isContinous() {
 			log.Printf("The rating plan %s is not covering all weekdays", rplTag)
@@ -1047,6 +1272,30 @@ func (tpr *TpReader) WriteToDatabase(flush, verbose bool) (err error) {
 			log.Print("\t", d.Id, " : ", d.Prefixes)
 		}
 	}
+	if verbose {
+		log.Print("Holiday calendars:")
+	}
+	for _, hc := range tpr.holidayCalendars {
+		err = tpr.ratingStorage.SetHolidayCalendar(hc)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			log.Print("\t", hc.Id)
+		}
+	}
+	if verbose {
+		log.Print("Ported numbers:")
+	}
+	for number, routingNumber := range tpr.portedNumbers {
+		err = tpr.ratingStorage.SetRoutingNumber(number, routingNumber)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			log.Print("\t", number, " : ", routingNumber)
+		}
+	}
 	if verbose {
 		log.Print("Rating Plans:")
 	}
@@ -1185,6 +1434,30 @@ func (tpr *TpReader) WriteToDatabase(flush, verbose bool) (err error) {
 			log.Print("\t", sq.Id)
 		}
 	}
+	if verbose {
+		log.Print("Exchange Rates:")
+	}
+	for key, exr := range tpr.exchangeRates {
+		err = tpr.ratingStorage.SetExchangeRate(exr)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			log.Print("\t", key)
+		}
+	}
+	if verbose {
+		log.Print("Taxes:")
+	}
+	for key, taxes := range tpr.taxes {
+		err = tpr.ratingStorage.SetTaxes(taxes)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			log.Print("\t", key)
+		}
+	}
 	return
 }
 
@@ -1245,6 +1518,14 @@ func (tpr *TpReader) ShowStatistics() {
 	log.Print("LCR rules: ", len(tpr.lcrs))
 	// cdr stats
 	log.Print("CDR stats: ", len(tpr.cdrStats))
+	// exchange rates
+	log.Print("Exchange rates: ", len(tpr.exchangeRates))
+	// taxes
+	log.Print("Taxes: ", len(tpr.taxes))
+	// holiday calendars
+	log.Print("Holiday calendars: ", len(tpr.holidayCalendars))
+	// ported numbers
+	log.Print("Ported numbers: ", len(tpr.portedNumbers))
 }
 
 // Returns the identities loaded for a specific category, useful for cache reloads
@@ -1330,6 +1611,38 @@ func (tpr *TpReader) GetLoadedIds(categ string) ([]string, error) {
 			i++
 		}
 		return keys, nil
+	case EXCHANGE_RATE_PREFIX:
+		keys := make([]string, len(tpr.exchangeRates))
+		i := 0
+		for k := range tpr.exchangeRates {
+			keys[i] = k
+			i++
+		}
+		return keys, nil
+	case TAXES_PREFIX:
+		keys := make([]string, len(tpr.taxes))
+		i := 0
+		for k := range tpr.taxes {
+			keys[i] = k
+			i++
+		}
+		return keys, nil
+	case HOLIDAY_CALENDAR_PREFIX:
+		keys := make([]string, len(tpr.holidayCalendars))
+		i := 0
+		for k := range tpr.holidayCalendars {
+			keys[i] = k
+			i++
+		}
+		return keys, nil
+	case NUMBER_PORTABILITY_PREFIX:
+		keys := make([]string, len(tpr.portedNumbers))
+		i := 0
+		for k := range tpr.portedNumbers {
+			keys[i] = k
+			i++
+		}
+		return keys, nil
 	}
 	return nil, errors.New("Unsupported category")
 }
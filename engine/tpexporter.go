@@ -152,6 +152,38 @@ func (self *TPExporter) Run() error {
 		}
 	}
 
+	if storData, err := self.storDb.GetTpExchangeRates(self.tpID, ""); err != nil {
+		return err
+	} else {
+		for _, sd := range storData {
+			toExportMap[utils.EXCHANGE_RATES_CSV] = append(toExportMap[utils.EXCHANGE_RATES_CSV], sd)
+		}
+	}
+
+	if storData, err := self.storDb.GetTpTaxes(&TpTax{Tpid: self.tpID}); err != nil {
+		return err
+	} else {
+		for _, sd := range storData {
+			toExportMap[utils.TAXES_CSV] = append(toExportMap[utils.TAXES_CSV], sd)
+		}
+	}
+
+	if storData, err := self.storDb.GetTpHolidays(self.tpID, ""); err != nil {
+		return err
+	} else {
+		for _, sd := range storData {
+			toExportMap[utils.HOLIDAYS_CSV] = append(toExportMap[utils.HOLIDAYS_CSV], sd)
+		}
+	}
+
+	if storData, err := self.storDb.GetTpNumberPortability(self.tpID, ""); err != nil {
+		return err
+	} else {
+		for _, sd := range storData {
+			toExportMap[utils.NUMBER_PORTABILITY_CSV] = append(toExportMap[utils.NUMBER_PORTABILITY_CSV], sd)
+		}
+	}
+
 	if storData, err := self.storDb.GetTpActions(self.tpID, ""); err != nil {
 		return err
 	} else {
@@ -41,19 +41,23 @@ type TPCSVImporter struct {
 // Maps csv file to handler which should process it. Defined like this since tests on 1.0.3 were failing on Travis.
 // Change it to func(string) error as soon as Travis updates.
 var fileHandlers = map[string]func(*TPCSVImporter, string) error{
-	utils.TIMINGS_CSV:           (*TPCSVImporter).importTimings,
-	utils.DESTINATIONS_CSV:      (*TPCSVImporter).importDestinations,
-	utils.RATES_CSV:             (*TPCSVImporter).importRates,
-	utils.DESTINATION_RATES_CSV: (*TPCSVImporter).importDestinationRates,
-	utils.RATING_PLANS_CSV:      (*TPCSVImporter).importRatingPlans,
-	utils.RATING_PROFILES_CSV:   (*TPCSVImporter).importRatingProfiles,
-	utils.SHARED_GROUPS_CSV:     (*TPCSVImporter).importSharedGroups,
-	utils.ACTIONS_CSV:           (*TPCSVImporter).importActions,
-	utils.ACTION_PLANS_CSV:      (*TPCSVImporter).importActionTimings,
-	utils.ACTION_TRIGGERS_CSV:   (*TPCSVImporter).importActionTriggers,
-	utils.ACCOUNT_ACTIONS_CSV:   (*TPCSVImporter).importAccountActions,
-	utils.DERIVED_CHARGERS_CSV:  (*TPCSVImporter).importDerivedChargers,
-	utils.CDR_STATS_CSV:         (*TPCSVImporter).importCdrStats,
+	utils.TIMINGS_CSV:            (*TPCSVImporter).importTimings,
+	utils.DESTINATIONS_CSV:       (*TPCSVImporter).importDestinations,
+	utils.RATES_CSV:              (*TPCSVImporter).importRates,
+	utils.DESTINATION_RATES_CSV:  (*TPCSVImporter).importDestinationRates,
+	utils.RATING_PLANS_CSV:       (*TPCSVImporter).importRatingPlans,
+	utils.RATING_PROFILES_CSV:    (*TPCSVImporter).importRatingProfiles,
+	utils.SHARED_GROUPS_CSV:      (*TPCSVImporter).importSharedGroups,
+	utils.ACTIONS_CSV:            (*TPCSVImporter).importActions,
+	utils.ACTION_PLANS_CSV:       (*TPCSVImporter).importActionTimings,
+	utils.ACTION_TRIGGERS_CSV:    (*TPCSVImporter).importActionTriggers,
+	utils.ACCOUNT_ACTIONS_CSV:    (*TPCSVImporter).importAccountActions,
+	utils.DERIVED_CHARGERS_CSV:   (*TPCSVImporter).importDerivedChargers,
+	utils.CDR_STATS_CSV:          (*TPCSVImporter).importCdrStats,
+	utils.EXCHANGE_RATES_CSV:     (*TPCSVImporter).importExchangeRates,
+	utils.TAXES_CSV:              (*TPCSVImporter).importTaxes,
+	utils.HOLIDAYS_CSV:           (*TPCSVImporter).importHolidays,
+	utils.NUMBER_PORTABILITY_CSV: (*TPCSVImporter).importNumberPortability,
 }
 
 func (self *TPCSVImporter) Run() error {
@@ -71,7 +75,11 @@ func (self *TPCSVImporter) Run() error {
 		path.Join(self.DirPath, utils.ACTION_TRIGGERS_CSV),
 		path.Join(self.DirPath, utils.ACCOUNT_ACTIONS_CSV),
 		path.Join(self.DirPath, utils.DERIVED_CHARGERS_CSV),
-		path.Join(self.DirPath, utils.CDR_STATS_CSV))
+		path.Join(self.DirPath, utils.CDR_STATS_CSV),
+		path.Join(self.DirPath, utils.EXCHANGE_RATES_CSV),
+		path.Join(self.DirPath, utils.TAXES_CSV),
+		path.Join(self.DirPath, utils.HOLIDAYS_CSV),
+		path.Join(self.DirPath, utils.NUMBER_PORTABILITY_CSV))
 	files, _ := ioutil.ReadDir(self.DirPath)
 	for _, f := range files {
 		fHandler, hasName := fileHandlers[f.Name()]
@@ -198,6 +206,58 @@ func (self *TPCSVImporter) importSharedGroups(fn string) error {
 	return self.StorDb.SetTpSharedGroups(tps)
 }
 
+func (self *TPCSVImporter) importExchangeRates(fn string) error {
+	if self.Verbose {
+		log.Printf("Processing file: <%s> ", fn)
+	}
+	tps, err := self.csvr.GetTpExchangeRates(self.TPid, "")
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(tps); i++ {
+		tps[i].Tpid = self.TPid
+	}
+
+	return self.StorDb.SetTpExchangeRates(tps)
+}
+
+func (self *TPCSVImporter) importTaxes(fn string) error {
+	if self.Verbose {
+		log.Printf("Processing file: <%s> ", fn)
+	}
+	tps, err := self.csvr.GetTpTaxes(nil)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(tps); i++ {
+		tps[i].Tpid = self.TPid
+	}
+
+	return self.StorDb.SetTpTaxes(tps)
+}
+
+func (self *TPCSVImporter) importHolidays(fn string) error {
+	if self.Verbose {
+		log.Printf("Processing file: <%s> ", fn)
+	}
+	tps, err := self.csvr.GetTpHolidays(self.TPid, "")
+	if err != nil {
+		return err
+	}
+	return self.StorDb.SetTpHolidays(tps)
+}
+
+func (self *TPCSVImporter) importNumberPortability(fn string) error {
+	if self.Verbose {
+		log.Printf("Processing file: <%s> ", fn)
+	}
+	tps, err := self.csvr.GetTpNumberPortability(self.TPid, "")
+	if err != nil {
+		return err
+	}
+	return self.StorDb.SetTpNumberPortability(tps)
+}
+
 func (self *TPCSVImporter) importActions(fn string) error {
 	if self.Verbose {
 		log.Printf("Processing file: <%s> ", fn)
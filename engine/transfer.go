@@ -0,0 +1,179 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// How long the transfer action waits for the receiving account, the source one is already locked by the action executor
+const TRANSFER_LOCK_TIMEOUT = 5 * time.Second
+
+// Parameters of the *transfer_balance action
+type transferParameters struct {
+	Account string // key of the account receiving the balance, eg: *out:cgrates.org:customer
+}
+
+// Moves the action balance value from one account to the other.
+// The source balances are selected with the action filter and the receiving balance is matched or created with the same one.
+func transferBalance(from, to *Account, a *Action) error {
+	if a == nil || a.Balance == nil || a.Balance.Value.Sign() <= 0 {
+		return errors.New("nothing to transfer")
+	}
+	if from.Id == to.Id {
+		return errors.New("cannot transfer to the same account")
+	}
+	amount := a.Balance.Value
+	from.CleanExpiredBalances()
+	var sources BalanceChain
+	var available utils.Decimal
+	for _, b := range from.BalanceMap[a.BalanceType+a.Direction] {
+		if b.IsExpired() || b.Value.Sign() <= 0 || !b.MatchFilter(a.Balance) {
+			continue
+		}
+		sources = append(sources, b)
+		available = available.Add(b.Value)
+	}
+	if available.Cmp(amount) < 0 {
+		return ErrInsufficientCredit
+	}
+	left := amount
+	for _, b := range sources {
+		debit := b.Value
+		if debit.Cmp(left) > 0 {
+			debit = left
+		}
+		b.SubstractAmount(debit)
+		if left = left.Sub(debit); left.Sign() <= 0 {
+			break
+		}
+	}
+	topup := a.Clone()
+	topup.Balance.Uuid = ""
+	topup.Balance.Value = amount.Neg()
+	if to.BalanceMap == nil {
+		to.BalanceMap = make(map[string]BalanceChain)
+	}
+	if err := to.debitBalanceAction(topup, false); err != nil {
+		return err
+	}
+	Logger.Info(fmt.Sprintf("Transferred %v %s from account %s", amount, a.BalanceType+a.Direction, from.Id))
+	Logger.Info(fmt.Sprintf("Transferred %v %s to account %s", amount, a.BalanceType+a.Direction, to.Id))
+	return nil
+}
+
+// The account executing the action is the source, the receiving one is in the extra parameters.
+// The executor holds the lock of the source account and saves it, the action locks and saves the receiving one.
+func transferBalanceAction(ub *Account, sq *StatsQueueTriggered, a *Action, acs Actions) (err error) {
+	if ub == nil {
+		return errors.New("nil user balance")
+	}
+	var params transferParameters
+	if err = json.Unmarshal([]byte(a.ExtraParameters), &params); err != nil || params.Account == "" {
+		return errors.New("missing transfer account")
+	}
+	if params.Account == ub.Id {
+		return errors.New("cannot transfer to the same account")
+	}
+	if a.Balance == nil || a.Balance.Value.Sign() <= 0 {
+		return errors.New("nothing to transfer")
+	}
+	_, err = AccLock.GuardWithin(func() (interface{}, error) {
+		return 0, transferFromAccount(ub, params.Account, a)
+	}, TRANSFER_LOCK_TIMEOUT, params.Account)
+	return
+}
+
+// Transfers from the account locked by the caller, its balances are restored if the receiving account is not saved
+func transferFromAccount(from *Account, toKey string, a *Action) error {
+	to, err := accountingStorage.GetAccount(toKey)
+	if err != nil {
+		return err
+	}
+	if to.Disabled {
+		return fmt.Errorf("User %s is disabled", to.Id)
+	}
+	original := from.Clone()
+	from.collectLedger() // the changes made so far keep their cause
+	prevCause, prevCauseId := from.SetLedgerCause(LEDGER_TRANSFER, to.Id)
+	defer from.SetLedgerCause(prevCause, prevCauseId)
+	to.SetLedgerCause(LEDGER_TRANSFER, from.Id)
+	if err := transferBalance(from, to, a); err != nil {
+		from.BalanceMap = original.BalanceMap
+		return err
+	}
+	if err := accountingStorage.SetAccount(to); err != nil {
+		from.BalanceMap = original.BalanceMap
+		return err
+	}
+	from.collectLedger()
+	from.executeActionTriggers(nil)
+	return nil
+}
+
+// Transfers the action balance between the two accounts, both locked for the whole operation.
+// The accounts are saved once the debit and the credit succeeded, the source is restored if the destination cannot be saved.
+func TransferBalance(fromKey, toKey string, a *Action) error {
+	if fromKey == toKey {
+		return errors.New("cannot transfer to the same account")
+	}
+	_, err := AccLock.Guard(func() (interface{}, error) {
+		from, err := accountingStorage.GetAccount(fromKey)
+		if err != nil {
+			return 0, err
+		}
+		to, err := accountingStorage.GetAccount(toKey)
+		if err != nil {
+			return 0, err
+		}
+		for _, acc := range []*Account{from, to} {
+			if acc.Disabled {
+				return 0, fmt.Errorf("User %s is disabled", acc.Id)
+			}
+		}
+		original, err := accountingStorage.GetAccount(fromKey) // untouched copy for the rollback
+		if err != nil {
+			return 0, err
+		}
+		from.SetLedgerCause(LEDGER_TRANSFER, to.Id)
+		to.SetLedgerCause(LEDGER_TRANSFER, from.Id)
+		if err := transferBalance(from, to, a); err != nil {
+			return 0, err
+		}
+		if err := accountingStorage.SetAccount(from); err != nil {
+			return 0, err
+		}
+		if err := accountingStorage.SetAccount(to); err != nil {
+			// the ledger records the rollback against the saved balances
+			original.ledgerBalances = from.ledgerBalances
+			original.SetLedgerCause(LEDGER_TRANSFER, to.Id)
+			if rbErr := accountingStorage.SetAccount(original); rbErr != nil {
+				Logger.Crit(fmt.Sprintf("<TransferBalance> Could not restore account %s after failing to save %s: %v", from.Id, to.Id, rbErr))
+			}
+			return 0, err
+		}
+		from.executeActionTriggers(nil)
+		return 0, nil
+	}, fromKey, toKey)
+	return err
+}
@@ -0,0 +1,129 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestTransferBalance(t *testing.T) {
+	from := &Account{Id: "*out:cgrates.org:reseller", BalanceMap: map[string]BalanceChain{
+		utils.MONETARY + OUTBOUND: BalanceChain{
+			&Balance{Uuid: "nat", Value: utils.NewDecimalFromFloat(5), DestinationIds: "NAT"},
+			&Balance{Uuid: "any", Value: utils.NewDecimalFromFloat(10)},
+			&Balance{Uuid: "nat2", Value: utils.NewDecimalFromFloat(4), DestinationIds: "NAT"},
+		}}}
+	to := &Account{Id: "*out:cgrates.org:customer"}
+	a := &Action{ActionType: TRANSFER_BALANCE, BalanceType: utils.MONETARY, Direction: OUTBOUND,
+		Balance: &Balance{Value: utils.NewDecimalFromFloat(10), DestinationIds: "NAT"}}
+	if err := transferBalance(from, to, a); err != ErrInsufficientCredit {
+		t.Error("Expecting insufficient credit, got: ", err)
+	}
+	a.Balance.Value = utils.NewDecimalFromFloat(7)
+	if err := transferBalance(from, to, a); err != nil {
+		t.Fatal("Error transferring: ", err)
+	}
+	if chain := from.BalanceMap[utils.MONETARY+OUTBOUND]; !chain[0].Value.IsZero() ||
+		chain[1].Value.String() != "10" || chain[2].Value.String() != "2" {
+		t.Errorf("Wrong source balances: %+v, %+v, %+v", chain[0], chain[1], chain[2])
+	}
+	if chain := to.BalanceMap[utils.MONETARY+OUTBOUND]; len(chain) != 1 ||
+		chain[0].Value.String() != "7" || chain[0].DestinationIds != "NAT" || chain[0].Uuid == "" {
+		t.Errorf("Wrong received balance: %+v", chain)
+	}
+	if err := transferBalance(from, from, a); err == nil {
+		t.Error("Expecting error on transfer to the same account")
+	}
+}
+
+func TestTransferBalanceAction(t *testing.T) {
+	accountingStorage.SetAccount(&Account{Id: "*out:cgrates.org:sub"})
+	from := &Account{Id: "*out:cgrates.org:parent", BalanceMap: map[string]BalanceChain{
+		utils.VOICE + OUTBOUND: BalanceChain{&Balance{Uuid: "minutes", Value: utils.NewDecimalFromFloat(100)}},
+	}}
+	a := &Action{ActionType: TRANSFER_BALANCE, BalanceType: utils.VOICE, Direction: OUTBOUND,
+		ExtraParameters: `{"Account":"*out:cgrates.org:sub"}`, Balance: &Balance{Value: utils.NewDecimalFromFloat(60)}}
+	transferFunc, exists := getActionFunc(TRANSFER_BALANCE)
+	if !exists {
+		t.Fatal("Transfer action not registered")
+	}
+	accountingStorage.SetAccount(from)
+	// the action runs under the lock of the source account, as the action executors do
+	_, err := AccLock.Guard(func() (interface{}, error) {
+		return 0, transferFunc(from, nil, a, nil)
+	}, from.Id)
+	if err != nil {
+		t.Fatal("Error executing transfer: ", err)
+	}
+	if to, err := accountingStorage.GetAccount("*out:cgrates.org:sub"); err != nil || to.BalanceMap[utils.VOICE+OUTBOUND].GetTotalValue().String() != "60" {
+		t.Fatalf("Wrong receiving account: %+v, %v", to, err)
+	}
+	if from.BalanceMap[utils.VOICE+OUTBOUND].GetTotalValue().String() != "40" {
+		t.Errorf("Wrong source account: %+v", from.BalanceMap)
+	}
+	accountingStorage.SetAccount(from)
+	if err := transferFunc(from, nil, &Action{ExtraParameters: `{"Account":"*out:cgrates.org:sub"}`}, nil); err == nil {
+		t.Error("Expecting error on transfer without value")
+	}
+	if err := TransferBalance("*out:cgrates.org:sub", from.Id, &Action{BalanceType: utils.VOICE, Direction: OUTBOUND,
+		Balance: &Balance{Value: utils.NewDecimalFromFloat(15)}}); err != nil {
+		t.Fatal("Error transferring back: ", err)
+	}
+	if acc, err := accountingStorage.GetAccount(from.Id); err != nil ||
+		acc.BalanceMap[utils.VOICE+OUTBOUND].GetTotalValue().String() != "55" {
+		t.Errorf("Wrong account after transfer back: %+v, %v", acc, err)
+	}
+}
+
+func TestTransferBalanceActionFailed(t *testing.T) {
+	from := &Account{Id: "*out:cgrates.org:parent_failed", BalanceMap: map[string]BalanceChain{
+		utils.VOICE + OUTBOUND: BalanceChain{&Balance{Uuid: "minutes", Value: utils.NewDecimalFromFloat(100)}},
+	}}
+	transferFunc, _ := getActionFunc(TRANSFER_BALANCE)
+	a := &Action{ActionType: TRANSFER_BALANCE, BalanceType: utils.VOICE, Direction: OUTBOUND,
+		ExtraParameters: `{"Account":"*out:cgrates.org:missing"}`, Balance: &Balance{Value: utils.NewDecimalFromFloat(60)}}
+	if err := transferFunc(from, nil, a, nil); err == nil {
+		t.Error("Expecting error on transfer to a missing account")
+	}
+	accountingStorage.SetAccount(&Account{Id: "*out:cgrates.org:sub_failed"})
+	a.ExtraParameters = `{"Account":"*out:cgrates.org:sub_failed"}`
+	a.Balance.Value = utils.NewDecimalFromFloat(160)
+	if err := transferFunc(from, nil, a, nil); err != ErrInsufficientCredit {
+		t.Error("Expecting insufficient credit, got: ", err)
+	}
+	if from.BalanceMap[utils.VOICE+OUTBOUND].GetTotalValue().String() != "100" {
+		t.Errorf("Failed transfer changed the source account: %+v", from.BalanceMap)
+	}
+	if to, err := accountingStorage.GetAccount("*out:cgrates.org:sub_failed"); err != nil || len(to.BalanceMap) != 0 {
+		t.Errorf("Failed transfer changed the receiving account: %+v, %v", to, err)
+	}
+	// the receiving account is busy for longer than the action waits
+	AccLock.Guard(func() (interface{}, error) {
+		a.Balance.Value = utils.NewDecimalFromFloat(10)
+		_, err := AccLock.GuardWithin(func() (interface{}, error) {
+			return 0, transferFromAccount(from, "*out:cgrates.org:sub_failed", a)
+		}, time.Millisecond, "*out:cgrates.org:sub_failed")
+		if err != ErrLockTimeout {
+			t.Error("Expecting lock timeout, got: ", err)
+		}
+		return 0, nil
+	}, "*out:cgrates.org:sub_failed")
+}
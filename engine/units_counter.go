@@ -20,8 +20,8 @@ package engine
 
 import (
 	"strings"
+	"time"
 
-	"github.com/cgrates/cgrates/cache2go"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -29,6 +29,8 @@ import (
 type UnitsCounter struct {
 	Direction   string
 	BalanceType string
+	ResetWindow string    // *daily, *weekly, *monthly[:day] or *rolling:days, empty resets only through the actions
+	WindowStart time.Time // start of the window the units were counted in
 	//	Units     float64
 	Balances BalanceChain // first balance is the general one (no destination)
 }
@@ -49,7 +51,7 @@ func (uc *UnitsCounter) initBalances(ats []*ActionTrigger) {
 		for _, a := range acs {
 			if a.Balance != nil {
 				b := a.Balance.Clone()
-				b.Value = 0
+				b.Value = utils.Decimal{}
 				if !uc.Balances.HasBalance(b) {
 					uc.Balances = append(uc.Balances, b)
 				}
@@ -69,18 +71,17 @@ func (uc *UnitsCounter) GetGeneralBalance() *Balance {
 
 // Adds the units from the received balance to an existing balance if the destination
 // is the same or ads the balance to the list if none matches.
-func (uc *UnitsCounter) addUnits(amount float64, prefix string) {
+func (uc *UnitsCounter) addUnits(amount utils.Decimal, prefix string) {
 	counted := false
 	if prefix != "" {
 		for _, mb := range uc.Balances {
 			if !mb.HasDestination() {
 				continue
 			}
-			for _, p := range utils.SplitPrefix(prefix, MIN_PREFIX_MATCH) {
-				if x, err := cache2go.GetCached(DESTINATION_PREFIX + p); err == nil {
-					destIds := x.(map[interface{}]struct{})
-					if _, found := destIds[mb.DestinationIds]; found {
-						mb.Value += amount
+			for _, match := range destIndex.Match(prefix) {
+				for _, dId := range match.Ids {
+					if dId == mb.DestinationIds {
+						mb.Value = mb.Value.Add(amount)
 						counted = true
 						break
 					}
@@ -94,7 +95,50 @@ func (uc *UnitsCounter) addUnits(amount float64, prefix string) {
 	if !counted {
 		// use general balance
 		b := uc.GetGeneralBalance()
-		b.Value += amount
+		b.Value = b.Value.Add(amount)
+	}
+}
+
+// Usage of a certain type accumulated in the current billing period, selects the rate tiers
+type UsageCounter struct {
+	Direction   string
+	TOR         string
+	ResetWindow string // billing period as the counters reset window, the calendar month if empty
+	PeriodStart time.Time
+	Usage       time.Duration
+}
+
+// The billing periods are the calendar months
+func getBillingPeriodStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+func (uc *UsageCounter) getPeriodStart(t time.Time) time.Time {
+	if uc.ResetWindow != "" {
+		if cw, err := parseCounterWindow(uc.ResetWindow); err == nil {
+			return cw.getStart(t, uc.PeriodStart)
+		}
+	}
+	return getBillingPeriodStart(t)
+}
+
+// Returns the usage accumulated before the time t, zero if t is in another billing period
+func (uc *UsageCounter) getUsage(t time.Time) time.Duration {
+	if !uc.getPeriodStart(t).Equal(uc.PeriodStart) {
+		return 0
+	}
+	return uc.Usage
+}
+
+// Adds the usage done at the time t, a new billing period starts the counting from zero
+func (uc *UsageCounter) addUsage(usage time.Duration, t time.Time) {
+	if periodStart := uc.getPeriodStart(t); periodStart.After(uc.PeriodStart) {
+		uc.PeriodStart = periodStart
+		uc.Usage = 0
+	}
+	uc.Usage += usage
+	if uc.Usage < 0 {
+		uc.Usage = 0
 	}
 }
 
@@ -20,6 +20,7 @@ package engine
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -28,9 +29,9 @@ func TestUnitsCounterAddBalance(t *testing.T) {
 	uc := &UnitsCounter{
 		Direction:   OUTBOUND,
 		BalanceType: utils.SMS,
-		Balances:    BalanceChain{&Balance{Value: 1}, &Balance{Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}},
+		Balances:    BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}, &Balance{Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}},
 	}
-	uc.addUnits(20, "test")
+	uc.addUnits(utils.NewDecimalFromFloat(20), "test")
 	if len(uc.Balances) != 3 {
 		t.Error("Error adding minute bucket: ", uc.Balances)
 	}
@@ -40,10 +41,26 @@ func TestUnitsCounterAddBalanceExists(t *testing.T) {
 	uc := &UnitsCounter{
 		Direction:   OUTBOUND,
 		BalanceType: utils.SMS,
-		Balances:    BalanceChain{&Balance{Value: 1}, &Balance{Value: 10, Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}},
+		Balances:    BalanceChain{&Balance{Value: utils.NewDecimalFromFloat(1)}, &Balance{Value: utils.NewDecimalFromFloat(10), Weight: 20, DestinationIds: "NAT"}, &Balance{Weight: 10, DestinationIds: "RET"}},
 	}
-	uc.addUnits(5, "0723")
-	if len(uc.Balances) != 3 || uc.Balances[1].Value != 15 {
+	uc.addUnits(utils.NewDecimalFromFloat(5), "0723")
+	if len(uc.Balances) != 3 || uc.Balances[1].Value.Float64() != 15 {
 		t.Error("Error adding minute bucket!")
 	}
 }
+
+func TestUsageCounterBillingPeriod(t *testing.T) {
+	uc := &UsageCounter{Direction: OUTBOUND, TOR: utils.VOICE}
+	uc.addUsage(10*time.Minute, time.Date(2015, 6, 10, 10, 0, 0, 0, time.UTC))
+	uc.addUsage(5*time.Minute, time.Date(2015, 6, 30, 23, 0, 0, 0, time.UTC))
+	if usage := uc.getUsage(time.Date(2015, 6, 30, 23, 30, 0, 0, time.UTC)); usage != 15*time.Minute {
+		t.Error("Wrong usage in the billing period: ", usage)
+	}
+	if usage := uc.getUsage(time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC)); usage != 0 {
+		t.Error("Usage carried in the next billing period: ", usage)
+	}
+	uc.addUsage(time.Minute, time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC))
+	if uc.Usage != time.Minute || !uc.PeriodStart.Equal(time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Error starting a new billing period: %+v", uc)
+	}
+}
@@ -44,21 +44,21 @@ func TestAcntActsLoadCsv(t *testing.T) {
 	ratingProfiles := ``
 	sharedGroups := ``
 	lcrs := ``
-	actions := `TOPUP10_AC,*topup_reset,,,*voice,*out,,*any,,,*unlimited,,10,10,10
-DISABLE_ACNT,*disable_account,,,,,,,,,,,,,10
-ENABLE_ACNT,*enable_account,,,,,,,,,,,,,10`
+	actions := `TOPUP10_AC,*topup_reset,,,*voice,*out,,*any,,,*unlimited,,10,10,10,
+DISABLE_ACNT,*disable_account,,,,,,,,,,,,,10,
+ENABLE_ACNT,*enable_account,,,,,,,,,,,,,10,`
 	actionPlans := `TOPUP10_AT,TOPUP10_AC,ASAP,10`
 	actionTriggers := ``
 	accountActions := `cgrates.org,1,*out,TOPUP10_AT,`
 	derivedCharges := ``
 	cdrStats := ``
 	csvr := engine.NewTpReader(ratingDbAcntActs, acntDbAcntActs, engine.NewStringCSVStorage(',', destinations, timings, rates, destinationRates, ratingPlans, ratingProfiles,
-		sharedGroups, lcrs, actions, actionPlans, actionTriggers, accountActions, derivedCharges, cdrStats), "")
+		sharedGroups, lcrs, actions, actionPlans, actionTriggers, accountActions, derivedCharges, cdrStats, "", "", "", ""), "")
 	if err := csvr.LoadAll(); err != nil {
 		t.Fatal(err)
 	}
 	csvr.WriteToDatabase(false, false)
-	ratingDbAcntActs.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingDbAcntActs.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	acntDbAcntActs.CacheAccounting(nil, nil, nil)
 	expectAcnt := &engine.Account{Id: "*out:cgrates.org:1"}
 	if acnt, err := acntDbAcntActs.GetAccount("*out:cgrates.org:1"); err != nil {
@@ -40,21 +40,21 @@ ASAP,*any,*any,*any,*any,*asap`
 GERMANY_MOBILE,+4915
 GERMANY_MOBILE,+4916
 GERMANY_MOBILE,+4917`
-	rates := `RT_1CENT,0,1,1s,1s,0s
-RT_DATA_2c,0,0.002,10,10,0
-RT_SMS_5c,0,0.005,1,1,0`
-	destinationRates := `DR_RETAIL,GERMANY,RT_1CENT,*up,4,0,
-DR_RETAIL,GERMANY_MOBILE,RT_1CENT,*up,4,0,
-DR_DATA_1,*any,RT_DATA_2c,*up,4,0,
-DR_SMS_1,*any,RT_SMS_5c,*up,4,0,`
+	rates := `RT_1CENT,0,1,1s,1s,0s,,
+RT_DATA_2c,0,0.002,10,10,0,,
+RT_SMS_5c,0,0.005,1,1,0,,`
+	destinationRates := `DR_RETAIL,GERMANY,RT_1CENT,*up,4,0,,,,
+DR_RETAIL,GERMANY_MOBILE,RT_1CENT,*up,4,0,,,,
+DR_DATA_1,*any,RT_DATA_2c,*up,4,0,,,,
+DR_SMS_1,*any,RT_SMS_5c,*up,4,0,,,,`
 	ratingPlans := `RP_RETAIL,DR_RETAIL,ALWAYS,10
 RP_DATA1,DR_DATA_1,ALWAYS,10
 RP_SMS1,DR_SMS_1,ALWAYS,10`
-	ratingProfiles := `*out,cgrates.org,call,*any,2012-01-01T00:00:00Z,RP_RETAIL,,
-*out,cgrates.org,data,*any,2012-01-01T00:00:00Z,RP_DATA1,,
-*out,cgrates.org,sms,*any,2012-01-01T00:00:00Z,RP_SMS1,,`
+	ratingProfiles := `*out,cgrates.org,call,*any,2012-01-01T00:00:00Z,RP_RETAIL,,,
+*out,cgrates.org,data,*any,2012-01-01T00:00:00Z,RP_DATA1,,,
+*out,cgrates.org,sms,*any,2012-01-01T00:00:00Z,RP_SMS1,,,`
 	csvr := engine.NewTpReader(ratingDb, acntDb, engine.NewStringCSVStorage(',', dests, timings, rates, destinationRates, ratingPlans, ratingProfiles,
-		"", "", "", "", "", "", "", ""), "")
+		"", "", "", "", "", "", "", "", "", "", "", ""), "")
 
 	if err := csvr.LoadTimings(); err != nil {
 		t.Fatal(err)
@@ -75,7 +75,7 @@ RP_SMS1,DR_SMS_1,ALWAYS,10`
 		t.Fatal(err)
 	}
 	csvr.WriteToDatabase(false, false)
-	ratingDb.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingDb.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	if cachedRPlans := cache2go.CountEntries(engine.RATING_PLAN_PREFIX); cachedRPlans != 3 {
 		t.Error("Wrong number of cached rating plans found", cachedRPlans)
@@ -100,7 +100,7 @@ func TestCosts1GetCost1(t *testing.T) {
 	}
 	if cc, err := cd.GetCost(); err != nil {
 		t.Error(err)
-	} else if cc.Cost != 90 {
+	} else if cc.Cost.Float64() != 90 {
 		t.Error("Wrong cost returned: ", cc.Cost)
 	}
 }
@@ -120,7 +120,7 @@ func TestCosts1GetCostZeroDuration(t *testing.T) {
 	}
 	if cc, err := cd.GetCost(); err != nil {
 		t.Error(err)
-	} else if cc.Cost != 0 {
+	} else if cc.Cost.Float64() != 0 {
 		t.Error("Wrong cost returned: ", cc.Cost)
 	}
 }
@@ -37,15 +37,15 @@ func TestSetStorageDtChrg1(t *testing.T) {
 func TestLoadCsvTpDtChrg1(t *testing.T) {
 	timings := `TM1,*any,*any,*any,*any,00:00:00
 TM2,*any,*any,*any,*any,01:00:00`
-	rates := `RT_DATA_2c,0,0.002,10,10,0
-RT_DATA_1c,0,0.001,10,10,0`
-	destinationRates := `DR_DATA_1,*any,RT_DATA_2c,*up,4,0,
-DR_DATA_2,*any,RT_DATA_1c,*up,4,0,`
+	rates := `RT_DATA_2c,0,0.002,10,10,0,,
+RT_DATA_1c,0,0.001,10,10,0,,`
+	destinationRates := `DR_DATA_1,*any,RT_DATA_2c,*up,4,0,,,,
+DR_DATA_2,*any,RT_DATA_1c,*up,4,0,,,,`
 	ratingPlans := `RP_DATA1,DR_DATA_1,TM1,10
 RP_DATA1,DR_DATA_2,TM2,10`
-	ratingProfiles := `*out,cgrates.org,data,*any,2012-01-01T00:00:00Z,RP_DATA1,,`
+	ratingProfiles := `*out,cgrates.org,data,*any,2012-01-01T00:00:00Z,RP_DATA1,,,`
 	csvr := engine.NewTpReader(ratingDb, acntDb, engine.NewStringCSVStorage(',', "", timings, rates, destinationRates, ratingPlans, ratingProfiles,
-		"", "", "", "", "", "", "", ""), "")
+		"", "", "", "", "", "", "", "", "", "", "", ""), "")
 	if err := csvr.LoadTimings(); err != nil {
 		t.Fatal(err)
 	}
@@ -62,7 +62,7 @@ RP_DATA1,DR_DATA_2,TM2,10`
 		t.Fatal(err)
 	}
 	csvr.WriteToDatabase(false, false)
-	ratingDb.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingDb.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	if cachedRPlans := cache2go.CountEntries(engine.RATING_PLAN_PREFIX); cachedRPlans != 1 {
 		t.Error("Wrong number of cached rating plans found", cachedRPlans)
@@ -89,7 +89,7 @@ func TestGetDataCostDtChrg1(t *testing.T) {
 	}
 	if cc, err := cd.GetCost(); err != nil {
 		t.Error(err)
-	} else if cc.Cost != 0.004 {
+	} else if cc.Cost.Float64() != 0.004 {
 		t.Error("Wrong cost returned: ", cc.Cost)
 	}
 }
@@ -111,7 +111,7 @@ func TestGetDataCostSecondIntDtChrg1(t *testing.T) {
 	}
 	if cc, err := cd.GetCost(); err != nil {
 		t.Error(err)
-	} else if cc.Cost != 0.002 {
+	} else if cc.Cost.Float64() != 0.002 {
 		t.Error("Wrong cost returned: ", cc.Cost)
 	}
 }
@@ -133,7 +133,7 @@ func TestGetDataBetweenCostDtChrg1(t *testing.T) {
 	}
 	if cc, err := cd.GetCost(); err != nil {
 		t.Error(err)
-	} else if cc.Cost != 0.004 {
+	} else if cc.Cost.Float64() != 0.004 {
 		t.Logf("%+v", cc.Timespans[1].RateInterval.Timing)
 		t.Error("Wrong cost returned: ", cc.Cost)
 	}
@@ -43,18 +43,18 @@ func TestLoadCsvTp2(t *testing.T) {
 ASAP,*any,*any,*any,*any,*asap`
 	destinations := `DST_UK_Mobile_BIG5,447596
 DST_UK_Mobile_BIG5,447956`
-	rates := `RT_UK_Mobile_BIG5_PKG,0.01,0,20s,20s,0s
-RT_UK_Mobile_BIG5,0.01,0.10,1s,1s,0s`
-	destinationRates := `DR_UK_Mobile_BIG5_PKG,DST_UK_Mobile_BIG5,RT_UK_Mobile_BIG5_PKG,*up,8,0,
-DR_UK_Mobile_BIG5,DST_UK_Mobile_BIG5,RT_UK_Mobile_BIG5,*up,8,0,`
+	rates := `RT_UK_Mobile_BIG5_PKG,0.01,0,20s,20s,0s,,
+RT_UK_Mobile_BIG5,0.01,0.10,1s,1s,0s,,`
+	destinationRates := `DR_UK_Mobile_BIG5_PKG,DST_UK_Mobile_BIG5,RT_UK_Mobile_BIG5_PKG,*up,8,0,,,,
+DR_UK_Mobile_BIG5,DST_UK_Mobile_BIG5,RT_UK_Mobile_BIG5,*up,8,0,,,,`
 	ratingPlans := `RP_UK_Mobile_BIG5_PKG,DR_UK_Mobile_BIG5_PKG,ALWAYS,10
 RP_UK,DR_UK_Mobile_BIG5,ALWAYS,10`
-	ratingProfiles := `*out,cgrates.org,call,*any,2013-01-06T00:00:00Z,RP_UK,,
-*out,cgrates.org,call,discounted_minutes,2013-01-06T00:00:00Z,RP_UK_Mobile_BIG5_PKG,,`
+	ratingProfiles := `*out,cgrates.org,call,*any,2013-01-06T00:00:00Z,RP_UK,,,
+*out,cgrates.org,call,discounted_minutes,2013-01-06T00:00:00Z,RP_UK_Mobile_BIG5_PKG,,,`
 	sharedGroups := ``
 	lcrs := ``
-	actions := `TOPUP10_AC,*topup_reset,,,*monetary,*out,,*any,,,*unlimited,,0,10,10
-TOPUP10_AC1,*topup_reset,,,*voice,*out,,DST_UK_Mobile_BIG5,discounted_minutes,,*unlimited,,40,10,10`
+	actions := `TOPUP10_AC,*topup_reset,,,*monetary,*out,,*any,,,*unlimited,,0,10,10,
+TOPUP10_AC1,*topup_reset,,,*voice,*out,,DST_UK_Mobile_BIG5,discounted_minutes,,*unlimited,,40,10,10,`
 	actionPlans := `TOPUP10_AT,TOPUP10_AC,ASAP,10
 TOPUP10_AT,TOPUP10_AC1,ASAP,10`
 	actionTriggers := ``
@@ -62,7 +62,7 @@ TOPUP10_AT,TOPUP10_AC1,ASAP,10`
 	derivedCharges := ``
 	cdrStats := ``
 	csvr := engine.NewTpReader(ratingDb2, acntDb2, engine.NewStringCSVStorage(',', destinations, timings, rates, destinationRates, ratingPlans, ratingProfiles,
-		sharedGroups, lcrs, actions, actionPlans, actionTriggers, accountActions, derivedCharges, cdrStats), "")
+		sharedGroups, lcrs, actions, actionPlans, actionTriggers, accountActions, derivedCharges, cdrStats, "", "", "", ""), "")
 	if err := csvr.LoadDestinations(); err != nil {
 		t.Fatal(err)
 	}
@@ -108,9 +108,9 @@ TOPUP10_AT,TOPUP10_AC1,ASAP,10`
 	} else if acnt == nil {
 		t.Error("No account saved")
 	}
-	ratingDb2.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingDb2.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	acntDb2.CacheAccounting(nil, nil, nil)
-	if cachedDests := cache2go.CountEntries(engine.DESTINATION_PREFIX); cachedDests != 2 {
+	if cachedDests := engine.CachedDestinationsCount(); cachedDests != 2 {
 		t.Error("Wrong number of cached destinations found", cachedDests)
 	}
 	if cachedRPlans := cache2go.CountEntries(engine.RATING_PLAN_PREFIX); cachedRPlans != 2 {
@@ -131,9 +131,9 @@ func TestExecuteActions2(t *testing.T) {
 		t.Error(err)
 	} else if len(acnt.BalanceMap) != 2 {
 		t.Error("Account does not have enough balances: ", acnt.BalanceMap)
-	} else if acnt.BalanceMap[utils.VOICE+engine.OUTBOUND][0].Value != 40 {
+	} else if acnt.BalanceMap[utils.VOICE+engine.OUTBOUND][0].Value.Float64() != 40 {
 		t.Error("Account does not have enough minutes in balance", acnt.BalanceMap[utils.VOICE+engine.OUTBOUND][0].Value)
-	} else if acnt.BalanceMap[utils.MONETARY+engine.OUTBOUND][0].Value != 0 {
+	} else if acnt.BalanceMap[utils.MONETARY+engine.OUTBOUND][0].Value.Float64() != 0 {
 		t.Error("Account does not have enough monetary balance", acnt.BalanceMap[utils.MONETARY+engine.OUTBOUND][0].Value)
 	}
 }
@@ -151,7 +151,7 @@ func TestDebit2(t *testing.T) {
 	}
 	if cc, err := cd.Debit(); err != nil {
 		t.Error(err)
-	} else if cc.Cost != 0.01 {
+	} else if cc.Cost.Float64() != 0.01 {
 		t.Error("Wrong cost returned: ", cc.Cost)
 	}
 	acnt, err := acntDb2.GetAccount("*out:cgrates.org:12345")
@@ -161,14 +161,14 @@ func TestDebit2(t *testing.T) {
 	if len(acnt.BalanceMap) != 2 {
 		t.Error("Wrong number of user balances found", acnt.BalanceMap)
 	}
-	if acnt.BalanceMap[utils.VOICE+engine.OUTBOUND][0].Value != 20 {
+	if acnt.BalanceMap[utils.VOICE+engine.OUTBOUND][0].Value.Float64() != 20 {
 		t.Error("Account does not have expected minutes in balance", acnt.BalanceMap[utils.VOICE+engine.OUTBOUND][0].Value)
 	}
 	for _, blnc := range acnt.BalanceMap[utils.MONETARY+engine.OUTBOUND] { // Test negative balance for default one
-		if blnc.Weight == 10 && blnc.Value != 0 {
-			t.Errorf("Balance with weight: %f, having value: %f  ", blnc.Weight, blnc.Value)
-		} else if blnc.Weight == 0 && blnc.Value != -0.01 {
-			t.Errorf("Balance with weight: %f, having value: %f  ", blnc.Weight, blnc.Value)
+		if blnc.Weight == 10 && blnc.Value.Float64() != 0 {
+			t.Errorf("Balance with weight: %f, having value: %v  ", blnc.Weight, blnc.Value)
+		} else if blnc.Weight == 0 && blnc.Value.Float64() != -0.01 {
+			t.Errorf("Balance with weight: %f, having value: %v  ", blnc.Weight, blnc.Value)
 		}
 	}
 }
@@ -36,12 +36,12 @@ func TestSMSSetStorageSmsChrg1(t *testing.T) {
 
 func TestSMSLoadCsvTpSmsChrg1(t *testing.T) {
 	timings := `ALWAYS,*any,*any,*any,*any,00:00:00`
-	rates := `RT_SMS_5c,0,0.005,1,1,0`
-	destinationRates := `DR_SMS_1,*any,RT_SMS_5c,*up,4,0,`
+	rates := `RT_SMS_5c,0,0.005,1,1,0,,`
+	destinationRates := `DR_SMS_1,*any,RT_SMS_5c,*up,4,0,,,,`
 	ratingPlans := `RP_SMS1,DR_SMS_1,ALWAYS,10`
-	ratingProfiles := `*out,cgrates.org,sms,*any,2012-01-01T00:00:00Z,RP_SMS1,,`
+	ratingProfiles := `*out,cgrates.org,sms,*any,2012-01-01T00:00:00Z,RP_SMS1,,,`
 	csvr := engine.NewTpReader(ratingDb, acntDb, engine.NewStringCSVStorage(',', "", timings, rates, destinationRates, ratingPlans, ratingProfiles,
-		"", "", "", "", "", "", "", ""), "")
+		"", "", "", "", "", "", "", "", "", "", "", ""), "")
 	if err := csvr.LoadTimings(); err != nil {
 		t.Fatal(err)
 	}
@@ -58,7 +58,7 @@ func TestSMSLoadCsvTpSmsChrg1(t *testing.T) {
 		t.Fatal(err)
 	}
 	csvr.WriteToDatabase(false, false)
-	ratingDb.CacheRating(nil, nil, nil, nil, nil, nil)
+	ratingDb.CacheRating(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	if cachedRPlans := cache2go.CountEntries(engine.RATING_PLAN_PREFIX); cachedRPlans != 1 {
 		t.Error("Wrong number of cached rating plans found", cachedRPlans)
@@ -85,7 +85,7 @@ func TestSMSGetDataCostSmsChrg1(t *testing.T) {
 	}
 	if cc, err := cd.GetCost(); err != nil {
 		t.Error(err)
-	} else if cc.Cost != 0.005 {
+	} else if cc.Cost.Float64() != 0.005 {
 		t.Error("Wrong cost returned: ", cc.Cost)
 	}
 }
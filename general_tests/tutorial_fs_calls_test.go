@@ -141,32 +141,32 @@ func TestTutFsCallsAccountsBefore(t *testing.T) {
 	attrs := &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1001", Direction: "*out"}
 	if err := tutFsCallsRpc.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 10.0 { // Make sure we debitted
-		t.Errorf("Calling ApierV1.GetBalance received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 10.0 { // Make sure we debitted
+		t.Errorf("Calling ApierV1.GetBalance received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 	attrs = &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1002", Direction: "*out"}
 	if err := tutFsCallsRpc.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 10.0 { // Make sure we debitted
-		t.Errorf("Calling ApierV1.GetBalance received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 10.0 { // Make sure we debitted
+		t.Errorf("Calling ApierV1.GetBalance received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 	attrs = &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1003", Direction: "*out"}
 	if err := tutFsCallsRpc.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 10.0 { // Make sure we debitted
-		t.Errorf("Calling ApierV1.GetBalance received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 10.0 { // Make sure we debitted
+		t.Errorf("Calling ApierV1.GetBalance received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 	attrs = &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1004", Direction: "*out"}
 	if err := tutFsCallsRpc.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 10.0 { // Make sure we debitted
-		t.Errorf("Calling ApierV1.GetBalance received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 10.0 { // Make sure we debitted
+		t.Errorf("Calling ApierV1.GetBalance received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 	attrs = &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1007", Direction: "*out"}
 	if err := tutFsCallsRpc.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() != 0.0 { // Make sure we debitted
-		t.Errorf("Calling ApierV1.GetBalance received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() != 0.0 { // Make sure we debitted
+		t.Errorf("Calling ApierV1.GetBalance received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	}
 	attrs = &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1005", Direction: "*out"}
 	if err := tutFsCallsRpc.Call("ApierV1.GetAccount", attrs, &reply); err == nil || !strings.HasSuffix(err.Error(), "does not exist") {
@@ -263,8 +263,8 @@ func TestTutFsCallsAccount1001(t *testing.T) {
 	attrs := &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1001", Direction: "*out"}
 	if err := tutFsCallsRpc.Call("ApierV1.GetAccount", attrs, &reply); err != nil {
 		t.Error("Got error on ApierV1.GetAccount: ", err.Error())
-	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue() == 10.0 { // Make sure we debitted
-		t.Errorf("Calling ApierV1.GetBalance received: %f", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
+	} else if reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue().Float64() == 10.0 { // Make sure we debitted
+		t.Errorf("Calling ApierV1.GetBalance received: %v", reply.BalanceMap[utils.MONETARY+attrs.Direction].GetTotalValue())
 	} else if reply.Disabled == true {
 		t.Error("Account disabled")
 	}
@@ -291,7 +291,7 @@ func TestTutFsCallsCdrs(t *testing.T) {
 		if reply[0].Usage != "67" { // Usage as seconds
 			t.Errorf("Unexpected Usage for CDR: %+v", reply[0])
 		}
-		if reply[0].Cost == -1.0 { // Cost was not calculated
+		if reply[0].Cost.Float64() == -1.0 { // Cost was not calculated
 			t.Errorf("Unexpected Cost for CDR: %+v", reply[0])
 		}
 	}
@@ -383,7 +383,7 @@ func TestTutFsCallsCdrs(t *testing.T) {
 		if reply[0].Usage != "64" { // Usage as seconds
 			t.Errorf("Unexpected Usage for CDR: %+v", reply[0])
 		}
-		if reply[0].Cost == -1.0 { // Cost was not calculated
+		if reply[0].Cost.Float64() == -1.0 { // Cost was not calculated
 			t.Errorf("Unexpected Cost for CDR: %+v", reply[0])
 		}
 	}
@@ -405,7 +405,7 @@ func TestTutFsCallsCdrs(t *testing.T) {
 		if reply[0].Usage != "66" { // Usage as seconds
 			t.Errorf("Unexpected Usage for CDR: %+v", reply[0])
 		}
-		if reply[0].Cost == -1.0 { // Cost was not calculated
+		if reply[0].Cost.Float64() == -1.0 { // Cost was not calculated
 			t.Errorf("Unexpected Cost for CDR: %+v", reply[0])
 		}
 	}
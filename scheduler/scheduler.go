@@ -73,6 +73,21 @@ func (s *Scheduler) Loop() {
 	}
 }
 
+// Periodically checks the accounts for balances about to expire
+// (*balance_expiring action triggers)
+func (s *Scheduler) LoopExpiringBalances(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if err := engine.CheckExpiringBalances(now); err != nil {
+			engine.Logger.Warning(fmt.Sprintf("Cannot check expiring balances: %v", err))
+		}
+	}
+}
+
 func (s *Scheduler) LoadActionPlans(storage engine.AccountingStorage) {
 	actionTimings, err := storage.GetAllActionPlans()
 	if err != nil {
@@ -70,9 +70,11 @@ const (
 	PDD_MEDIA_MS       = "variable_progress_mediamsec"
 	PDD_NOMEDIA_MS     = "variable_progressmsec"
 	IGNOREPARK         = "variable_cgr_ignorepark"
+	CALLER_ID_NR       = "Caller-Caller-ID-Number"
 
 	VAR_CGR_DISCONNECT_CAUSE = "variable_" + utils.CGR_DISCONNECT_CAUSE
 	VAR_CGR_CMPUTELCR        = "variable_" + utils.CGR_COMPUTELCR
+	VAR_CGR_ORIGIN           = "variable_" + utils.CGR_ORIGIN
 )
 
 // Nice printing for the event object.
@@ -248,6 +250,14 @@ func (fsev FSEvent) GetDisconnectCause(fieldName string) string {
 	return utils.FirstNonEmpty(fsev[fieldName], fsev[VAR_CGR_DISCONNECT_CAUSE], fsev[HANGUP_CAUSE])
 }
 
+// The origin defaults to the caller id number
+func (fsev FSEvent) GetOrigin(fieldName string) string {
+	if strings.HasPrefix(fieldName, utils.STATIC_VALUE_PREFIX) { // Static value
+		return fieldName[len(utils.STATIC_VALUE_PREFIX):]
+	}
+	return utils.FirstNonEmpty(fsev[fieldName], fsev[VAR_CGR_ORIGIN], fsev[CALLER_ID_NR])
+}
+
 func (fsev FSEvent) GetOriginatorIP(fieldName string) string {
 	if strings.HasPrefix(fieldName, utils.STATIC_VALUE_PREFIX) { // Static value
 		return fieldName[len(utils.STATIC_VALUE_PREFIX):]
@@ -308,6 +318,8 @@ func (fsev FSEvent) ParseEventValue(rsrFld *utils.RSRField) string {
 		return rsrFld.ParseValue(fsev.GetSupplier(""))
 	case utils.DISCONNECT_CAUSE:
 		return rsrFld.ParseValue(fsev.GetDisconnectCause(""))
+	case utils.ORIGIN:
+		return rsrFld.ParseValue(fsev.GetOrigin(""))
 	case utils.MEDI_RUNID:
 		return rsrFld.ParseValue(utils.DEFAULT_RUNID)
 	case utils.COST:
@@ -357,9 +369,10 @@ func (fsev FSEvent) AsStoredCdr() *engine.StoredCdr {
 	storCdr.Usage, _ = fsev.GetDuration(utils.META_DEFAULT)
 	storCdr.Pdd, _ = fsev.GetPdd(utils.META_DEFAULT)
 	storCdr.ExtraFields = fsev.GetExtraFields()
-	storCdr.Cost = -1
+	storCdr.Cost = utils.NewDecimalFromInt(-1)
 	storCdr.Supplier = fsev.GetSupplier(utils.META_DEFAULT)
 	storCdr.DisconnectCause = fsev.GetDisconnectCause(utils.META_DEFAULT)
+	storCdr.Origin = fsev.GetOrigin(utils.META_DEFAULT)
 	return storCdr
 }
 
@@ -190,6 +190,7 @@ func (sm *FSSessionManager) setCgrLcr(ev engine.Event, connId string) error {
 		Subject:     ev.GetSubject(utils.META_DEFAULT),
 		Account:     ev.GetAccount(utils.META_DEFAULT),
 		Destination: ev.GetDestination(utils.META_DEFAULT),
+		Origin:      ev.GetOrigin(utils.META_DEFAULT),
 		TimeStart:   startTime,
 		TimeEnd:     startTime.Add(config.CgrConfig().MaxCallDuration),
 	}
@@ -51,7 +51,7 @@ const (
 )
 
 var primaryFields = []string{EVENT, CALLID, FROM_TAG, HASH_ENTRY, HASH_ID, CGR_ACCOUNT, CGR_SUBJECT, CGR_DESTINATION,
-	CGR_CATEGORY, CGR_TENANT, CGR_REQTYPE, CGR_ANSWERTIME, CGR_SETUPTIME, CGR_STOPTIME, CGR_DURATION, CGR_PDD, utils.CGR_SUPPLIER, utils.CGR_DISCONNECT_CAUSE}
+	CGR_CATEGORY, CGR_TENANT, CGR_REQTYPE, CGR_ANSWERTIME, CGR_SETUPTIME, CGR_STOPTIME, CGR_DURATION, CGR_PDD, utils.CGR_SUPPLIER, utils.CGR_DISCONNECT_CAUSE, utils.CGR_ORIGIN}
 
 type KamAuthReply struct {
 	Event            string // Kamailio will use this to differentiate between requests and replies
@@ -211,6 +211,13 @@ func (kev KamEvent) GetDisconnectCause(fieldName string) string {
 	return utils.FirstNonEmpty(kev[fieldName], kev[utils.CGR_DISCONNECT_CAUSE])
 }
 
+func (kev KamEvent) GetOrigin(fieldName string) string {
+	if strings.HasPrefix(fieldName, utils.STATIC_VALUE_PREFIX) { // Static value
+		return fieldName[len(utils.STATIC_VALUE_PREFIX):]
+	}
+	return utils.FirstNonEmpty(kev[fieldName], kev[utils.CGR_ORIGIN])
+}
+
 //ToDo: extract the IP of the kamailio server generating the event
 func (kev KamEvent) GetOriginatorIP(string) string {
 	return "127.0.0.1"
@@ -303,6 +310,8 @@ func (kev KamEvent) ParseEventValue(rsrFld *utils.RSRField) string {
 		return rsrFld.ParseValue(kev.GetSupplier(utils.META_DEFAULT))
 	case utils.DISCONNECT_CAUSE:
 		return rsrFld.ParseValue(kev.GetDisconnectCause(utils.META_DEFAULT))
+	case utils.ORIGIN:
+		return rsrFld.ParseValue(kev.GetOrigin(utils.META_DEFAULT))
 	case utils.MEDI_RUNID:
 		return rsrFld.ParseValue(utils.META_DEFAULT)
 	case utils.COST:
@@ -335,8 +344,9 @@ func (kev KamEvent) AsStoredCdr() *engine.StoredCdr {
 	storCdr.Pdd, _ = kev.GetPdd(utils.META_DEFAULT)
 	storCdr.Supplier = kev.GetSupplier(utils.META_DEFAULT)
 	storCdr.DisconnectCause = kev.GetDisconnectCause(utils.META_DEFAULT)
+	storCdr.Origin = kev.GetOrigin(utils.META_DEFAULT)
 	storCdr.ExtraFields = kev.GetExtraFields()
-	storCdr.Cost = -1
+	storCdr.Cost = utils.NewDecimalFromInt(-1)
 
 	return storCdr
 }
@@ -199,6 +199,12 @@ func (osipsev *OsipsEvent) GetDisconnectCause(fieldName string) string {
 	}
 	return utils.FirstNonEmpty(osipsev.osipsEvent.AttrValues[fieldName], osipsev.osipsEvent.AttrValues[OSIPS_SIPCODE], osipsev.osipsEvent.AttrValues[utils.DISCONNECT_CAUSE])
 }
+func (osipsev *OsipsEvent) GetOrigin(fieldName string) string {
+	if strings.HasPrefix(fieldName, utils.STATIC_VALUE_PREFIX) { // Static value
+		return fieldName[len(utils.STATIC_VALUE_PREFIX):]
+	}
+	return utils.FirstNonEmpty(osipsev.osipsEvent.AttrValues[fieldName], osipsev.osipsEvent.AttrValues[utils.CGR_ORIGIN])
+}
 func (osipsEv *OsipsEvent) GetOriginatorIP(fieldName string) string {
 	if osipsEv.osipsEvent == nil || osipsEv.osipsEvent.OriginatorAddress == nil {
 		return ""
@@ -248,7 +254,7 @@ func (osipsev *OsipsEvent) PassesFieldFilter(*utils.RSRField) (bool, string) {
 }
 func (osipsev *OsipsEvent) GetExtraFields() map[string]string {
 	primaryFields := []string{TO_TAG, SETUP_DURATION, OSIPS_SETUP_TIME, "method", "callid", "sip_reason", OSIPS_EVENT_TIME, "sip_code", "duration", "from_tag", "dialog_id",
-		CGR_TENANT, CGR_CATEGORY, CGR_REQTYPE, CGR_ACCOUNT, CGR_SUBJECT, CGR_DESTINATION, utils.CGR_SUPPLIER, CGR_PDD}
+		CGR_TENANT, CGR_CATEGORY, CGR_REQTYPE, CGR_ACCOUNT, CGR_SUBJECT, CGR_DESTINATION, utils.CGR_SUPPLIER, CGR_PDD, utils.CGR_ORIGIN}
 	extraFields := make(map[string]string)
 	for field, val := range osipsev.osipsEvent.AttrValues {
 		if !utils.IsSliceMember(primaryFields, field) {
@@ -282,8 +288,9 @@ func (osipsEv *OsipsEvent) AsStoredCdr() *engine.StoredCdr {
 	storCdr.Pdd, _ = osipsEv.GetPdd(utils.META_DEFAULT)
 	storCdr.Supplier = osipsEv.GetSupplier(utils.META_DEFAULT)
 	storCdr.DisconnectCause = osipsEv.GetDisconnectCause(utils.META_DEFAULT)
+	storCdr.Origin = osipsEv.GetOrigin(utils.META_DEFAULT)
 	storCdr.ExtraFields = osipsEv.GetExtraFields()
-	storCdr.Cost = -1
+	storCdr.Cost = utils.NewDecimalFromInt(-1)
 	return storCdr
 }
 
@@ -102,7 +102,8 @@ func (s *Session) debitLoop(runIdx int) {
 		// update call duration with real debited duration
 		nextCd.DurationIndex -= debitPeriod
 		nextCd.DurationIndex += nextCd.GetDuration()
-		nextCd.MaxCostSoFar += cc.Cost
+		nextCd.MaxCostSoFar = nextCd.MaxCostSoFar.Add(cc.Cost)
+		nextCd.MinCostCredit = cc.MinCostCredit
 		time.Sleep(cc.GetDuration())
 		index++
 	}
@@ -136,7 +137,7 @@ func (s *Session) Close(ev engine.Event) error {
 			return err
 		}
 		hangupTime := startTime.Add(duration)
-		err = s.Refund(lastCC, hangupTime)
+		err = s.refund(lastCC, hangupTime, minCostRefundLimit(sr))
 		if err != nil {
 			return err
 		}
@@ -146,6 +147,28 @@ func (s *Session) Close(ev engine.Event) error {
 }
 
 func (s *Session) Refund(lastCC *engine.CallCost, hangupTime time.Time) error {
+	return s.refund(lastCC, hangupTime, nil)
+}
+
+// Refunds may not take the cost of the session run below the minimum cost of the call
+func minCostRefundLimit(sr *engine.SessionRun) *utils.Decimal {
+	minCost := sr.CallCosts[0].GetMinCost()
+	if minCost.Sign() <= 0 {
+		return nil
+	}
+	var charged utils.Decimal
+	for _, cc := range sr.CallCosts {
+		charged = charged.Add(cc.Cost)
+	}
+	limit := charged.Sub(minCost)
+	if limit.Sign() < 0 {
+		limit = utils.Decimal{}
+	}
+	return &limit
+}
+
+// Puts back the credit debited after the hangup time, the refunded cost is limited to maxRefund if set
+func (s *Session) refund(lastCC *engine.CallCost, hangupTime time.Time, maxRefund *utils.Decimal) error {
 	end := lastCC.Timespans[len(lastCC.Timespans)-1].TimeEnd
 	refundDuration := end.Sub(hangupTime)
 	var refundIncrements engine.Increments
@@ -179,6 +202,9 @@ func (s *Session) Refund(lastCC *engine.CallCost, hangupTime time.Time) error {
 			refundDuration -= tsDuration
 		}
 	}
+	if maxRefund != nil {
+		refundIncrements = refundIncrements.LimitCost(*maxRefund)
+	}
 	// show only what was actualy refunded (stopped in timespan)
 	// engine.Logger.Info(fmt.Sprintf("Refund duration: %v", initialRefundDuration-refundDuration))
 	if len(refundIncrements) > 0 {
@@ -189,6 +215,7 @@ func (s *Session) Refund(lastCC *engine.CallCost, hangupTime time.Time) error {
 			Subject:     lastCC.Subject,
 			Account:     lastCC.Account,
 			Destination: lastCC.Destination,
+			CgrId:       s.eventStart.GetCgrId(),
 			Increments:  refundIncrements,
 		}
 		var response float64
@@ -198,8 +225,17 @@ func (s *Session) Refund(lastCC *engine.CallCost, hangupTime time.Time) error {
 		}
 	}
 	cost := refundIncrements.GetTotalCost()
-	lastCC.Cost -= cost
+	lastCC.Cost = lastCC.Cost.Sub(cost)
+	lastCC.RefundTaxes(cost)
 	lastCC.Timespans.Compress()
+	for _, rcc := range lastCC.ResellerCosts { // the resellers were debited for the same usage
+		if len(rcc.Timespans) == 0 {
+			continue
+		}
+		if err := s.refund(rcc, hangupTime, nil); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -277,6 +313,9 @@ func (s *Session) AsActiveSessions() []*ActiveSession {
 			aSession.MaxRate = sessionRun.CallDescriptor.MaxRate
 			aSession.MaxRateUnit = sessionRun.CallDescriptor.MaxRateUnit
 			aSession.MaxCostSoFar = sessionRun.CallDescriptor.MaxCostSoFar
+			if len(sessionRun.CallCosts) != 0 && sessionRun.CallCosts[0].Destination != sessionRun.CallDescriptor.Destination { // ported number
+				aSession.RoutingNumber = sessionRun.CallCosts[0].Destination
+			}
 		}
 		aSessions = append(aSessions, aSession)
 	}
@@ -297,6 +336,7 @@ type ActiveSession struct {
 	Account       string            // account id (accounting subsystem) the record should be attached to
 	Subject       string            // rating subject (rating subsystem) this record should be attached to
 	Destination   string            // destination to be charged
+	RoutingNumber string            // number the destination is rated on when ported to another network
 	SetupTime     time.Time         // set-up time of the event. Supported formats: datetime RFC3339 compatible, SQL datetime (eg: MySQL), unix timestamp.
 	Pdd           time.Duration     // PDD value
 	AnswerTime    time.Time         // answer time of the event. Supported formats: datetime RFC3339 compatible, SQL datetime (eg: MySQL), unix timestamp.
@@ -310,5 +350,5 @@ type ActiveSession struct {
 	DurationIndex time.Duration // the call duration so far (till TimeEnd)
 	MaxRate       float64
 	MaxRateUnit   time.Duration
-	MaxCostSoFar  float64
+	MaxCostSoFar  utils.Decimal
 }
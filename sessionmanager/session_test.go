@@ -103,14 +103,14 @@ func (mc *MockConnector) GetLCR(*engine.CallDescriptor, *engine.LCRCost) error
 
 func TestSessionRefund(t *testing.T) {
 	mc := &MockConnector{}
-	s := &Session{sessionManager: &FSSessionManager{rater: mc}}
+	s := &Session{eventStart: make(FSEvent), sessionManager: &FSSessionManager{rater: mc}}
 	ts := &engine.TimeSpan{
 		TimeStart: time.Date(2015, 6, 10, 14, 7, 0, 0, time.UTC),
 		TimeEnd:   time.Date(2015, 6, 10, 14, 7, 30, 0, time.UTC),
 	}
 	// add increments
 	for i := 0; i < 30; i++ {
-		ts.AddIncrement(&engine.Increment{Duration: time.Second, Cost: 1.0})
+		ts.AddIncrement(&engine.Increment{Duration: time.Second, Cost: utils.NewDecimalFromFloat(1.0)})
 	}
 
 	cc := &engine.CallCost{Timespans: engine.TimeSpans{ts}}
@@ -123,14 +123,14 @@ func TestSessionRefund(t *testing.T) {
 
 func TestSessionRefundAll(t *testing.T) {
 	mc := &MockConnector{}
-	s := &Session{sessionManager: &FSSessionManager{rater: mc}}
+	s := &Session{eventStart: make(FSEvent), sessionManager: &FSSessionManager{rater: mc}}
 	ts := &engine.TimeSpan{
 		TimeStart: time.Date(2015, 6, 10, 14, 7, 0, 0, time.UTC),
 		TimeEnd:   time.Date(2015, 6, 10, 14, 7, 30, 0, time.UTC),
 	}
 	// add increments
 	for i := 0; i < 30; i++ {
-		ts.AddIncrement(&engine.Increment{Duration: time.Second, Cost: 1.0})
+		ts.AddIncrement(&engine.Increment{Duration: time.Second, Cost: utils.NewDecimalFromFloat(1.0)})
 	}
 
 	cc := &engine.CallCost{Timespans: engine.TimeSpans{ts}}
@@ -143,14 +143,14 @@ func TestSessionRefundAll(t *testing.T) {
 
 func TestSessionRefundManyAll(t *testing.T) {
 	mc := &MockConnector{}
-	s := &Session{sessionManager: &FSSessionManager{rater: mc}}
+	s := &Session{eventStart: make(FSEvent), sessionManager: &FSSessionManager{rater: mc}}
 	ts1 := &engine.TimeSpan{
 		TimeStart: time.Date(2015, 6, 10, 14, 7, 0, 0, time.UTC),
 		TimeEnd:   time.Date(2015, 6, 10, 14, 7, 30, 0, time.UTC),
 	}
 	// add increments
 	for i := 0; i < int(ts1.GetDuration().Seconds()); i++ {
-		ts1.AddIncrement(&engine.Increment{Duration: time.Second, Cost: 1.0})
+		ts1.AddIncrement(&engine.Increment{Duration: time.Second, Cost: utils.NewDecimalFromFloat(1.0)})
 	}
 
 	ts2 := &engine.TimeSpan{
@@ -159,7 +159,7 @@ func TestSessionRefundManyAll(t *testing.T) {
 	}
 	// add increments
 	for i := 0; i < int(ts2.GetDuration().Seconds()); i++ {
-		ts2.AddIncrement(&engine.Increment{Duration: time.Second, Cost: 1.0})
+		ts2.AddIncrement(&engine.Increment{Duration: time.Second, Cost: utils.NewDecimalFromFloat(1.0)})
 	}
 
 	cc := &engine.CallCost{Timespans: engine.TimeSpans{ts1, ts2}}
@@ -169,3 +169,23 @@ func TestSessionRefundManyAll(t *testing.T) {
 		t.Errorf("Error refunding: %+v, %+v", len(mc.refundCd.Increments), cc.Timespans)
 	}
 }
+
+func TestSessionRefundMinCost(t *testing.T) {
+	mc := &MockConnector{}
+	s := &Session{eventStart: make(FSEvent), sessionManager: &FSSessionManager{rater: mc}}
+	ts := &engine.TimeSpan{
+		TimeStart:    time.Date(2015, 6, 10, 14, 7, 0, 0, time.UTC),
+		TimeEnd:      time.Date(2015, 6, 10, 14, 7, 30, 0, time.UTC),
+		RateInterval: &engine.RateInterval{Rating: &engine.RIRate{MinCost: utils.NewDecimalFromFloat(25)}},
+	}
+	for i := 0; i < 30; i++ {
+		ts.AddIncrement(&engine.Increment{Duration: time.Second, Cost: utils.NewDecimalFromFloat(1.0)})
+	}
+	cc := &engine.CallCost{Cost: utils.NewDecimalFromFloat(30), Timespans: engine.TimeSpans{ts}}
+	sr := &engine.SessionRun{CallCosts: []*engine.CallCost{cc}}
+	hangupTime := time.Date(2015, 6, 10, 14, 7, 20, 0, time.UTC)
+	s.refund(cc, hangupTime, minCostRefundLimit(sr))
+	if len(mc.refundCd.Increments) != 10 || mc.refundCd.Increments.GetTotalCost().String() != "5" || cc.Cost.String() != "25" {
+		t.Errorf("Error refunding with minimum cost: %+v, %v", mc.refundCd.Increments, cc.Cost)
+	}
+}
@@ -50,7 +50,7 @@ type Paginator struct {
 type TPDestination struct {
 	TPid          string   // Tariff plan id
 	DestinationId string   // Destination id
-	Prefixes      []string // Prefixes attached to this destination
+	Prefixes      []string // Prefixes attached to this destination, number ranges (start-end) and ~ regular expressions accepted
 }
 
 // This file deals with tp_* data definition
@@ -77,6 +77,8 @@ type RateSlot struct {
 	RateUnit              string  //  Number of billing units this rate applies to
 	RateIncrement         string  // This rate will apply in increments of duration
 	GroupIntervalStart    string  // Group position
+	MinCost               float64 // Minimum cost of a call, taken from the first slot
+	MaxCost               float64 // Maximum cost of a call, taken from the first slot
 	rateUnitDur           time.Duration
 	rateIncrementDur      time.Duration
 	groupIntervalStartDur time.Duration
@@ -121,6 +123,17 @@ type DestinationRate struct {
 	RoundingDecimals int
 	MaxCost          float64
 	MaxCostStrategy  string
+	Currency         string
+	UsageStart       string // usage in the billing period after which this rate replaces the one without usage start
+	OriginId         string // rate only for the calls originated in this destination, eg: roaming
+}
+
+// Returns the usage start as duration, zero for the rates applied from the start of the billing period
+func (dr *DestinationRate) UsageStartDuration() (time.Duration, error) {
+	if dr.UsageStart == "" {
+		return 0, nil
+	}
+	return ParseDurationWithSecs(dr.UsageStart)
 }
 
 type ApierTPTiming struct {
@@ -138,6 +151,7 @@ type TPTiming struct {
 	Years     Years
 	Months    Months
 	MonthDays MonthDays
+	Holidays  HolidayCalendars
 	WeekDays  WeekDays
 	StartTime string
 	EndTime   string
@@ -149,6 +163,7 @@ func NewTiming(timingInfo ...string) (rt *TPTiming) {
 	rt.Years.Parse(timingInfo[1], INFIELD_SEP)
 	rt.Months.Parse(timingInfo[2], INFIELD_SEP)
 	rt.MonthDays.Parse(timingInfo[3], INFIELD_SEP)
+	rt.Holidays.Parse(timingInfo[3], INFIELD_SEP)
 	rt.WeekDays.Parse(timingInfo[4], INFIELD_SEP)
 	times := strings.Split(timingInfo[5], INFIELD_SEP)
 	rt.StartTime = times[0]
@@ -227,6 +242,7 @@ type TPRatingActivation struct {
 	RatingPlanId     string // Id of RatingPlan profile
 	FallbackSubjects string // So we follow the api
 	CdrStatQueueIds  string
+	Timezone         string // IANA location used to match the timings, eg: Europe/Berlin, empty for the one of the call
 }
 
 // Helper to return the subject fallback keys we need in dataDb
@@ -281,6 +297,7 @@ type TPAction struct {
 	BalanceWeight   float64 // Balance weight
 	ExtraParameters string
 	Weight          float64 // Action's weight
+	Currency        string  // Currency of monetary balances
 }
 
 type TPSharedGroups struct {
@@ -295,6 +312,66 @@ type TPSharedGroup struct {
 	RatingSubject string
 }
 
+type TPExchangeRates struct {
+	TPid            string
+	ExchangeRatesId string
+	ExchangeRates   []*TPExchangeRate
+}
+
+type TPExchangeRate struct {
+	FromCurrency string
+	ToCurrency   string
+	Rate         float64 // Units of ToCurrency for one unit of FromCurrency
+}
+
+type TPTaxes struct {
+	TPid     string
+	Tenant   string
+	Category string
+	Taxes    []*TPTax
+}
+
+// Key used in dataDb to identify the taxes set
+func (tpt *TPTaxes) GetTaxesId() string {
+	return ConcatenatedKey(tpt.Tenant, tpt.Category)
+}
+
+func (tpt *TPTaxes) SetTaxesId(id string) error {
+	ids := strings.Split(id, CONCATENATED_KEY_SEP)
+	if len(ids) != 2 {
+		return fmt.Errorf("Wrong TP Taxes Id: %s", id)
+	}
+	tpt.Tenant = ids[0]
+	tpt.Category = ids[1]
+	return nil
+}
+
+type TPTax struct {
+	DestinationId string
+	TaxId         string
+	Rate          float64 // Percent out of the cost
+	Debit         bool
+	Weight        float64
+}
+
+type TPHolidayCalendar struct {
+	TPid       string
+	CalendarId string
+	Holidays   []*TPHoliday
+}
+
+type TPHoliday struct {
+	Date string // 2015-12-25 for one time, 12-25 for every year or *easter+1 relative to the Easter Sunday
+	Name string
+}
+
+// Number ported out of its original network
+type TPPortedNumber struct {
+	TPid          string
+	Number        string // dialled number
+	RoutingNumber string // number used for destination matching, eg: the recipient network prefix followed by the number
+}
+
 type TPLcrRules struct {
 	TPid       string
 	LcrRulesId string
@@ -408,6 +485,7 @@ type TPDerivedCharger struct {
 	UsageField           string
 	SupplierField        string
 	DisconnectCauseField string
+	OriginField          string
 }
 
 type TPActionPlan struct {
@@ -516,21 +594,29 @@ type ApiReloadCache struct {
 	LCRIds           []string
 	DerivedChargers  []string
 	LcrProfiles      []string
+	ExchangeRates    []string
+	Taxes            []string
+	HolidayCalendars []string
+	PortedNumbers    []string
 }
 
 type AttrCacheStats struct { // Add in the future filters here maybe so we avoid counting complete cache
 }
 
 type CacheStats struct {
-	Destinations    int
-	RatingPlans     int
-	RatingProfiles  int
-	Actions         int
-	SharedGroups    int
-	RatingAliases   int
-	AccountAliases  int
-	DerivedChargers int
-	LcrProfiles     int
+	Destinations     int
+	RatingPlans      int
+	RatingProfiles   int
+	Actions          int
+	SharedGroups     int
+	RatingAliases    int
+	AccountAliases   int
+	DerivedChargers  int
+	LcrProfiles      int
+	ExchangeRates    int
+	Taxes            int
+	HolidayCalendars int
+	PortedNumbers    int
 }
 
 type AttrCachedItemAge struct {
@@ -635,7 +721,7 @@ func (self *AttrExpFileCdrs) AsCdrsFilter() (*CdrsFilter, error) {
 type ExportedFileCdrs struct {
 	ExportedFilePath          string            // Full path to the newly generated export file
 	TotalRecords              int               // Number of CDRs to be exported
-	TotalCost                 float64           // Sum of all costs in exported CDRs
+	TotalCost                 Decimal           // Sum of all costs in exported CDRs
 	FirstOrderId, LastOrderId int64             // The order id of the last exported CDR
 	ExportedCgrIds            []string          // List of successfuly exported cgrids in the file
 	UnexportedCgrIds          map[string]string // Map of errored CDRs, map key is cgrid, value will be the error string
@@ -1052,14 +1138,28 @@ type AttrExecuteAction struct {
 }
 
 type AttrSetAccount struct {
-	Tenant        string
-	Direction     string
-	Account       string
-	ActionPlanId  string
-	AllowNegative bool
+	Tenant          string
+	Direction       string
+	Account         string
+	ActionPlanId    string
+	AllowNegative   bool     // legacy flag, sets an unlimited credit limit on the direction
+	CreditLimit     *float64 // debt allowed on the monetary balance, nil leaves it unchanged
+	ParentAccount   *string  // reseller account with the same tenant and direction, empty removes it
+	ResellerSubject *string  // rating subject of the usage debited from the child accounts
 }
 
 type AttrGetSMASessions struct {
 	SessionManagerIndex int // Index of the session manager queried, defaults to first in the list
 
 }
+
+// Filter used in engine.GetLedgerEntries
+type LedgerFilter struct {
+	AccountIds   []string   // If provided, filter on the account keys (direction:tenant:account)
+	BalanceUuids []string   // If provided, filter on the balances
+	Causes       []string   // If provided, filter on the cause of the movement (*cdr, *action...)
+	CauseIds     []string   // If provided, filter on the CDR cgrids, action ids or API methods
+	TimeStart    *time.Time // Start of interval, bigger or equal than configured
+	TimeEnd      *time.Time // End interval, smaller than configured
+	Paginator
+}
@@ -58,10 +58,16 @@ const (
 	TBL_TP_ACTION_TRIGGERS       = "tp_action_triggers"
 	TBL_TP_ACCOUNT_ACTIONS       = "tp_account_actions"
 	TBL_TP_DERIVED_CHARGERS      = "tp_derived_chargers"
+	TBL_TP_EXCHANGE_RATES        = "tp_exchange_rates"
+	TBL_TP_TAXES                 = "tp_taxes"
+	TBL_TP_HOLIDAYS              = "tp_holidays"
+	TBL_TP_NUMBER_PORTABILITY    = "tp_number_portability"
 	TBL_CDRS_PRIMARY             = "cdrs_primary"
 	TBL_CDRS_EXTRA               = "cdrs_extra"
 	TBL_COST_DETAILS             = "cost_details"
 	TBL_RATED_CDRS               = "rated_cdrs"
+	TBL_ACCOUNT_LEDGER           = "account_ledger"
+	TBL_ACCOUNT_SNAPSHOTS        = "account_snapshots"
 	TIMINGS_CSV                  = "Timings.csv"
 	DESTINATIONS_CSV             = "Destinations.csv"
 	RATES_CSV                    = "Rates.csv"
@@ -76,6 +82,10 @@ const (
 	ACCOUNT_ACTIONS_CSV          = "AccountActions.csv"
 	DERIVED_CHARGERS_CSV         = "DerivedChargers.csv"
 	CDR_STATS_CSV                = "CdrStats.csv"
+	EXCHANGE_RATES_CSV           = "ExchangeRates.csv"
+	TAXES_CSV                    = "Taxes.csv"
+	HOLIDAYS_CSV                 = "Holidays.csv"
+	NUMBER_PORTABILITY_CSV       = "NumberPortability.csv"
 	ROUNDING_UP                  = "*up"
 	ROUNDING_MIDDLE              = "*middle"
 	ROUNDING_DOWN                = "*down"
@@ -116,8 +126,13 @@ const (
 	RATED_SUBJECT                = "rated_subject"
 	COST                         = "cost"
 	COST_DETAILS                 = "cost_details"
+	TAX_COST                     = "tax_cost"
+	TAXES                        = "taxes"
+	ROUTING_NUMBER               = "routing_number"
+	ORIGIN                       = "origin"
 	DEFAULT_RUNID                = "*default"
 	META_DEFAULT                 = "*default"
+	META_RESELLER                = "*reseller"
 	STATIC_VALUE_PREFIX          = "^"
 	CSV                          = "csv"
 	DRYRUN                       = "dry_run"
@@ -164,6 +179,10 @@ const (
 	DESTINATION_PREFIX           = "dst_"
 	LCR_PREFIX                   = "lcr_"
 	DERIVEDCHARGERS_PREFIX       = "dcs_"
+	EXCHANGE_RATE_PREFIX         = "exr_"
+	TAXES_PREFIX                 = "tax_"
+	HOLIDAY_CALENDAR_PREFIX      = "hcl_"
+	NUMBER_PORTABILITY_PREFIX    = "mnp_"
 	TEMP_DESTINATION_PREFIX      = "tmp_"
 	LOG_CALL_COST_PREFIX         = "cco_"
 	LOG_ACTION_TIMMING_PREFIX    = "ltm_"
@@ -191,10 +210,11 @@ const (
 	CGR_SUPPLIERS                = "cgr_suppliers"
 	DISCONNECT_CAUSE             = "disconnect_cause"
 	CGR_DISCONNECT_CAUSE         = "cgr_disconnectcause"
+	CGR_ORIGIN                   = "cgr_origin"
 	CGR_COMPUTELCR               = "cgr_computelcr"
 )
 
 var (
 	CdreCdrFormats   = []string{CSV, DRYRUN, CDRE_FIXED_WIDTH}
-	PrimaryCdrFields = []string{TOR, ACCID, CDRHOST, CDRSOURCE, REQTYPE, DIRECTION, TENANT, CATEGORY, ACCOUNT, SUBJECT, DESTINATION, SETUP_TIME, ANSWER_TIME, USAGE, SUPPLIER}
+	PrimaryCdrFields = []string{TOR, ACCID, CDRHOST, CDRSOURCE, REQTYPE, DIRECTION, TENANT, CATEGORY, ACCOUNT, SUBJECT, DESTINATION, SETUP_TIME, ANSWER_TIME, USAGE, SUPPLIER, ORIGIN}
 )
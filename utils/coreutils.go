@@ -39,6 +39,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -224,6 +225,27 @@ func CopyHour(src, dest time.Time) time.Time {
 	return time.Date(dest.Year(), dest.Month(), dest.Day(), src.Hour(), src.Minute(), src.Second(), src.Nanosecond(), src.Location())
 }
 
+var locations = make(map[string]*time.Location)
+var locationsMux sync.RWMutex
+
+// Loads the IANA location once and keeps it for the following calls
+func GetLocation(tz string) (*time.Location, error) {
+	locationsMux.RLock()
+	loc, found := locations[tz]
+	locationsMux.RUnlock()
+	if found {
+		return loc, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+	locationsMux.Lock()
+	locations[tz] = loc
+	locationsMux.Unlock()
+	return loc, nil
+}
+
 // Parses duration, considers s as time unit if not provided, seconds as float to specify subunits
 func ParseDurationWithSecs(durStr string) (time.Duration, error) {
 	if durSecs, err := strconv.ParseFloat(durStr, 64); err == nil { // Seconds format considered
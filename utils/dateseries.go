@@ -277,3 +277,18 @@ func (wd WeekDays) Serialize(sep string) string {
 	}
 	return wdStr
 }
+
+// Defines the holiday calendars referenced along the month days, eg: 1;*holidays_de
+type HolidayCalendars []string
+
+// Collects the non numeric month day entries as holiday calendar ids
+func (hc *HolidayCalendars) Parse(input, sep string) {
+	if input == "*any" || input == "" {
+		return
+	}
+	for _, hcs := range strings.Split(input, sep) {
+		if _, err := strconv.Atoi(hcs); err != nil && hcs != "" && hcs != "*any" {
+			*hc = append(*hc, hcs)
+		}
+	}
+}
@@ -161,3 +161,16 @@ func TestDateseriesMonthsIsCompleteYes(t *testing.T) {
 		t.Error("Error months IsComplete: ", months)
 	}
 }
+
+func TestDateseriesHolidayCalendarsParse(t *testing.T) {
+	var hc HolidayCalendars
+	hc.Parse("1;*holidays_de;15;*holidays_ro", ";")
+	if !reflect.DeepEqual(hc, HolidayCalendars{"*holidays_de", "*holidays_ro"}) {
+		t.Error("Error parsing holiday calendars: ", hc)
+	}
+	var md MonthDays
+	md.Parse("1;*holidays_de;15;*holidays_ro", ";")
+	if !reflect.DeepEqual(md, MonthDays{1, 15}) {
+		t.Error("Error parsing month days: ", md)
+	}
+}
@@ -0,0 +1,329 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const DECIMAL_PRECISION = 10 // number of digits kept after the decimal point
+
+var decimalScale = new(big.Int).Exp(big.NewInt(10), big.NewInt(DECIMAL_PRECISION), nil)
+
+// Fixed point decimal used for money amounts and balance values.
+// Values are integers of DECIMAL_PRECISION decimal places so sums and differences are exact.
+// The zero value is 0; values are immutable so they can be copied freely.
+type Decimal struct {
+	v *big.Int  // scaled value, nil for zero, never modified once set
+	_ [0]func() // disallow ==, use Equal or Cmp
+}
+
+func newDecimal(v *big.Int) Decimal {
+	if v.Sign() == 0 {
+		return Decimal{}
+	}
+	return Decimal{v: v}
+}
+
+func (d Decimal) scaled() *big.Int {
+	if d.v == nil {
+		return new(big.Int)
+	}
+	return d.v
+}
+
+// divides rounding half away from zero
+func divRound(x, y *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	r2 := new(big.Int).Abs(r)
+	r2.Lsh(r2, 1)
+	if r2.Cmp(new(big.Int).Abs(y)) >= 0 {
+		if (x.Sign() < 0) != (y.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+func NewDecimalFromInt(i int64) Decimal {
+	return newDecimal(new(big.Int).Mul(big.NewInt(i), decimalScale))
+}
+
+// Converts the float, rounding it to DECIMAL_PRECISION digits
+func NewDecimalFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}
+	}
+	// shortest representation avoids carrying the binary noise of the float
+	d, _ := NewDecimalFromString(strconv.FormatFloat(f, 'f', -1, 64))
+	return d
+}
+
+// Parses decimal strings as 10, -0.25 or 1e-3, rounding to DECIMAL_PRECISION digits
+func NewDecimalFromString(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, errors.New("empty decimal")
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal: %s", s)
+	}
+	num := new(big.Int).Mul(r.Num(), decimalScale)
+	return newDecimal(divRound(num, r.Denom())), nil
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	if o.v == nil {
+		return d
+	}
+	if d.v == nil {
+		return o
+	}
+	return newDecimal(new(big.Int).Add(d.v, o.v))
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	if o.v == nil {
+		return d
+	}
+	return newDecimal(new(big.Int).Sub(d.scaled(), o.v))
+}
+
+func (d Decimal) Neg() Decimal {
+	if d.v == nil {
+		return d
+	}
+	return newDecimal(new(big.Int).Neg(d.v))
+}
+
+func (d Decimal) Abs() Decimal {
+	if d.Sign() >= 0 {
+		return d
+	}
+	return d.Neg()
+}
+
+// Product rounded to DECIMAL_PRECISION digits
+func (d Decimal) Mul(o Decimal) Decimal {
+	if d.v == nil || o.v == nil {
+		return Decimal{}
+	}
+	return newDecimal(divRound(new(big.Int).Mul(d.v, o.v), decimalScale))
+}
+
+func (d Decimal) MulInt(n int64) Decimal {
+	if d.v == nil || n == 0 {
+		return Decimal{}
+	}
+	return newDecimal(new(big.Int).Mul(d.v, big.NewInt(n)))
+}
+
+// Quotient rounded to DECIMAL_PRECISION digits, panics on division by zero
+func (d Decimal) Div(o Decimal) Decimal {
+	if d.v == nil {
+		return d
+	}
+	return newDecimal(divRound(new(big.Int).Mul(d.v, decimalScale), o.scaled()))
+}
+
+// Quotient rounded to DECIMAL_PRECISION digits, panics on division by zero
+func (d Decimal) DivInt(n int64) Decimal {
+	if d.v == nil {
+		return d
+	}
+	return newDecimal(divRound(d.v, big.NewInt(n)))
+}
+
+// Computes d * num / den with only one rounding at the end
+func (d Decimal) MulDivInt(num, den int64) Decimal {
+	if d.v == nil || num == 0 {
+		return Decimal{}
+	}
+	return newDecimal(divRound(new(big.Int).Mul(d.v, big.NewInt(num)), big.NewInt(den)))
+}
+
+// Rounds to decimals digits using one of the *up, *down or *middle methods.
+// Unknown methods leave the value untouched.
+func (d Decimal) Round(decimals int, method string) Decimal {
+	if d.v == nil || decimals >= DECIMAL_PRECISION || decimals < 0 {
+		return d
+	}
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(DECIMAL_PRECISION-decimals)), nil)
+	q, m := new(big.Int).DivMod(d.v, unit, new(big.Int)) // euclidean, so q is the floor
+	switch method {
+	case ROUNDING_UP:
+		if m.Sign() != 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	case ROUNDING_DOWN:
+	case ROUNDING_MIDDLE:
+		q = divRound(d.v, unit)
+	default:
+		return d
+	}
+	return newDecimal(q.Mul(q, unit))
+}
+
+func (d Decimal) Cmp(o Decimal) int {
+	return d.scaled().Cmp(o.scaled())
+}
+
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+func (d Decimal) Sign() int {
+	if d.v == nil {
+		return 0
+	}
+	return d.v.Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.v == nil
+}
+
+func (d Decimal) Float64() float64 {
+	if d.v == nil {
+		return 0
+	}
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Shortest decimal representation, without trailing zeros
+func (d Decimal) String() string {
+	if d.v == nil {
+		return "0"
+	}
+	abs := new(big.Int).Abs(d.v).String()
+	if len(abs) <= DECIMAL_PRECISION {
+		abs = strings.Repeat("0", DECIMAL_PRECISION-len(abs)+1) + abs
+	}
+	intPart, fracPart := abs[:len(abs)-DECIMAL_PRECISION], strings.TrimRight(abs[len(abs)-DECIMAL_PRECISION:], "0")
+	str := intPart
+	if fracPart != "" {
+		str += "." + fracPart
+	}
+	if d.v.Sign() < 0 {
+		str = "-" + str
+	}
+	return str
+}
+
+// Representation rounded (*middle) to exactly decimals digits, shortest one for negative decimals
+func (d Decimal) StringFixed(decimals int) string {
+	if decimals < 0 {
+		return d.String()
+	}
+	str := d.Round(decimals, ROUNDING_MIDDLE).String()
+	if decimals == 0 {
+		return str
+	}
+	dotIdx := strings.IndexByte(str, '.')
+	if dotIdx == -1 {
+		str += "."
+		dotIdx = len(str) - 1
+	}
+	return str + strings.Repeat("0", decimals-(len(str)-dotIdx-1))
+}
+
+// Encoded as JSON number to stay compatible with the float values used before
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(string(data), `"`)
+	if str == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	return d.UnmarshalText([]byte(str))
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) (err error) {
+	*d, err = NewDecimalFromString(string(text))
+	return
+}
+
+// Binary encoding is the text one, keeping all the digits
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	return d.MarshalText()
+}
+
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	return d.UnmarshalText(data)
+}
+
+// sql.Scanner interface, reading DECIMAL/NUMERIC columns without passing through float
+func (d *Decimal) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case nil:
+		*d = Decimal{}
+	case []byte:
+		*d, err = NewDecimalFromString(string(v))
+	case string:
+		*d, err = NewDecimalFromString(v)
+	case float64:
+		*d = NewDecimalFromFloat(v)
+	case int64:
+		*d = NewDecimalFromInt(v)
+	default:
+		err = fmt.Errorf("cannot scan %T into Decimal", src)
+	}
+	return
+}
+
+// driver.Valuer interface
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Returns the smaller of the two values
+func MinDecimal(a, b Decimal) Decimal {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
+
+// Returns the bigger of the two values
+func MaxDecimal(a, b Decimal) Decimal {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
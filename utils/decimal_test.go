@@ -0,0 +1,145 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDecimalNoDrift(t *testing.T) {
+	var sum Decimal
+	incr := NewDecimalFromFloat(0.1)
+	for i := 0; i < 1000; i++ {
+		sum = sum.Add(incr)
+	}
+	if !sum.Equal(NewDecimalFromInt(100)) {
+		t.Error("Drift in sum: ", sum)
+	}
+	if left := NewDecimalFromInt(100).Sub(sum); !left.IsZero() || !reflect.DeepEqual(left, Decimal{}) {
+		t.Errorf("Expecting zero, received: %+v", left)
+	}
+}
+
+func TestDecimalFromString(t *testing.T) {
+	for str, eStr := range map[string]string{
+		"10":              "10",
+		"-0.25":           "-0.25",
+		"1e-3":            "0.001",
+		"0.3416666666666": "0.3416666667",
+		"0.00000000004":   "0",
+		"1073741824000":   "1073741824000",
+	} {
+		if d, err := NewDecimalFromString(str); err != nil {
+			t.Error(err)
+		} else if d.String() != eStr {
+			t.Errorf("Expecting: %s, received: %s", eStr, d.String())
+		}
+	}
+	if _, err := NewDecimalFromString("1,5"); err == nil {
+		t.Error("Expecting error on invalid decimal")
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	rate := NewDecimalFromFloat(0.01)
+	if cost := rate.MulDivInt(61, 60); cost.String() != "0.0101666667" {
+		t.Error("Wrong cost: ", cost)
+	}
+	if d := NewDecimalFromInt(1).DivInt(3); d.String() != "0.3333333333" {
+		t.Error("Wrong quotient: ", d)
+	}
+	if d := NewDecimalFromFloat(2.5).Mul(NewDecimalFromFloat(0.2)); d.String() != "0.5" {
+		t.Error("Wrong product: ", d)
+	}
+	if d := NewDecimalFromFloat(1.5).Div(NewDecimalFromFloat(0.5)); d.String() != "3" {
+		t.Error("Wrong quotient: ", d)
+	}
+	if d := NewDecimalFromFloat(-1.5).Abs(); d.String() != "1.5" {
+		t.Error("Wrong absolute value: ", d)
+	}
+	if NewDecimalFromFloat(1.5).Cmp(NewDecimalFromFloat(1.25)) != 1 || NewDecimalFromFloat(-1).Sign() != -1 {
+		t.Error("Wrong comparison")
+	}
+	if d := MinDecimal(NewDecimalFromInt(1), NewDecimalFromInt(2)); d.String() != "1" {
+		t.Error("Wrong minimum: ", d)
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	d := NewDecimalFromFloat(1.23451)
+	if r := d.Round(4, ROUNDING_UP); r.String() != "1.2346" {
+		t.Error("Wrong rounding: ", r)
+	}
+	if r := d.Round(4, ROUNDING_DOWN); r.String() != "1.2345" {
+		t.Error("Wrong rounding: ", r)
+	}
+	if r := d.Round(2, ROUNDING_MIDDLE); r.String() != "1.23" {
+		t.Error("Wrong rounding: ", r)
+	}
+	if r := NewDecimalFromFloat(1.235).Round(2, ROUNDING_MIDDLE); r.String() != "1.24" {
+		t.Error("Wrong rounding: ", r)
+	}
+	if r := NewDecimalFromFloat(-1.231).Round(2, ROUNDING_UP); r.String() != "-1.23" {
+		t.Error("Wrong rounding: ", r)
+	}
+	if r := d.Round(2, "*unknown"); !r.Equal(d) {
+		t.Error("Wrong rounding: ", r)
+	}
+	if str := NewDecimalFromFloat(1.5).StringFixed(4); str != "1.5000" {
+		t.Error("Wrong fixed string: ", str)
+	}
+	if str := NewDecimalFromFloat(-2.125).StringFixed(2); str != "-2.13" {
+		t.Error("Wrong fixed string: ", str)
+	}
+	if str := NewDecimalFromInt(3).StringFixed(0); str != "3" {
+		t.Error("Wrong fixed string: ", str)
+	}
+}
+
+func TestDecimalEncoding(t *testing.T) {
+	type costHolder struct {
+		Cost Decimal
+	}
+	ch := &costHolder{Cost: NewDecimalFromFloat(0.3416666667)}
+	if b, err := json.Marshal(ch); err != nil {
+		t.Error(err)
+	} else if string(b) != `{"Cost":0.3416666667}` {
+		t.Error("Unexpected json: ", string(b))
+	}
+	var rcv costHolder
+	if err := json.Unmarshal([]byte(`{"Cost":0.3416666667}`), &rcv); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(ch, &rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", ch, rcv)
+	}
+	if b, err := ch.Cost.MarshalBinary(); err != nil {
+		t.Error(err)
+	} else if err := rcv.Cost.UnmarshalBinary(b); err != nil || !rcv.Cost.Equal(ch.Cost) {
+		t.Error("Binary round trip failed: ", rcv.Cost, err)
+	}
+	var scanned Decimal
+	if err := scanned.Scan([]byte("12.3456")); err != nil || scanned.String() != "12.3456" {
+		t.Error("Scan failed: ", scanned, err)
+	}
+	if val, err := scanned.Value(); err != nil || val != "12.3456" {
+		t.Error("Value failed: ", val, err)
+	}
+}
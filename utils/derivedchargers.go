@@ -24,7 +24,7 @@ import (
 )
 
 // Wraps regexp compiling in case of rsr fields
-func NewDerivedCharger(runId, runFilters, reqTypeFld, dirFld, tenantFld, catFld, acntFld, subjFld, dstFld, sTimeFld, pddFld, aTimeFld, durFld, supplFld, dCauseFld string) (dc *DerivedCharger, err error) {
+func NewDerivedCharger(runId, runFilters, reqTypeFld, dirFld, tenantFld, catFld, acntFld, subjFld, dstFld, sTimeFld, pddFld, aTimeFld, durFld, supplFld, dCauseFld, originFld string) (dc *DerivedCharger, err error) {
 	if len(runId) == 0 {
 		return nil, errors.New("Empty run id field")
 	}
@@ -113,6 +113,12 @@ func NewDerivedCharger(runId, runFilters, reqTypeFld, dirFld, tenantFld, catFld,
 			return nil, err
 		}
 	}
+	dc.OriginField = originFld
+	if strings.HasPrefix(dc.OriginField, REGEXP_PREFIX) || strings.HasPrefix(dc.OriginField, STATIC_VALUE_PREFIX) {
+		if dc.rsrOriginField, err = NewRSRField(dc.OriginField); err != nil {
+			return nil, err
+		}
+	}
 	return dc, nil
 }
 
@@ -132,6 +138,7 @@ type DerivedCharger struct {
 	UsageField              string      // Field containing usage information
 	SupplierField           string      // Field containing supplier information
 	DisconnectCauseField    string      // Field containing disconnect cause information
+	OriginField             string      // Field containing the origin (A-number or caller location) information
 	rsrRunFilters           []*RSRField // Storage for compiled Regexp in case of RSRFields
 	rsrReqTypeField         *RSRField
 	rsrDirectionField       *RSRField
@@ -146,6 +153,7 @@ type DerivedCharger struct {
 	rsrUsageField           *RSRField
 	rsrSupplierField        *RSRField
 	rsrDisconnectCauseField *RSRField
+	rsrOriginField          *RSRField
 }
 
 func DerivedChargersKey(direction, tenant, category, account, subject string) string {
@@ -169,7 +177,7 @@ func (dcs DerivedChargers) Append(dc *DerivedCharger) (DerivedChargers, error) {
 
 func (dcs DerivedChargers) AppendDefaultRun() (DerivedChargers, error) {
 	dcDf, _ := NewDerivedCharger(DEFAULT_RUNID, "", META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT,
-		META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT)
+		META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT, META_DEFAULT)
 	return append(dcs, dcDf), nil
 }
 
@@ -197,5 +205,6 @@ func (dc *DerivedCharger) Equal(other *DerivedCharger) bool {
 		dc.AnswerTimeField == other.AnswerTimeField &&
 		dc.UsageField == other.UsageField &&
 		dc.SupplierField == other.SupplierField &&
-		dc.DisconnectCauseField == other.DisconnectCauseField
+		dc.DisconnectCauseField == other.DisconnectCauseField &&
+		dc.OriginField == other.OriginField
 }
@@ -61,9 +61,10 @@ func TestNewDerivedCharger(t *testing.T) {
 		UsageField:           "duration1",
 		SupplierField:        "supplier1",
 		DisconnectCauseField: "NORMAL_CLEARING",
+		OriginField:          "origin1",
 	}
 	if dc1, err := NewDerivedCharger("test1", "", "reqtype1", "direction1", "tenant1", "tor1", "account1", "subject1", "destination1",
-		"setuptime1", "pdd1", "answertime1", "duration1", "supplier1", "NORMAL_CLEARING"); err != nil {
+		"setuptime1", "pdd1", "answertime1", "duration1", "supplier1", "NORMAL_CLEARING", "origin1"); err != nil {
 		t.Error("Unexpected error", err.Error)
 	} else if !reflect.DeepEqual(edc1, dc1) {
 		t.Errorf("Expecting: %v, received: %v", edc1, dc1)
@@ -84,6 +85,7 @@ func TestNewDerivedCharger(t *testing.T) {
 		UsageField:           "~duration2:s/sip:(.+)/$1/",
 		SupplierField:        "~supplier2:s/(.+)/$1/",
 		DisconnectCauseField: "~cgr_disconnect:s/(.+)/$1/",
+		OriginField:          "~cgr_origin:s/^00(.+)/+$1/",
 	}
 	edc2.rsrRunFilters, _ = ParseRSRFields("^cdr_source/tdm_cdrs/", INFIELD_SEP)
 	edc2.rsrReqTypeField, _ = NewRSRField("~reqtype2:s/sip:(.+)/$1/")
@@ -99,6 +101,7 @@ func TestNewDerivedCharger(t *testing.T) {
 	edc2.rsrUsageField, _ = NewRSRField("~duration2:s/sip:(.+)/$1/")
 	edc2.rsrSupplierField, _ = NewRSRField("~supplier2:s/(.+)/$1/")
 	edc2.rsrDisconnectCauseField, _ = NewRSRField("~cgr_disconnect:s/(.+)/$1/")
+	edc2.rsrOriginField, _ = NewRSRField("~cgr_origin:s/^00(.+)/+$1/")
 	if dc2, err := NewDerivedCharger("test2",
 		"^cdr_source/tdm_cdrs/",
 		"~reqtype2:s/sip:(.+)/$1/",
@@ -113,7 +116,8 @@ func TestNewDerivedCharger(t *testing.T) {
 		"~answertime2:s/sip:(.+)/$1/",
 		"~duration2:s/sip:(.+)/$1/",
 		"~supplier2:s/(.+)/$1/",
-		"~cgr_disconnect:s/(.+)/$1/"); err != nil {
+		"~cgr_disconnect:s/(.+)/$1/",
+		"~cgr_origin:s/^00(.+)/+$1/"); err != nil {
 		t.Error("Unexpected error", err)
 	} else if !reflect.DeepEqual(edc2, dc2) {
 		t.Errorf("Expecting: %v, received: %v", edc2, dc2)
@@ -131,7 +135,7 @@ func TestAppendDefaultRun(t *testing.T) {
 	dcDf := &DerivedCharger{RunId: DEFAULT_RUNID, RunFilters: "", ReqTypeField: META_DEFAULT, DirectionField: META_DEFAULT,
 		TenantField: META_DEFAULT, CategoryField: META_DEFAULT, AccountField: META_DEFAULT, SubjectField: META_DEFAULT,
 		DestinationField: META_DEFAULT, SetupTimeField: META_DEFAULT, PddField: META_DEFAULT, AnswerTimeField: META_DEFAULT, UsageField: META_DEFAULT, SupplierField: META_DEFAULT,
-		DisconnectCauseField: META_DEFAULT}
+		DisconnectCauseField: META_DEFAULT, OriginField: META_DEFAULT}
 	eDc1 := DerivedChargers{dcDf}
 	if dc1, _ = dc1.AppendDefaultRun(); !reflect.DeepEqual(dc1, eDc1) {
 		t.Errorf("Expecting: %+v, received: %+v", eDc1[0], dc1[0])
@@ -0,0 +1,167 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+// One matched prefix together with the ids indexed on it
+type PrefixMatch struct {
+	Prefix    string
+	Ids       []string
+	Precision int // length of the prefix, the matches ordering criteria
+}
+
+// Compact trie indexing ids on string prefixes.
+// Finds all the indexed prefixes of a string in one walk instead of probing every substring.
+// Not safe for concurrent writes, callers need to do their own locking.
+type PrefixTrie struct {
+	root *prefixTrieNode
+	size int // number of prefixes having ids attached
+}
+
+type prefixTrieNode struct {
+	labels   []byte // one byte per child, kept in the same order as children
+	children []*prefixTrieNode
+	ids      []string
+}
+
+func (n *prefixTrieNode) child(c byte) *prefixTrieNode {
+	for i, l := range n.labels {
+		if l == c {
+			return n.children[i]
+		}
+	}
+	return nil
+}
+
+func (n *prefixTrieNode) removeChild(c byte) {
+	for i, l := range n.labels {
+		if l == c {
+			n.labels = append(n.labels[:i], n.labels[i+1:]...)
+			n.children = append(n.children[:i], n.children[i+1:]...)
+			return
+		}
+	}
+}
+
+func NewPrefixTrie() *PrefixTrie {
+	return &PrefixTrie{root: new(prefixTrieNode)}
+}
+
+// Attaches id to prefix, duplicates are ignored
+func (pt *PrefixTrie) Add(prefix, id string) {
+	node := pt.root
+	for i := 0; i < len(prefix); i++ {
+		next := node.child(prefix[i])
+		if next == nil {
+			next = new(prefixTrieNode)
+			node.labels = append(node.labels, prefix[i])
+			node.children = append(node.children, next)
+		}
+		node = next
+	}
+	for _, existing := range node.ids {
+		if existing == id {
+			return
+		}
+	}
+	if len(node.ids) == 0 {
+		pt.size++
+	}
+	node.ids = append(node.ids, id)
+}
+
+// Detaches id from prefix, pruning the branches left without ids
+func (pt *PrefixTrie) Remove(prefix, id string) {
+	path := make([]*prefixTrieNode, 1, len(prefix)+1)
+	path[0] = pt.root
+	node := pt.root
+	for i := 0; i < len(prefix); i++ {
+		if node = node.child(prefix[i]); node == nil {
+			return
+		}
+		path = append(path, node)
+	}
+	for i, existing := range node.ids {
+		if existing == id {
+			if len(node.ids) == 1 {
+				node.ids = nil
+				pt.size--
+				break
+			}
+			// copy so the slices already returned by Match stay untouched
+			ids := make([]string, 0, len(node.ids)-1)
+			node.ids = append(append(ids, node.ids[:i]...), node.ids[i+1:]...)
+			break
+		}
+	}
+	for i := len(path) - 1; i > 0; i-- {
+		if len(path[i].ids) != 0 || len(path[i].children) != 0 {
+			break
+		}
+		path[i-1].removeChild(prefix[i-1])
+	}
+}
+
+// Returns the ids attached to exactly this prefix
+func (pt *PrefixTrie) Get(prefix string) []string {
+	node := pt.root
+	for i := 0; i < len(prefix) && node != nil; i++ {
+		node = node.child(prefix[i])
+	}
+	if node == nil {
+		return nil
+	}
+	return node.ids
+}
+
+// Returns the indexed prefixes of s having at least minLength characters, longest first.
+// The returned Ids are shared with the trie and should not be modified.
+func (pt *PrefixTrie) Match(s string, minLength int) (matches []*PrefixMatch) {
+	node := pt.root
+	for i := 0; i < len(s); i++ {
+		if node = node.child(s[i]); node == nil {
+			break
+		}
+		if len(node.ids) != 0 && i+1 >= minLength {
+			matches = append(matches, &PrefixMatch{Prefix: s[:i+1], Ids: node.ids, Precision: i + 1})
+		}
+	}
+	for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+		matches[i], matches[j] = matches[j], matches[i]
+	}
+	return
+}
+
+// Returns the longest indexed prefix of s or empty string if none
+func (pt *PrefixTrie) LongestPrefix(s string) (prefix string) {
+	node := pt.root
+	for i := 0; i < len(s); i++ {
+		if node = node.child(s[i]); node == nil {
+			break
+		}
+		if len(node.ids) != 0 {
+			prefix = s[:i+1]
+		}
+	}
+	return
+}
+
+// Number of prefixes indexed
+func (pt *PrefixTrie) Len() int {
+	return pt.size
+}
@@ -0,0 +1,106 @@
+/*
+Rating system designed to be used in VoIP Carriers World
+Copyright (C) 2012-2015 ITsysCOM
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestPrefixTrieMatch(t *testing.T) {
+	pt := NewPrefixTrie()
+	pt.Add("49", "GERMANY")
+	pt.Add("4915", "GERMANY_MOBILE")
+	pt.Add("4915", "GERMANY_O2")
+	pt.Add("4915", "GERMANY_O2") // duplicate ignored
+	pt.Add("40", "ROMANIA")
+	eMatches := []*PrefixMatch{
+		&PrefixMatch{Prefix: "4915", Ids: []string{"GERMANY_MOBILE", "GERMANY_O2"}, Precision: 4},
+		&PrefixMatch{Prefix: "49", Ids: []string{"GERMANY"}, Precision: 2},
+	}
+	if matches := pt.Match("491511111", 1); !reflect.DeepEqual(eMatches, matches) {
+		t.Errorf("Expecting: %+v, received: %+v", eMatches, matches)
+	}
+	if matches := pt.Match("491511111", 3); !reflect.DeepEqual(eMatches[:1], matches) {
+		t.Errorf("Expecting: %+v, received: %+v", eMatches[:1], matches)
+	}
+	if matches := pt.Match("33123", 1); len(matches) != 0 {
+		t.Errorf("Unexpected matches: %+v", matches)
+	}
+	if pt.Len() != 3 {
+		t.Error("Wrong number of prefixes: ", pt.Len())
+	}
+}
+
+func TestPrefixTrieLongestPrefix(t *testing.T) {
+	pt := NewPrefixTrie()
+	pt.Add("49", "GERMANY")
+	pt.Add("4915", "GERMANY_MOBILE")
+	if prfx := pt.LongestPrefix("49151"); prfx != "4915" {
+		t.Error("Unexpected prefix: ", prfx)
+	}
+	if prfx := pt.LongestPrefix("4916"); prfx != "49" {
+		t.Error("Unexpected prefix: ", prfx)
+	}
+	if prfx := pt.LongestPrefix("40"); prfx != "" {
+		t.Error("Unexpected prefix: ", prfx)
+	}
+}
+
+func TestPrefixTrieRemove(t *testing.T) {
+	pt := NewPrefixTrie()
+	pt.Add("49", "GERMANY")
+	pt.Add("4915", "GERMANY_MOBILE")
+	pt.Add("4915", "GERMANY_O2")
+	matches := pt.Match("4915", 1)
+	pt.Remove("4915", "GERMANY_MOBILE")
+	if ids := pt.Get("4915"); !reflect.DeepEqual([]string{"GERMANY_O2"}, ids) {
+		t.Error("Unexpected ids: ", ids)
+	}
+	if !reflect.DeepEqual([]string{"GERMANY_MOBILE", "GERMANY_O2"}, matches[0].Ids) {
+		t.Error("Previous match modified: ", matches[0].Ids)
+	}
+	pt.Remove("4915", "GERMANY_O2")
+	if ids := pt.Get("4915"); ids != nil {
+		t.Error("Unexpected ids: ", ids)
+	}
+	if len(pt.root.child('4').child('9').children) != 0 {
+		t.Error("Branch not pruned")
+	}
+	pt.Remove("49", "NOT_INDEXED")
+	pt.Remove("491", "GERMANY")
+	if ids := pt.Get("49"); !reflect.DeepEqual([]string{"GERMANY"}, ids) {
+		t.Error("Unexpected ids: ", ids)
+	}
+	if pt.Len() != 1 {
+		t.Error("Wrong number of prefixes: ", pt.Len())
+	}
+}
+
+func BenchmarkPrefixTrieMatch(b *testing.B) {
+	pt := NewPrefixTrie()
+	for i := 0; i < 400000; i++ {
+		pt.Add(strconv.Itoa(4400000+i), "DST_"+strconv.Itoa(i%1000))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pt.Match("440012345678", 1)
+	}
+}
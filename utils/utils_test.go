@@ -459,3 +459,16 @@ func TestAvgEmpty(t *testing.T) {
 		t.Errorf("Wrong Avg: expected %v got %v", expected, result)
 	}
 }
+
+func TestGetLocation(t *testing.T) {
+	loc, err := GetLocation("Europe/Berlin")
+	if err != nil || loc.String() != "Europe/Berlin" {
+		t.Error("Error loading location: ", loc, err)
+	}
+	if cached, err := GetLocation("Europe/Berlin"); err != nil || cached != loc {
+		t.Error("Location not cached: ", cached, err)
+	}
+	if _, err := GetLocation("Europe/Nowhere"); err == nil {
+		t.Error("Expecting error for unknown location")
+	}
+}